@@ -45,6 +45,7 @@ export class File extends RestModel {
       Name: "",
       OriginalName: "",
       Hash: "",
+      Thumb: "",
       Size: 0,
       ModTime: 0,
       Codec: "",
@@ -125,7 +126,7 @@ export class File extends RestModel {
       return `${config.contentUri}/svg/file`;
     }
 
-    return `${config.contentUri}/t/${this.Hash}/${config.previewToken}/${size}`;
+    return `${config.contentUri}/t/${this.Thumb ? this.Thumb : this.Hash}/${config.previewToken}/${size}`;
   }
 
   getDownloadUrl() {
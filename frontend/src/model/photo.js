@@ -155,6 +155,29 @@ export class Photo extends RestModel {
         CopyrightSrc: "",
         License: "",
         LicenseSrc: "",
+        CreditLine: "",
+        CreatorAddress: "",
+        CreatorCity: "",
+        CreatorRegion: "",
+        CreatorPostalCode: "",
+        CreatorCountry: "",
+        CreatorPhone: "",
+        CreatorEmail: "",
+        CreatorUrl: "",
+        CreatedSublocation: "",
+        CreatedCity: "",
+        CreatedState: "",
+        CreatedCountry: "",
+        CreatedCountryCode: "",
+        ShownSublocation: "",
+        ShownCity: "",
+        ShownState: "",
+        ShownCountry: "",
+        ShownCountryCode: "",
+        PersonsShown: "",
+        Event: "",
+        DigitalSourceType: "",
+        IptcSrc: "",
         Software: "",
         SoftwareSrc: "",
       },
@@ -181,6 +204,7 @@ export class Photo extends RestModel {
       FPS: 0.0,
       Frames: 0,
       Hash: "",
+      Thumb: "",
       Width: "",
       Height: "",
       // Date fields.
@@ -552,6 +576,26 @@ export class Photo extends RestModel {
     return "";
   });
 
+  mainFileThumb() {
+    return this.generateMainFileThumb(this.mainFile(), this.Hash, this.Thumb);
+  }
+
+  generateMainFileThumb = memoizeOne((mainFile, hash, thumb) => {
+    if (this.Files) {
+      if (mainFile && mainFile.Thumb) {
+        return mainFile.Thumb;
+      } else if (mainFile && mainFile.Hash) {
+        return mainFile.Hash;
+      }
+    } else if (thumb) {
+      return thumb;
+    } else if (hash) {
+      return hash;
+    }
+
+    return "";
+  });
+
   fileModels() {
     let result = [];
 
@@ -578,7 +622,7 @@ export class Photo extends RestModel {
 
   thumbnailUrl(size) {
     return this.generateThumbnailUrl(
-      this.mainFileHash(),
+      this.mainFileThumb(),
       this.videoFile(),
       config.contentUri,
       config.previewToken,
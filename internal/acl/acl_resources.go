@@ -55,6 +55,14 @@ var Resources = ACL{
 	ResourcePassword: Roles{
 		RoleAdmin: GrantFullAccess,
 	},
+	ResourceComments: Roles{
+		RoleAdmin:   GrantFullAccess,
+		RoleVisitor: Grant{AccessShared: true, ActionView: true, ActionCreate: true, ActionUpdate: true, ActionDelete: true},
+	},
+	ResourceReactions: Roles{
+		RoleAdmin:   GrantFullAccess,
+		RoleVisitor: Grant{AccessShared: true, ActionView: true, ActionReact: true},
+	},
 	ResourceShares: Roles{
 		RoleAdmin: GrantFullAccess,
 	},
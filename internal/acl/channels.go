@@ -19,5 +19,6 @@ const (
 	ChannelLabels    Resource = "labels"
 	ChannelSubjects  Resource = "subjects"
 	ChannelPeople    Resource = "people"
+	ChannelComments  Resource = "comments"
 	ChannelSync      Resource = "sync"
 )
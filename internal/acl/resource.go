@@ -24,6 +24,8 @@ const (
 	ResourceShares    Resource = "shares"
 	ResourceVideos    Resource = "videos"
 	ResourceFeedback  Resource = "feedback"
+	ResourceComments  Resource = "comments"
+	ResourceReactions Resource = "reactions"
 )
 
 // Resource represents a resource for which roles can be granted Permission.
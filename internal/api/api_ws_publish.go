@@ -3,6 +3,7 @@ package api
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/search"
@@ -55,3 +56,12 @@ func PublishSubjectEvent(ev EntityEvent, uid string, c *gin.Context) {
 		event.PublishEntities("subjects", string(ev), result)
 	}
 }
+
+// PublishCommentEvent notifies the owner of the commented photo or album, or all users if the owner is unknown.
+func PublishCommentEvent(ev EntityEvent, m *entity.Comment, ownerUid string) {
+	if m == nil {
+		return
+	}
+
+	event.PublishUserEntities("comments", string(ev), entity.Comments{*m}, ownerUid)
+}
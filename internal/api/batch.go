@@ -291,6 +291,66 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 	})
 }
 
+// BatchPhotosRating changes the star rating and color label of multiple photos.
+//
+// POST /api/v1/batch/photos/rating
+func BatchPhotosRating(router *gin.RouterGroup) {
+	router.POST("/batch/photos/rating", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionRate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.Rating
+
+		if err := c.BindJSON(&f); err != nil || f.Empty() {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Photos) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		log.Infof("photos: updating rating of %s", clean.Log(f.String()))
+
+		// Fetch selection from index.
+		photos, err := query.SelectedPhotos(f.Selection)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		var updated entity.Photos
+
+		for _, p := range photos {
+			rating, label := int(p.PhotoRating), p.PhotoColorLabel
+
+			if f.Rating != nil {
+				rating = *f.Rating
+			}
+
+			if f.ColorLabel != nil {
+				label = *f.ColorLabel
+			}
+
+			if err = p.Rate(rating, label); err != nil {
+				log.Errorf("rating: %s", err)
+			} else {
+				updated = append(updated, p)
+				SavePhotoAsYaml(p)
+			}
+		}
+
+		event.EntitiesUpdated("photos", updated)
+
+		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgChangesSaved))
+	})
+}
+
 // BatchLabelsDelete deletes multiple labels.
 //
 // POST /api/v1/batch/labels/delete
@@ -415,3 +475,92 @@ func BatchPhotosDelete(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgPermanentlyDeleted))
 	})
 }
+
+// BatchPhotosEdit changes the metadata of multiple photos and returns the result for each photo.
+// Each photo is saved in its own transaction, so it is either changed completely or not at all.
+// Photos whose result contains an error remain unchanged and can be edited again.
+//
+// POST /api/v1/batch/photos/edit
+func BatchPhotosEdit(router *gin.RouterGroup) {
+	router.POST("/batch/photos/edit", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.BatchEdit
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if f.Selection.Empty() {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		if err := f.Validate(); err != nil {
+			log.Errorf("edit: %s", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		for _, albumUid := range f.AddAlbums {
+			if a := entity.FindAlbum(entity.Album{AlbumUID: albumUid}); a == nil || a.Deleted() {
+				Abort(c, http.StatusNotFound, i18n.ErrAlbumNotFound)
+				return
+			}
+		}
+
+		log.Infof("photos: editing metadata of %s", clean.Log(f.Selection.String()))
+
+		// Fetch selection from index.
+		photos, err := query.SelectedPhotos(f.Selection)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		results := make([]entity.BatchResult, 0, len(photos))
+
+		var updated entity.Photos
+
+		for _, p := range photos {
+			photo, err := query.PhotoPreloadByUID(p.PhotoUID)
+
+			if err != nil {
+				results = append(results, entity.BatchResult{PhotoUID: p.PhotoUID, Changed: []string{}, Error: err.Error()})
+				continue
+			}
+
+			res, err := photo.BatchEdit(f)
+
+			if err != nil {
+				log.Errorf("edit: %s (%s)", err, photo.String())
+				res.Error = err.Error()
+			} else {
+				updated = append(updated, photo)
+				SavePhotoAsYaml(photo)
+			}
+
+			results = append(results, res)
+		}
+
+		// Update precalculated photo and file counts.
+		logWarn("index", entity.UpdateCounts())
+
+		// Update album, subject, and label cover thumbs.
+		logWarn("index", query.UpdateCovers())
+
+		UpdateClientConfig()
+
+		FlushCoverCache()
+
+		event.EntitiesUpdated("photos", updated)
+
+		c.JSON(http.StatusOK, results)
+	})
+}
@@ -136,6 +136,45 @@ func TestBatchAlbumsDelete(t *testing.T) {
 	})
 }
 
+func TestBatchPhotosRating(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		// Register routes.
+		GetPhoto(router)
+		BatchPhotosRating(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rating", `{"photos": ["pt9jtdre2lvl0y12", "pt9jtdre2lvl0y13"], "Rating": 3, "ColorLabel": "Yellow"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r2 := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y12")
+		assert.Equal(t, http.StatusOK, r2.Code)
+		assert.Equal(t, int64(3), gjson.Get(r2.Body.String(), "Rating").Int())
+		assert.Equal(t, "yellow", gjson.Get(r2.Body.String(), "ColorLabel").String())
+
+		r3 := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rating", `{"photos": ["pt9jtdre2lvl0y12"], "Rating": 5}`)
+		assert.Equal(t, http.StatusOK, r3.Code)
+
+		r4 := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y12")
+		assert.Equal(t, int64(5), gjson.Get(r4.Body.String(), "Rating").Int())
+		assert.Equal(t, "yellow", gjson.Get(r4.Body.String(), "ColorLabel").String())
+	})
+	t.Run("no values", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosRating(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rating", `{"photos": ["pt9jtdre2lvl0y12"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("no items selected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosRating(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/rating", `{"photos": [], "Rating": 1}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrNoItemsSelected), val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
 func TestBatchPhotosPrivate(t *testing.T) {
 	t.Run("successful request", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -274,3 +313,35 @@ func TestBatchPhotosDelete(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, r.Code)
 	})
 }
+
+func TestBatchPhotosEdit(t *testing.T) {
+	t.Run("successful request", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": ["pt9jtdre2lvl0y12"], "TakenOffset": 3600, "Copyright": "Batch Copyright"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "pt9jtdre2lvl0y12", gjson.Get(r.Body.String(), "0.PhotoUID").String())
+		assert.Equal(t, "TakenAt", gjson.Get(r.Body.String(), "0.Changed.0").String())
+		assert.Equal(t, "Copyright", gjson.Get(r.Body.String(), "0.Changed.1").String())
+	})
+	t.Run("no changes", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": ["pt9jtdre2lvl0y12"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("album not found", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": ["pt9jtdre2lvl0y12"], "AddAlbums": ["at9lxuqxpoxxxxxx"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("no items selected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		BatchPhotosEdit(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/batch/photos/edit", `{"photos": [], "TakenOffset": 60}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrNoItemsSelected), val.String())
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
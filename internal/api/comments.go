@@ -0,0 +1,357 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// CanModerateComments checks if the session may see, edit, and delete all comments.
+func CanModerateComments(s *entity.Session) bool {
+	return acl.Resources.Allow(acl.ResourceComments, s.User().AclRole(), acl.ActionManage)
+}
+
+// CommentPerm checks if the session may view and write comments on the photo or album with the specified uid.
+// Link visitors may view comments on shared content, and write comments if the link grants permission.
+func CommentPerm(s *entity.Session, uid string) (canView, canComment bool) {
+	if CanModerateComments(s) {
+		return true, true
+	}
+
+	for _, shareUid := range sharedUIDs(uid) {
+		if !s.HasShare(shareUid) {
+			continue
+		}
+
+		canView = true
+
+		if entity.HasPerm(s.SharePerm(shareUid), entity.PermComment) {
+			return true, true
+		}
+	}
+
+	return canView, false
+}
+
+// sharedUIDs returns the uids through which a photo or album can be shared with link visitors,
+// since photos can also be shared as part of an album.
+func sharedUIDs(uid string) []string {
+	shared := []string{uid}
+
+	if rnd.IsUID(uid, entity.PhotoUID) {
+		if albums, err := query.PhotoAlbumUIDs(uid); err == nil {
+			shared = append(shared, albums...)
+		}
+	}
+
+	return shared
+}
+
+// commentOwner returns the uid of the user who owns a photo or album, so that they can be notified.
+func commentOwner(uid string) string {
+	if rnd.IsUID(uid, entity.PhotoUID) {
+		if m, err := query.PhotoByUID(uid); err == nil {
+			return m.CreatedBy
+		}
+	} else if m, err := query.AlbumByUID(uid); err == nil {
+		return m.CreatedBy
+	}
+
+	return ""
+}
+
+// getComments returns the comments of a photo or album as JSON.
+func getComments(c *gin.Context, s *entity.Session, uid string) {
+	canView, _ := CommentPerm(s, uid)
+
+	if !canView {
+		AbortForbidden(c)
+		return
+	}
+
+	results, err := query.EntityComments(uid, CanModerateComments(s))
+
+	if err != nil {
+		log.Errorf("comments: %s", err)
+		AbortUnexpected(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// addComment adds a comment to a photo or album and returns it as JSON.
+func addComment(c *gin.Context, s *entity.Session, uid string) {
+	if _, canComment := CommentPerm(s, uid); !canComment {
+		AbortForbidden(c)
+		return
+	}
+
+	var f form.Comment
+
+	if err := c.BindJSON(&f); err != nil {
+		AbortBadRequest(c)
+		return
+	}
+
+	m := entity.NewComment(uid, f.CommentText, s)
+	m.SetAuthorName(f.AuthorName)
+
+	if err := m.Create(); err != nil {
+		log.Errorf("comments: %s", err)
+		AbortBadRequest(c)
+		return
+	}
+
+	event.AuditInfo([]string{ClientIP(c), "session %s", "comment %s", "added to %s"}, s.RefID, m.CommentUID, clean.Log(uid))
+
+	PublishCommentEvent(EntityCreated, m, commentOwner(uid))
+
+	c.JSON(http.StatusOK, m)
+}
+
+// GetPhotoComments returns the comments on a photo.
+//
+// GET /api/v1/photos/:uid/comments
+func GetPhotoComments(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/comments", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceComments, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		if _, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		getComments(c, s, uid)
+	})
+}
+
+// AddPhotoComment adds a comment to a photo.
+//
+// POST /api/v1/photos/:uid/comments
+func AddPhotoComment(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/comments", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceComments, acl.ActionCreate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		if _, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		addComment(c, s, uid)
+	})
+}
+
+// GetAlbumComments returns the discussion thread of an album.
+//
+// GET /api/v1/albums/:uid/comments
+func GetAlbumComments(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/comments", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceComments, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		if _, err := query.AlbumByUID(uid); err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		getComments(c, s, uid)
+	})
+}
+
+// AddAlbumComment adds a comment to the discussion thread of an album.
+//
+// POST /api/v1/albums/:uid/comments
+func AddAlbumComment(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/comments", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceComments, acl.ActionCreate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		if _, err := query.AlbumByUID(uid); err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		addComment(c, s, uid)
+	})
+}
+
+// UpdateComment changes the text of a comment, which is only allowed for its author and moderators.
+//
+// PUT /api/v1/comments/:uid
+func UpdateComment(router *gin.RouterGroup) {
+	router.PUT("/comments/:uid", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceComments, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := entity.FindComment(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		} else if !m.IsAuthor(s) && !CanModerateComments(s) {
+			AbortForbidden(c)
+			return
+		}
+
+		var f form.Comment
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := m.Edit(f.CommentText); err != nil {
+			log.Errorf("comments: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		PublishCommentEvent(EntityUpdated, m, commentOwner(m.EntityUID))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeleteComment removes a comment, which is only allowed for its author and moderators.
+//
+// DELETE /api/v1/comments/:uid
+func DeleteComment(router *gin.RouterGroup) {
+	router.DELETE("/comments/:uid", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceComments, acl.ActionDelete)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := entity.FindComment(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		} else if !m.IsAuthor(s) && !CanModerateComments(s) {
+			AbortForbidden(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("comments: %s", err)
+			AbortDeleteFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "comment %s", "deleted"}, s.RefID, m.CommentUID)
+
+		PublishCommentEvent(EntityDeleted, m, commentOwner(m.EntityUID))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// SearchComments finds comments for moderation and returns them as JSON.
+//
+// GET /api/v1/comments
+func SearchComments(router *gin.RouterGroup) {
+	router.GET("/comments", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceComments, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.SearchComments
+
+		if err := c.MustBindWith(&f, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		results, err := search.Comments(f)
+
+		if err != nil {
+			c.AbortWithStatusJSON(400, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		AddCountHeader(c, len(results))
+		AddLimitHeader(c, f.Count)
+		AddOffsetHeader(c, f.Offset)
+
+		c.JSON(http.StatusOK, results)
+	})
+}
+
+// ModerateComment hides or shows an inappropriate comment.
+//
+// PUT /api/v1/comments/:uid/moderation
+func ModerateComment(router *gin.RouterGroup) {
+	router.PUT("/comments/:uid/moderation", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceComments, acl.ActionManage)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := entity.FindComment(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		var f form.CommentModeration
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if err := m.Hide(f.CommentHidden); err != nil {
+			log.Errorf("comments: %s", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "comment %s", "hidden %t"}, s.RefID, m.CommentUID, m.CommentHidden)
+
+		PublishCommentEvent(EntityUpdated, m, commentOwner(m.EntityUID))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
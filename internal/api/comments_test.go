@@ -0,0 +1,102 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetPhotoComments(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoComments(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh8/comments")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.GreaterOrEqual(t, len(gjson.Get(r.Body.String(), "@this").Array()), 2)
+		assert.Equal(t, "cs6sg6bw45bn0001", gjson.Get(r.Body.String(), "0.UID").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoComments(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0xxx/comments")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestAddPhotoComment(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddPhotoComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh0/comments", `{"Text": "Uncle Bob in his garden"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Uncle Bob in his garden", gjson.Get(r.Body.String(), "Text").String())
+		assert.Equal(t, "pt9jtdre2lvl0yh0", gjson.Get(r.Body.String(), "EntityUID").String())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddPhotoComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh0/comments", `{"Text": " "}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestAlbumComments(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetAlbumComments(router)
+	AddAlbumComment(router)
+
+	r := PerformRequestWithBody(app, "POST", "/api/v1/albums/at9lxuqxpogaaba8/comments", `{"Text": "Best holiday ever"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	r = PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/comments")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Contains(t, r.Body.String(), "Best holiday ever")
+
+	r = PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogxxxxx/comments")
+	assert.Equal(t, http.StatusNotFound, r.Code)
+}
+
+func TestUpdateComment(t *testing.T) {
+	app, router, _ := NewApiTest()
+	AddPhotoComment(router)
+	UpdateComment(router)
+	DeleteComment(router)
+
+	r := PerformRequestWithBody(app, "POST", "/api/v1/photos/pt9jtdre2lvl0yh0/comments", `{"Text": "Typo in commnet"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	uid := gjson.Get(r.Body.String(), "UID").String()
+
+	r = PerformRequestWithBody(app, "PUT", "/api/v1/comments/"+uid, `{"Text": "Typo in comment"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, "Typo in comment", gjson.Get(r.Body.String(), "Text").String())
+	assert.True(t, gjson.Get(r.Body.String(), "EditedAt").Exists())
+
+	r = PerformRequest(app, "DELETE", "/api/v1/comments/"+uid)
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	r = PerformRequest(app, "DELETE", "/api/v1/comments/"+uid)
+	assert.Equal(t, http.StatusNotFound, r.Code)
+}
+
+func TestModerateComment(t *testing.T) {
+	app, router, _ := NewApiTest()
+	SearchComments(router)
+	ModerateComment(router)
+
+	r := PerformRequest(app, "GET", "/api/v1/comments?count=10&hidden=yes")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Contains(t, r.Body.String(), "cs6sg6bw45bn0003")
+
+	r = PerformRequestWithBody(app, "PUT", "/api/v1/comments/cs6sg6bw45bn0002/moderation", `{"Hidden": true}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.True(t, gjson.Get(r.Body.String(), "Hidden").Bool())
+
+	r = PerformRequestWithBody(app, "PUT", "/api/v1/comments/cs6sg6bw45bn0002/moderation", `{"Hidden": false}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.False(t, gjson.Get(r.Body.String(), "Hidden").Bool())
+
+	r = PerformRequestWithBody(app, "PUT", "/api/v1/comments/cs6sg6bw45bnxxxx/moderation", `{"Hidden": true}`)
+	assert.Equal(t, http.StatusNotFound, r.Code)
+}
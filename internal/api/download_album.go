@@ -84,7 +84,7 @@ func DownloadAlbum(router *gin.RouterGroup) {
 			aliases[key] += 1
 
 			if fs.FileExists(fileName) {
-				if err := addFileToZip(zipWriter, fileName, alias); err != nil {
+				if err := addDownloadToZip(zipWriter, file.PhotoUID, file.FileType, file.FileHash, file.FileOrientation, fileName, alias); err != nil {
 					log.Errorf("download: failed adding %s to album zip (%s)", clean.Log(file.FileName), err)
 					Abort(c, http.StatusInternalServerError, i18n.ErrZipFailed)
 					return
@@ -68,6 +68,29 @@ func GetDownload(router *gin.RouterGroup) {
 			return
 		}
 
+		// Download edited image unless the original was requested.
+		if c.Query("original") == "" {
+			if editedName := downloadEdited(fileName, f.FileType, f.FileHash, f.FileOrientation); editedName != "" {
+				downloadName := fs.StripKnownExt(fs.StripExt(f.DownloadName(DownloadName(c), 0))) + fs.ExtJPEG
+
+				if data, ok := downloadWithMetadata(f.PhotoUID, fs.ImageJPEG.String(), editedName); ok {
+					AddDownloadHeader(c, downloadName)
+					c.Data(http.StatusOK, fs.MimeTypeJPEG, data)
+				} else {
+					c.FileAttachment(editedName, downloadName)
+				}
+
+				return
+			}
+		}
+
+		// Embed picture metadata in JPEG images if enabled.
+		if data, ok := downloadWithMetadata(f.PhotoUID, f.FileType, fileName); ok {
+			AddDownloadHeader(c, f.DownloadName(DownloadName(c), 0))
+			c.Data(http.StatusOK, fs.MimeTypeJPEG, data)
+			return
+		}
+
 		c.FileAttachment(fileName, f.DownloadName(DownloadName(c), 0))
 	})
 }
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// downloadWithMetadata returns the JPEG image data with the picture metadata embedded as XMP,
+// if enabled in the download settings. Returns false if the original file should be sent instead.
+func downloadWithMetadata(photoUID, fileType, fileName string) ([]byte, bool) {
+	if !get.Config().Settings().Download.Metadata || fs.Type(fileType) != fs.ImageJPEG || photoUID == "" {
+		return nil, false
+	}
+
+	p, err := query.PhotoPreloadByUID(photoUID)
+
+	if err != nil {
+		log.Warnf("download: %s (find picture %s)", err, clean.Log(photoUID))
+		return nil, false
+	}
+
+	img, err := os.ReadFile(fileName)
+
+	if err != nil {
+		log.Warnf("download: %s (read %s)", err, clean.Log(filepath.Base(fileName)))
+		return nil, false
+	}
+
+	data, err := meta.JpegWithXmp(img, p.Xmp())
+
+	if err != nil {
+		log.Warnf("download: %s in %s (embed metadata)", err, clean.Log(filepath.Base(fileName)))
+		return nil, false
+	}
+
+	return data, true
+}
+
+// downloadEdited returns the name of the full-size edited JPEG image, or an empty string if the file has not
+// been edited or the edited image could not be created.
+func downloadEdited(fileName, fileType, fileHash string, orientation int) string {
+	if fs.ImageJPEG.NotEqual(fileType) && fs.ImagePNG.NotEqual(fileType) {
+		return ""
+	}
+
+	edit := entity.FindFileEditByHash(fileHash)
+
+	if edit == nil {
+		return ""
+	}
+
+	editedName, err := thumb.Edited(fileName, fileHash, get.Config().ThumbCachePath(), orientation, edit.Edit())
+
+	if err != nil {
+		log.Errorf("download: %s in %s (edited)", err, clean.Log(filepath.Base(fileName)))
+		return ""
+	}
+
+	return editedName
+}
+
+// addDownloadToZip adds a file to a zip archive, with the picture metadata embedded if enabled.
+// Edited images are added instead of the original, so that zip downloads match single file downloads.
+func addDownloadToZip(zipWriter *zip.Writer, photoUID, fileType, fileHash string, orientation int, fileName, fileAlias string) error {
+	if editedName := downloadEdited(fileName, fileType, fileHash, orientation); editedName != "" {
+		fileName = editedName
+		fileType = fs.ImageJPEG.String()
+		fileAlias = fs.StripKnownExt(fs.StripExt(fileAlias)) + fs.ExtJPEG
+	}
+
+	data, ok := downloadWithMetadata(photoUID, fileType, fileName)
+
+	if !ok {
+		return addFileToZip(zipWriter, fileName, fileAlias)
+	}
+
+	header := &zip.FileHeader{
+		Name:   fileAlias,
+		Method: zip.Deflate,
+	}
+
+	if info, err := os.Stat(fileName); err == nil {
+		header.Modified = info.ModTime()
+	} else {
+		header.Modified = time.Now()
+	}
+
+	writer, err := zipWriter.CreateHeader(header)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(data)
+
+	return err
+}
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// findEditFile returns the file specified in the request or aborts with an error if it was not found.
+func findEditFile(c *gin.Context) *entity.File {
+	m, err := query.FileByUID(clean.UID(c.Param("file_uid")))
+
+	if err != nil || m.PhotoUID != clean.UID(c.Param("uid")) {
+		AbortEntityNotFound(c)
+		return nil
+	}
+
+	return m
+}
+
+// GetFileEdit returns the non-destructive adjustments of a file.
+//
+// GET /api/v1/photos/:uid/files/:file_uid/edit
+//
+// Parameters:
+//
+//	uid: string Photo UID as returned by the API
+//	file_uid: string File UID as returned by the API
+func GetFileEdit(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/files/:file_uid/edit", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := findEditFile(c)
+
+		if m == nil {
+			return
+		}
+
+		if edit := entity.FindFileEdit(m.FileUID); edit != nil {
+			c.JSON(http.StatusOK, edit)
+		} else {
+			c.JSON(http.StatusOK, entity.NewFileEdit(m))
+		}
+	})
+}
+
+// UpdateFileEdit changes the non-destructive adjustments of a file, the original is not modified.
+//
+// PUT /api/v1/photos/:uid/files/:file_uid/edit
+//
+// Parameters:
+//
+//	uid: string Photo UID as returned by the API
+//	file_uid: string File UID as returned by the API
+func UpdateFileEdit(router *gin.RouterGroup) {
+	router.PUT("/photos/:uid/files/:file_uid/edit", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		// Abort in read-only mode or if editing is disabled.
+		if conf.ReadOnly() || !conf.Settings().Features.Edit {
+			c.AbortWithStatusJSON(http.StatusForbidden, i18n.NewResponse(http.StatusForbidden, i18n.ErrReadOnly))
+			return
+		}
+
+		m := findEditFile(c)
+
+		if m == nil {
+			return
+		}
+
+		var f form.FileEdit
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if err = f.Validate(); err != nil {
+			log.Errorf("files: %s (update edit)", err)
+			AbortBadRequest(c)
+			return
+		}
+
+		edit := entity.FindFileEdit(m.FileUID)
+
+		if edit == nil {
+			edit = entity.NewFileEdit(m)
+		}
+
+		edit.FileHash = m.FileHash
+		edit.SetValues(f)
+
+		if err := edit.Save(); err != nil {
+			log.Errorf("files: %s (update edit)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		// Remove images created from previous adjustments.
+		removeEditedThumbs(m.FileHash)
+
+		PublishPhotoEvent(EntityUpdated, m.PhotoUID, c)
+
+		c.JSON(http.StatusOK, edit)
+	})
+}
+
+// ResetFileEdit removes the non-destructive adjustments of a file, so that the original is used again.
+//
+// DELETE /api/v1/photos/:uid/files/:file_uid/edit
+//
+// Parameters:
+//
+//	uid: string Photo UID as returned by the API
+//	file_uid: string File UID as returned by the API
+func ResetFileEdit(router *gin.RouterGroup) {
+	router.DELETE("/photos/:uid/files/:file_uid/edit", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		// Abort in read-only mode or if editing is disabled.
+		if conf.ReadOnly() || !conf.Settings().Features.Edit {
+			c.AbortWithStatusJSON(http.StatusForbidden, i18n.NewResponse(http.StatusForbidden, i18n.ErrReadOnly))
+			return
+		}
+
+		m := findEditFile(c)
+
+		if m == nil {
+			return
+		}
+
+		if edit := entity.FindFileEdit(m.FileUID); edit != nil {
+			if err := edit.Delete(); err != nil {
+				log.Errorf("files: %s (reset edit)", err)
+				AbortSaveFailed(c)
+				return
+			}
+		}
+
+		removeEditedThumbs(m.FileHash)
+
+		PublishPhotoEvent(EntityUpdated, m.PhotoUID, c)
+
+		c.JSON(http.StatusOK, entity.NewFileEdit(m))
+	})
+}
+
+// GetFileEditXmp returns the non-destructive adjustments of a file as XMP sidecar file,
+// which can be imported by darktable and other apps.
+//
+// GET /api/v1/photos/:uid/files/:file_uid/edit/xmp
+//
+// Parameters:
+//
+//	uid: string Photo UID as returned by the API
+//	file_uid: string File UID as returned by the API
+func GetFileEditXmp(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/files/:file_uid/edit/xmp", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionDownload)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m := findEditFile(c)
+
+		if m == nil {
+			return
+		}
+
+		edit := entity.FindFileEdit(m.FileUID)
+
+		if edit == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fs.BasePrefix(m.FileName, false)+fs.SidecarXMP.DefaultExt()))
+		c.Data(http.StatusOK, "application/rdf+xml", edit.Edit().Xmp(m.InstanceID))
+	})
+}
+
+// removeEditedThumbs deletes cached images created from edits of the file with the specified hash.
+func removeEditedThumbs(fileHash string) {
+	if removed, err := thumb.RemoveEdited(fileHash, get.Config().ThumbCachePath()); err != nil {
+		log.Warnf("files: %s (remove edited thumbs)", err)
+	} else if removed > 0 {
+		log.Debugf("files: removed %d edited thumbs", removed)
+	}
+}
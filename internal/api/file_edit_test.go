@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestFileEdit(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetFileEdit(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/files/ft9es39w45bnlqdw/edit")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("UpdateAndReset", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetFileEdit(router)
+		UpdateFileEdit(router)
+		ResetFileEdit(router)
+		GetFileEditXmp(router)
+
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0yh7/files/ft8es39w45bnlqdw/edit", `{"CropX": 0.1, "CropY": 0.1, "CropW": 0.5, "CropH": 0.5, "Exposure": 0.5}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "ft8es39w45bnlqdw", gjson.Get(r.Body.String(), "FileUID").String())
+		assert.InDelta(t, 0.5, gjson.Get(r.Body.String(), "CropW").Float(), 0.001)
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/files/ft8es39w45bnlqdw/edit")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.InDelta(t, 0.5, gjson.Get(r.Body.String(), "Exposure").Float(), 0.001)
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/files/ft8es39w45bnlqdw/edit/xmp")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), `crs:HasCrop="True"`)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/photos/pt9jtdre2lvl0yh7/files/ft8es39w45bnlqdw/edit")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, float64(0), gjson.Get(r.Body.String(), "CropW").Float())
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/files/ft8es39w45bnlqdw/edit/xmp")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidValues", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdateFileEdit(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0yh7/files/ft8es39w45bnlqdw/edit", `{"Angle": 90}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -162,6 +162,11 @@ func CancelIndexing(router *gin.RouterGroup) {
 
 		ind := get.Index()
 
+		// Cancel pending index jobs, so that they are not resumed by the job queue.
+		if _, err := entity.CancelJobs(entity.JobIndex); err != nil {
+			log.Warnf("index: %s (cancel jobs)", err)
+		}
+
 		ind.Cancel()
 
 		c.JSON(http.StatusOK, i18n.NewResponse(http.StatusOK, i18n.MsgIndexingCanceled))
@@ -0,0 +1,287 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// processJobs starts processing queued jobs in the background unless another worker is running.
+func processJobs() {
+	if mutex.IndexWorkersRunning() {
+		return
+	}
+
+	go func() {
+		if _, err := get.Jobs().Start(); err != nil {
+			log.Warnf("jobs: %s", err)
+		}
+	}()
+}
+
+// findJob returns the job specified in the request or aborts with an error if it was not found.
+func findJob(c *gin.Context) *entity.Job {
+	job := entity.FindJob(txt.UInt(c.Param("id")))
+
+	if job == nil {
+		AbortEntityNotFound(c)
+		return nil
+	}
+
+	return job
+}
+
+// publishJobCounts publishes the current number of jobs by status.
+func publishJobCounts() {
+	if counts, err := entity.CountJobs(); err == nil {
+		event.Publish("jobs.updated", event.Data{"counts": counts})
+	}
+}
+
+// GetJobs returns queued and processed jobs as JSON.
+//
+// GET /api/v1/jobs
+//
+// Query:
+//
+//	count: int Maximum number of results
+//	offset: int Result offset
+//	status: string Job status, e.g. queued, running, paused, done, failed, or canceled
+//	type: string Job type, e.g. index, convert, or thumbs
+func GetJobs(router *gin.RouterGroup) {
+	router.GET("/jobs", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionSearch)
+
+		if s.Abort(c) {
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		if limit <= 0 {
+			limit = 100
+		}
+
+		if resp, err := query.Jobs(limit, offset, c.Query("status"), c.Query("type")); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		} else {
+			AddCountHeader(c, len(resp))
+			AddLimitHeader(c, limit)
+			AddOffsetHeader(c, offset)
+
+			c.JSON(http.StatusOK, resp)
+		}
+	})
+}
+
+// GetJobCounts returns the number of jobs by status as JSON.
+//
+// GET /api/v1/jobs/counts
+func GetJobCounts(router *gin.RouterGroup) {
+	router.GET("/jobs/counts", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionSearch)
+
+		if s.Abort(c) {
+			return
+		}
+
+		counts, err := entity.CountJobs()
+
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusOK, counts)
+	})
+}
+
+// CreateJob adds an index, convert, or thumbnail job for a file or folder in originals to the queue.
+//
+// POST /api/v1/jobs
+func CreateJob(router *gin.RouterGroup) {
+	router.POST("/jobs", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		if !get.Config().Settings().Features.Library {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		var f form.Job
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		job, err := get.Jobs().Enqueue(f.Type, f.Path, f.Priority, f.Force)
+
+		if err != nil {
+			log.Errorf("jobs: %s (create)", err)
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		log.Infof("jobs: queued %s", job)
+
+		processJobs()
+
+		c.JSON(http.StatusOK, job)
+	})
+}
+
+// PauseJobs pauses queued jobs, optionally only of the type specified in the request.
+//
+// POST /api/v1/jobs/pause
+//
+// Query:
+//
+//	type: string Job type, e.g. index, convert, or thumbs
+func PauseJobs(router *gin.RouterGroup) {
+	router.POST("/jobs/pause", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		jobType := clean.TypeLower(c.Query("type"))
+
+		n, err := entity.PauseJobs(jobType)
+
+		if err != nil {
+			log.Errorf("jobs: %s (pause)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		log.Infof("jobs: paused %d jobs", n)
+
+		publishJobCounts()
+
+		c.JSON(http.StatusOK, gin.H{"paused": n})
+	})
+}
+
+// ResumeJobs resumes paused jobs, optionally only of the type specified in the request.
+//
+// POST /api/v1/jobs/resume
+//
+// Query:
+//
+//	type: string Job type, e.g. index, convert, or thumbs
+func ResumeJobs(router *gin.RouterGroup) {
+	router.POST("/jobs/resume", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		jobType := clean.TypeLower(c.Query("type"))
+
+		n, err := entity.ResumeJobs(jobType)
+
+		if err != nil {
+			log.Errorf("jobs: %s (resume)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		log.Infof("jobs: resumed %d jobs", n)
+
+		publishJobCounts()
+
+		if n > 0 {
+			processJobs()
+		}
+
+		c.JSON(http.StatusOK, gin.H{"resumed": n})
+	})
+}
+
+// CancelJob cancels a job that has not been processed yet.
+//
+// DELETE /api/v1/jobs/:id
+//
+// Parameters:
+//
+//	id: int Job ID as returned by the API
+func CancelJob(router *gin.RouterGroup) {
+	router.DELETE("/jobs/:id", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		job := findJob(c)
+
+		if job == nil {
+			return
+		}
+
+		if err := job.Cancel(); err != nil {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		log.Infof("jobs: canceled %s", job)
+
+		publishJobCounts()
+
+		c.JSON(http.StatusOK, job)
+	})
+}
+
+// RetryJob queues a failed or canceled job again.
+//
+// POST /api/v1/jobs/:id/retry
+//
+// Parameters:
+//
+//	id: int Job ID as returned by the API
+func RetryJob(router *gin.RouterGroup) {
+	router.POST("/jobs/:id/retry", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		job := findJob(c)
+
+		if job == nil {
+			return
+		}
+
+		if err := job.Retry(); err != nil {
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": txt.UpperFirst(err.Error())})
+			return
+		}
+
+		log.Infof("jobs: retrying %s", job)
+
+		publishJobCounts()
+		processJobs()
+
+		c.JSON(http.StatusOK, job)
+	})
+}
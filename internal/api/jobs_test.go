@@ -0,0 +1,111 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestGetJobs(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetJobs(router)
+		r := PerformRequest(app, "GET", "/api/v1/jobs?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "10", r.Header().Get("X-Limit"))
+	})
+}
+
+func TestGetJobCounts(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetJobCounts(router)
+	r := PerformRequest(app, "GET", "/api/v1/jobs/counts")
+	assert.Equal(t, http.StatusOK, r.Code)
+}
+
+func TestCreateJob(t *testing.T) {
+	t.Run("InvalidType", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateJob(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/jobs", `{"type": "foo", "path": "/"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateJob(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/jobs", `{"type": "index", "path": "/does-not-exist"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateJob(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/jobs", `{"type": 1}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestPauseJobs(t *testing.T) {
+	app, router, _ := NewApiTest()
+	PauseJobs(router)
+	ResumeJobs(router)
+
+	job := entity.NewJob(entity.JobThumbs, "2790/02", entity.JobPriorityLow, false)
+
+	if err := job.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer entity.UnscopedDb().Delete(job)
+
+	r := PerformRequest(app, "POST", "/api/v1/jobs/pause?type=thumbs")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "paused").Int())
+	assert.Equal(t, entity.JobPaused, entity.FindJob(job.ID).JobStatus)
+
+	r = PerformRequest(app, "POST", "/api/v1/jobs/resume?type=convert")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Equal(t, entity.JobPaused, entity.FindJob(job.ID).JobStatus)
+}
+
+func TestCancelJob(t *testing.T) {
+	t.Run("CancelAndRetry", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CancelJob(router)
+		RetryJob(router)
+
+		job := entity.NewJob(entity.JobConvert, "2790/03", entity.JobPriorityLow, false)
+		job.JobStatus = entity.JobPaused
+
+		if err := job.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.UnscopedDb().Delete(job)
+
+		r := PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/jobs/%d", job.ID))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, entity.JobCanceled, gjson.Get(r.Body.String(), "Status").String())
+
+		r = PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/jobs/%d", job.ID))
+		assert.Equal(t, http.StatusConflict, r.Code)
+
+		r = PerformRequest(app, "POST", fmt.Sprintf("/api/v1/jobs/%d/retry", job.ID))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, entity.JobQueued, gjson.Get(r.Body.String(), "Status").String())
+
+		if _, err := entity.PauseJobs(entity.JobConvert); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CancelJob(router)
+		r := PerformRequest(app, "DELETE", "/api/v1/jobs/999999999")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
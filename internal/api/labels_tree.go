@@ -0,0 +1,324 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/i18n"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// GetLabelParents returns the parent labels of a label.
+//
+// GET /api/v1/labels/:uid/parents
+//
+// Parameters:
+//
+//	uid: string Label UID
+func GetLabelParents(router *gin.RouterGroup) {
+	router.GET("/labels/:uid/parents", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceLabels, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m, err := query.LabelByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, m.Parents())
+	})
+}
+
+// GetLabelChildren returns the child labels of a label.
+//
+// GET /api/v1/labels/:uid/children
+//
+// Parameters:
+//
+//	uid: string Label UID
+func GetLabelChildren(router *gin.RouterGroup) {
+	router.GET("/labels/:uid/children", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceLabels, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m, err := query.LabelByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, m.Children())
+	})
+}
+
+// AddLabelParent makes a label the child of another label.
+//
+// POST /api/v1/labels/:uid/parents/:parent
+//
+// Parameters:
+//
+//	uid: string Label UID
+//	parent: string Parent Label UID
+func AddLabelParent(router *gin.RouterGroup) {
+	router.POST("/labels/:uid/parents/:parent", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceLabels, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		id := clean.UID(c.Param("uid"))
+		m, err := query.LabelByUID(id)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		parent, err := query.LabelByUID(clean.UID(c.Param("parent")))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		if err = m.AddParent(&parent); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrBadRequest)
+			return
+		}
+
+		event.SuccessMsg(i18n.MsgLabelSaved)
+
+		PublishLabelEvent(EntityUpdated, id, c)
+
+		c.JSON(http.StatusOK, m.Parents())
+	})
+}
+
+// RemoveLabelParent removes the relationship between a label and its parent.
+//
+// DELETE /api/v1/labels/:uid/parents/:parent
+//
+// Parameters:
+//
+//	uid: string Label UID
+//	parent: string Parent Label UID
+func RemoveLabelParent(router *gin.RouterGroup) {
+	router.DELETE("/labels/:uid/parents/:parent", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceLabels, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		id := clean.UID(c.Param("uid"))
+		m, err := query.LabelByUID(id)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		parent, err := query.LabelByUID(clean.UID(c.Param("parent")))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		if err = m.RemoveParent(&parent); err != nil {
+			Error(c, http.StatusInternalServerError, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		event.SuccessMsg(i18n.MsgLabelSaved)
+
+		PublishLabelEvent(EntityUpdated, id, c)
+
+		c.JSON(http.StatusOK, m.Parents())
+	})
+}
+
+// MergeLabels merges the selected labels into a label, so that their photos are assigned to it
+// and their names become synonyms.
+//
+// POST /api/v1/labels/:uid/merge
+//
+// Parameters:
+//
+//	uid: string Label UID
+func MergeLabels(router *gin.RouterGroup) {
+	router.POST("/labels/:uid/merge", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceLabels, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.Selection
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if len(f.Labels) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoLabelsSelected)
+			return
+		}
+
+		id := clean.UID(c.Param("uid"))
+		m, err := query.LabelByUID(id)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		var merged []string
+
+		for _, uid := range f.Labels {
+			other, err := query.LabelByUID(clean.UID(uid))
+
+			if err != nil {
+				Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+				return
+			}
+
+			if err = m.Merge(&other); err != nil {
+				Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+				return
+			}
+
+			log.Infof("labels: merged %s into %s", clean.Log(other.LabelName), clean.Log(m.LabelName))
+
+			merged = append(merged, other.LabelUID)
+		}
+
+		logError("labels", entity.UpdateLabelCounts())
+
+		UpdateClientConfig()
+
+		event.EntitiesDeleted("labels", merged)
+		event.SuccessMsg(i18n.MsgLabelSaved)
+
+		PublishLabelEvent(EntityUpdated, id, c)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// GetLabelSynonyms returns the synonyms of a label.
+//
+// GET /api/v1/labels/:uid/synonyms
+//
+// Parameters:
+//
+//	uid: string Label UID
+func GetLabelSynonyms(router *gin.RouterGroup) {
+	router.GET("/labels/:uid/synonyms", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceLabels, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m, err := query.LabelByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		c.JSON(http.StatusOK, m.Synonyms())
+	})
+}
+
+// AddLabelSynonym adds an alternative name that can be used to find a label.
+//
+// POST /api/v1/labels/:uid/synonyms
+//
+// Parameters:
+//
+//	uid: string Label UID
+func AddLabelSynonym(router *gin.RouterGroup) {
+	router.POST("/labels/:uid/synonyms", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceLabels, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var f form.Label
+
+		if err := c.BindJSON(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		m, err := query.LabelByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		if _, err = m.AddSynonym(f.LabelName); err != nil {
+			Error(c, http.StatusBadRequest, err, i18n.ErrSaveFailed)
+			return
+		}
+
+		event.SuccessMsg(i18n.MsgLabelSaved)
+
+		c.JSON(http.StatusOK, m.Synonyms())
+	})
+}
+
+// RemoveLabelSynonym removes an alternative label name.
+//
+// DELETE /api/v1/labels/:uid/synonyms/:slug
+//
+// Parameters:
+//
+//	uid: string Label UID
+//	slug: string Synonym Slug
+func RemoveLabelSynonym(router *gin.RouterGroup) {
+	router.DELETE("/labels/:uid/synonyms/:slug", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceLabels, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		m, err := query.LabelByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrLabelNotFound)
+			return
+		}
+
+		if err = m.RemoveSynonym(c.Param("slug")); err != nil {
+			Error(c, http.StatusNotFound, err, i18n.ErrEntityNotFound)
+			return
+		}
+
+		event.SuccessMsg(i18n.MsgLabelSaved)
+
+		c.JSON(http.StatusOK, m.Synonyms())
+	})
+}
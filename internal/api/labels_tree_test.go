@@ -0,0 +1,101 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+)
+
+func TestGetLabelParents(t *testing.T) {
+	t.Run("Flower", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLabelParents(router)
+		r := PerformRequest(app, "GET", "/api/v1/labels/lt9k3pw1wowuy3c3/parents")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "landscape", gjson.Get(r.Body.String(), "0.Slug").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLabelParents(router)
+		r := PerformRequest(app, "GET", "/api/v1/labels/xxx/parents")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetLabelChildren(t *testing.T) {
+	t.Run("Landscape", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLabelChildren(router)
+		r := PerformRequest(app, "GET", "/api/v1/labels/lt9k3pw1wowuy3c2/children")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "flower", gjson.Get(r.Body.String(), "0.Slug").String())
+	})
+}
+
+func TestAddLabelParent(t *testing.T) {
+	t.Run("Cycle", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddLabelParent(router)
+		r := PerformRequest(app, "POST", "/api/v1/labels/lt9k3pw1wowuy3c2/parents/lt9k3pw1wowuy3c3")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("AddAndRemove", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddLabelParent(router)
+		RemoveLabelParent(router)
+		r := PerformRequest(app, "POST", "/api/v1/labels/lt9k3pw1wowuy3c4/parents/lt9k3pw1wowuy3c5")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "cow", gjson.Get(r.Body.String(), "0.Slug").String())
+		r = PerformRequest(app, "DELETE", "/api/v1/labels/lt9k3pw1wowuy3c4/parents/lt9k3pw1wowuy3c5")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "[]", r.Body.String())
+	})
+}
+
+func TestMergeLabels(t *testing.T) {
+	t.Run("NoLabelsSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		MergeLabels(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/labels/lt9k3pw1wowuy3c4/merge", `{"labels": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		MergeLabels(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/labels/xxx/merge", `{"labels": ["lt9k3pw1wowuy3c5"]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("Itself", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		MergeLabels(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/labels/lt9k3pw1wowuy3c4/merge", `{"labels": ["lt9k3pw1wowuy3c4"]}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestLabelSynonyms(t *testing.T) {
+	t.Run("AddAndRemove", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetLabelSynonyms(router)
+		AddLabelSynonym(router)
+		RemoveLabelSynonym(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/labels/lt9k3pw1wowuy3c4/synonyms", `{"Name": "Gateau"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "gateau", gjson.Get(r.Body.String(), "0.Slug").String())
+		r = PerformRequest(app, "GET", "/api/v1/labels/lt9k3pw1wowuy3c4/synonyms")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Gateau", gjson.Get(r.Body.String(), "0.Name").String())
+		r = PerformRequest(app, "DELETE", "/api/v1/labels/lt9k3pw1wowuy3c4/synonyms/gateau")
+		assert.Equal(t, http.StatusOK, r.Code)
+		r = PerformRequest(app, "DELETE", "/api/v1/labels/lt9k3pw1wowuy3c4/synonyms/gateau")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("LabelName", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddLabelSynonym(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/labels/lt9k3pw1wowuy3c4/synonyms", `{"Name": "Flower"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
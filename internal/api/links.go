@@ -36,6 +36,7 @@ func UpdateLink(c *gin.Context) {
 	link := entity.FindLink(clean.Token(c.Param("link")))
 
 	link.SetSlug(f.ShareSlug)
+	link.SetPerm(f.CanComment, f.CanEdit)
 	link.MaxViews = f.MaxViews
 	link.LinkExpires = f.LinkExpires
 
@@ -115,6 +116,7 @@ func CreateLink(c *gin.Context) {
 	link := entity.NewUserLink(uid, s.UserUID)
 
 	link.SetSlug(f.ShareSlug)
+	link.SetPerm(f.CanComment, f.CanEdit)
 	link.MaxViews = f.MaxViews
 	link.LinkExpires = f.LinkExpires
 
@@ -33,6 +33,8 @@ func TestCreateAlbumLink(t *testing.T) {
 		assert.NotEmpty(t, link.ShareUID)
 		assert.NotEmpty(t, link.LinkToken)
 		assert.Equal(t, 0, link.LinkExpires)
+		assert.True(t, link.CanEdit())
+		assert.False(t, link.CanComment())
 	})
 	t.Run("album does not exist", func(t *testing.T) {
 		app, router, _ := NewApiTest()
@@ -9,13 +9,16 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/crop"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/txt"
 )
 
 // Checks if background worker runs less than once per hour.
@@ -211,3 +214,201 @@ func ClearMarkerSubject(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, marker)
 	})
 }
+
+// CreateMarker adds a face or pet marker for an image area that was selected manually,
+// e.g. because the face was not detected automatically.
+//
+// POST /api/v1/markers
+func CreateMarker(router *gin.RouterGroup) {
+	router.POST("/markers", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceFiles, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		// Check feature flags.
+		conf := get.Config()
+		if !conf.Settings().Features.People {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		// Abort if workers runs less than once per hour.
+		if wakeupIntervalTooHigh(c) {
+			return
+		}
+
+		// Abort if another update is running.
+		if err := mutex.UpdatePeople.Start(); err != nil {
+			AbortBusy(c)
+			return
+		}
+
+		defer mutex.UpdatePeople.Stop()
+
+		var f form.MarkerCreate
+
+		if err := c.BindJSON(&f); err != nil {
+			log.Errorf("faces: %s (create marker)", err)
+			AbortBadRequest(c)
+			return
+		} else if !f.Valid() {
+			AbortBadRequest(c)
+			return
+		}
+
+		file, err := query.FileByUID(f.FileUID)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		} else if !file.FilePrimary {
+			log.Infof("faces: cannot add marker to non-primary file")
+			AbortBadRequest(c)
+			return
+		}
+
+		var marker *entity.Marker
+
+		if f.MarkerType == entity.MarkerPet {
+			marker, err = get.Index().PetMarker(*file, crop.NewArea("pet", f.X, f.Y, f.W, f.H))
+		} else {
+			marker, err = get.Index().FaceMarker(*file, crop.NewArea("face", f.X, f.Y, f.W, f.H))
+		}
+
+		if err != nil {
+			log.Errorf("faces: %s (create marker)", err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		log.Infof("faces: added marker %s to %s", marker.MarkerUID, file.FileUID)
+
+		// Assign subject if a name was provided.
+		if f.MarkerName != "" {
+			if _, err = marker.SetName(f.MarkerName, entity.SrcManual); err != nil {
+				log.Errorf("faces: %s (set marker name)", err)
+			} else if err = marker.Save(); err != nil {
+				log.Errorf("faces: %s (save marker)", err)
+			} else if err = query.UpdateSubjectCovers(); err != nil {
+				log.Errorf("faces: %s (update covers)", err)
+			} else if err = entity.UpdateSubjectCounts(); err != nil {
+				log.Errorf("faces: %s (update counts)", err)
+			}
+		}
+
+		// Update photo metadata.
+		if _, err = file.UpdatePhotoFaceCount(); err != nil {
+			log.Errorf("faces: %s (update face count)", err)
+		} else if p, err := query.PhotoByUID(file.PhotoUID); err != nil {
+			log.Errorf("faces: %s (find photo))", err)
+		} else if err := p.UpdateAndSaveTitle(); err != nil {
+			log.Errorf("faces: %s (update photo title)", err)
+		} else {
+			// Notify clients.
+			PublishPhotoEvent(EntityUpdated, file.PhotoUID, c)
+		}
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, marker)
+	})
+}
+
+// DeleteMarker removes a face marker, e.g. a false positive. Detected faces are flagged as invalid
+// instead of being deleted, so that they are not detected again when the file is re-indexed.
+//
+// DELETE /api/v1/markers/:marker_uid
+//
+// Parameters:
+//
+//	marker_uid: string Marker UID as returned by the API
+func DeleteMarker(router *gin.RouterGroup) {
+	router.DELETE("/markers/:marker_uid", func(c *gin.Context) {
+		// Abort if workers runs less than once per hour.
+		if wakeupIntervalTooHigh(c) {
+			return
+		}
+
+		// Abort if another update is running.
+		if err := mutex.UpdatePeople.Start(); err != nil {
+			AbortBusy(c)
+			return
+		}
+
+		defer mutex.UpdatePeople.Stop()
+
+		file, marker, err := findFileMarker(c)
+
+		if err != nil {
+			log.Debugf("faces: %s (find marker to delete)", err)
+			return
+		}
+
+		if deleted, err := marker.Reject(); err != nil {
+			log.Errorf("faces: %s (delete marker)", err)
+			AbortDeleteFailed(c)
+			return
+		} else if deleted {
+			log.Infof("faces: deleted marker %s", marker.MarkerUID)
+		} else {
+			log.Infof("faces: flagged marker %s as invalid", marker.MarkerUID)
+		}
+
+		if err := query.UpdateSubjectCovers(); err != nil {
+			log.Errorf("faces: %s (update covers)", err)
+		} else if err := entity.UpdateSubjectCounts(); err != nil {
+			log.Errorf("faces: %s (update counts)", err)
+		}
+
+		// Update photo metadata.
+		if _, err = file.UpdatePhotoFaceCount(); err != nil {
+			log.Errorf("faces: %s (update face count)", err)
+		} else if !file.FilePrimary {
+			log.Infof("faces: skipped updating photo for non-primary file")
+		} else if p, err := query.PhotoByUID(file.PhotoUID); err != nil {
+			log.Errorf("faces: %s (find photo))", err)
+		} else if err := p.UpdateAndSaveTitle(); err != nil {
+			log.Errorf("faces: %s (update photo title)", err)
+		} else {
+			// Notify clients.
+			PublishPhotoEvent(EntityUpdated, file.PhotoUID, c)
+		}
+
+		event.SuccessMsg(i18n.MsgChangesSaved)
+
+		c.JSON(http.StatusOK, marker)
+	})
+}
+
+// GetMarkerSuggestions returns the known pets that are most similar to a pet marker.
+//
+// GET /api/v1/markers/:marker_uid/suggestions
+//
+// Parameters:
+//
+//	marker_uid: string Marker UID as returned by the API
+func GetMarkerSuggestions(router *gin.RouterGroup) {
+	router.GET("/markers/:marker_uid/suggestions", func(c *gin.Context) {
+		_, marker, err := findFileMarker(c)
+
+		if err != nil {
+			log.Debugf("pets: %s (find marker)", err)
+			return
+		} else if marker.MarkerType != entity.MarkerPet {
+			AbortBadRequest(c)
+			return
+		}
+
+		results, err := photoprism.SuggestPets(*marker, txt.Int(c.Query("count")))
+
+		if err != nil {
+			log.Errorf("pets: %s (suggest)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
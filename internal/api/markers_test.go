@@ -218,3 +218,92 @@ func TestClearMarkerSubject(t *testing.T) {
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
 }
+
+func TestCreateMarker(t *testing.T) {
+	t.Run("InvalidArea", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		CreateMarker(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/markers", `{"FileUID": "ft8es39w45bnlqdw", "X": 0.9, "Y": 0.1, "W": 0.3, "H": 0.3}`)
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("FileNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		CreateMarker(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/markers", `{"FileUID": "ft8es39w45bnlxxx", "X": 0.1, "Y": 0.1, "W": 0.3, "H": 0.3}`)
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		CreateMarker(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/markers", "test")
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestDeleteMarker(t *testing.T) {
+	t.Run("Detected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetPhoto(router)
+		DeleteMarker(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y11")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		markerUid := gjson.Get(r.Body.String(), "Files.0.Markers.0.UID").String()
+
+		assert.NotEmpty(t, markerUid)
+
+		r = PerformRequest(app, "DELETE", fmt.Sprintf("/api/v1/markers/%s", markerUid))
+
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "Invalid").Bool())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		DeleteMarker(router)
+
+		r := PerformRequest(app, "DELETE", "/api/v1/markers/mt9k3pw1wowuxxxx")
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetMarkerSuggestions(t *testing.T) {
+	t.Run("FaceMarker", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetPhoto(router)
+		GetMarkerSuggestions(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0y11")
+
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		markerUid := gjson.Get(r.Body.String(), "Files.0.Markers.0.UID").String()
+
+		r = PerformRequest(app, "GET", fmt.Sprintf("/api/v1/markers/%s/suggestions", markerUid))
+
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetMarkerSuggestions(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/markers/mt9k3pw1wowuxxxx/suggestions")
+
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -151,6 +151,13 @@ func GetPhotoDownload(router *gin.RouterGroup) {
 			return
 		}
 
+		// Embed picture metadata in JPEG images if enabled.
+		if data, ok := downloadWithMetadata(f.PhotoUID, f.FileType, fileName); ok {
+			AddDownloadHeader(c, f.DownloadName(DownloadName(c), 0))
+			c.Data(http.StatusOK, fs.MimeTypeJPEG, data)
+			return
+		}
+
 		c.FileAttachment(fileName, f.DownloadName(DownloadName(c), 0))
 	})
 }
@@ -191,6 +198,35 @@ func GetPhotoYaml(router *gin.RouterGroup) {
 	})
 }
 
+// GetPhotoXmp returns photo metadata as XMP sidecar, including IPTC Core and Extension properties.
+//
+// GET /api/v1/photos/:uid/xmp
+// Params:
+//
+//	uid: string PhotoUID as returned by the API
+func GetPhotoXmp(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/xmp", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.AccessAll)
+
+		if s.Abort(c) {
+			return
+		}
+
+		p, err := query.PhotoPreloadByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
+		if c.Query("download") != "" {
+			AddDownloadHeader(c, clean.UID(c.Param("uid"))+".xmp")
+		}
+
+		c.Data(http.StatusOK, "application/rdf+xml; charset=utf-8", p.Xmp())
+	})
+}
+
 // ApprovePhoto marks a photo in review as approved.
 //
 // POST /api/v1/photos/:uid/approve
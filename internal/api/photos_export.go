@@ -0,0 +1,96 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// SearchPhotosExport streams the metadata of all pictures matching the search as CSV, TSV, or JSON Lines.
+// See form.SearchPhotos for supported search params and form.PhotoExport for format and column params.
+//
+// GET /api/v1/photos/export
+func SearchPhotosExport(router *gin.RouterGroup) {
+	router.GET("/photos/export", func(c *gin.Context) {
+		s := AuthAny(c, acl.ResourcePhotos, acl.Permissions{acl.ActionSearch, acl.ActionDownload})
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if !conf.Settings().Features.Download {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		var f form.PhotoExport
+		var frm form.SearchPhotos
+
+		if err := c.MustBindWith(&frm, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if err = c.ShouldBindQuery(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		format, ok := photoprism.ParsePhotoExportFormat(f.Format)
+
+		if !ok && f.Format != "" {
+			AbortBadRequest(c)
+			return
+		}
+
+		columns, err := photoprism.ParsePhotoExportColumns(f.Columns)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		settings := conf.Settings()
+
+		// Ignore private flag if feature is disabled.
+		if !settings.Features.Private {
+			frm.Public = false
+		}
+
+		// Exclude pictures in review if the user is not allowed to manage them.
+		if frm.Scope == "" &&
+			settings.Features.Review &&
+			acl.Resources.Deny(acl.ResourcePhotos, s.User().AclRole(), acl.ActionManage) {
+			frm.Quality = 3
+		}
+
+		start := time.Now()
+
+		export := photoprism.NewPhotoExport(conf, photoprism.PhotoExportOptions{
+			Format:  format,
+			Columns: columns,
+		})
+
+		fileName := export.FileName()
+
+		AddDownloadHeader(c, fileName)
+		c.Header("Content-Type", export.ContentType())
+		c.Status(http.StatusOK)
+
+		if count, err := export.Write(c.Writer, frm, s); err != nil {
+			event.AuditWarn([]string{ClientIP(c), "session %s", string(acl.ResourcePhotos), "export", "%s"}, s.RefID, err)
+		} else {
+			log.Infof("export: created %s with %s [%s]", clean.Log(fileName), english.Plural(count, "picture", "pictures"), time.Since(start))
+		}
+	})
+}
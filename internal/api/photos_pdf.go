@@ -0,0 +1,160 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/pdf"
+)
+
+// PhotoBookLimit is the maximum number of photos in a printable PDF export.
+const PhotoBookLimit = 1000
+
+// AlbumPdf returns the album pictures as printable PDF document.
+// See form.PhotoBook for supported layout params.
+//
+// GET /api/v1/albums/:uid/pdf
+func AlbumPdf(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/pdf", func(c *gin.Context) {
+		if InvalidDownloadToken(c) {
+			AbortForbidden(c)
+			return
+		}
+
+		conf := get.Config()
+
+		if !conf.Settings().Features.Download {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		a, err := query.AlbumByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		var f form.PhotoBook
+
+		if err = c.ShouldBindQuery(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		if f.Title == "" {
+			f.Title = a.AlbumTitle
+		}
+
+		// Only include public pictures, as the document may be requested with a share link.
+		photos, err := search.AlbumPhotos(a, PhotoBookLimit, true)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		writePhotoBook(c, f, photos)
+	})
+}
+
+// SearchPhotosPdf returns the pictures matching the search as printable PDF document.
+// See form.SearchPhotos for supported search params and form.PhotoBook for layout params.
+//
+// GET /api/v1/photos/pdf
+func SearchPhotosPdf(router *gin.RouterGroup) {
+	router.GET("/photos/pdf", func(c *gin.Context) {
+		s := AuthAny(c, acl.ResourcePhotos, acl.Permissions{acl.ActionSearch, acl.ActionDownload})
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if !conf.Settings().Features.Download {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		var f form.PhotoBook
+		var frm form.SearchPhotos
+
+		if err := c.MustBindWith(&frm, binding.Form); err != nil {
+			AbortBadRequest(c)
+			return
+		} else if err = c.ShouldBindQuery(&f); err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		settings := conf.Settings()
+
+		// Ignore private flag if feature is disabled.
+		if !settings.Features.Private {
+			frm.Public = false
+		}
+
+		// Exclude pictures in review if the user is not allowed to manage them.
+		if frm.Scope == "" &&
+			settings.Features.Review &&
+			acl.Resources.Deny(acl.ResourcePhotos, s.User().AclRole(), acl.ActionManage) {
+			frm.Quality = 3
+		}
+
+		if frm.Count <= 0 || frm.Count > PhotoBookLimit {
+			frm.Count = PhotoBookLimit
+		}
+
+		photos, _, err := search.UserPhotos(frm, s)
+
+		if err != nil {
+			AbortBadRequest(c)
+			return
+		}
+
+		writePhotoBook(c, f, photos)
+	})
+}
+
+// writePhotoBook writes the photos as PDF document to the response.
+func writePhotoBook(c *gin.Context, f form.PhotoBook, photos search.PhotoResults) {
+	start := time.Now()
+
+	layout, _ := photoprism.ParsePhotoBookLayout(f.Layout)
+	size, _ := pdf.FindPageSize(f.Size)
+
+	if f.Landscape {
+		size = size.Landscape()
+	}
+
+	book := photoprism.NewPhotoBook(get.Config(), photoprism.PhotoBookOptions{
+		Title:    f.Title,
+		Layout:   layout,
+		PageSize: size,
+		Captions: !f.NoCaptions,
+	})
+
+	fileName := book.FileName()
+
+	AddDownloadHeader(c, fileName)
+	c.Header("Content-Type", "application/pdf")
+	c.Status(http.StatusOK)
+
+	if pages, err := book.Write(c.Writer, photos); err != nil {
+		log.Errorf("pdf: %s", err)
+	} else {
+		log.Infof("pdf: created %s with %s [%s]", clean.Log(fileName), english.Plural(pages, "page", "pages"), time.Since(start))
+	}
+}
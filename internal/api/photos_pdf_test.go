@@ -0,0 +1,51 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlbumPdf(t *testing.T) {
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		AlbumPdf(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/pdf?t=xxx")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		AlbumPdf(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/5678/pdf?t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("ContactSheet", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		AlbumPdf(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/pdf?layout=contact&size=letter&t="+conf.DownloadToken())
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "application/pdf", r.Header().Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(r.Body.String(), "%PDF-1.4"))
+	})
+}
+
+func TestSearchPhotosPdf(t *testing.T) {
+	t.Run("Grid", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		SearchPhotosPdf(router)
+
+		r := PerformRequest(app, "GET", "/api/v1/photos/pdf?count=10&title=Family&landscape=true")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Header().Get("Content-Disposition"), "Family-grid.pdf")
+		assert.True(t, strings.HasSuffix(r.Body.String(), "%%EOF\n"))
+	})
+}
@@ -163,6 +163,23 @@ func TestGetPhotoYaml(t *testing.T) {
 	})
 }
 
+func TestGetPhotoXmp(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoXmp(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh7/xmp")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "<x:xmpmeta")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoXmp(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/xxx/xmp")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
 func TestApprovePhoto(t *testing.T) {
 	t.Run("Ok", func(t *testing.T) {
 		app, router, _ := NewApiTest()
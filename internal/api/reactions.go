@@ -6,12 +6,117 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/server/limiter"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/react"
 )
 
+// ReactionPerm checks if the session may view and add reactions to the photo or album with the specified uid.
+// Link visitors may react to all content that has been shared with them.
+func ReactionPerm(s *entity.Session, uid string) bool {
+	if acl.Resources.Allow(acl.ResourceReactions, s.User().AclRole(), acl.ActionManage) {
+		return true
+	}
+
+	for _, shareUid := range sharedUIDs(uid) {
+		if s.HasShare(shareUid) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reactionUserUID returns the uid under which the reactions of a session are stored. Link visitors
+// without a user account react anonymously, so their session is used instead.
+func reactionUserUID(s *entity.Session) string {
+	if user := s.User(); user.IsRegistered() {
+		return user.UserUID
+	}
+
+	return s.RefID
+}
+
+// getReactions returns who reacted with what to a photo or album as JSON.
+func getReactions(c *gin.Context, s *entity.Session, uid string) {
+	if !ReactionPerm(s, uid) {
+		AbortForbidden(c)
+		return
+	}
+
+	results, err := query.Reactions(uid)
+
+	if err != nil {
+		log.Errorf("react: %s", err)
+		AbortUnexpected(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// setReaction adds or changes the reaction of the session to a photo or album, and returns all reactions as JSON.
+func setReaction(c *gin.Context, s *entity.Session, uid string) {
+	if !ReactionPerm(s, uid) {
+		AbortForbidden(c)
+		return
+	}
+
+	// Limit the reaction rate of users without an account.
+	anonymous := !s.User().IsRegistered()
+
+	if anonymous && limiter.React.Reject(ClientIP(c)) {
+		limiter.AbortJSON(c)
+		return
+	}
+
+	var f form.Reaction
+
+	if err := c.BindJSON(&f); err != nil {
+		AbortBadRequest(c)
+		return
+	}
+
+	emo := react.Find(f.Reaction)
+
+	if emo.Unknown() {
+		AbortBadRequest(c)
+		return
+	}
+
+	if anonymous {
+		limiter.React.Reserve(ClientIP(c))
+	}
+
+	if _, err := entity.SetReaction(uid, reactionUserUID(s), emo); err != nil {
+		log.Errorf("react: %s", err)
+		AbortSaveFailed(c)
+		return
+	}
+
+	getReactions(c, s, uid)
+}
+
+// removeReaction deletes the reaction of the session to a photo or album, and returns the remaining reactions as JSON.
+func removeReaction(c *gin.Context, s *entity.Session, uid string) {
+	if !ReactionPerm(s, uid) {
+		AbortForbidden(c)
+		return
+	}
+
+	if err := entity.RemoveReaction(uid, reactionUserUID(s)); err != nil {
+		log.Errorf("react: %s", err)
+		AbortDeleteFailed(c)
+		return
+	}
+
+	getReactions(c, s, uid)
+}
+
 // LikePhoto flags a photo as favorite.
 //
 // POST /api/v1/photos/:uid/like
@@ -91,3 +196,135 @@ func DislikePhoto(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, gin.H{"photo": m})
 	})
 }
+
+// GetPhotoReactions returns who reacted with what to a photo.
+//
+// GET /api/v1/photos/:uid/reactions
+func GetPhotoReactions(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/reactions", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceReactions, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		if _, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		getReactions(c, s, uid)
+	})
+}
+
+// ReactToPhoto adds or changes the reaction of the current user to a photo.
+//
+// PUT /api/v1/photos/:uid/reactions
+func ReactToPhoto(router *gin.RouterGroup) {
+	router.PUT("/photos/:uid/reactions", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceReactions, acl.ActionReact)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		if _, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		setReaction(c, s, uid)
+	})
+}
+
+// RemovePhotoReaction deletes the reaction of the current user to a photo.
+//
+// DELETE /api/v1/photos/:uid/reactions
+func RemovePhotoReaction(router *gin.RouterGroup) {
+	router.DELETE("/photos/:uid/reactions", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceReactions, acl.ActionReact)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		if _, err := query.PhotoByUID(uid); err != nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		removeReaction(c, s, uid)
+	})
+}
+
+// GetAlbumReactions returns who reacted with what to an album.
+//
+// GET /api/v1/albums/:uid/reactions
+func GetAlbumReactions(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/reactions", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceReactions, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		if _, err := query.AlbumByUID(uid); err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		getReactions(c, s, uid)
+	})
+}
+
+// ReactToAlbum adds or changes the reaction of the current user to an album.
+//
+// PUT /api/v1/albums/:uid/reactions
+func ReactToAlbum(router *gin.RouterGroup) {
+	router.PUT("/albums/:uid/reactions", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceReactions, acl.ActionReact)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		if _, err := query.AlbumByUID(uid); err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		setReaction(c, s, uid)
+	})
+}
+
+// RemoveAlbumReaction deletes the reaction of the current user to an album.
+//
+// DELETE /api/v1/albums/:uid/reactions
+func RemoveAlbumReaction(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/reactions", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceReactions, acl.ActionReact)
+
+		if s.Abort(c) {
+			return
+		}
+
+		uid := clean.UID(c.Param("uid"))
+
+		if _, err := query.AlbumByUID(uid); err != nil {
+			AbortAlbumNotFound(c)
+			return
+		}
+
+		removeReaction(c, s, uid)
+	})
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/pkg/react"
+)
+
+func TestGetPhotoReactions(t *testing.T) {
+	t.Run("Ok", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoReactions(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0yh8/reactions")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.GreaterOrEqual(t, len(gjson.Get(r.Body.String(), "@this").Array()), 2)
+		assert.Equal(t, react.Love.String(), gjson.Get(r.Body.String(), "0.Reaction").String())
+		assert.NotEmpty(t, gjson.Get(r.Body.String(), "0.Name").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoReactions(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/pt9jtdre2lvl0xxx/reactions")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestPhotoReactions(t *testing.T) {
+	app, router, _ := NewApiTest()
+	ReactToPhoto(router)
+	RemovePhotoReaction(router)
+
+	t.Run("Name", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0yh0/reactions", `{"Reaction": "rainbow"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), react.Rainbow.String())
+	})
+	t.Run("Emoji", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0yh0/reactions", `{"Reaction": "🔥"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), "🔥")
+		assert.NotContains(t, r.Body.String(), react.Rainbow.String())
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/pt9jtdre2lvl0yh0/reactions", `{"Reaction": "foo"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("Remove", func(t *testing.T) {
+		r := PerformRequest(app, "DELETE", "/api/v1/photos/pt9jtdre2lvl0yh0/reactions")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotContains(t, r.Body.String(), "🔥")
+	})
+}
+
+func TestAlbumReactions(t *testing.T) {
+	app, router, _ := NewApiTest()
+	GetAlbumReactions(router)
+	ReactToAlbum(router)
+	RemoveAlbumReaction(router)
+
+	r := PerformRequestWithBody(app, "PUT", "/api/v1/albums/at9lxuqxpogaaba8/reactions", `{"Reaction": "in-love"}`)
+	assert.Equal(t, http.StatusOK, r.Code)
+
+	r = PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogaaba8/reactions")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Len(t, gjson.Get(r.Body.String(), "@this").Array(), 1)
+
+	r = PerformRequest(app, "DELETE", "/api/v1/albums/at9lxuqxpogaaba8/reactions")
+	assert.Equal(t, http.StatusOK, r.Code)
+	assert.Empty(t, gjson.Get(r.Body.String(), "@this").Array())
+
+	r = PerformRequest(app, "GET", "/api/v1/albums/at9lxuqxpogxxxxx/reactions")
+	assert.Equal(t, http.StatusNotFound, r.Code)
+}
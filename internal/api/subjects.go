@@ -11,6 +11,7 @@ import (
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -158,3 +159,36 @@ func DislikeSubject(router *gin.RouterGroup) {
 		c.JSON(http.StatusOK, http.Response{})
 	})
 }
+
+// GetSubjectSuggestions returns pet markers without subject that look similar to a known pet.
+//
+// GET /api/v1/subjects/:uid/suggestions
+func GetSubjectSuggestions(router *gin.RouterGroup) {
+	router.GET("/subjects/:uid/suggestions", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePeople, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		subj := entity.FindSubject(clean.UID(c.Param("uid")))
+
+		if subj == nil {
+			Abort(c, http.StatusNotFound, i18n.ErrSubjectNotFound)
+			return
+		} else if !subj.IsPet() {
+			AbortBadRequest(c)
+			return
+		}
+
+		markers, err := photoprism.SimilarPetMarkers(subj.SubjUID, txt.Int(c.Query("count")))
+
+		if err != nil {
+			log.Errorf("pets: %s (find similar markers)", err)
+			AbortUnexpected(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, markers)
+	})
+}
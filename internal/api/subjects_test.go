@@ -120,3 +120,18 @@ func TestUpdateSubject(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, r.Code)
 	})
 }
+
+func TestGetSubjectSuggestions(t *testing.T) {
+	t.Run("NotPet", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSubjectSuggestions(router)
+		r := PerformRequest(app, "GET", "/api/v1/subjects/jqy1y111h1njaaaa/suggestions")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetSubjectSuggestions(router)
+		r := PerformRequest(app, "GET", "/api/v1/subjects/xxx1y111h1njaaaa/suggestions")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/query"
@@ -22,7 +23,7 @@ import (
 //
 // Parameters:
 //
-//	thumb: string sha1 file hash plus optional crop area
+//	thumb: string sha1 file hash plus optional edit checksum or crop area
 //	token: string url security token, see config
 //	size: string thumb type, see thumb.Sizes
 func GetThumb(router *gin.RouterGroup) {
@@ -39,6 +40,9 @@ func GetThumb(router *gin.RouterGroup) {
 		download := c.Query("download") != ""
 		fileHash, cropArea := crop.ParseThumb(clean.Token(c.Param("thumb")))
 
+		// Thumbnails of edited files are requested with a checksum of the adjustments.
+		fileHash, editChecksum := thumb.ParseKey(fileHash)
+
 		// Is cropped thumbnail?
 		if cropArea != "" {
 			cropName := crop.Name(clean.Token(c.Param("size")))
@@ -95,6 +99,16 @@ func GetThumb(router *gin.RouterGroup) {
 			}
 		}
 
+		// Apply non-destructive adjustments if the file was edited.
+		if edit := entity.FindFileEditByHash(fileHash); edit != nil {
+			editedThumb(c, fileHash, size, edit.Edit(), editChecksum, download)
+			return
+		} else if editChecksum != "" {
+			// Adjustments have been reset, so the URL must not be cached permanently.
+			editedThumb(c, fileHash, size, thumb.Edit{}, editChecksum, download)
+			return
+		}
+
 		cache := get.ThumbCache()
 		cacheKey := CacheKey("thumbs", fileHash, string(sizeName))
 
@@ -231,3 +245,48 @@ func GetThumb(router *gin.RouterGroup) {
 		}
 	})
 }
+
+// editedThumb returns a thumbnail of an edited image, which is only cached as immutable content
+// if the URL contains the checksum of the current adjustments.
+func editedThumb(c *gin.Context, fileHash string, size thumb.Size, edit thumb.Edit, checksum string, download bool) {
+	conf := get.Config()
+
+	f, err := query.FileByHash(fileHash)
+
+	if err != nil {
+		c.Data(http.StatusOK, "image/svg+xml", photoIconSvg)
+		return
+	} else if f.FileError != "" || f.NoJPEG() && f.NoPNG() {
+		c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+		return
+	}
+
+	fileName, err := fs.Resolve(photoprism.FileName(f.FileRoot, f.FileName))
+
+	if err != nil {
+		log.Errorf("thumb: file %s is missing", clean.Log(f.FileName))
+		c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+		return
+	}
+
+	thumbName, err := size.FromEdit(fileName, f.FileHash, conf.ThumbCachePath(), f.FileOrientation, edit)
+
+	if err != nil {
+		log.Errorf("thumb: %s (edited)", err)
+		c.Data(http.StatusOK, "image/svg+xml", brokenIconSvg)
+		return
+	}
+
+	// URLs with a matching checksum always refer to the same image and can be cached permanently.
+	if checksum != "" && !edit.Empty() && checksum == edit.Checksum() {
+		AddImmutableCacheHeader(c)
+	} else {
+		AddCacheHeader(c, CoverMaxAge, thumb.CachePublic)
+	}
+
+	if download {
+		c.FileAttachment(thumbName, f.DownloadName(DownloadName(c), 0))
+	} else {
+		c.File(thumbName)
+	}
+}
@@ -37,6 +37,9 @@ var stop = make(chan bool, 1)
 
 // Start periodically checks if the library needs to be indexed or files need to be imported.
 func Start(conf *config.Config) {
+	// Watch originals and import folders for changes if enabled.
+	Watch(conf)
+
 	// Do not start the ticker if both are disabled.
 	if conf.AutoIndex().Seconds() <= 0 && conf.AutoImport().Seconds() <= 0 {
 		return
@@ -69,7 +72,8 @@ func Start(conf *config.Config) {
 	}()
 }
 
-// Stop stops waiting for indexing & importing opportunities.
+// Stop stops waiting for indexing & importing opportunities and watching for changes.
 func Stop() {
 	stop <- true
+	StopWatch()
 }
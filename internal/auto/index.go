@@ -1,10 +1,13 @@
 package auto
 
 import (
+	"errors"
 	"path/filepath"
 	"sync"
 	"time"
 
+	"github.com/dustin/go-humanize/english"
+
 	"github.com/photoprism/photoprism/internal/api"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
@@ -12,11 +15,15 @@ import (
 	"github.com/photoprism/photoprism/internal/i18n"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 var autoIndex = time.Time{}
 var indexMutex = sync.Mutex{}
 
+// ErrIndexBusy is returned if changes could not be indexed because another worker is running.
+var ErrIndexBusy = errors.New("index: busy, try again later")
+
 // ResetIndex resets the auto index trigger time.
 func ResetIndex() {
 	indexMutex.Lock()
@@ -118,3 +125,100 @@ func Index() error {
 
 	return nil
 }
+
+// IndexChanges indexes changed files and folders in the originals folder, e.g. after they have been
+// added or modified on disk, and flags files that have been removed or moved away as missing.
+// Returns ErrIndexBusy if another worker is running, so that the changes can be indexed later.
+func IndexChanges(fileNames []string) error {
+	if len(fileNames) == 0 {
+		return nil
+	} else if mutex.MainWorker.Running() {
+		return ErrIndexBusy
+	}
+
+	conf := get.Config()
+	settings := conf.Settings()
+
+	start := time.Now()
+
+	path := conf.OriginalsPath()
+
+	var changed []string
+	removed := make(map[string]bool)
+
+	for _, fileName := range fileNames {
+		if fs.PathExists(fileName) || fs.FileExists(fileName) {
+			changed = append(changed, fileName)
+		} else if dir := filepath.Dir(fs.RelName(fileName, path)); dir != "." {
+			removed[filepath.Join(entity.RootPath, dir)] = true
+		} else {
+			// Check only the removed file or folder itself if it was in the originals root,
+			// as checking the root would include the whole library.
+			removed[filepath.Join(entity.RootPath, fs.RelName(fileName, path))] = true
+		}
+	}
+
+	ind := get.Index()
+
+	convert := settings.Index.Convert && conf.SidecarWritable()
+	indOpt := photoprism.IndexOptionsFiles(changed, convert)
+	indOpt.Action = photoprism.ActionAutoIndex
+
+	found := make(fs.Done)
+	indexed := 0
+
+	if len(changed) > 0 {
+		started := entity.TimeStamp()
+		found, indexed = ind.Start(indOpt)
+
+		// Indexing did not run if another worker has started in the meantime.
+		if lastRun, _ := ind.LastRun(); lastRun.Before(started) && mutex.MainWorker.Running() {
+			return ErrIndexBusy
+		}
+	}
+
+	if indexed == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	api.RemoveFromFolderCache(entity.RootOriginals)
+
+	prg := get.Purge()
+
+	// Flag files in folders with removed files as missing.
+	for dir := range removed {
+		prgOpt := photoprism.PurgeOptions{
+			Path:   dir,
+			Ignore: found,
+			Force:  true,
+		}
+
+		if files, photos, updated, err := prg.Start(prgOpt); err != nil && mutex.MainWorker.Running() {
+			return ErrIndexBusy
+		} else if err != nil {
+			return err
+		} else if updated > 0 {
+			event.InfoMsg(i18n.MsgRemovedFilesAndPhotos, len(files), len(photos))
+		}
+	}
+
+	moments := get.Moments()
+
+	if err := moments.Start(); err != nil {
+		log.Warnf("moments: %s", err)
+	}
+
+	log.Infof("watch: indexed %s and checked %s [%s]", english.Plural(indexed, "file", "files"),
+		english.Plural(len(removed), "folder", "folders"), time.Since(start))
+
+	event.Publish("index.completed", event.Data{
+		"uid":     indOpt.UID,
+		"action":  indOpt.Action,
+		"path":    path,
+		"seconds": int(time.Since(start).Seconds()),
+	})
+
+	api.UpdateClientConfig()
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package auto
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/watch"
+)
+
+var stopWatch = make(chan bool, 1)
+
+// Changes represents changed file and folder names that have not been indexed yet.
+type Changes map[string]bool
+
+// Add adds a changed file or folder name.
+func (c Changes) Add(fileName string) {
+	c[filepath.Clean(fileName)] = true
+}
+
+// Names returns the sorted file and folder names without the ones in changed parent folders,
+// as folders are indexed including their contents.
+func (c Changes) Names() (result []string) {
+	names := make([]string, 0, len(c))
+
+	for name := range c {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	dir := ""
+
+	for _, name := range names {
+		if dir != "" && strings.HasPrefix(name, dir+string(filepath.Separator)) {
+			continue
+		}
+
+		if fs.PathExists(name) {
+			dir = name
+		}
+
+		result = append(result, name)
+	}
+
+	return result
+}
+
+// Watch starts watching the originals and import folders for changes if enabled, so that changed files
+// can be indexed incrementally. It falls back to periodic full rescans if changes cannot be watched.
+func Watch(conf *config.Config) {
+	if !conf.Watch() {
+		return
+	}
+
+	w, err := watch.New()
+
+	if err == nil {
+		err = w.Add(conf.OriginalsPath())
+	}
+
+	if err == nil && watchImport(conf) {
+		err = w.Add(conf.ImportPath())
+	}
+
+	if err != nil {
+		if w != nil {
+			_ = w.Close()
+		}
+
+		log.Warnf("%s, falling back to full rescans", err)
+		go rescan(conf)
+		return
+	}
+
+	log.Infof("watch: watching %s for changes", english.Plural(w.Len(), "folder", "folders"))
+
+	go watchChanges(conf, w)
+}
+
+// StopWatch stops watching for changes.
+func StopWatch() {
+	select {
+	case stopWatch <- true:
+	default:
+	}
+}
+
+// watchImport tests if the import folder should be watched.
+func watchImport(conf *config.Config) bool {
+	return conf.AutoImport() > 0 && conf.ImportPath() != conf.OriginalsPath() && fs.PathExists(conf.ImportPath())
+}
+
+// watchChanges collects changes until the quiet period has passed, and then indexes them.
+func watchChanges(conf *config.Config, w *watch.Watcher) {
+	originalsPath := conf.OriginalsPath()
+	importPath := conf.ImportPath()
+	importEnabled := watchImport(conf)
+
+	// Path prefixes with separator, so that sibling folders like "import2" do not match.
+	originalsPrefix := originalsPath + string(os.PathSeparator)
+	importPrefix := importPath + string(os.PathSeparator)
+	delay := conf.WatchDelay()
+
+	changes := make(Changes)
+	rescanAll := false
+
+	timer := time.NewTimer(delay)
+	timer.Stop()
+
+	for {
+		select {
+		case <-stopWatch:
+			timer.Stop()
+			_ = w.Close()
+			return
+		case ev, ok := <-w.Events:
+			if !ok {
+				return
+			}
+
+			switch {
+			case ev.Op == watch.Overflow:
+				log.Warnf("watch: too many changes, rescanning originals")
+				rescanAll = true
+			case importEnabled && strings.HasPrefix(ev.Name, importPrefix):
+				ShouldImport()
+				continue
+			case strings.HasPrefix(ev.Name, originalsPrefix):
+				log.Tracef("watch: %s %s", ev.Op, clean.Log(fs.RelName(ev.Name, originalsPath)))
+				changes.Add(ev.Name)
+			default:
+				continue
+			}
+
+			timer.Reset(delay)
+		case err, ok := <-w.Errors:
+			if !ok {
+				return
+			}
+
+			log.Warnf("%s", err)
+
+			// Fall back to full rescans if the maximum number of watches has been reached.
+			if errors.Is(err, watch.ErrLimit) {
+				_ = w.Close()
+				timer.Stop()
+				indexChanges(nil, true)
+				go rescan(conf)
+				return
+			}
+		case <-timer.C:
+			if mutex.MainWorker.Running() {
+				timer.Reset(delay)
+				continue
+			}
+
+			// Keep the changes pending if another worker has started in the meantime.
+			if err := indexChanges(changes.Names(), rescanAll); errors.Is(err, ErrIndexBusy) {
+				timer.Reset(delay)
+				continue
+			}
+
+			changes = make(Changes)
+			rescanAll = false
+		}
+	}
+}
+
+// indexChanges indexes the changed files and folders, or all originals if rescanAll is true.
+// Returns ErrIndexBusy if the changes could not be indexed because another worker is running.
+func indexChanges(fileNames []string, rescanAll bool) error {
+	if rescanAll {
+		log.Debugf("watch: rescanning originals")
+
+		if err := Index(); err != nil {
+			log.Errorf("watch: %s", err)
+		}
+	} else if len(fileNames) > 0 {
+		log.Debugf("watch: indexing %s", english.Plural(len(fileNames), "change", "changes"))
+
+		if err := IndexChanges(fileNames); errors.Is(err, ErrIndexBusy) {
+			log.Debugf("watch: %s", err)
+			return err
+		} else if err != nil {
+			log.Errorf("watch: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// rescan periodically indexes all originals and imports new files if changes cannot be watched.
+func rescan(conf *config.Config) {
+	interval := conf.WatchRescan()
+
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+
+	for {
+		select {
+		case <-stopWatch:
+			ticker.Stop()
+			return
+		case <-ticker.C:
+			if mutex.MainWorker.Running() {
+				continue
+			}
+
+			indexChanges(nil, true)
+
+			if conf.AutoImport() > 0 {
+				ShouldImport()
+			}
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package auto
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+)
+
+func TestChanges_Names(t *testing.T) {
+	dir := t.TempDir()
+	album := filepath.Join(dir, "2023")
+
+	if err := os.Mkdir(album, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := make(Changes)
+
+	changes.Add(filepath.Join(dir, "b.jpg"))
+	changes.Add(filepath.Join(album, "a.jpg"))
+	changes.Add(album + "/")
+	changes.Add(filepath.Join(album, "sub", "c.jpg"))
+	changes.Add(filepath.Join(dir, "2023 Summer", "d.jpg"))
+	changes.Add(filepath.Join(dir, "removed"))
+	changes.Add(filepath.Join(dir, "removed", "e.jpg"))
+
+	assert.Equal(t, []string{
+		album,
+		filepath.Join(dir, "2023 Summer", "d.jpg"),
+		filepath.Join(dir, "b.jpg"),
+		filepath.Join(dir, "removed"),
+		filepath.Join(dir, "removed", "e.jpg"),
+	}, changes.Names())
+}
+
+func TestWatch(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		conf := config.TestConfig()
+
+		Watch(conf)
+		StopWatch()
+		StopWatch()
+	})
+}
+
+func TestIndexChanges(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.NoError(t, IndexChanges(nil))
+	})
+	t.Run("Busy", func(t *testing.T) {
+		if err := mutex.MainWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer mutex.MainWorker.Stop()
+
+		assert.ErrorIs(t, IndexChanges([]string{"/photos/originals/2023"}), ErrIndexBusy)
+	})
+}
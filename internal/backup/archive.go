@@ -0,0 +1,224 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Archive writes a compressed backup archive, and creates a checksum file when it is closed.
+// The archive is written to a temporary file first, so that incomplete archives are never
+// mistaken for valid backups.
+type Archive struct {
+	fileName string
+	tmpName  string
+	file     *os.File
+	gz       *gzip.Writer
+	tw       *tar.Writer
+	manifest Manifest
+}
+
+// FileName returns the archive file name for the specified time.
+func FileName(dir string, t time.Time) string {
+	return filepath.Join(dir, FilePrefix+t.UTC().Format(TimeFormat)+FileExt)
+}
+
+// NewArchive creates a new backup archive.
+func NewArchive(fileName, driver string) (*Archive, error) {
+	if err := os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+		return nil, err
+	}
+
+	tmpName := fileName + ".tmp"
+
+	f, err := os.OpenFile(tmpName, os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(f)
+
+	return &Archive{
+		fileName: fileName,
+		tmpName:  tmpName,
+		file:     f,
+		gz:       gz,
+		tw:       tar.NewWriter(gz),
+		manifest: Manifest{Version: ManifestVersion, CreatedAt: time.Now().UTC(), Driver: driver},
+	}, nil
+}
+
+// FileName returns the archive file name.
+func (a *Archive) FileName() string {
+	return a.fileName
+}
+
+// Manifest returns the manifest of the files added so far.
+func (a *Archive) Manifest() Manifest {
+	return a.manifest
+}
+
+// AddFile adds a file to the archive.
+func (a *Archive) AddFile(name, src string) error {
+	f, err := os.Open(src)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	info, err := f.Stat()
+
+	if err != nil {
+		return err
+	}
+
+	return a.add(name, f, info.Size(), info.ModTime())
+}
+
+// AddDir adds all files with the specified extensions in a directory to the archive, keeping their relative paths.
+func (a *Archive) AddDir(prefix, dir string, extensions ...string) (count int, err error) {
+	if !fs.PathExists(dir) {
+		return 0, nil
+	}
+
+	err = filepath.Walk(dir, func(fileName string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		} else if info.IsDir() || !hasExt(fileName, extensions) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, fileName)
+
+		if err != nil {
+			return err
+		}
+
+		if err = a.AddFile(path.Join(prefix, filepath.ToSlash(rel)), fileName); err != nil {
+			return err
+		}
+
+		count++
+
+		return nil
+	})
+
+	return count, err
+}
+
+// add writes a file to the archive and adds it to the manifest.
+func (a *Archive) add(name string, r io.Reader, size int64, modTime time.Time) error {
+	if a.manifest.Find(name) != nil {
+		return fmt.Errorf("%s has already been added", name)
+	}
+
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(fs.ModeFile),
+		Size:     size,
+		ModTime:  modTime,
+	}
+
+	if err := a.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+
+	h := sha256.New()
+
+	if n, err := io.Copy(a.tw, io.TeeReader(r, h)); err != nil {
+		return err
+	} else if n != size {
+		return fmt.Errorf("%s changed while it was added (%d of %d bytes)", name, n, size)
+	}
+
+	a.manifest.Files = append(a.manifest.Files, File{Name: name, Size: size, Hash: hex.EncodeToString(h.Sum(nil))})
+
+	return nil
+}
+
+// Close adds the manifest, completes the archive and writes its checksum file.
+func (a *Archive) Close() (err error) {
+	defer func() {
+		if err != nil {
+			_ = a.file.Close()
+			_ = os.Remove(a.tmpName)
+		}
+	}()
+
+	data, err := json.MarshalIndent(a.manifest, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     ManifestName,
+		Mode:     int64(fs.ModeFile),
+		Size:     int64(len(data)),
+		ModTime:  a.manifest.CreatedAt,
+	}
+
+	if err = a.tw.WriteHeader(hdr); err != nil {
+		return err
+	} else if _, err = a.tw.Write(data); err != nil {
+		return err
+	} else if err = a.tw.Close(); err != nil {
+		return err
+	} else if err = a.gz.Close(); err != nil {
+		return err
+	} else if err = a.file.Sync(); err != nil {
+		return err
+	} else if err = a.file.Close(); err != nil {
+		return err
+	}
+
+	hash, err := Checksum(a.tmpName)
+
+	if err != nil {
+		return err
+	}
+
+	if err = os.Rename(a.tmpName, a.fileName); err != nil {
+		return err
+	}
+
+	return WriteChecksum(a.fileName, hash)
+}
+
+// Abort cancels writing the archive and removes the temporary file.
+func (a *Archive) Abort() {
+	_ = a.file.Close()
+	_ = os.Remove(a.tmpName)
+}
+
+// hasExt tests if the file name has one of the specified extensions.
+func hasExt(fileName string, extensions []string) bool {
+	if len(extensions) == 0 {
+		return true
+	}
+
+	ext := filepath.Ext(fileName)
+
+	for _, e := range extensions {
+		if ext == e {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,139 @@
+package backup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testDump = "PRAGMA foreign_keys=OFF;\nBEGIN TRANSACTION;\n" +
+	"CREATE TABLE IF NOT EXISTS \"photos\" (\"id\" integer primary key autoincrement);\n" +
+	"INSERT INTO photos VALUES(1);\nINSERT INTO photos VALUES(2);\n" +
+	"CREATE TABLE IF NOT EXISTS \"albums\" (\"id\" integer primary key autoincrement);\n" +
+	"COMMIT;\n"
+
+// createTestArchive creates an archive with an index dump and an album YAML file.
+func createTestArchive(t *testing.T, dir, dump string) string {
+	dumpFile := filepath.Join(dir, "dump.sql")
+	albumFile := filepath.Join(dir, "albums", "album", "at9lxuqxpogaaba8.yml")
+
+	if err := os.WriteFile(dumpFile, []byte(dump), 0o644); err != nil {
+		t.Fatal(err)
+	} else if err = os.MkdirAll(filepath.Dir(albumFile), 0o755); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(albumFile, []byte("UID: at9lxuqxpogaaba8\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := NewArchive(FileName(dir, time.Now()), SQLite3)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = a.AddFile(IndexName, dumpFile); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := a.AddDir(AlbumsDir, filepath.Join(dir, "albums"), ".yml"); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Equal(t, 1, n)
+	}
+
+	if err = a.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return a.FileName()
+}
+
+func TestArchive(t *testing.T) {
+	t.Run("Verify", func(t *testing.T) {
+		fileName := createTestArchive(t, t.TempDir(), testDump)
+
+		assert.FileExists(t, fileName+ChecksumExt)
+		assert.NoFileExists(t, fileName+".tmp")
+
+		manifest, err := Verify(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, SQLite3, manifest.Driver)
+		assert.Len(t, manifest.Files, 2)
+		assert.NotNil(t, manifest.Find(IndexName))
+		assert.NotNil(t, manifest.Find("albums/album/at9lxuqxpogaaba8.yml"))
+	})
+	t.Run("Truncated", func(t *testing.T) {
+		fileName := createTestArchive(t, t.TempDir(), testDump)
+
+		data, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = os.WriteFile(fileName, data[:len(data)/2], 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = Verify(fileName)
+		assert.Error(t, err)
+
+		// Still fails if the checksum file is updated as well.
+		hash, _ := Checksum(fileName)
+		assert.NoError(t, WriteChecksum(fileName, hash))
+
+		_, err = Verify(fileName)
+		assert.Error(t, err)
+	})
+	t.Run("IncompleteDump", func(t *testing.T) {
+		fileName := createTestArchive(t, t.TempDir(), testDump[:len(testDump)-8])
+
+		_, err := Verify(fileName)
+		assert.EqualError(t, err, "index dump is incomplete")
+	})
+	t.Run("NoChecksum", func(t *testing.T) {
+		fileName := createTestArchive(t, t.TempDir(), testDump)
+
+		assert.NoError(t, os.Remove(fileName+ChecksumExt))
+
+		_, err := Verify(fileName)
+		assert.Error(t, err)
+	})
+}
+
+func TestExtract(t *testing.T) {
+	dir := t.TempDir()
+	fileName := createTestArchive(t, dir, testDump)
+
+	t.Run("Index", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.NoError(t, Extract(fileName, IndexName, &buf))
+		assert.Equal(t, testDump, buf.String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.Error(t, Extract(fileName, "foo.sql", &buf))
+	})
+	t.Run("Albums", func(t *testing.T) {
+		dest := filepath.Join(dir, "restored")
+
+		count, err := ExtractDir(fileName, AlbumsDir, dest, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.FileExists(t, filepath.Join(dest, "album", "at9lxuqxpogaaba8.yml"))
+
+		count, err = ExtractDir(fileName, AlbumsDir, dest, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, count)
+	})
+}
@@ -0,0 +1,56 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Checksum returns the SHA256 checksum of a file as hex string.
+func Checksum(fileName string) (string, error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer f.Close()
+
+	h := sha256.New()
+
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// WriteChecksum writes the checksum of an archive to a file in the format used by sha256sum.
+func WriteChecksum(fileName, hash string) error {
+	data := fmt.Sprintf("%s  %s\n", hash, filepath.Base(fileName))
+
+	return os.WriteFile(fileName+ChecksumExt, []byte(data), fs.ModeFile)
+}
+
+// ReadChecksum returns the checksum of an archive from its checksum file.
+func ReadChecksum(fileName string) (string, error) {
+	data, err := os.ReadFile(fileName + ChecksumExt)
+
+	if err != nil {
+		return "", err
+	}
+
+	fields := strings.Fields(string(data))
+
+	if len(fields) == 0 || len(fields[0]) != sha256.Size*2 {
+		return "", fmt.Errorf("invalid checksum file")
+	}
+
+	return strings.ToLower(fields[0]), nil
+}
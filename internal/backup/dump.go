@@ -0,0 +1,127 @@
+package backup
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Database drivers that can create index dumps.
+const (
+	MySQL   = "mysql"
+	MariaDB = "mariadb"
+	SQLite3 = "sqlite3"
+)
+
+var createTableRegexp = regexp.MustCompile("^CREATE TABLE (?:IF NOT EXISTS )?[`\"]?([A-Za-z0-9_]+)")
+var insertIntoRegexp = regexp.MustCompile("^INSERT INTO [`\"]?([A-Za-z0-9_]+)")
+
+// DumpInfo contains information about an index dump, such as the tables it creates.
+type DumpInfo struct {
+	Size     int64
+	Tables   map[string]int
+	LastLine string
+}
+
+// ReadDump reads an SQL index dump and returns information about its contents.
+func ReadDump(r io.Reader) (*DumpInfo, error) {
+	info := &DumpInfo{Tables: make(map[string]int)}
+	br := bufio.NewReader(r)
+
+	for {
+		line, err := br.ReadString('\n')
+		info.Size += int64(len(line))
+
+		if s := strings.TrimSpace(line); s != "" {
+			info.LastLine = s
+
+			if m := createTableRegexp.FindStringSubmatch(s); m != nil {
+				if _, ok := info.Tables[m[1]]; !ok {
+					info.Tables[m[1]] = 0
+				}
+			} else if m = insertIntoRegexp.FindStringSubmatch(s); m != nil {
+				info.Tables[m[1]]++
+			}
+		}
+
+		if err == io.EOF {
+			return info, nil
+		} else if err != nil {
+			return info, err
+		}
+	}
+}
+
+// Complete checks if the dump is complete, as truncated dumps lack the trailer written by the dump tool.
+func (info *DumpInfo) Complete(driver string) error {
+	if info.Size == 0 {
+		return fmt.Errorf("index dump is empty")
+	}
+
+	switch driver {
+	case SQLite3:
+		if info.LastLine != "COMMIT;" {
+			return fmt.Errorf("index dump is incomplete")
+		}
+	case MySQL, MariaDB:
+		if !strings.HasPrefix(info.LastLine, "-- Dump completed") {
+			return fmt.Errorf("index dump is incomplete")
+		}
+	}
+
+	return nil
+}
+
+// TableNames returns the sorted names of the tables created by the dump.
+func (info *DumpInfo) TableNames() []string {
+	result := make([]string, 0, len(info.Tables))
+
+	for name := range info.Tables {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// Compare returns the tables of the current schema that are missing in the dump, and the tables
+// in the dump that are unknown to the current schema.
+func (info *DumpInfo) Compare(schema []string) (missing, unknown []string) {
+	known := make(map[string]bool, len(schema))
+
+	for _, name := range schema {
+		known[name] = true
+
+		if _, ok := info.Tables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	for _, name := range info.TableNames() {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+
+	sort.Strings(missing)
+
+	return missing, unknown
+}
+
+// ReadDumpFile reads an SQL index dump file and returns information about its contents.
+func ReadDumpFile(fileName string) (*DumpInfo, error) {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return ReadDump(f)
+}
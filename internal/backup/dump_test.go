@@ -0,0 +1,54 @@
+package backup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadDump(t *testing.T) {
+	t.Run("SQLite", func(t *testing.T) {
+		info, err := ReadDump(strings.NewReader(testDump))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int64(len(testDump)), info.Size)
+		assert.Equal(t, []string{"albums", "photos"}, info.TableNames())
+		assert.Equal(t, 2, info.Tables["photos"])
+		assert.NoError(t, info.Complete(SQLite3))
+		assert.Error(t, info.Complete(MySQL))
+	})
+	t.Run("MySQL", func(t *testing.T) {
+		dump := "CREATE TABLE `photos` (\n  `id` int(10)\n);\nINSERT INTO `photos` VALUES (1),(2);\n-- Dump completed on 2023-01-01 12:00:00\n"
+		info, err := ReadDump(strings.NewReader(dump))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, info.Tables["photos"])
+		assert.NoError(t, info.Complete(MariaDB))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		info, err := ReadDump(strings.NewReader(""))
+
+		assert.NoError(t, err)
+		assert.EqualError(t, info.Complete(SQLite3), "index dump is empty")
+	})
+}
+
+func TestDumpInfo_Compare(t *testing.T) {
+	info, err := ReadDump(strings.NewReader(testDump))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	missing, unknown := info.Compare([]string{"photos", "files"})
+
+	assert.Equal(t, []string{"files"}, missing)
+	assert.Equal(t, []string{"albums"}, unknown)
+}
@@ -0,0 +1,124 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// walk calls fn for each file in an archive until it returns an error or io.EOF.
+func walk(fileName string, fn func(hdr *tar.Header, r io.Reader) error) error {
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+
+	if err != nil {
+		return err
+	}
+
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+
+	for {
+		hdr, err := tr.Next()
+
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if err = fn(hdr, tr); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// Extract writes the file with the specified name in an archive to w.
+func Extract(fileName, name string, w io.Writer) error {
+	found := false
+
+	err := walk(fileName, func(hdr *tar.Header, r io.Reader) error {
+		if hdr.Name != name {
+			return nil
+		}
+
+		found = true
+
+		if _, err := io.Copy(w, r); err != nil {
+			return err
+		}
+
+		return io.EOF
+	})
+
+	if err != nil {
+		return err
+	} else if !found {
+		return fmt.Errorf("%s not found in archive", name)
+	}
+
+	return nil
+}
+
+// ExtractDir extracts all files in an archive directory to the specified path, and returns the number of
+// extracted files. Existing files are only replaced if force is true.
+func ExtractDir(fileName, prefix, dir string, force bool) (count int, err error) {
+	prefix = strings.Trim(prefix, "/") + "/"
+
+	err = walk(fileName, func(hdr *tar.Header, r io.Reader) error {
+		if !strings.HasPrefix(hdr.Name, prefix) || hdr.Typeflag != tar.TypeReg {
+			return nil
+		}
+
+		rel := path.Clean(strings.TrimPrefix(hdr.Name, prefix))
+
+		// Ignore names that point outside the destination directory.
+		if rel == "." || strings.HasPrefix(rel, "../") || path.IsAbs(rel) {
+			return nil
+		}
+
+		dest := filepath.Join(dir, filepath.FromSlash(rel))
+
+		if fs.FileExists(dest) && !force {
+			return nil
+		}
+
+		if err := os.MkdirAll(filepath.Dir(dest), fs.ModeDir); err != nil {
+			return err
+		}
+
+		f, err := os.OpenFile(dest, os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeFile)
+
+		if err != nil {
+			return err
+		}
+
+		if _, err = io.Copy(f, r); err != nil {
+			_ = f.Close()
+			return err
+		}
+
+		count++
+
+		return f.Close()
+	})
+
+	return count, err
+}
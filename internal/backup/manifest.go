@@ -0,0 +1,43 @@
+package backup
+
+import (
+	"time"
+)
+
+// ManifestVersion is the current version of the archive manifest format.
+const ManifestVersion = 1
+
+// File represents a file in a backup archive along with its size and SHA256 checksum.
+type File struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+	Hash string `json:"sha256"`
+}
+
+// Manifest describes the contents of a backup archive so that it can be verified.
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Driver    string    `json:"driver"`
+	Files     []File    `json:"files"`
+}
+
+// Find returns the manifest entry with the specified name, or nil if it was not found.
+func (m *Manifest) Find(name string) *File {
+	for i := range m.Files {
+		if m.Files[i].Name == name {
+			return &m.Files[i]
+		}
+	}
+
+	return nil
+}
+
+// Size returns the total size of all files in bytes.
+func (m *Manifest) Size() (size int64) {
+	for _, f := range m.Files {
+		size += f.Size
+	}
+
+	return size
+}
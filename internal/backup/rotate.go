@@ -0,0 +1,127 @@
+package backup
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Retention specifies how many daily, weekly, and monthly archives are kept.
+type Retention struct {
+	Daily   int
+	Weekly  int
+	Monthly int
+}
+
+// Entry represents an existing backup archive.
+type Entry struct {
+	FileName  string
+	CreatedAt time.Time
+	Size      int64
+}
+
+// Entries represents a list of backup archives, sorted from newest to oldest.
+type Entries []Entry
+
+// List returns the backup archives in a directory, sorted from newest to oldest.
+func List(dir string) (result Entries, err error) {
+	matches, err := filepath.Glob(filepath.Join(dir, FilePrefix+"*"+FileExt))
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, fileName := range matches {
+		name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(fileName), FilePrefix), FileExt)
+		createdAt, err := time.Parse(TimeFormat, name)
+
+		if err != nil {
+			continue
+		}
+
+		info, err := os.Stat(fileName)
+
+		if err != nil {
+			continue
+		}
+
+		result = append(result, Entry{FileName: fileName, CreatedAt: createdAt, Size: info.Size()})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+// Latest returns the newest archive, or nil if there is none.
+func (m Entries) Latest() *Entry {
+	if len(m) == 0 {
+		return nil
+	}
+
+	return &m[0]
+}
+
+// Expired returns the archives that are not kept by the retention policy. The newest archive of
+// each of the most recent days, weeks, and months is kept, as well as the newest archive overall.
+func (m Entries) Expired(r Retention) (expired Entries) {
+	days := make(map[string]bool)
+	weeks := make(map[string]bool)
+	months := make(map[string]bool)
+
+	for i, e := range m {
+		keep := i == 0
+
+		day := e.CreatedAt.Format("2006-01-02")
+		year, week := e.CreatedAt.ISOWeek()
+		weekKey := fmt.Sprintf("%d-%02d", year, week)
+		month := e.CreatedAt.Format("2006-01")
+
+		if !days[day] && len(days) < r.Daily {
+			days[day] = true
+			keep = true
+		}
+
+		if !weeks[weekKey] && len(weeks) < r.Weekly {
+			weeks[weekKey] = true
+			keep = true
+		}
+
+		if !months[month] && len(months) < r.Monthly {
+			months[month] = true
+			keep = true
+		}
+
+		if !keep {
+			expired = append(expired, e)
+		}
+	}
+
+	return expired
+}
+
+// Rotate removes the archives in a directory that are not kept by the retention policy.
+func Rotate(dir string, r Retention) (removed Entries, err error) {
+	entries, err := List(dir)
+
+	if err != nil {
+		return removed, err
+	}
+
+	for _, e := range entries.Expired(r) {
+		if err = os.Remove(e.FileName); err != nil {
+			return removed, err
+		}
+
+		_ = os.Remove(e.FileName + ChecksumExt)
+
+		removed = append(removed, e)
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,75 @@
+package backup
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntries_Expired(t *testing.T) {
+	start := time.Date(2023, 3, 31, 3, 0, 0, 0, time.UTC)
+
+	var entries Entries
+
+	// One archive per day for 90 days, plus a second archive on the most recent day.
+	entries = append(entries, Entry{FileName: "latest", CreatedAt: start.Add(time.Hour)})
+
+	for i := 0; i < 90; i++ {
+		entries = append(entries, Entry{FileName: start.AddDate(0, 0, -i).Format(TimeFormat), CreatedAt: start.AddDate(0, 0, -i)})
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		expired := entries.Expired(Retention{Daily: 7, Weekly: 4, Monthly: 3})
+		kept := len(entries) - len(expired)
+
+		assert.GreaterOrEqual(t, kept, 8)
+		assert.LessOrEqual(t, kept, 14)
+
+		for _, e := range expired {
+			assert.NotEqual(t, "latest", e.FileName)
+		}
+	})
+	t.Run("None", func(t *testing.T) {
+		expired := entries.Expired(Retention{})
+		assert.Len(t, expired, len(entries)-1)
+	})
+	t.Run("Daily", func(t *testing.T) {
+		expired := entries.Expired(Retention{Daily: 7})
+
+		// The second archive of the most recent day expires.
+		assert.Len(t, expired, len(entries)-7)
+		assert.Equal(t, start.Format(TimeFormat), expired[0].FileName)
+	})
+}
+
+func TestRotate(t *testing.T) {
+	dir := t.TempDir()
+
+	for i := 0; i < 5; i++ {
+		fileName := FileName(dir, time.Now().AddDate(0, 0, -i))
+
+		if err := os.WriteFile(fileName, []byte("test"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, WriteChecksum(fileName, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"))
+	}
+
+	entries, err := List(dir)
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 5)
+
+	removed, err := Rotate(dir, Retention{Daily: 3})
+
+	assert.NoError(t, err)
+	assert.Len(t, removed, 2)
+
+	entries, err = List(dir)
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	assert.NoFileExists(t, removed[0].FileName+ChecksumExt)
+}
@@ -0,0 +1,118 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Verify checks the checksum of an archive and of each file it contains, and returns its manifest.
+// It also checks that the index dump is complete, so that truncated backups are detected.
+func Verify(fileName string) (*Manifest, error) {
+	if expected, err := ReadChecksum(fileName); err != nil {
+		return nil, fmt.Errorf("checksum file of %s is missing or invalid: %s", clean.Log(fileName), err)
+	} else if actual, err := Checksum(fileName); err != nil {
+		return nil, err
+	} else if actual != expected {
+		return nil, fmt.Errorf("checksum of %s does not match", clean.Log(fileName))
+	}
+
+	f, err := os.Open(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	hashes := make(map[string]File)
+	var manifest *Manifest
+	var dump *DumpInfo
+
+	for {
+		hdr, err := tr.Next()
+
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("archive is damaged: %s", err)
+		}
+
+		if hdr.Name == ManifestName {
+			manifest = &Manifest{}
+
+			if err = json.NewDecoder(tr).Decode(manifest); err != nil {
+				return nil, fmt.Errorf("invalid manifest: %s", err)
+			}
+
+			continue
+		}
+
+		h := sha256.New()
+		r := io.TeeReader(tr, h)
+		var n int64
+
+		// Analyze the index dump while reading it.
+		if hdr.Name == IndexName {
+			if dump, err = ReadDump(r); err == nil {
+				n = dump.Size
+			}
+		} else {
+			n, err = io.Copy(io.Discard, r)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("archive is damaged: %s", err)
+		}
+
+		hashes[hdr.Name] = File{Name: hdr.Name, Size: n, Hash: hex.EncodeToString(h.Sum(nil))}
+	}
+
+	if manifest == nil {
+		return nil, fmt.Errorf("manifest is missing")
+	} else if manifest.Version > ManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d", manifest.Version)
+	}
+
+	for _, expected := range manifest.Files {
+		if actual, ok := hashes[expected.Name]; !ok {
+			return manifest, fmt.Errorf("%s is missing", clean.Log(expected.Name))
+		} else if actual.Size != expected.Size {
+			return manifest, fmt.Errorf("%s is truncated (%d of %d bytes)", clean.Log(expected.Name), actual.Size, expected.Size)
+		} else if actual.Hash != expected.Hash {
+			return manifest, fmt.Errorf("checksum of %s does not match", clean.Log(expected.Name))
+		}
+
+		delete(hashes, expected.Name)
+	}
+
+	for name := range hashes {
+		return manifest, fmt.Errorf("%s is not in manifest", clean.Log(name))
+	}
+
+	if manifest.Find(IndexName) != nil {
+		if dump == nil {
+			return manifest, fmt.Errorf("index dump could not be read")
+		} else if err = dump.Complete(manifest.Driver); err != nil {
+			return manifest, err
+		}
+	}
+
+	return manifest, nil
+}
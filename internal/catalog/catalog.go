@@ -0,0 +1,187 @@
+/*
+Package catalog provides readers for photo management catalogs like Adobe Lightroom Classic and digiKam.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package catalog
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+var log = event.Log
+
+// Supported catalog types.
+const (
+	Lightroom = "lightroom"
+	DigiKam   = "digikam"
+)
+
+// Types contains the supported catalog types.
+var Types = []string{Lightroom, DigiKam}
+
+// Pick flags of rejected and picked images.
+const (
+	Rejected = -1
+	Picked   = 1
+)
+
+// Image represents the metadata of an image in a catalog.
+type Image struct {
+	FileName   string   `json:"FileName"`
+	Title      string   `json:"Title,omitempty"`
+	Caption    string   `json:"Caption,omitempty"`
+	Keywords   []string `json:"Keywords,omitempty"`
+	Labels     []string `json:"Labels,omitempty"`
+	Rating     int      `json:"Rating,omitempty"`
+	Pick       int      `json:"Pick,omitempty"`
+	ColorLabel string   `json:"ColorLabel,omitempty"`
+	Lat        float64  `json:"Lat,omitempty"`
+	Lng        float64  `json:"Lng,omitempty"`
+	Altitude   float64  `json:"Altitude,omitempty"`
+	Albums     []string `json:"Albums,omitempty"`
+	Faces      Faces    `json:"Faces,omitempty"`
+}
+
+// HasLatLng tests if the image has GPS coordinates.
+func (m Image) HasLatLng() bool {
+	return m.Lat != 0 || m.Lng != 0
+}
+
+// Face represents a named face region, the coordinates are relative to the image size.
+type Face struct {
+	Name string  `json:"Name"`
+	X    float32 `json:"X"`
+	Y    float32 `json:"Y"`
+	W    float32 `json:"W"`
+	H    float32 `json:"H"`
+}
+
+// Faces represents a list of face regions.
+type Faces []Face
+
+// Images represents a list of catalog images.
+type Images []Image
+
+// Catalog represents the images and albums in a photo catalog.
+type Catalog struct {
+	Type     string   `json:"Type"`
+	FileName string   `json:"FileName"`
+	Images   Images   `json:"Images"`
+	Albums   []string `json:"Albums"`
+}
+
+// Open reads the catalog of the specified type from a file.
+func Open(catalogType, fileName string) (*Catalog, error) {
+	if !fs.FileExists(fileName) {
+		return nil, fmt.Errorf("catalog %s not found", clean.Log(fileName))
+	}
+
+	switch strings.ToLower(strings.TrimSpace(catalogType)) {
+	case Lightroom:
+		return OpenLightroom(fileName)
+	case DigiKam:
+		return OpenDigiKam(fileName)
+	default:
+		return nil, fmt.Errorf("unsupported catalog type %s", clean.Log(catalogType))
+	}
+}
+
+// openSqlite opens an SQLite database file in read-only mode.
+func openSqlite(fileName string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", "file:"+fileName+"?mode=ro")
+
+	if err != nil {
+		return nil, err
+	} else if err = db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// columns returns the column names of a table, or an empty map if it does not exist.
+func columns(db *sql.DB, table string) map[string]bool {
+	result := make(map[string]bool)
+
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+
+	if err != nil {
+		return result
+	}
+
+	defer rows.Close()
+
+	cols, _ := rows.Columns()
+
+	for rows.Next() {
+		values := make([]interface{}, len(cols))
+		ptrs := make([]interface{}, len(cols))
+
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+
+		if err = rows.Scan(ptrs...); err != nil {
+			continue
+		}
+
+		for i, col := range cols {
+			if col != "name" {
+				continue
+			}
+
+			switch v := values[i].(type) {
+			case string:
+				result[v] = true
+			case []byte:
+				result[string(v)] = true
+			}
+		}
+	}
+
+	return result
+}
+
+// appendUnique appends a string to the list if it is not empty and not already included.
+func appendUnique(list []string, s string) []string {
+	if s = strings.TrimSpace(s); s == "" {
+		return list
+	}
+
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+
+	return append(list, s)
+}
@@ -0,0 +1,417 @@
+package catalog
+
+import (
+	"database/sql"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// digiKam database constants.
+const (
+	DigiKamInternalTags = "_Digikam_Internal_Tags_"
+	DigiKamColorLabel   = "Color Label "
+	DigiKamPickLabel    = "Pick Label "
+	DigiKamRegion       = "tagRegion"
+	DigiKamPerson       = "person"
+	DigiKamComment      = 1
+	DigiKamTitle        = 3
+	DigiKamVisible      = 1
+)
+
+// digiKamTag represents a tag in the digiKam database.
+type digiKamTag struct {
+	ID     int64
+	Parent int64
+	Name   string
+	Person bool
+	Ignore bool
+}
+
+// digiKamRect represents a face region in the digiKam database, e.g. <rect x="10" y="20" width="100" height="120"/>.
+type digiKamRect struct {
+	X int `xml:"x,attr"`
+	Y int `xml:"y,attr"`
+	W int `xml:"width,attr"`
+	H int `xml:"height,attr"`
+}
+
+// OpenDigiKam reads the images, tags, face regions, and albums from a digiKam database (digikam4.db).
+func OpenDigiKam(fileName string) (result *Catalog, err error) {
+	db, err := openSqlite(fileName)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s (open %s)", err, clean.Log(fileName))
+	}
+
+	defer db.Close()
+
+	if len(columns(db, "ImageTags")) == 0 || len(columns(db, "AlbumRoots")) == 0 {
+		return nil, fmt.Errorf("%s is not a digikam database", clean.Log(fileName))
+	}
+
+	result = &Catalog{Type: DigiKam, FileName: fileName, Images: Images{}, Albums: []string{}}
+
+	index, err := digiKamImages(db, result)
+
+	if err != nil {
+		return result, err
+	}
+
+	tags, err := digiKamTags(db)
+
+	if err != nil {
+		return result, err
+	}
+
+	if err = digiKamImageTags(db, result, index, tags); err != nil {
+		return result, err
+	} else if err = digiKamRegions(db, result, index, tags); err != nil {
+		return result, err
+	}
+
+	steps := []func(*sql.DB, *Catalog, map[int64]int) error{
+		digiKamInfo,
+		digiKamComments,
+		digiKamPositions,
+	}
+
+	for _, step := range steps {
+		if err = step(db, result, index); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// digiKamRootPath returns the path of an album root, the mount point of removable volumes is unknown.
+func digiKamRootPath(identifier, specificPath string) string {
+	if i := strings.Index(identifier, "?"); i >= 0 {
+		if values, err := url.ParseQuery(identifier[i+1:]); err == nil && values.Get("path") != "" {
+			return path.Join(values.Get("path"), specificPath)
+		}
+	}
+
+	return path.Join("/", specificPath)
+}
+
+// digiKamImages adds the visible images in the database and returns a map of database IDs to list indexes.
+func digiKamImages(db *sql.DB, cat *Catalog) (map[int64]int, error) {
+	index := make(map[int64]int)
+
+	rows, err := db.Query(`SELECT i.id, r.identifier, r.specificPath, a.relativePath, i.name FROM Images i
+		JOIN Albums a ON a.id = i.album
+		JOIN AlbumRoots r ON r.id = a.albumRoot
+		WHERE i.status = ? ORDER BY i.id`, DigiKamVisible)
+
+	if err != nil {
+		return index, fmt.Errorf("%s (find images)", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var identifier, specificPath, relativePath, name sql.NullString
+
+		if err = rows.Scan(&id, &identifier, &specificPath, &relativePath, &name); err != nil {
+			return index, err
+		}
+
+		img := Image{
+			FileName: path.Join(digiKamRootPath(identifier.String, specificPath.String), relativePath.String, name.String),
+		}
+
+		// Add photos in sub folders to albums named after the folder.
+		if album := path.Base(path.Clean("/" + relativePath.String)); album != "/" {
+			img.Albums = []string{album}
+			cat.Albums = appendUnique(cat.Albums, album)
+		}
+
+		index[id] = len(cat.Images)
+		cat.Images = append(cat.Images, img)
+	}
+
+	return index, rows.Err()
+}
+
+// digiKamTags returns the tags by ID and flags people and internal tags.
+func digiKamTags(db *sql.DB) (map[int64]*digiKamTag, error) {
+	tags := make(map[int64]*digiKamTag)
+
+	rows, err := db.Query("SELECT id, pid, name FROM Tags")
+
+	if err != nil {
+		return tags, fmt.Errorf("%s (find tags)", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var pid sql.NullInt64
+		var name sql.NullString
+
+		if err = rows.Scan(&id, &pid, &name); err != nil {
+			return tags, err
+		}
+
+		tags[id] = &digiKamTag{ID: id, Parent: pid.Int64, Name: strings.TrimSpace(name.String)}
+	}
+
+	if err = rows.Err(); err != nil {
+		return tags, err
+	}
+
+	// Tags with a person property are people, unknown and unconfirmed faces are ignored.
+	if len(columns(db, "TagProperties")) > 0 {
+		props, err := db.Query("SELECT tagid, property FROM TagProperties")
+
+		if err != nil {
+			return tags, fmt.Errorf("%s (find tag properties)", err)
+		}
+
+		defer props.Close()
+
+		for props.Next() {
+			var id int64
+			var property sql.NullString
+
+			if err = props.Scan(&id, &property); err != nil {
+				return tags, err
+			} else if t, ok := tags[id]; !ok {
+				continue
+			} else if property.String == DigiKamPerson {
+				t.Person = true
+			} else if property.String == "unknownPerson" || property.String == "unconfirmedPerson" {
+				t.Ignore = true
+			}
+		}
+	}
+
+	return tags, nil
+}
+
+// digiKamTagPath returns the names of the tag and its parents, starting with the top-level tag.
+func digiKamTagPath(tags map[int64]*digiKamTag, id int64) (names []string) {
+	for depth := 0; depth < 32; depth++ {
+		t, ok := tags[id]
+
+		if !ok || t.ID == 0 {
+			break
+		}
+
+		names = append([]string{t.Name}, names...)
+		id = t.Parent
+	}
+
+	return names
+}
+
+// digiKamImageTags adds keywords and labels based on the tag hierarchy, as well as color and pick labels.
+func digiKamImageTags(db *sql.DB, cat *Catalog, index map[int64]int, tags map[int64]*digiKamTag) error {
+	rows, err := db.Query("SELECT imageid, tagid FROM ImageTags ORDER BY imageid, tagid")
+
+	if err != nil {
+		return fmt.Errorf("%s (find image tags)", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var imageId, tagId int64
+
+		if err = rows.Scan(&imageId, &tagId); err != nil {
+			return err
+		}
+
+		i, ok := index[imageId]
+		t, found := tags[tagId]
+
+		if !ok || !found || t.Ignore || t.Name == "" {
+			continue
+		}
+
+		names := digiKamTagPath(tags, tagId)
+
+		if len(names) == 0 {
+			continue
+		} else if names[0] == DigiKamInternalTags {
+			switch {
+			case strings.HasPrefix(t.Name, DigiKamColorLabel):
+				if label := strings.ToLower(strings.TrimPrefix(t.Name, DigiKamColorLabel)); label != "none" {
+					cat.Images[i].ColorLabel = label
+				}
+			case t.Name == DigiKamPickLabel+"Rejected":
+				cat.Images[i].Pick = Rejected
+			case t.Name == DigiKamPickLabel+"Accepted":
+				cat.Images[i].Pick = Picked
+			}
+
+			continue
+		} else if t.Person {
+			continue
+		}
+
+		// Top-level tags like "Places" are added as keywords, the tag itself is also added as label.
+		for _, name := range names {
+			cat.Images[i].Keywords = appendUnique(cat.Images[i].Keywords, name)
+		}
+
+		cat.Images[i].Labels = appendUnique(cat.Images[i].Labels, t.Name)
+	}
+
+	return rows.Err()
+}
+
+// digiKamRegions adds named face regions.
+func digiKamRegions(db *sql.DB, cat *Catalog, index map[int64]int, tags map[int64]*digiKamTag) error {
+	if len(columns(db, "ImageTagProperties")) == 0 {
+		return nil
+	}
+
+	rows, err := db.Query(`SELECT p.imageid, p.tagid, p.value, n.width, n.height FROM ImageTagProperties p
+		JOIN ImageInformation n ON n.imageid = p.imageid
+		WHERE p.property = ? ORDER BY p.imageid`, DigiKamRegion)
+
+	if err != nil {
+		return fmt.Errorf("%s (find face regions)", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var imageId, tagId int64
+		var value sql.NullString
+		var width, height sql.NullInt64
+
+		if err = rows.Scan(&imageId, &tagId, &value, &width, &height); err != nil {
+			return err
+		}
+
+		i, ok := index[imageId]
+		t, found := tags[tagId]
+
+		if !ok || !found || t.Ignore || !t.Person || width.Int64 <= 0 || height.Int64 <= 0 {
+			continue
+		}
+
+		var r digiKamRect
+
+		if err = xml.Unmarshal([]byte(value.String), &r); err != nil || r.W <= 0 || r.H <= 0 {
+			log.Debugf("catalog: invalid face region %s", clean.Log(value.String))
+			continue
+		}
+
+		w, h := float32(width.Int64), float32(height.Int64)
+
+		cat.Images[i].Faces = append(cat.Images[i].Faces, Face{
+			Name: t.Name,
+			X:    float32(r.X) / w,
+			Y:    float32(r.Y) / h,
+			W:    float32(r.W) / w,
+			H:    float32(r.H) / h,
+		})
+	}
+
+	return rows.Err()
+}
+
+// digiKamInfo adds star ratings.
+func digiKamInfo(db *sql.DB, cat *Catalog, index map[int64]int) error {
+	rows, err := db.Query("SELECT imageid, rating FROM ImageInformation WHERE rating > 0")
+
+	if err != nil {
+		return fmt.Errorf("%s (find ratings)", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var rating sql.NullInt64
+
+		if err = rows.Scan(&id, &rating); err != nil {
+			return err
+		} else if i, ok := index[id]; ok {
+			cat.Images[i].Rating = int(rating.Int64)
+		}
+	}
+
+	return rows.Err()
+}
+
+// digiKamComments adds titles and captions, preferring the default language.
+func digiKamComments(db *sql.DB, cat *Catalog, index map[int64]int) error {
+	if len(columns(db, "ImageComments")) == 0 {
+		return nil
+	}
+
+	rows, err := db.Query(`SELECT imageid, type, comment FROM ImageComments
+		WHERE type IN (?, ?) ORDER BY imageid, CASE WHEN language = 'x-default' THEN 0 ELSE 1 END, id`,
+		DigiKamComment, DigiKamTitle)
+
+	if err != nil {
+		return fmt.Errorf("%s (find comments)", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, commentType int64
+		var comment sql.NullString
+
+		if err = rows.Scan(&id, &commentType, &comment); err != nil {
+			return err
+		}
+
+		i, ok := index[id]
+		s := strings.TrimSpace(comment.String)
+
+		if !ok || s == "" {
+			continue
+		} else if commentType == DigiKamTitle && cat.Images[i].Title == "" {
+			cat.Images[i].Title = s
+		} else if commentType == DigiKamComment && cat.Images[i].Caption == "" {
+			cat.Images[i].Caption = s
+		}
+	}
+
+	return rows.Err()
+}
+
+// digiKamPositions adds GPS coordinates and altitude.
+func digiKamPositions(db *sql.DB, cat *Catalog, index map[int64]int) error {
+	if len(columns(db, "ImagePositions")) == 0 {
+		return nil
+	}
+
+	rows, err := db.Query(`SELECT imageid, latitudeNumber, longitudeNumber, altitude FROM ImagePositions
+		WHERE latitudeNumber IS NOT NULL AND longitudeNumber IS NOT NULL`)
+
+	if err != nil {
+		return fmt.Errorf("%s (find coordinates)", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var lat, lng, alt sql.NullFloat64
+
+		if err = rows.Scan(&id, &lat, &lng, &alt); err != nil {
+			return err
+		} else if i, ok := index[id]; ok {
+			cat.Images[i].Lat = lat.Float64
+			cat.Images[i].Lng = lng.Float64
+			cat.Images[i].Altitude = alt.Float64
+		}
+	}
+
+	return rows.Err()
+}
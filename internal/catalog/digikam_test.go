@@ -0,0 +1,103 @@
+package catalog
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createDigiKamDatabase creates a minimal digiKam database for testing.
+func createDigiKamDatabase(t *testing.T) string {
+	fileName := filepath.Join(t.TempDir(), "digikam4.db")
+
+	db, err := sql.Open("sqlite3", fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	statements := []string{
+		`CREATE TABLE AlbumRoots (id INTEGER PRIMARY KEY, label TEXT, status INTEGER, type INTEGER, identifier TEXT, specificPath TEXT)`,
+		`CREATE TABLE Albums (id INTEGER PRIMARY KEY, albumRoot INTEGER, relativePath TEXT)`,
+		`CREATE TABLE Images (id INTEGER PRIMARY KEY, album INTEGER, name TEXT, status INTEGER)`,
+		`CREATE TABLE ImageInformation (imageid INTEGER PRIMARY KEY, rating INTEGER, width INTEGER, height INTEGER)`,
+		`CREATE TABLE ImageComments (id INTEGER PRIMARY KEY, imageid INTEGER, type INTEGER, language TEXT, comment TEXT)`,
+		`CREATE TABLE ImagePositions (imageid INTEGER PRIMARY KEY, latitudeNumber REAL, longitudeNumber REAL, altitude REAL)`,
+		`CREATE TABLE Tags (id INTEGER PRIMARY KEY, pid INTEGER, name TEXT)`,
+		`CREATE TABLE TagProperties (tagid INTEGER, property TEXT, value TEXT)`,
+		`CREATE TABLE ImageTags (imageid INTEGER, tagid INTEGER)`,
+		`CREATE TABLE ImageTagProperties (imageid INTEGER, tagid INTEGER, property TEXT, value TEXT)`,
+		`INSERT INTO AlbumRoots VALUES (1, 'Pictures', 0, 1, 'volumeid:?path=%2Fhome%2Fjane', '/Pictures')`,
+		`INSERT INTO Albums VALUES (1, 1, '/'), (2, 1, '/2023/Berlin')`,
+		`INSERT INTO Images VALUES (1, 2, 'IMG_1234.jpg', 1), (2, 1, 'scan.png', 1), (3, 2, 'deleted.jpg', 3)`,
+		`INSERT INTO ImageInformation VALUES (1, 3, 4000, 3000), (2, -1, 1000, 1000)`,
+		`INSERT INTO ImageComments VALUES (1, 1, 1, 'de-DE', 'Sonnenuntergang'), (2, 1, 1, 'x-default', 'Sunset'), (3, 1, 3, 'x-default', 'Spree')`,
+		`INSERT INTO ImagePositions VALUES (1, 52.52, 13.405, 34)`,
+		`INSERT INTO Tags VALUES (1, 0, 'Places'), (2, 1, 'Berlin'), (3, 0, 'People'), (4, 3, 'Jane Doe'), (5, 3, 'Unknown'),
+			(10, 0, '_Digikam_Internal_Tags_'), (11, 10, 'Color Label Red'), (12, 10, 'Pick Label Rejected')`,
+		`INSERT INTO TagProperties VALUES (4, 'person', NULL), (5, 'unknownPerson', NULL)`,
+		`INSERT INTO ImageTags VALUES (1, 2), (1, 4), (1, 5), (1, 11), (2, 12)`,
+		`INSERT INTO ImageTagProperties VALUES (1, 4, 'tagRegion', '<rect x="1000" y="750" width="400" height="600"/>'),
+			(1, 5, 'tagRegion', '<rect x="10" y="10" width="40" height="60"/>')`,
+	}
+
+	for _, s := range statements {
+		if _, err = db.Exec(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return fileName
+}
+
+func TestOpenDigiKam(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		cat, err := Open(DigiKam, createDigiKamDatabase(t))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, DigiKam, cat.Type)
+		assert.Equal(t, []string{"Berlin"}, cat.Albums)
+
+		if assert.Len(t, cat.Images, 2) {
+			img := cat.Images[0]
+			assert.Equal(t, "/home/jane/Pictures/2023/Berlin/IMG_1234.jpg", img.FileName)
+			assert.Equal(t, "Spree", img.Title)
+			assert.Equal(t, "Sunset", img.Caption)
+			assert.Equal(t, []string{"Places", "Berlin"}, img.Keywords)
+			assert.Equal(t, []string{"Berlin"}, img.Labels)
+			assert.Equal(t, 3, img.Rating)
+			assert.Equal(t, "red", img.ColorLabel)
+			assert.Equal(t, 0, img.Pick)
+			assert.InDelta(t, 52.52, img.Lat, 0.0001)
+			assert.InDelta(t, 34, img.Altitude, 0.0001)
+			assert.Equal(t, []string{"Berlin"}, img.Albums)
+
+			if assert.Len(t, img.Faces, 1) {
+				assert.Equal(t, Face{Name: "Jane Doe", X: 0.25, Y: 0.25, W: 0.1, H: 0.2}, img.Faces[0])
+			}
+
+			img = cat.Images[1]
+			assert.Equal(t, "/home/jane/Pictures/scan.png", img.FileName)
+			assert.Equal(t, Rejected, img.Pick)
+			assert.Equal(t, 0, img.Rating)
+			assert.Empty(t, img.Albums)
+			assert.Empty(t, img.Faces)
+		}
+	})
+	t.Run("NotDigiKam", func(t *testing.T) {
+		_, err := Open(DigiKam, createLightroomCatalog(t))
+		assert.Error(t, err)
+	})
+}
+
+func TestDigiKamRootPath(t *testing.T) {
+	assert.Equal(t, "/home/jane/Pictures", digiKamRootPath("volumeid:?path=%2Fhome%2Fjane", "/Pictures"))
+	assert.Equal(t, "/Pictures", digiKamRootPath("volumeid:?uuid=2b1e4c0f", "/Pictures"))
+}
@@ -0,0 +1,244 @@
+package catalog
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// LightroomCollection is the creation ID of regular Lightroom collections,
+// smart collections and collection sets are not imported as albums.
+const LightroomCollection = "com.adobe.ag.library.collection"
+
+// OpenLightroom reads the images, keywords, and collections from a Lightroom Classic catalog (.lrcat).
+func OpenLightroom(fileName string) (result *Catalog, err error) {
+	db, err := openSqlite(fileName)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s (open %s)", err, clean.Log(fileName))
+	}
+
+	defer db.Close()
+
+	if len(columns(db, "Adobe_images")) == 0 {
+		return nil, fmt.Errorf("%s is not a lightroom catalog", clean.Log(fileName))
+	}
+
+	result = &Catalog{Type: Lightroom, FileName: fileName, Images: Images{}, Albums: []string{}}
+
+	index, err := lightroomImages(db, result)
+
+	if err != nil {
+		return result, err
+	}
+
+	steps := []func(*sql.DB, *Catalog, map[int64]int) error{
+		lightroomIptc,
+		lightroomGps,
+		lightroomKeywords,
+		lightroomCollections,
+	}
+
+	for _, step := range steps {
+		if err = step(db, result, index); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// lightroomImages adds the images in the catalog and returns a map of catalog IDs to list indexes.
+func lightroomImages(db *sql.DB, cat *Catalog) (map[int64]int, error) {
+	index := make(map[int64]int)
+	cols := columns(db, "Adobe_images")
+
+	stmt := `SELECT i.id_local, r.absolutePath, fo.pathFromRoot, f.baseName, f.extension, i.rating, i.pick, i.colorLabels
+		FROM Adobe_images i
+		JOIN AgLibraryFile f ON f.id_local = i.rootFile
+		JOIN AgLibraryFolder fo ON fo.id_local = f.folder
+		JOIN AgLibraryRootFolder r ON r.id_local = fo.rootFolder`
+
+	// Skip virtual copies.
+	if cols["masterImage"] {
+		stmt += " WHERE i.masterImage IS NULL"
+	}
+
+	rows, err := db.Query(stmt + " ORDER BY i.id_local")
+
+	if err != nil {
+		return index, fmt.Errorf("%s (find images)", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var root, folder, base, ext, label sql.NullString
+		var rating, pick sql.NullFloat64
+
+		if err = rows.Scan(&id, &root, &folder, &base, &ext, &rating, &pick, &label); err != nil {
+			return index, err
+		}
+
+		fileName := root.String + folder.String + base.String
+
+		if ext.String != "" {
+			fileName += "." + ext.String
+		}
+
+		index[id] = len(cat.Images)
+
+		cat.Images = append(cat.Images, Image{
+			FileName:   fileName,
+			Rating:     int(rating.Float64),
+			Pick:       int(pick.Float64),
+			ColorLabel: label.String,
+		})
+	}
+
+	return index, rows.Err()
+}
+
+// lightroomIptc adds titles and captions.
+func lightroomIptc(db *sql.DB, cat *Catalog, index map[int64]int) error {
+	cols := columns(db, "AgLibraryIPTC")
+
+	if !cols["caption"] {
+		return nil
+	}
+
+	title := "NULL"
+
+	if cols["title"] {
+		title = "title"
+	}
+
+	rows, err := db.Query(fmt.Sprintf("SELECT image, caption, %s FROM AgLibraryIPTC", title))
+
+	if err != nil {
+		return fmt.Errorf("%s (find captions)", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var caption, title sql.NullString
+
+		if err = rows.Scan(&id, &caption, &title); err != nil {
+			return err
+		} else if i, ok := index[id]; ok {
+			cat.Images[i].Caption = strings.TrimSpace(caption.String)
+			cat.Images[i].Title = strings.TrimSpace(title.String)
+		}
+	}
+
+	return rows.Err()
+}
+
+// lightroomGps adds GPS coordinates and the altitude, if available.
+func lightroomGps(db *sql.DB, cat *Catalog, index map[int64]int) error {
+	cols := columns(db, "AgHarvestedExifMetadata")
+
+	if !cols["gpsLatitude"] || !cols["gpsLongitude"] {
+		return nil
+	}
+
+	alt := "NULL"
+
+	if cols["gpsAltitude"] {
+		alt = "gpsAltitude"
+	}
+
+	stmt := "SELECT image, gpsLatitude, gpsLongitude, " + alt + " FROM AgHarvestedExifMetadata WHERE gpsLatitude IS NOT NULL"
+
+	if cols["hasGPS"] {
+		stmt += " AND hasGPS = 1"
+	}
+
+	rows, err := db.Query(stmt)
+
+	if err != nil {
+		return fmt.Errorf("%s (find coordinates)", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var lat, lng, alt sql.NullFloat64
+
+		if err = rows.Scan(&id, &lat, &lng, &alt); err != nil {
+			return err
+		} else if i, ok := index[id]; ok {
+			cat.Images[i].Lat = lat.Float64
+			cat.Images[i].Lng = lng.Float64
+			cat.Images[i].Altitude = alt.Float64
+		}
+	}
+
+	return rows.Err()
+}
+
+// lightroomKeywords adds keywords.
+func lightroomKeywords(db *sql.DB, cat *Catalog, index map[int64]int) error {
+	if len(columns(db, "AgLibraryKeywordImage")) == 0 {
+		return nil
+	}
+
+	rows, err := db.Query(`SELECT ki.image, k.name FROM AgLibraryKeywordImage ki
+		JOIN AgLibraryKeyword k ON k.id_local = ki.tag WHERE k.name IS NOT NULL ORDER BY k.name`)
+
+	if err != nil {
+		return fmt.Errorf("%s (find keywords)", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var name string
+
+		if err = rows.Scan(&id, &name); err != nil {
+			return err
+		} else if i, ok := index[id]; ok {
+			cat.Images[i].Keywords = appendUnique(cat.Images[i].Keywords, name)
+		}
+	}
+
+	return rows.Err()
+}
+
+// lightroomCollections adds the names of regular collections as albums.
+func lightroomCollections(db *sql.DB, cat *Catalog, index map[int64]int) error {
+	if len(columns(db, "AgLibraryCollectionImage")) == 0 {
+		return nil
+	}
+
+	rows, err := db.Query(`SELECT ci.image, c.name FROM AgLibraryCollectionImage ci
+		JOIN AgLibraryCollection c ON c.id_local = ci.collection
+		WHERE c.creationId = ? AND c.name IS NOT NULL ORDER BY c.name`, LightroomCollection)
+
+	if err != nil {
+		return fmt.Errorf("%s (find collections)", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var name string
+
+		if err = rows.Scan(&id, &name); err != nil {
+			return err
+		} else if i, ok := index[id]; ok {
+			cat.Images[i].Albums = appendUnique(cat.Images[i].Albums, name)
+			cat.Albums = appendUnique(cat.Albums, name)
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,97 @@
+package catalog
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createLightroomCatalog creates a minimal Lightroom catalog for testing.
+func createLightroomCatalog(t *testing.T) string {
+	fileName := filepath.Join(t.TempDir(), "test.lrcat")
+
+	db, err := sql.Open("sqlite3", fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	statements := []string{
+		`CREATE TABLE AgLibraryRootFolder (id_local INTEGER PRIMARY KEY, absolutePath TEXT, name TEXT)`,
+		`CREATE TABLE AgLibraryFolder (id_local INTEGER PRIMARY KEY, pathFromRoot TEXT, rootFolder INTEGER)`,
+		`CREATE TABLE AgLibraryFile (id_local INTEGER PRIMARY KEY, baseName TEXT, extension TEXT, folder INTEGER)`,
+		`CREATE TABLE Adobe_images (id_local INTEGER PRIMARY KEY, rootFile INTEGER, rating REAL, pick REAL, colorLabels TEXT, masterImage INTEGER)`,
+		`CREATE TABLE AgLibraryIPTC (id_local INTEGER PRIMARY KEY, image INTEGER, caption TEXT, title TEXT)`,
+		`CREATE TABLE AgHarvestedExifMetadata (id_local INTEGER PRIMARY KEY, image INTEGER, gpsLatitude REAL, gpsLongitude REAL, gpsAltitude REAL, hasGPS INTEGER)`,
+		`CREATE TABLE AgLibraryKeyword (id_local INTEGER PRIMARY KEY, name TEXT)`,
+		`CREATE TABLE AgLibraryKeywordImage (id_local INTEGER PRIMARY KEY, image INTEGER, tag INTEGER)`,
+		`CREATE TABLE AgLibraryCollection (id_local INTEGER PRIMARY KEY, name TEXT, creationId TEXT)`,
+		`CREATE TABLE AgLibraryCollectionImage (id_local INTEGER PRIMARY KEY, image INTEGER, collection INTEGER)`,
+		`INSERT INTO AgLibraryRootFolder VALUES (1, '/Users/jane/Pictures/', 'Pictures')`,
+		`INSERT INTO AgLibraryFolder VALUES (1, '2023/Berlin/', 1)`,
+		`INSERT INTO AgLibraryFile VALUES (1, 'IMG_1234', 'CR2', 1), (2, 'IMG_1235', 'jpg', 1)`,
+		`INSERT INTO Adobe_images VALUES (1, 1, 4, 1, 'Red', NULL), (2, 2, NULL, -1, '', NULL), (3, 1, 5, 0, '', 1)`,
+		`INSERT INTO AgLibraryIPTC VALUES (1, 1, 'Sunset at the river', 'Spree')`,
+		`INSERT INTO AgHarvestedExifMetadata VALUES (1, 1, 52.52, 13.405, 34, 1), (2, 2, 0, 0, NULL, 0)`,
+		`INSERT INTO AgLibraryKeyword VALUES (1, 'sunset'), (2, 'river'), (3, NULL)`,
+		`INSERT INTO AgLibraryKeywordImage VALUES (1, 1, 1), (2, 1, 2), (3, 1, 3)`,
+		`INSERT INTO AgLibraryCollection VALUES (1, 'Best of 2023', 'com.adobe.ag.library.collection'), (2, 'Five Stars', 'com.adobe.ag.library.smart_collection')`,
+		`INSERT INTO AgLibraryCollectionImage VALUES (1, 1, 1), (2, 1, 2)`,
+	}
+
+	for _, s := range statements {
+		if _, err = db.Exec(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return fileName
+}
+
+func TestOpenLightroom(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		cat, err := Open(Lightroom, createLightroomCatalog(t))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, Lightroom, cat.Type)
+		assert.Equal(t, []string{"Best of 2023"}, cat.Albums)
+
+		if assert.Len(t, cat.Images, 2) {
+			img := cat.Images[0]
+			assert.Equal(t, "/Users/jane/Pictures/2023/Berlin/IMG_1234.CR2", img.FileName)
+			assert.Equal(t, "Spree", img.Title)
+			assert.Equal(t, "Sunset at the river", img.Caption)
+			assert.Equal(t, []string{"river", "sunset"}, img.Keywords)
+			assert.Equal(t, 4, img.Rating)
+			assert.Equal(t, Picked, img.Pick)
+			assert.Equal(t, "Red", img.ColorLabel)
+			assert.InDelta(t, 52.52, img.Lat, 0.0001)
+			assert.InDelta(t, 13.405, img.Lng, 0.0001)
+			assert.InDelta(t, 34, img.Altitude, 0.0001)
+			assert.True(t, img.HasLatLng())
+			assert.Equal(t, []string{"Best of 2023"}, img.Albums)
+
+			img = cat.Images[1]
+			assert.Equal(t, "/Users/jane/Pictures/2023/Berlin/IMG_1235.jpg", img.FileName)
+			assert.Equal(t, Rejected, img.Pick)
+			assert.Equal(t, 0, img.Rating)
+			assert.False(t, img.HasLatLng())
+			assert.Empty(t, img.Albums)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Open(Lightroom, "testdata/missing.lrcat")
+		assert.Error(t, err)
+	})
+	t.Run("UnsupportedType", func(t *testing.T) {
+		_, err := Open("aperture", createLightroomCatalog(t))
+		assert.Error(t, err)
+	})
+}
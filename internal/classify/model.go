@@ -0,0 +1,92 @@
+package classify
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Model describes a TensorFlow SavedModel that can be used for image classification.
+type Model struct {
+	Name   string   // Model directory name in the assets path or absolute model path.
+	Tags   []string // SavedModel tags, e.g. "serve".
+	Labels string   // Labels filename, relative to the model path.
+	Input  string   // Name of the input operation.
+	Output string   // Name of the output operation.
+	Size   int      // Input image width and height in pixels.
+	Rules  string   // Optional YAML file with label rules that extend or override the embedded rules.
+
+	Embedding     string // Name of the operation that returns the image features before classification, if any.
+	EmbeddingSize int    // Number of image features, or 0 if it should be read from the model graph.
+}
+
+// NasnetModel is the default image classification model.
+var NasnetModel = Model{
+	Name:   "nasnet",
+	Tags:   []string{"photoprism"},
+	Labels: "labels.txt",
+	Input:  "input_1",
+	Output: "predictions/Softmax",
+	Size:   224,
+
+	Embedding:     "global_average_pooling2d_1/Mean",
+	EmbeddingSize: 1056,
+}
+
+// Path returns the absolute model path based on the models path.
+func (m Model) Path(modelsPath string) string {
+	if m.Name == "" {
+		return filepath.Join(modelsPath, NasnetModel.Name)
+	} else if filepath.IsAbs(m.Name) {
+		return m.Name
+	}
+
+	return filepath.Join(modelsPath, m.Name)
+}
+
+// LabelsFile returns the absolute labels filename based on the models path.
+func (m Model) LabelsFile(modelsPath string) string {
+	if m.Labels == "" {
+		return filepath.Join(m.Path(modelsPath), NasnetModel.Labels)
+	} else if filepath.IsAbs(m.Labels) {
+		return m.Labels
+	}
+
+	return filepath.Join(m.Path(modelsPath), m.Labels)
+}
+
+// Default returns a copy of the model with missing values replaced by defaults.
+func (m Model) Default() Model {
+	if m.Name == "" {
+		m.Name = NasnetModel.Name
+	}
+
+	if len(m.Tags) == 0 {
+		m.Tags = NasnetModel.Tags
+	}
+
+	if m.Labels == "" {
+		m.Labels = NasnetModel.Labels
+	}
+
+	if m.Input = strings.TrimSpace(m.Input); m.Input == "" {
+		m.Input = NasnetModel.Input
+	}
+
+	if m.Output = strings.TrimSpace(m.Output); m.Output == "" {
+		m.Output = NasnetModel.Output
+	}
+
+	if m.Size <= 0 {
+		m.Size = NasnetModel.Size
+	}
+
+	// The feature layer of the default model does not exist in custom models.
+	if m.Embedding = strings.TrimSpace(m.Embedding); m.Embedding == "" && m.Name == NasnetModel.Name {
+		m.Embedding = NasnetModel.Embedding
+		m.EmbeddingSize = NasnetModel.EmbeddingSize
+	} else if m.EmbeddingSize < 0 {
+		m.EmbeddingSize = 0
+	}
+
+	return m
+}
@@ -0,0 +1,42 @@
+package classify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModel_Default(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		m := Model{}.Default()
+		assert.Equal(t, NasnetModel, m)
+	})
+	t.Run("Custom", func(t *testing.T) {
+		m := Model{Name: "/models/birds", Tags: []string{"serve"}, Output: "Softmax", Size: 260}.Default()
+		assert.Equal(t, "/models/birds", m.Name)
+		assert.Equal(t, []string{"serve"}, m.Tags)
+		assert.Equal(t, "labels.txt", m.Labels)
+		assert.Equal(t, "input_1", m.Input)
+		assert.Equal(t, "Softmax", m.Output)
+		assert.Equal(t, 260, m.Size)
+		assert.Equal(t, "", m.Embedding)
+		assert.Equal(t, 0, m.EmbeddingSize)
+	})
+	t.Run("CustomEmbedding", func(t *testing.T) {
+		m := Model{Name: "/models/birds", Embedding: " avg_pool/Mean ", EmbeddingSize: -1}.Default()
+		assert.Equal(t, "avg_pool/Mean", m.Embedding)
+		assert.Equal(t, 0, m.EmbeddingSize)
+	})
+}
+
+func TestModel_Path(t *testing.T) {
+	assert.Equal(t, "/assets/nasnet", Model{}.Path("/assets"))
+	assert.Equal(t, "/assets/birds", Model{Name: "birds"}.Path("/assets"))
+	assert.Equal(t, "/models/birds", Model{Name: "/models/birds"}.Path("/assets"))
+}
+
+func TestModel_LabelsFile(t *testing.T) {
+	assert.Equal(t, "/assets/nasnet/labels.txt", Model{}.LabelsFile("/assets"))
+	assert.Equal(t, "/assets/birds/birds.txt", Model{Name: "birds", Labels: "birds.txt"}.LabelsFile("/assets"))
+	assert.Equal(t, "/labels/birds.txt", Model{Name: "birds", Labels: "/labels/birds.txt"}.LabelsFile("/assets"))
+}
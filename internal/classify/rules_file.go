@@ -0,0 +1,100 @@
+package classify
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// labelRuleYaml represents a label rule as defined in rules.yml.
+type labelRuleYaml struct {
+	Label      string   `yaml:"label"`
+	See        string   `yaml:"see"`
+	Threshold  float32  `yaml:"threshold"`
+	Categories []string `yaml:"categories"`
+	Priority   int      `yaml:"priority"`
+}
+
+// LoadRules reads label rules from a YAML file in the same format as the embedded rules.yml.
+func LoadRules(fileName string) (LabelRules, error) {
+	if !fs.FileExists(fileName) {
+		return nil, fmt.Errorf("classify: found no label rules in %s", clean.Log(filepath.Base(fileName)))
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRules(data)
+}
+
+// ParseRules parses label rules in YAML format and resolves references to other rules.
+func ParseRules(data []byte) (LabelRules, error) {
+	raw := make(map[string]labelRuleYaml)
+
+	if err := yaml.Unmarshal(data, raw); err != nil {
+		return nil, fmt.Errorf("classify: %s (parse rules)", err)
+	}
+
+	// Normalize names so that references are resolved regardless of case and whitespace.
+	parsed := make(map[string]labelRuleYaml, len(raw))
+
+	for name, rule := range raw {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			parsed[name] = rule
+		}
+	}
+
+	result := make(LabelRules, len(parsed))
+
+	for name, rule := range parsed {
+		if rule.See != "" {
+			see := strings.ToLower(strings.TrimSpace(rule.See))
+
+			if ref, ok := parsed[see]; ok {
+				rule = ref
+			} else if ref, ok := Rules[see]; ok {
+				result[name] = ref
+				continue
+			} else {
+				return nil, fmt.Errorf("classify: missing label %s referenced by %s", clean.Log(see), clean.Log(name))
+			}
+		}
+
+		if rule.Categories == nil {
+			rule.Categories = []string{}
+		}
+
+		result[name] = LabelRule{
+			Label:      strings.ToLower(strings.TrimSpace(rule.Label)),
+			Threshold:  rule.Threshold,
+			Categories: rule.Categories,
+			Priority:   rule.Priority,
+		}
+	}
+
+	return result, nil
+}
+
+// Merge returns a new list of rules that contains the existing rules, extended and overridden by the rules passed.
+func (rules LabelRules) Merge(other LabelRules) LabelRules {
+	result := make(LabelRules, len(rules)+len(other))
+
+	for name, rule := range rules {
+		result[name] = rule
+	}
+
+	for name, rule := range other {
+		result[name] = rule
+	}
+
+	return result
+}
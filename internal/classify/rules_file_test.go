@@ -0,0 +1,83 @@
+package classify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRules(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		rules, err := ParseRules([]byte(`
+Eurasian Blue Tit:
+  label: Blue Tit
+  priority: 3
+  threshold: 0.25
+  categories:
+    - bird
+    - animal
+
+cyanistes caeruleus:
+  see: eurasian blue tit
+
+house cat:
+  see: cat
+`))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, rules, 3)
+
+		tit, ok := rules.Find("eurasian blue tit")
+		assert.True(t, ok)
+		assert.Equal(t, "blue tit", tit.Label)
+		assert.Equal(t, float32(0.25), tit.Threshold)
+		assert.Equal(t, 3, tit.Priority)
+		assert.Equal(t, []string{"bird", "animal"}, tit.Categories)
+
+		latin, ok := rules.Find("cyanistes caeruleus")
+		assert.True(t, ok)
+		assert.Equal(t, tit, latin)
+
+		cat, ok := rules.Find("house cat")
+		assert.True(t, ok)
+		assert.Equal(t, "cat", cat.Label)
+	})
+	t.Run("MissingReference", func(t *testing.T) {
+		_, err := ParseRules([]byte("robin:\n  see: missing bird\n"))
+		assert.Error(t, err)
+	})
+	t.Run("InvalidYaml", func(t *testing.T) {
+		_, err := ParseRules([]byte("robin: [\n"))
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadRules(t *testing.T) {
+	_, err := LoadRules("testdata/missing.yml")
+	assert.Error(t, err)
+}
+
+func TestLabelRules_Merge(t *testing.T) {
+	custom := LabelRules{
+		"cat":   {Label: "kitty", Threshold: 0.5},
+		"robin": {Label: "robin", Threshold: 0.2, Categories: []string{"bird"}},
+	}
+
+	result := Rules.Merge(custom)
+
+	assert.Len(t, result, len(Rules)+1)
+
+	cat, ok := result.Find("cat")
+	assert.True(t, ok)
+	assert.Equal(t, "kitty", cat.Label)
+
+	robin, ok := result.Find("robin")
+	assert.True(t, ok)
+	assert.Equal(t, "robin", robin.Label)
+
+	original, _ := Rules.Find("cat")
+	assert.Equal(t, "cat", original.Label)
+}
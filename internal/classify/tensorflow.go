@@ -7,7 +7,6 @@ import (
 	"image"
 	"math"
 	"os"
-	"path"
 	"path/filepath"
 	"runtime/debug"
 	"sort"
@@ -23,14 +22,29 @@ type TensorFlow struct {
 	model      *tf.SavedModel
 	modelsPath string
 	disabled   bool
-	modelName  string
-	modelTags  []string
+	modelInfo  Model
 	labels     []string
+	rules      LabelRules
 }
 
 // New returns new TensorFlow instance with Nasnet model.
 func New(modelsPath string, disabled bool) *TensorFlow {
-	return &TensorFlow{modelsPath: modelsPath, disabled: disabled, modelName: "nasnet", modelTags: []string{"photoprism"}}
+	return NewModel(modelsPath, NasnetModel, disabled)
+}
+
+// NewModel returns a new TensorFlow instance with a custom classification model.
+func NewModel(modelsPath string, model Model, disabled bool) *TensorFlow {
+	return &TensorFlow{modelsPath: modelsPath, disabled: disabled, modelInfo: model.Default(), rules: Rules}
+}
+
+// Model returns the classification model information.
+func (t *TensorFlow) Model() Model {
+	return t.modelInfo
+}
+
+// Rules returns the label rules used to filter and map classification results.
+func (t *TensorFlow) Rules() LabelRules {
+	return t.rules
 }
 
 // Init initialises tensorflow models if not disabled
@@ -69,6 +83,74 @@ func (t *TensorFlow) Labels(img []byte) (result Labels, err error) {
 		return result, nil
 	}
 
+	probabilities, err := t.inference(img)
+
+	if err != nil {
+		return result, err
+	}
+
+	// Return best labels
+	result = t.bestLabels(probabilities)
+
+	if len(result) > 0 {
+		log.Tracef("classify: image classified as %+v", result)
+	}
+
+	return result, nil
+}
+
+// Embedding returns the normalized image features computed by the model before classification,
+// so that images of the same subject can be compared by similarity.
+func (t *TensorFlow) Embedding(img []byte) (result []float32, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("classify: %s (inference panic)\nstack: %s", r, debug.Stack())
+		}
+	}()
+
+	if t.disabled {
+		return result, fmt.Errorf("classify: image classification is disabled")
+	} else if err = t.loadModel(); err != nil {
+		return result, err
+	} else if t.modelInfo.Embedding == "" {
+		return result, fmt.Errorf("classify: model has no feature layer")
+	}
+
+	features, err := t.run(img, t.modelInfo.Embedding)
+
+	if err != nil {
+		return result, err
+	} else if len(features) != t.modelInfo.EmbeddingSize {
+		return result, fmt.Errorf("classify: expected %d image features, got %d", t.modelInfo.EmbeddingSize, len(features))
+	}
+
+	var sum float64
+
+	for _, v := range features {
+		sum += float64(v) * float64(v)
+	}
+
+	if sum == 0 {
+		return result, fmt.Errorf("classify: empty inference result")
+	}
+
+	norm := float32(math.Sqrt(sum))
+	result = make([]float32, len(features))
+
+	for i, v := range features {
+		result[i] = v / norm
+	}
+
+	return result, nil
+}
+
+// inference runs the model on an image and returns the output probabilities.
+func (t *TensorFlow) inference(img []byte) ([]float32, error) {
+	return t.run(img, t.modelInfo.Output)
+}
+
+// run runs the model on an image and returns the values of the specified operation.
+func (t *TensorFlow) run(img []byte, operation string) ([]float32, error) {
 	if err := t.loadModel(); err != nil {
 		return nil, err
 	}
@@ -83,35 +165,32 @@ func (t *TensorFlow) Labels(img []byte) (result Labels, err error) {
 	// Run inference.
 	output, err := t.model.Session.Run(
 		map[tf.Output]*tf.Tensor{
-			t.model.Graph.Operation("input_1").Output(0): tensor,
+			t.model.Graph.Operation(t.modelInfo.Input).Output(0): tensor,
 		},
 		[]tf.Output{
-			t.model.Graph.Operation("predictions/Softmax").Output(0),
+			t.model.Graph.Operation(operation).Output(0),
 		},
 		nil)
 
 	if err != nil {
-		return result, fmt.Errorf("classify: %s (run inference)", err.Error())
+		return nil, fmt.Errorf("classify: %s (run inference)", err.Error())
 	}
 
 	if len(output) < 1 {
-		return result, fmt.Errorf("classify: inference failed, no output")
+		return nil, fmt.Errorf("classify: inference failed, no output")
 	}
 
-	// Return best labels
-	result = t.bestLabels(output[0].Value().([][]float32)[0])
+	values, ok := output[0].Value().([][]float32)
 
-	if len(result) > 0 {
-		log.Tracef("classify: image classified as %+v", result)
+	if !ok || len(values) < 1 {
+		return nil, fmt.Errorf("classify: unexpected output of %s", clean.Log(operation))
 	}
 
-	return result, nil
+	return values[0], nil
 }
 
-func (t *TensorFlow) loadLabels(path string) error {
-	modelLabels := path + "/labels.txt"
-
-	log.Infof("classify: loading labels from labels.txt")
+func (t *TensorFlow) loadLabels(modelLabels string) error {
+	log.Infof("classify: loading labels from %s", clean.Log(filepath.Base(modelLabels)))
 
 	// Load labels
 	f, err := os.Open(modelLabels)
@@ -146,20 +225,76 @@ func (t *TensorFlow) loadModel() error {
 		return nil
 	}
 
-	modelPath := path.Join(t.modelsPath, t.modelName)
+	modelPath := t.modelInfo.Path(t.modelsPath)
+
+	// Load custom label rules?
+	if t.modelInfo.Rules != "" {
+		if rules, err := LoadRules(t.modelInfo.Rules); err != nil {
+			return err
+		} else {
+			log.Infof("classify: loaded %d custom label rules from %s", len(rules), clean.Log(filepath.Base(t.modelInfo.Rules)))
+			t.rules = Rules.Merge(rules)
+		}
+	}
 
 	log.Infof("classify: loading %s", clean.Log(filepath.Base(modelPath)))
 
 	// Load model
-	model, err := tf.LoadSavedModel(modelPath, t.modelTags, nil)
+	model, err := tf.LoadSavedModel(modelPath, t.modelInfo.Tags, nil)
 
 	if err != nil {
 		return err
 	}
 
+	// Check if input and output operations exist.
+	if model.Graph.Operation(t.modelInfo.Input) == nil {
+		return fmt.Errorf("classify: model input %s not found", clean.Log(t.modelInfo.Input))
+	} else if model.Graph.Operation(t.modelInfo.Output) == nil {
+		return fmt.Errorf("classify: model output %s not found", clean.Log(t.modelInfo.Output))
+	}
+
+	// Check the feature layer, as embeddings of different sizes cannot be compared.
+	if err = t.checkEmbedding(model); err != nil {
+		log.Warnf("%s, image embeddings disabled", err)
+		t.modelInfo.Embedding = ""
+		t.modelInfo.EmbeddingSize = 0
+	}
+
 	t.model = model
 
-	return t.loadLabels(modelPath)
+	return t.loadLabels(t.modelInfo.LabelsFile(t.modelsPath))
+}
+
+// checkEmbedding checks if the model has the configured feature layer and updates the number of features.
+func (t *TensorFlow) checkEmbedding(model *tf.SavedModel) error {
+	if t.modelInfo.Embedding == "" {
+		return nil
+	}
+
+	op := model.Graph.Operation(t.modelInfo.Embedding)
+
+	if op == nil {
+		return fmt.Errorf("classify: model feature layer %s not found", clean.Log(t.modelInfo.Embedding))
+	}
+
+	shape := op.Output(0).Shape()
+
+	if shape.NumDimensions() != 2 {
+		return fmt.Errorf("classify: model feature layer %s has shape %s, expected [batch, features]", clean.Log(t.modelInfo.Embedding), shape.String())
+	}
+
+	size := int(shape.Size(1))
+
+	switch {
+	case size > 0 && t.modelInfo.EmbeddingSize > 0 && size != t.modelInfo.EmbeddingSize:
+		return fmt.Errorf("classify: model feature layer %s has %d features, expected %d", clean.Log(t.modelInfo.Embedding), size, t.modelInfo.EmbeddingSize)
+	case size > 0:
+		t.modelInfo.EmbeddingSize = size
+	case t.modelInfo.EmbeddingSize <= 0:
+		return fmt.Errorf("classify: number of features in model layer %s is unknown", clean.Log(t.modelInfo.Embedding))
+	}
+
+	return nil
 }
 
 // bestLabels returns the best 5 labels (if enough high probability labels) from the prediction of the model
@@ -179,7 +314,7 @@ func (t *TensorFlow) bestLabels(probabilities []float32) Labels {
 
 		labelText := strings.ToLower(t.labels[i])
 
-		rule, _ := Rules.Find(labelText)
+		rule, _ := t.rules.Find(labelText)
 
 		// discard labels that don't met the threshold
 		if p < rule.Threshold {
@@ -217,7 +352,7 @@ func (t *TensorFlow) createTensor(image []byte, imageFormat string) (*tf.Tensor,
 		return nil, err
 	}
 
-	width, height := 224, 224
+	width, height := t.modelInfo.Size, t.modelInfo.Size
 
 	img = imaging.Fill(img, width, height, imaging.Center, imaging.Lanczos)
 
@@ -174,6 +174,53 @@ func TestTensorFlow_Labels(t *testing.T) {
 	})
 }
 
+func TestTensorFlow_Embedding(t *testing.T) {
+	t.Run("cat_brown.jpg", func(t *testing.T) {
+		tensorFlow := NewTest(t)
+
+		imageBuffer, err := os.ReadFile(examplesPath + "/cat_brown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := tensorFlow.Embedding(imageBuffer)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, NasnetModel.EmbeddingSize)
+
+		var sum float64
+
+		for _, v := range result {
+			sum += float64(v) * float64(v)
+		}
+
+		assert.InDelta(t, 1.0, sum, 0.001)
+	})
+	t.Run("NoFeatureLayer", func(t *testing.T) {
+		model := NasnetModel
+		model.Embedding = "no_such_layer"
+
+		tensorFlow := NewModel(assetsPath, model, false)
+
+		result, err := tensorFlow.Embedding([]byte{})
+
+		assert.EqualError(t, err, "classify: model has no feature layer")
+		assert.Empty(t, result)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		tensorFlow := New(assetsPath, true)
+
+		result, err := tensorFlow.Embedding([]byte{})
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
 func TestTensorFlow_LoadModel(t *testing.T) {
 	t.Run("model loaded", func(t *testing.T) {
 		tf := NewTest(t)
@@ -1,19 +1,18 @@
 package commands
 
 import (
-	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/dustin/go-humanize/english"
 	"github.com/urfave/cli"
 
-	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/backup"
+	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -22,7 +21,9 @@ import (
 const backupDescription = "A user-defined filename or - for stdout can be passed as the first argument. " +
 	"The -i parameter can be omitted in this case.\n" +
 	"   Make sure to run the command with exec -T when using Docker to prevent log messages from being sent to stdout.\n" +
-	"   The index backup and album file paths are automatically detected if not specified explicitly."
+	"   The index backup and album file paths are automatically detected if not specified explicitly.\n" +
+	"   Use the -z parameter to create a compressed and checksummed archive that also contains album and\n" +
+	"   photo YAML files, and to remove old archives according to the configured retention policy."
 
 // BackupCommand configures the command name, flags, and action.
 var BackupCommand = cli.Command{
@@ -32,6 +33,9 @@ var BackupCommand = cli.Command{
 	ArgsUsage:   "[filename]",
 	Flags:       backupFlags,
 	Action:      backupAction,
+	Subcommands: []cli.Command{
+		BackupVerifyCommand,
+	},
 }
 
 var backupFlags = []cli.Flag{
@@ -55,6 +59,10 @@ var backupFlags = []cli.Flag{
 		Name:  "index-path",
 		Usage: "custom index backup `PATH`",
 	},
+	cli.BoolFlag{
+		Name:  "archive, z",
+		Usage: "create a compressed and checksummed archive including album and photo YAML files",
+	},
 }
 
 // backupAction creates a database backup.
@@ -68,7 +76,9 @@ func backupAction(ctx *cli.Context) error {
 
 	backupAlbums := ctx.Bool("albums") || albumsPath != ""
 
-	if !backupIndex && !backupAlbums {
+	backupArchive := ctx.Bool("archive")
+
+	if !backupIndex && !backupAlbums && !backupArchive {
 		return cli.ShowSubcommandHelp(ctx)
 	}
 
@@ -86,7 +96,33 @@ func backupAction(ctx *cli.Context) error {
 	conf.RegisterDb()
 	defer conf.Shutdown()
 
-	if backupIndex {
+	get.SetConfig(conf)
+
+	if backupArchive {
+		archivePath := conf.BackupArchivePath()
+
+		if indexPath != "" {
+			archivePath = indexPath
+		}
+
+		fileName, err := photoprism.BackupArchive(archivePath)
+
+		if err != nil {
+			return err
+		}
+
+		if manifest, err := backup.Verify(fileName); err != nil {
+			return fmt.Errorf("%s failed verification: %s", clean.Log(fileName), err)
+		} else {
+			log.Infof("archive %s contains %s (%s)", clean.Log(filepath.Base(fileName)), english.Plural(len(manifest.Files), "file", "files"), humanize.Bytes(uint64(manifest.Size())))
+		}
+
+		if _, err = photoprism.RotateBackups(archivePath); err != nil {
+			return err
+		}
+	}
+
+	if backupIndex && !backupArchive {
 		// If empty, use default backup file name.
 		if indexFileName == "" {
 			if !fs.PathWritable(indexPath) {
@@ -116,29 +152,6 @@ func backupAction(ctx *cli.Context) error {
 			}
 		}
 
-		var cmd *exec.Cmd
-
-		switch conf.DatabaseDriver() {
-		case config.MySQL, config.MariaDB:
-			cmd = exec.Command(
-				conf.MysqldumpBin(),
-				"--protocol", "tcp",
-				"-h", conf.DatabaseHost(),
-				"-P", conf.DatabasePortString(),
-				"-u", conf.DatabaseUser(),
-				"-p"+conf.DatabasePassword(),
-				conf.DatabaseName(),
-			)
-		case config.SQLite3:
-			cmd = exec.Command(
-				conf.SqliteBin(),
-				conf.DatabaseFile(),
-				".dump",
-			)
-		default:
-			return fmt.Errorf("unsupported database type: %s", conf.DatabaseDriver())
-		}
-
 		// Write to stdout or file.
 		var f *os.File
 		if indexFileName == "-" {
@@ -151,18 +164,8 @@ func backupAction(ctx *cli.Context) error {
 			defer f.Close()
 		}
 
-		var stderr bytes.Buffer
-		cmd.Stderr = &stderr
-		cmd.Stdout = f
-
-		// Log exact command for debugging in trace mode.
-		log.Trace(cmd.String())
-
-		// Run backup command.
-		if err := cmd.Run(); err != nil {
-			if stderr.String() != "" {
-				return errors.New(stderr.String())
-			}
+		if err = photoprism.BackupIndex(f); err != nil {
+			return err
 		}
 	}
 
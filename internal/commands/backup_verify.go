@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/backup"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// BackupVerifyCommand configures the command name, flags, and action.
+var BackupVerifyCommand = cli.Command{
+	Name:      "verify",
+	Usage:     "Verifies the checksums and completeness of index backup archives",
+	ArgsUsage: "[filename]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all, a",
+			Usage: "verify all archives in the backup path",
+		},
+	},
+	Action: backupVerifyAction,
+}
+
+// backupVerifyAction verifies the latest, all, or the specified index backup archive.
+func backupVerifyAction(ctx *cli.Context) error {
+	conf, err := InitConfig(ctx)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err != nil {
+		return err
+	}
+
+	var fileNames []string
+
+	if fileName := ctx.Args().First(); fileName != "" {
+		fileNames = append(fileNames, fileName)
+	} else if entries, err := backup.List(conf.BackupArchivePath()); err != nil {
+		return err
+	} else if len(entries) == 0 {
+		return fmt.Errorf("no backup archives found in %s", clean.Log(conf.BackupArchivePath()))
+	} else if ctx.Bool("all") {
+		for _, e := range entries {
+			fileNames = append(fileNames, e.FileName)
+		}
+	} else {
+		fileNames = append(fileNames, entries.Latest().FileName)
+	}
+
+	failed := 0
+
+	for _, fileName := range fileNames {
+		if manifest, err := backup.Verify(fileName); err != nil {
+			log.Errorf("backup: %s is invalid (%s)", clean.Log(filepath.Base(fileName)), err)
+			failed++
+		} else {
+			log.Infof("backup: %s is valid, contains %s (%s)", clean.Log(filepath.Base(fileName)), english.Plural(len(manifest.Files), "file", "files"), humanize.Bytes(uint64(manifest.Size())))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%s failed verification", english.Plural(failed, "archive", "archives"))
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/report"
 )
 
 // CleanUpCommand configures the command name, flags, and action.
@@ -19,12 +20,12 @@ var CleanUpCommand = cli.Command{
 	Action: cleanUpAction,
 }
 
-var cleanUpFlags = []cli.Flag{
+var cleanUpFlags = append([]cli.Flag{
 	cli.BoolFlag{
 		Name:  "dry",
 		Usage: "dry run, don't actually remove anything",
 	},
-}
+}, report.ResultFlags...)
 
 // cleanUpAction removes orphaned index entries, sidecar and thumbnail files.
 func cleanUpAction(ctx *cli.Context) error {
@@ -52,12 +53,22 @@ func cleanUpAction(ctx *cli.Context) error {
 		Dry: ctx.Bool("dry"),
 	}
 
+	results := newResults(ctx, "cleanup", "")
+
 	// Start cleanup worker.
-	if thumbnails, _, sidecars, err := w.Start(opt); err != nil {
+	thumbnails, orphans, sidecars, err := w.Start(opt)
+
+	if err != nil {
 		return err
-	} else if total := thumbnails + sidecars; total > 0 {
+	}
+
+	results.Add("RemovedThumbnails", thumbnails)
+	results.Add("RemovedOrphans", orphans)
+	results.Add("RemovedSidecars", sidecars)
+
+	if total := thumbnails + sidecars; total > 0 {
 		log.Infof("removed %s in %s", english.Plural(total, "file", "files"), time.Since(cleanupStart))
 	}
 
-	return nil
+	return printResults(ctx, results)
 }
@@ -46,12 +46,16 @@ var PhotoPrism = []cli.Command{
 	StatusCommand,
 	IndexCommand,
 	ImportCommand,
+	ImportCatalogCommand,
+	ExportCommand,
 	CopyCommand,
 	FacesCommand,
+	LabelsCommand,
 	PlacesCommand,
 	PurgeCommand,
 	CleanUpCommand,
 	OptimizeCommand,
+	TimeRulesCommand,
 	MomentsCommand,
 	ConvertCommand,
 	ThumbsCommand,
@@ -13,6 +13,7 @@ import (
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/report"
 )
 
 // CopyCommand configures the command name, flags, and action.
@@ -21,12 +22,12 @@ var CopyCommand = cli.Command{
 	Aliases:   []string{"copy"},
 	Usage:     "Copies media files to originals",
 	ArgsUsage: "[source]",
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		cli.BoolFlag{
 			Name:  "dest, d",
 			Usage: "relative originals `PATH` to which the files should be imported",
 		},
-	},
+	}, report.ResultFlags...),
 	Action: copyAction,
 }
 
@@ -81,12 +82,15 @@ func copyAction(ctx *cli.Context) error {
 
 	w := get.Import()
 	opt := photoprism.ImportOptionsCopy(sourcePath, destFolder)
+	opt.Results = newResults(ctx, photoprism.ActionImport, sourcePath)
 
-	w.Start(opt)
+	imported := w.Start(opt)
+
+	opt.Results.Add("Found", len(imported))
 
 	elapsed := time.Since(start)
 
 	log.Infof("completed in %s", elapsed)
 
-	return nil
+	return printResults(ctx, opt.Results)
 }
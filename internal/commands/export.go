@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ExportCommand configures the command name, flags, and action.
+var ExportCommand = cli.Command{
+	Name:      "export",
+	Usage:     "Exports the metadata of pictures matching a search as CSV, TSV, or JSON Lines",
+	ArgsUsage: "[search query]",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "format, f",
+			Usage: "export `FORMAT` (csv, tsv, excel, jsonl)",
+			Value: string(photoprism.PhotoExportCSV),
+		},
+		cli.StringFlag{
+			Name:  "columns, c",
+			Usage: "comma-separated list of `COLUMNS` to export (default: all)",
+		},
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "export `FILENAME` (default: stdout)",
+		},
+		cli.StringFlag{
+			Name:  "order",
+			Usage: "sort `ORDER` of the pictures, e.g. oldest, newest, or name",
+			Value: "oldest",
+		},
+		cli.IntFlag{
+			Name:  "count, n",
+			Usage: "maximum `NUMBER` of pictures (0 for all)",
+		},
+		cli.BoolFlag{
+			Name:  "archived, a",
+			Usage: "export archived pictures",
+		},
+		cli.BoolFlag{
+			Name:  "list-columns",
+			Usage: "show the supported columns and exit",
+		},
+	},
+	Action: exportAction,
+}
+
+// exportAction exports the metadata of pictures matching a search.
+func exportAction(ctx *cli.Context) error {
+	if ctx.Bool("list-columns") {
+		fmt.Println(strings.Join(photoprism.PhotoExportColumns, "\n"))
+		return nil
+	}
+
+	format, ok := photoprism.ParsePhotoExportFormat(ctx.String("format"))
+
+	if !ok {
+		return fmt.Errorf("unknown export format %s", clean.Log(ctx.String("format")))
+	}
+
+	columns, err := photoprism.ParsePhotoExportColumns(ctx.String("columns"))
+
+	if err != nil {
+		return err
+	}
+
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.InitDb()
+
+		start := time.Now()
+
+		frm := form.SearchPhotos{
+			Query:    strings.TrimSpace(strings.Join(ctx.Args(), " ")),
+			Order:    ctx.String("order"),
+			Count:    ctx.Int("count"),
+			Archived: ctx.Bool("archived"),
+		}
+
+		var w io.Writer = os.Stdout
+		var dest = "stdout"
+
+		if fileName := ctx.String("output"); fileName != "" {
+			if fileName, err = filepath.Abs(fileName); err != nil {
+				return err
+			} else if err = os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+				return err
+			}
+
+			f, err := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fs.ModeFile)
+
+			if err != nil {
+				return err
+			}
+
+			defer f.Close()
+
+			w = f
+			dest = clean.Log(fileName)
+		}
+
+		export := photoprism.NewPhotoExport(conf, photoprism.PhotoExportOptions{
+			Format:  format,
+			Columns: columns,
+		})
+
+		count, err := export.Write(w, frm, nil)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("export: wrote metadata of %s to %s [%s]", english.Plural(count, "picture", "pictures"), dest, time.Since(start))
+
+		return nil
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/photoprism/photoprism/internal/query"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/report"
 )
 
 // FacesCommand configures the command name, flags, and action.
@@ -54,17 +55,18 @@ var FacesCommand = cli.Command{
 			Name:      "index",
 			Usage:     "Searches originals for faces",
 			ArgsUsage: "[subfolder]",
+			Flags:     report.ResultFlags,
 			Action:    facesIndexAction,
 		},
 		{
 			Name:  "update",
 			Usage: "Performs face clustering and matching",
-			Flags: []cli.Flag{
+			Flags: append([]cli.Flag{
 				cli.BoolFlag{
 					Name:  "force, f",
 					Usage: "update all faces",
 				},
-			},
+			}, report.ResultFlags...),
 			Action: facesUpdateAction,
 		},
 		{
@@ -72,6 +74,7 @@ var FacesCommand = cli.Command{
 			Usage:  "Optimizes face clusters",
 			Action: facesOptimizeAction,
 		},
+		FacesEvaluateCommand,
 	},
 }
 
@@ -248,15 +251,20 @@ func facesIndexAction(ctx *cli.Context) error {
 	var lastFound, indexed int
 
 	settings := conf.Settings()
+	results := newResults(ctx, "faces", subPath)
 
 	if w := get.Index(); w != nil {
 		indexStart := time.Now()
 		_, lastFound = w.LastRun()
 		convert := settings.Index.Convert && conf.SidecarWritable()
 		opt := photoprism.NewIndexOptions(subPath, true, convert, true, true, true)
+		opt.Results = results
 
 		found, indexed = w.Start(opt)
 
+		results.Add("Found", len(found))
+		results.Add("Indexed", indexed)
+
 		log.Infof("index: updated %s [%s]", english.Plural(indexed, "file", "files"), time.Since(indexStart))
 	}
 
@@ -269,7 +277,10 @@ func facesIndexAction(ctx *cli.Context) error {
 
 		if files, photos, updated, err := w.Start(opt); err != nil {
 			log.Error(err)
+			results.Fail("", err)
 		} else if updated > 0 {
+			results.Add("PurgedFiles", len(files))
+			results.Add("PurgedPhotos", len(photos))
 			log.Infof("purge: removed %s and %s", english.Plural(len(files), "file", "files"), english.Plural(len(photos), "photo", "photos"))
 		}
 	}
@@ -278,7 +289,7 @@ func facesIndexAction(ctx *cli.Context) error {
 
 	log.Infof("indexed %s in %s", english.Plural(len(found), "file", "files"), elapsed)
 
-	return nil
+	return printResults(ctx, results)
 }
 
 // facesUpdateAction performs face clustering and matching.
@@ -299,7 +310,8 @@ func facesUpdateAction(ctx *cli.Context) error {
 	defer conf.Shutdown()
 
 	opt := photoprism.FacesOptions{
-		Force: ctx.Bool("force"),
+		Force:   ctx.Bool("force"),
+		Results: newResults(ctx, "faces", ""),
 	}
 
 	w := get.Faces()
@@ -312,7 +324,7 @@ func facesUpdateAction(ctx *cli.Context) error {
 		log.Infof("completed in %s", elapsed)
 	}
 
-	return nil
+	return printResults(ctx, opt.Results)
 }
 
 // facesOptimizeAction optimizes existing face clusters.
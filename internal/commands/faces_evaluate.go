@@ -0,0 +1,185 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/report"
+)
+
+// FacesEvaluateCommand configures the command name, flags, and action.
+var FacesEvaluateCommand = cli.Command{
+	Name:  "evaluate",
+	Usage: "Evaluates face clustering thresholds using people that have been named",
+	Flags: append(report.CliFlags,
+		cli.StringFlag{
+			Name:  "cluster-core",
+			Usage: "comma-separated `NUMBERS` of faces forming a cluster core",
+		},
+		cli.StringFlag{
+			Name:  "cluster-dist",
+			Usage: "comma-separated similarity `DISTANCES` of faces forming a cluster core",
+		},
+		cli.StringFlag{
+			Name:  "match-dist",
+			Usage: "comma-separated similarity `OFFSETS` for matching faces with clusters",
+		},
+		cli.Float64Flag{
+			Name:  "holdout",
+			Usage: "share of named faces per person held out for validation",
+			Value: photoprism.FaceHoldout,
+		},
+		cli.BoolFlag{
+			Name:  "confusion",
+			Usage: "show which people are confused with the best thresholds",
+		},
+		cli.BoolFlag{
+			Name:  "save, s",
+			Usage: "save the best thresholds in faces.yml",
+		},
+	),
+	Action: facesEvaluateAction,
+}
+
+// facesEvaluateAction compares face clustering results with known subjects for different thresholds.
+func facesEvaluateAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	opt := photoprism.FacesEvaluateOptions{Holdout: ctx.Float64("holdout")}
+
+	if values, err := parseFloats(ctx.String("cluster-core")); err != nil {
+		return fmt.Errorf("invalid cluster core: %s", err)
+	} else {
+		for _, v := range values {
+			opt.ClusterCore = append(opt.ClusterCore, int(v))
+		}
+	}
+
+	if values, err := parseFloats(ctx.String("cluster-dist")); err != nil {
+		return fmt.Errorf("invalid cluster distance: %s", err)
+	} else {
+		opt.ClusterDist = values
+	}
+
+	if values, err := parseFloats(ctx.String("match-dist")); err != nil {
+		return fmt.Errorf("invalid match distance: %s", err)
+	} else {
+		opt.MatchDist = values
+	}
+
+	conf, err := InitConfig(ctx)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	w := get.Faces()
+
+	results, err := w.Evaluate(opt)
+
+	if err != nil {
+		return err
+	}
+
+	cols := []string{"Core", "Dist", "Match", "Clusters", "Mixed", "Matched", "Correct", "Precision", "Recall", "F1"}
+	rows := make([][]string, 0, len(results))
+
+	for _, r := range results {
+		rows = append(rows, []string{
+			strconv.Itoa(r.Thresholds.ClusterCore),
+			fmt.Sprintf("%.2f", r.Thresholds.ClusterDist),
+			fmt.Sprintf("%.2f", r.Thresholds.MatchDist),
+			strconv.Itoa(r.Clusters),
+			strconv.Itoa(r.Mixed),
+			strconv.Itoa(r.Matched),
+			strconv.Itoa(r.Correct),
+			fmt.Sprintf("%.3f", r.Precision()),
+			fmt.Sprintf("%.3f", r.Recall()),
+			fmt.Sprintf("%.3f", r.F1()),
+		})
+	}
+
+	format := report.CliFormat(ctx)
+
+	if info, err := report.RenderFormat(rows, cols, format); err != nil {
+		return err
+	} else {
+		fmt.Println(info)
+	}
+
+	best, ok := results.Best()
+
+	if !ok {
+		return nil
+	}
+
+	log.Infof("best thresholds for %d people: %s", best.Subjects, best.Thresholds.String())
+
+	if ctx.Bool("confusion") {
+		confusionRows := make([][]string, 0, len(best.Confusion))
+
+		for _, c := range best.Confusion {
+			confusionRows = append(confusionRows, []string{subjectName(c.Actual), subjectName(c.Predicted), strconv.Itoa(c.Count)})
+		}
+
+		if info, err := report.RenderFormat(confusionRows, []string{"Person", "Matched As", "Faces"}, format); err != nil {
+			return err
+		} else {
+			fmt.Println(info)
+		}
+	}
+
+	if ctx.Bool("save") {
+		if conf.NoSponsor() {
+			log.Warnf("config: custom face thresholds are only used by sponsors")
+		}
+
+		if err = conf.SaveFaceThresholds(best.Thresholds.ClusterCore, best.Thresholds.ClusterDist, best.Thresholds.MatchDist); err != nil {
+			return err
+		}
+
+		log.Infof("config: saved face thresholds in %s", conf.FacesYaml())
+	}
+
+	log.Infof("completed in %s", time.Since(start))
+
+	return nil
+}
+
+// parseFloats parses a comma-separated list of numbers.
+func parseFloats(s string) (result []float64, err error) {
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		} else if f, err := strconv.ParseFloat(v, 64); err != nil {
+			return result, err
+		} else {
+			result = append(result, f)
+		}
+	}
+
+	return result, nil
+}
+
+// subjectName returns the name of the subject with the specified UID, or the UID if it was not found.
+func subjectName(uid string) string {
+	if m := entity.FindSubject(uid); m != nil && m.SubjName != "" {
+		return m.SubjName
+	}
+
+	return uid
+}
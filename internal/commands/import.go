@@ -3,16 +3,20 @@ package commands
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize/english"
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/report"
 )
 
 // ImportCommand configures the command name, flags, and action.
@@ -21,12 +25,20 @@ var ImportCommand = cli.Command{
 	Aliases:   []string{"import"},
 	Usage:     "Moves media files to originals",
 	ArgsUsage: "[source]",
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		cli.BoolFlag{
 			Name:  "dest, d",
 			Usage: "relative originals `PATH` to which the files should be imported",
 		},
-	},
+		cli.BoolFlag{
+			Name:  "takeout",
+			Usage: "import a Google Photos Takeout export, reconstruct albums, and report unmatched files",
+		},
+		cli.BoolFlag{
+			Name:  "apple",
+			Usage: "import an Apple Photos export, stack edits and Live Photos, and add album folders to albums",
+		},
+	}, report.ResultFlags...),
 	Action: importAction,
 }
 
@@ -79,14 +91,133 @@ func importAction(ctx *cli.Context) error {
 
 	log.Infof("moving media files from %s to %s", sourcePath, filepath.Join(conf.OriginalsPath(), destFolder))
 
+	if ctx.Bool("takeout") {
+		return takeoutImport(ctx, sourcePath, destFolder, start)
+	} else if ctx.Bool("apple") {
+		return appleImport(ctx, sourcePath, destFolder, start)
+	}
+
 	w := get.Import()
 	opt := photoprism.ImportOptionsMove(sourcePath, destFolder)
+	opt.Results = newResults(ctx, photoprism.ActionImport, sourcePath)
 
-	w.Start(opt)
+	imported := w.Start(opt)
+
+	opt.Results.Add("Found", len(imported))
 
 	elapsed := time.Since(start)
 
 	log.Infof("completed in %s", elapsed)
 
+	return printResults(ctx, opt.Results)
+}
+
+// takeoutImport imports a Google Photos Takeout export and prints the reconciliation report.
+func takeoutImport(ctx *cli.Context, sourcePath, destFolder string, start time.Time) error {
+	w := photoprism.NewTakeout(get.Config(), get.Import())
+
+	res, err := w.Start(photoprism.TakeoutOptions{
+		Path:       sourcePath,
+		DestFolder: destFolder,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("takeout: found %s, matched %s, stacked %s",
+		english.Plural(res.Files, "file", "files"),
+		english.Plural(res.Sidecars, "sidecar", "sidecars"),
+		english.Plural(res.Edited, "edited copy", "edited copies"))
+
+	if n := len(res.MissingSidecars); n > 0 {
+		log.Warnf("takeout: %s without sidecar", english.Plural(n, "file", "files"))
+	}
+
+	if n := len(res.OrphanedSidecars); n > 0 {
+		log.Warnf("takeout: %s without media file", english.Plural(n, "sidecar", "sidecars"))
+	}
+
+	log.Infof("completed in %s", time.Since(start))
+
+	if format := report.CliFormat(ctx); format == report.JSON || format == report.NDJSON {
+		return report.Encode(os.Stdout, res, format)
+	}
+
+	rows := make([][]string, len(res.Albums))
+
+	for i, a := range res.Albums {
+		rows[i] = []string{a.Title, a.Path, report.Bool(a.Shared, "Yes", "No"), fmt.Sprintf("%d", a.Files)}
+	}
+
+	result, err := report.RenderFormat(rows, []string{"Album", "Folder", "Shared", "Files"}, report.Default)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result)
+
+	for _, fileName := range res.MissingSidecars {
+		fmt.Printf("missing sidecar: %s\n", fileName)
+	}
+
+	for _, fileName := range res.OrphanedSidecars {
+		fmt.Printf("orphaned sidecar: %s\n", fileName)
+	}
+
+	for _, fileName := range res.UnpairedEdits {
+		fmt.Printf("edited copy without original: %s\n", fileName)
+	}
+
+	return nil
+}
+
+// appleImport imports an Apple Photos export and prints the import report.
+func appleImport(ctx *cli.Context, sourcePath, destFolder string, start time.Time) error {
+	w := photoprism.NewApplePhotos(get.Config(), get.Import())
+
+	res, err := w.Start(photoprism.ApplePhotosOptions{
+		Path:       sourcePath,
+		DestFolder: destFolder,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("apple: found %s, stacked %s and %s",
+		english.Plural(res.Files, "file", "files"),
+		english.Plural(res.Edited, "edited version", "edited versions"),
+		english.Plural(res.LivePhotos, "live photo", "live photos"))
+
+	if n := len(res.Unpaired); n > 0 {
+		log.Warnf("apple: %s without original", english.Plural(n, "file", "files"))
+	}
+
+	log.Infof("completed in %s", time.Since(start))
+
+	if format := report.CliFormat(ctx); format == report.JSON || format == report.NDJSON {
+		return report.Encode(os.Stdout, res, format)
+	}
+
+	rows := make([][]string, len(res.Albums))
+
+	for i, a := range res.Albums {
+		rows[i] = []string{a.Title, a.Path, fmt.Sprintf("%d", a.Files)}
+	}
+
+	result, err := report.RenderFormat(rows, []string{"Album", "Folder", "Files"}, report.Default)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result)
+
+	for _, fileName := range res.Unpaired {
+		fmt.Printf("file without original: %s\n", fileName)
+	}
+
 	return nil
 }
@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/catalog"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/report"
+)
+
+// catalogFlags are the flags of the catalog import subcommands.
+var catalogFlags = append([]cli.Flag{
+	cli.BoolFlag{
+		Name:  "dry-run, n",
+		Usage: "show the changes without updating the index",
+	},
+}, report.CliFlags...)
+
+// ImportCatalogCommand configures the command name, flags, and action.
+var ImportCatalogCommand = cli.Command{
+	Name:  "import-catalog",
+	Usage: "Imports metadata and albums from photo management catalogs",
+	Subcommands: []cli.Command{
+		{
+			Name:      catalog.Lightroom,
+			Usage:     "Imports keywords, titles, captions, ratings, locations, and collections from a Lightroom Classic catalog",
+			ArgsUsage: "[catalog.lrcat]",
+			Flags:     catalogFlags,
+			Action: func(ctx *cli.Context) error {
+				return importCatalogAction(ctx, catalog.Lightroom)
+			},
+		},
+		{
+			Name:      catalog.DigiKam,
+			Usage:     "Imports tags, face regions, ratings, captions, locations, and albums from a digiKam database",
+			ArgsUsage: "[digikam4.db]",
+			Flags:     catalogFlags,
+			Action: func(ctx *cli.Context) error {
+				return importCatalogAction(ctx, catalog.DigiKam)
+			},
+		},
+	},
+}
+
+// importCatalogAction imports the metadata of indexed photos from a catalog file.
+func importCatalogAction(ctx *cli.Context, catalogType string) error {
+	start := time.Now()
+
+	fileName := strings.TrimSpace(ctx.Args().First())
+
+	if fileName == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	} else if abs, err := filepath.Abs(fileName); err != nil {
+		return err
+	} else {
+		fileName = abs
+	}
+
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.InitDb()
+
+		cat, err := catalog.Open(catalogType, fileName)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("catalog: found %s in %s", english.Plural(len(cat.Images), "image", "images"), clean.Log(filepath.Base(fileName)))
+
+		dryRun := ctx.Bool("dry-run")
+
+		res, err := photoprism.NewCatalogImport(conf, get.Index()).Start(cat, photoprism.CatalogOptions{DryRun: dryRun})
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("catalog: matched %s, %s by hash",
+			english.Plural(res.Matched, "file", "files"),
+			english.Plural(res.ByHash, "file", "files"))
+
+		if n := len(res.Unmatched); n > 0 {
+			log.Warnf("catalog: %s not found in index", english.Plural(n, "image", "images"))
+		}
+
+		if dryRun {
+			log.Infof("catalog: %s would be updated (dry run)", english.Plural(res.Updated, "photo", "photos"))
+		} else {
+			log.Infof("catalog: updated %s in %s", english.Plural(res.Updated, "photo", "photos"), time.Since(start))
+		}
+
+		format := report.CliFormat(ctx)
+
+		if format == report.JSON || format == report.NDJSON {
+			return report.Encode(os.Stdout, res, format)
+		}
+
+		rows := make([][]string, len(res.Changes))
+
+		for i, c := range res.Changes {
+			rows[i] = []string{c.FileName, c.Field, c.From, c.To}
+		}
+
+		result, err := report.RenderFormat(rows, []string{"File", "Field", "From", "To"}, format)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("\n%s\n", result)
+
+		return nil
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/report"
 )
 
 // IndexCommand registers the index cli command.
@@ -24,7 +25,7 @@ var IndexCommand = cli.Command{
 	Action:    indexAction,
 }
 
-var indexFlags = []cli.Flag{
+var indexFlags = append([]cli.Flag{
 	cli.BoolFlag{
 		Name:  "force, f",
 		Usage: "rescan all originals, including unchanged files",
@@ -37,7 +38,7 @@ var indexFlags = []cli.Flag{
 		Name:  "cleanup, c",
 		Usage: "remove orphan index entries and thumbnails",
 	},
-}
+}, report.ResultFlags...)
 
 // indexAction indexes all photos in originals directory (photo library)
 func indexAction(ctx *cli.Context) error {
@@ -71,13 +72,19 @@ func indexAction(ctx *cli.Context) error {
 	var found fs.Done
 	var indexed int
 
+	results := newResults(ctx, photoprism.ActionIndex, subPath)
+
 	if w := get.Index(); w != nil {
 		indexStart := time.Now()
 		convert := conf.Settings().Index.Convert && conf.SidecarWritable()
 		opt := photoprism.NewIndexOptions(subPath, ctx.Bool("force"), convert, true, false, !ctx.Bool("archived"))
+		opt.Results = results
 
 		found, indexed = w.Start(opt)
 
+		results.Add("Found", len(found))
+		results.Add("Indexed", indexed)
+
 		log.Infof("index: updated %s [%s]", english.Plural(indexed, "file", "files"), time.Since(indexStart))
 	}
 
@@ -91,7 +98,10 @@ func indexAction(ctx *cli.Context) error {
 
 		if files, photos, updated, err := w.Start(opt); err != nil {
 			log.Error(err)
+			results.Fail("", err)
 		} else if updated > 0 {
+			results.Add("PurgedFiles", len(files))
+			results.Add("PurgedPhotos", len(photos))
 			log.Infof("purge: removed %s and %s [%s]", english.Plural(len(files), "file", "files"), english.Plural(len(photos), "photo", "photos"), time.Since(purgeStart))
 		}
 	}
@@ -105,9 +115,17 @@ func indexAction(ctx *cli.Context) error {
 		}
 
 		// Start cleanup worker.
-		if thumbnails, _, sidecars, err := w.Start(opt); err != nil {
+		thumbnails, orphans, sidecars, err := w.Start(opt)
+
+		if err != nil {
 			return err
-		} else if total := thumbnails + sidecars; total > 0 {
+		}
+
+		results.Add("RemovedThumbnails", thumbnails)
+		results.Add("RemovedOrphans", orphans)
+		results.Add("RemovedSidecars", sidecars)
+
+		if total := thumbnails + sidecars; total > 0 {
 			log.Infof("cleanup: removed %s in total [%s]", english.Plural(total, "file", "files"), time.Since(cleanupStart))
 		}
 	}
@@ -116,5 +134,5 @@ func indexAction(ctx *cli.Context) error {
 
 	log.Infof("indexed %s in %s", english.Plural(len(found), "file", "files"), elapsed)
 
-	return nil
+	return printResults(ctx, results)
 }
@@ -0,0 +1,284 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/get"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// LabelsCommand configures the command name, flags, and action.
+var LabelsCommand = cli.Command{
+	Name:  "labels",
+	Usage: "Label management subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:      "reclassify",
+			Usage:     "Runs image classification again and replaces detected labels",
+			ArgsUsage: "[subfolder]",
+			Action:    labelsReclassifyAction,
+		},
+		{
+			Name:  "parent",
+			Usage: "Label hierarchy subcommands",
+			Subcommands: []cli.Command{
+				{
+					Name:      "add",
+					Usage:     "Makes a label the child of another label",
+					ArgsUsage: "[label] [parent]",
+					Action:    labelsParentAddAction,
+				},
+				{
+					Name:      "rm",
+					Usage:     "Removes the relationship between a label and its parent",
+					ArgsUsage: "[label] [parent]",
+					Action:    labelsParentRemoveAction,
+				},
+			},
+		},
+		{
+			Name:      "merge",
+			Usage:     "Merges labels into the first label and keeps their names as synonyms",
+			ArgsUsage: "[target] [label]...",
+			Action:    labelsMergeAction,
+		},
+		{
+			Name:  "synonyms",
+			Usage: "Label synonym subcommands",
+			Subcommands: []cli.Command{
+				{
+					Name:      "add",
+					Usage:     "Adds alternative names that can be used to find a label",
+					ArgsUsage: "[label] [synonym]...",
+					Action:    labelsSynonymsAddAction,
+				},
+				{
+					Name:      "rm",
+					Usage:     "Removes alternative label names",
+					ArgsUsage: "[label] [synonym]...",
+					Action:    labelsSynonymsRemoveAction,
+				},
+			},
+		},
+	},
+}
+
+// labelsReclassifyAction runs image classification on existing photos again.
+func labelsReclassifyAction(ctx *cli.Context) error {
+	start := time.Now()
+
+	conf, err := InitConfig(ctx)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	// Use first argument to limit scope if set.
+	subPath := strings.TrimSpace(ctx.Args().First())
+
+	if subPath == "" {
+		log.Infof("classifying photos in %s", clean.Log(conf.OriginalsPath()))
+	} else {
+		log.Infof("classifying photos in %s", clean.Log(filepath.Join(conf.OriginalsPath(), subPath)))
+	}
+
+	model := conf.ClassificationModel()
+
+	log.Infof("classify: using %s model", clean.Log(filepath.Base(model.Path(conf.AssetsPath()))))
+
+	if model.Rules != "" {
+		log.Infof("classify: using custom label rules from %s", clean.Log(model.Rules))
+	}
+
+	w := get.Index()
+
+	if updated, err := w.Reclassify(photoprism.ReclassifyOptions{Path: subPath}); err != nil {
+		return err
+	} else {
+		elapsed := time.Since(start)
+
+		log.Infof("reclassified %s in %s", english.Plural(updated, "photo", "photos"), elapsed)
+	}
+
+	return nil
+}
+
+// findLabel returns the label with the specified name or an error if it was not found.
+func findLabel(name string) (*entity.Label, error) {
+	if m := entity.FindLabel(name); m != nil {
+		return m, nil
+	}
+
+	return nil, fmt.Errorf("label %s not found", clean.Log(name))
+}
+
+// labelsParentAddAction makes a label the child of another label.
+func labelsParentAddAction(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	return withLabelsDb(ctx, func() error {
+		m, err := findLabel(ctx.Args().Get(0))
+
+		if err != nil {
+			return err
+		}
+
+		parent, err := findLabel(ctx.Args().Get(1))
+
+		if err != nil {
+			return err
+		}
+
+		if err = m.AddParent(parent); err != nil {
+			return err
+		}
+
+		log.Infof("%s is now a child of %s", clean.Log(m.LabelName), clean.Log(parent.LabelName))
+
+		return nil
+	})
+}
+
+// labelsParentRemoveAction removes the relationship between a label and its parent.
+func labelsParentRemoveAction(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	return withLabelsDb(ctx, func() error {
+		m, err := findLabel(ctx.Args().Get(0))
+
+		if err != nil {
+			return err
+		}
+
+		parent, err := findLabel(ctx.Args().Get(1))
+
+		if err != nil {
+			return err
+		}
+
+		if err = m.RemoveParent(parent); err != nil {
+			return err
+		}
+
+		log.Infof("%s is no longer a child of %s", clean.Log(m.LabelName), clean.Log(parent.LabelName))
+
+		return nil
+	})
+}
+
+// labelsMergeAction merges labels into the first label passed.
+func labelsMergeAction(ctx *cli.Context) error {
+	if ctx.NArg() < 2 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	return withLabelsDb(ctx, func() error {
+		m, err := findLabel(ctx.Args().First())
+
+		if err != nil {
+			return err
+		}
+
+		for _, name := range ctx.Args().Tail() {
+			other, err := findLabel(name)
+
+			if err != nil {
+				return err
+			}
+
+			if err = m.Merge(other); err != nil {
+				return err
+			}
+
+			log.Infof("merged %s into %s", clean.Log(other.LabelName), clean.Log(m.LabelName))
+		}
+
+		return entity.UpdateLabelCounts()
+	})
+}
+
+// labelsSynonymsAddAction adds alternative names to a label.
+func labelsSynonymsAddAction(ctx *cli.Context) error {
+	if ctx.NArg() < 2 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	return withLabelsDb(ctx, func() error {
+		m, err := findLabel(ctx.Args().First())
+
+		if err != nil {
+			return err
+		}
+
+		for _, name := range ctx.Args().Tail() {
+			if s, err := m.AddSynonym(name); err != nil {
+				return err
+			} else {
+				log.Infof("added synonym %s to %s", clean.Log(s.SynonymName), clean.Log(m.LabelName))
+			}
+		}
+
+		return nil
+	})
+}
+
+// labelsSynonymsRemoveAction removes alternative names from a label.
+func labelsSynonymsRemoveAction(ctx *cli.Context) error {
+	if ctx.NArg() < 2 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	return withLabelsDb(ctx, func() error {
+		m, err := findLabel(ctx.Args().First())
+
+		if err != nil {
+			return err
+		}
+
+		for _, name := range ctx.Args().Tail() {
+			if err = m.RemoveSynonym(name); err != nil {
+				return err
+			}
+
+			log.Infof("removed synonym %s from %s", clean.Log(name), clean.Log(m.LabelName))
+		}
+
+		return nil
+	})
+}
+
+// withLabelsDb initializes the config and database before running the function passed.
+func withLabelsDb(ctx *cli.Context, f func() error) error {
+	conf, err := InitConfig(ctx)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err != nil {
+		return err
+	}
+
+	conf.InitDb()
+	defer conf.Shutdown()
+
+	return f()
+}
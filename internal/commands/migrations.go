@@ -8,10 +8,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize/english"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/migrate"
 	"github.com/photoprism/photoprism/pkg/report"
 )
@@ -43,6 +45,29 @@ var MigrationsRunCommand = cli.Command{
 	Action: migrationsRunAction,
 }
 
+var MigrationsRollbackCommand = cli.Command{
+	Name:        "rollback",
+	Aliases:     []string{"revert", "down"},
+	Usage:       "Rolls back previously executed schema migrations",
+	Description: "Lossy migrations, e.g. 20230313-000001, cannot restore the original values and require --force.",
+	ArgsUsage:   "[migrations...]",
+	Flags: []cli.Flag{
+		cli.BoolFlag{
+			Name:  "force, f",
+			Usage: "roll back lossy migrations that cannot fully restore the previous data",
+		},
+	},
+	Action: migrationsRollbackAction,
+}
+
+var MigrationsCheckCommand = cli.Command{
+	Name:    "check",
+	Aliases: []string{"drift"},
+	Usage:   "Reports missing and extra columns and indexes compared to the expected schema",
+	Flags:   report.CliFlags,
+	Action:  migrationsCheckAction,
+}
+
 // MigrationsCommand registers the "migrations" CLI command.
 var MigrationsCommand = cli.Command{
 	Name:  "migrations",
@@ -50,6 +75,8 @@ var MigrationsCommand = cli.Command{
 	Subcommands: []cli.Command{
 		MigrationsStatusCommand,
 		MigrationsRunCommand,
+		MigrationsRollbackCommand,
+		MigrationsCheckCommand,
 	},
 }
 
@@ -185,3 +212,79 @@ func migrationsRunAction(ctx *cli.Context) error {
 
 	return nil
 }
+
+// migrationsRollbackAction rolls back previously executed schema migrations.
+func migrationsRollbackAction(ctx *cli.Context) error {
+	ids := ctx.Args()
+
+	if len(ids) == 0 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	conf, err := InitConfig(ctx)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err != nil {
+		return err
+	}
+
+	conf.RegisterDb()
+	defer conf.Shutdown()
+
+	result, err := migrate.Rollback(conf.Db(), ids, ctx.Bool("force"))
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("rolled back %s", english.Plural(len(result), "migration", "migrations"))
+
+	return nil
+}
+
+// migrationsCheckAction compares the database schema with the registered entities.
+func migrationsCheckAction(ctx *cli.Context) error {
+	conf, err := InitConfig(ctx)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err != nil {
+		return err
+	}
+
+	conf.RegisterDb()
+	defer conf.Shutdown()
+
+	result, err := entity.Entities.Check(conf.Db())
+
+	if err != nil {
+		return err
+	} else if len(result) == 0 {
+		log.Infof("%s schema matches the registered entities", conf.DatabaseDriver())
+		return nil
+	}
+
+	// Report columns.
+	cols := []string{"Table", "Type", "Name", "Problem"}
+
+	// Report rows.
+	rows := make([][]string, 0, len(result))
+
+	for _, d := range result {
+		rows = append(rows, []string{d.Table, d.Type, d.Name, d.Problem})
+	}
+
+	// Display report.
+	info, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(info)
+
+	return nil
+}
@@ -2,24 +2,37 @@ package commands
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/dustin/go-humanize/english"
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/report"
 )
 
 // OptimizeCommand configures the command name, flags, and action.
 var OptimizeCommand = cli.Command{
 	Name:  "optimize",
 	Usage: "Maintains titles, estimates, and other metadata",
-	Flags: []cli.Flag{
+	Flags: append([]cli.Flag{
 		cli.BoolFlag{
 			Name:  "force, f",
 			Usage: "update all, including recently optimized",
 		},
-	},
+		cli.BoolFlag{
+			Name:  "time-rules",
+			Usage: "correct the time of indexed photos with matching camera time rules",
+		},
+		cli.BoolFlag{
+			Name:  "preview",
+			Usage: "only report photos that would be corrected by time rules",
+		},
+	}, report.CliFlags...),
 	Action: optimizeAction,
 }
 
@@ -43,6 +56,10 @@ func optimizeAction(ctx *cli.Context) error {
 		log.Infof("config: enabled read-only mode")
 	}
 
+	if ctx.Bool("time-rules") || ctx.Bool("preview") {
+		return optimizeTimeRules(ctx)
+	}
+
 	force := ctx.Bool("force")
 	worker := workers.NewMeta(conf)
 
@@ -64,3 +81,41 @@ func optimizeAction(ctx *cli.Context) error {
 
 	return nil
 }
+
+// optimizeTimeRules corrects the time of indexed photos with matching camera time rules
+// and displays a report of the photos that are, or would be in preview mode, corrected.
+func optimizeTimeRules(ctx *cli.Context) error {
+	preview := ctx.Bool("preview")
+
+	results, err := photoprism.ApplyTimeRules(preview)
+
+	if err != nil {
+		return err
+	}
+
+	if preview {
+		log.Infof("time rules would correct %s", english.Plural(len(results), "photo", "photos"))
+	} else {
+		log.Infof("time rules corrected %s", english.Plural(len(results), "photo", "photos"))
+	}
+
+	cols := []string{"Photo UID", "Name", "Rule", "Taken At", "Corrected", "Time Zone"}
+	rows := make([][]string, len(results))
+
+	for i, r := range results {
+		rows[i] = []string{
+			r.PhotoUID,
+			r.PhotoName,
+			strconv.FormatUint(uint64(r.RuleID), 10),
+			r.TakenAt.Format("2006-01-02 15:04:05"),
+			r.Corrected.Format("2006-01-02 15:04:05"),
+			r.TimeZone,
+		}
+	}
+
+	result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+	fmt.Printf("\n%s\n", result)
+
+	return err
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/report"
 )
 
 // PurgeCommand configures the command name, flags, and action.
@@ -23,7 +24,7 @@ var PurgeCommand = cli.Command{
 	Action: purgeAction,
 }
 
-var purgeFlags = []cli.Flag{
+var purgeFlags = append([]cli.Flag{
 	cli.BoolFlag{
 		Name:  "hard",
 		Usage: "permanently remove from index",
@@ -32,7 +33,7 @@ var purgeFlags = []cli.Flag{
 		Name:  "dry",
 		Usage: "dry run, don't actually remove anything",
 	},
-}
+}, report.ResultFlags...)
 
 // purgeAction removes missing files from search results
 func purgeAction(ctx *cli.Context) error {
@@ -72,13 +73,18 @@ func purgeAction(ctx *cli.Context) error {
 		Force: true,
 	}
 
+	results := newResults(ctx, "purge", subPath)
+
 	if files, photos, updated, err := w.Start(opt); err != nil {
 		return err
 	} else if updated > 0 {
+		results.Add("PurgedFiles", len(files))
+		results.Add("PurgedPhotos", len(photos))
+		results.Add("Updated", updated)
 		log.Infof("purged %s and %s in %s", english.Plural(len(files), "file", "files"), english.Plural(len(photos), "photo", "photos"), time.Since(start))
 	} else {
 		log.Infof("purge completed in %s", time.Since(start))
 	}
 
-	return nil
+	return printResults(ctx, results)
 }
@@ -9,11 +9,14 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/dustin/go-humanize/english"
 	"github.com/urfave/cli"
 
+	"github.com/photoprism/photoprism/internal/backup"
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/get"
@@ -24,7 +27,9 @@ import (
 
 const restoreDescription = "A user-defined filename or - for stdin can be passed as the first argument. " +
 	"The -i parameter can be omitted in this case.\n" +
-	"   The index backup and album file paths are automatically detected if not specified explicitly."
+	"   The index backup and album file paths are automatically detected if not specified explicitly.\n" +
+	"   Archives are verified before they are restored, and --dry-run checks a backup against the\n" +
+	"   current schema without making any changes."
 
 // RestoreCommand configures the command name, flags, and action.
 var RestoreCommand = cli.Command{
@@ -57,6 +62,14 @@ var restoreFlags = []cli.Flag{
 		Name:  "index-path",
 		Usage: "custom index backup `PATH`",
 	},
+	cli.BoolFlag{
+		Name:  "archive, z",
+		Usage: "restore from the latest compressed and checksummed backup archive",
+	},
+	cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "validate the backup against the current schema without making changes",
+	},
 }
 
 // restoreAction restores a database backup.
@@ -64,7 +77,8 @@ func restoreAction(ctx *cli.Context) error {
 	// Use command argument as backup file name.
 	indexFileName := ctx.Args().First()
 	indexPath := ctx.String("index-path")
-	restoreIndex := ctx.Bool("index") || indexFileName != "" || indexPath != ""
+	restoreArchive := ctx.Bool("archive") || strings.HasSuffix(indexFileName, backup.FileExt)
+	restoreIndex := ctx.Bool("index") || indexFileName != "" || indexPath != "" || restoreArchive
 
 	albumsPath := ctx.String("albums-path")
 	restoreAlbums := ctx.Bool("albums") || albumsPath != ""
@@ -87,9 +101,28 @@ func restoreAction(ctx *cli.Context) error {
 	conf.RegisterDb()
 	defer conf.Shutdown()
 
+	if albumsPath == "" {
+		albumsPath = conf.AlbumsPath()
+	}
+
 	if restoreIndex {
 		// If empty, use default backup file name.
-		if indexFileName == "" {
+		if indexFileName == "" && restoreArchive {
+			if indexPath == "" {
+				indexPath = conf.BackupArchivePath()
+			}
+
+			entries, err := backup.List(indexPath)
+
+			if err != nil {
+				return err
+			} else if len(entries) == 0 {
+				log.Errorf("no backup archives found in %s", indexPath)
+				return nil
+			}
+
+			indexFileName = entries.Latest().FileName
+		} else if indexFileName == "" {
 			if indexPath == "" {
 				indexPath = filepath.Join(conf.BackupPath(), conf.DatabaseDriver())
 			}
@@ -108,6 +141,33 @@ func restoreAction(ctx *cli.Context) error {
 			indexFileName = matches[len(matches)-1]
 		}
 
+		driver := conf.DatabaseDriver()
+
+		// Verify archive checksums and make sure it was created with a compatible database.
+		if restoreArchive {
+			manifest, err := backup.Verify(indexFileName)
+
+			if err != nil {
+				return fmt.Errorf("%s failed verification: %s", clean.Log(indexFileName), err)
+			} else if !sameDatabase(manifest.Driver, driver) {
+				return fmt.Errorf("%s contains a %s backup, but the index uses %s", clean.Log(indexFileName), manifest.Driver, driver)
+			}
+
+			log.Infof("verified %s created at %s", clean.Log(filepath.Base(indexFileName)), manifest.CreatedAt.Format(time.RFC3339))
+		}
+
+		r, err := openIndexBackup(indexFileName, restoreArchive)
+
+		if err != nil {
+			return err
+		}
+
+		defer r.Close()
+
+		if ctx.Bool("dry-run") {
+			return restoreDryRun(r, driver)
+		}
+
 		counts := struct{ Photos int }{}
 
 		conf.Db().Unscoped().Table("photos").
@@ -126,7 +186,7 @@ func restoreAction(ctx *cli.Context) error {
 
 		var cmd *exec.Cmd
 
-		switch conf.DatabaseDriver() {
+		switch driver {
 		case config.MySQL, config.MariaDB:
 			cmd = exec.Command(
 				conf.MysqlBin(),
@@ -146,19 +206,13 @@ func restoreAction(ctx *cli.Context) error {
 				conf.DatabaseFile(),
 			)
 		default:
-			return fmt.Errorf("unsupported database type: %s", conf.DatabaseDriver())
+			return fmt.Errorf("unsupported database type: %s", driver)
 		}
 
-		// Read from stdin or file.
-		var f *os.File
 		if indexFileName == "-" {
 			log.Infof("restoring index from stdin")
-			f = os.Stdin
-		} else if f, err = os.OpenFile(indexFileName, os.O_RDONLY, 0); err != nil {
-			return fmt.Errorf("failed to open %s: %s", clean.Log(indexFileName), err)
 		} else {
 			log.Infof("restoring index from %s", clean.Log(indexFileName))
-			defer f.Close()
 		}
 
 		var stderr bytes.Buffer
@@ -173,7 +227,7 @@ func restoreAction(ctx *cli.Context) error {
 
 		go func() {
 			defer stdin.Close()
-			if _, err = io.Copy(stdin, f); err != nil {
+			if _, err = io.Copy(stdin, r); err != nil {
 				log.Errorf(err.Error())
 			}
 		}()
@@ -188,6 +242,21 @@ func restoreAction(ctx *cli.Context) error {
 				log.Warnf("index could not be restored completely")
 			}
 		}
+
+		// Extract album and photo YAML files from the archive, if requested.
+		if restoreArchive && restoreAlbums {
+			if count, err := backup.ExtractDir(indexFileName, backup.AlbumsDir, albumsPath, ctx.Bool("force")); err != nil {
+				return err
+			} else {
+				log.Infof("extracted %s", english.Plural(count, "album file", "album files"))
+			}
+
+			if count, err := backup.ExtractDir(indexFileName, backup.SidecarDir, conf.SidecarPath(), ctx.Bool("force")); err != nil {
+				return err
+			} else {
+				log.Infof("extracted %s", english.Plural(count, "sidecar file", "sidecar files"))
+			}
+		}
 	}
 
 	log.Infoln("migrating index database schema")
@@ -197,10 +266,6 @@ func restoreAction(ctx *cli.Context) error {
 	if restoreAlbums {
 		get.SetConfig(conf)
 
-		if albumsPath == "" {
-			albumsPath = conf.AlbumsPath()
-		}
-
 		if !fs.PathExists(albumsPath) {
 			log.Warnf("album files path %s not found", clean.Log(albumsPath))
 		} else {
@@ -220,3 +285,62 @@ func restoreAction(ctx *cli.Context) error {
 
 	return nil
 }
+
+// openIndexBackup returns a reader for the index dump in stdin, an SQL file, or an archive.
+func openIndexBackup(fileName string, archive bool) (io.ReadCloser, error) {
+	if fileName == "-" {
+		return io.NopCloser(os.Stdin), nil
+	} else if !archive {
+		f, err := os.OpenFile(fileName, os.O_RDONLY, 0)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %s", clean.Log(fileName), err)
+		}
+
+		return f, nil
+	}
+
+	r, w := io.Pipe()
+
+	go func() {
+		w.CloseWithError(backup.Extract(fileName, backup.IndexName, w))
+	}()
+
+	return r, nil
+}
+
+// restoreDryRun checks an index dump against the current schema without making any changes.
+func restoreDryRun(r io.Reader, driver string) error {
+	info, err := backup.ReadDump(r)
+
+	if err != nil {
+		return err
+	} else if err = info.Complete(driver); err != nil {
+		return err
+	} else if _, ok := info.Tables[entity.Photo{}.TableName()]; !ok {
+		return fmt.Errorf("backup does not contain an index")
+	}
+
+	log.Infof("dry run: backup contains %s (%s)", english.Plural(len(info.Tables), "table", "tables"), humanize.Bytes(uint64(info.Size)))
+
+	missing, unknown := info.Compare(entity.Entities.Names())
+
+	for _, name := range missing {
+		log.Warnf("dry run: table %s is missing and will be created empty", clean.Log(name))
+	}
+
+	for _, name := range unknown {
+		log.Infof("dry run: table %s is not part of the current schema", clean.Log(name))
+	}
+
+	log.Infof("dry run: backup is valid, no changes have been made")
+
+	return nil
+}
+
+// sameDatabase checks if backups of the database drivers are compatible.
+func sameDatabase(a, b string) bool {
+	mysql := func(s string) bool { return s == config.MySQL || s == config.MariaDB }
+
+	return a == b || mysql(a) && mysql(b)
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/report"
+)
+
+// newResults returns structured results if JSON or NDJSON output was requested, or nil otherwise.
+// In NDJSON mode, file results are streamed to stdout as they occur.
+func newResults(ctx *cli.Context, action, path string) *photoprism.Results {
+	format := report.CliFormat(ctx)
+
+	if format != report.JSON && format != report.NDJSON {
+		return nil
+	}
+
+	results := photoprism.NewResults(action, path)
+
+	if format == report.NDJSON {
+		results.OnFile = func(f photoprism.FileResult) {
+			if err := report.Encode(os.Stdout, f, report.NDJSON); err != nil {
+				log.Warnf("results: %s", err)
+			}
+		}
+	}
+
+	return results
+}
+
+// printResults writes the results to stdout in the requested format, if any.
+func printResults(ctx *cli.Context, results *photoprism.Results) error {
+	if results == nil {
+		return nil
+	}
+
+	return report.Encode(os.Stdout, results.Finish(), report.CliFormat(ctx))
+}
@@ -14,6 +14,7 @@ var ShowCommand = cli.Command{
 		ShowConfigYamlCommand,
 		ShowSearchFiltersCommand,
 		ShowFileFormatsCommand,
+		ShowConvertersCommand,
 		ShowMetadataCommand,
 	},
 }
@@ -50,7 +50,7 @@ func showConfigAction(ctx *cli.Context) error {
 			fmt.Printf("\n%s\n\n", strings.ToUpper(rep.Title))
 		}
 
-		fmt.Println(result)
+		fmt.Print(result)
 	}
 
 	return nil
@@ -25,11 +25,11 @@ func showConfigYamlAction(ctx *cli.Context) error {
 
 	rows, cols := conf.Options().Report()
 
-	// CSV Export?
-	if ctx.Bool("csv") || ctx.Bool("tsv") {
+	// CSV or JSON Export?
+	if ctx.Bool("csv") || ctx.Bool("tsv") || ctx.Bool("json") || ctx.Bool("ndjson") {
 		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
 
-		fmt.Println(result)
+		fmt.Print(result)
 
 		return err
 	}
@@ -60,6 +60,10 @@ func showConfigYamlAction(ctx *cli.Context) error {
 	j := 0
 
 	for i, sec := range s {
+		if i > 0 {
+			fmt.Println()
+		}
+
 		fmt.Printf("### %s ###\n\n", sec.Title)
 		if sec.Info != "" && ctx.Bool("md") {
 			fmt.Printf("%s\n\n", sec.Info)
@@ -94,7 +98,7 @@ func showConfigYamlAction(ctx *cli.Context) error {
 			return err
 		}
 
-		fmt.Println(result)
+		fmt.Print(result)
 
 		if j >= len(rows) {
 			break
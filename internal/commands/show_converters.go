@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/report"
+)
+
+// ShowConvertersCommand configures the command name, flags, and action.
+var ShowConvertersCommand = cli.Command{
+	Name:   "converters",
+	Usage:  "Displays user-defined file converters in the order they are used",
+	Flags:  report.CliFlags,
+	Action: showConvertersAction,
+}
+
+// showConvertersAction displays the user-defined file converters.
+func showConvertersAction(ctx *cli.Context) error {
+	conf := config.NewConfig(ctx)
+	conf.SetLogLevel(logrus.WarnLevel)
+
+	converters := conf.Converters()
+
+	if len(converters) == 0 && report.CliFormat(ctx) == report.Default {
+		fmt.Printf("No user-defined converters found in %s.\n", clean.Log(conf.ConvertersYaml()))
+		return nil
+	}
+
+	rows, cols := converters.Report()
+
+	result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+	fmt.Println(result)
+
+	return err
+}
@@ -40,7 +40,7 @@ func showMetadataAction(ctx *cli.Context) error {
 
 	fmt.Println(result)
 
-	if err != nil || ctx.Bool("short") || format == report.TSV {
+	if err != nil || ctx.Bool("short") || format == report.TSV || format == report.JSON || format == report.NDJSON {
 		return err
 	}
 
@@ -94,3 +94,19 @@ func TestShowFormatsCommand(t *testing.T) {
 	assert.Contains(t, output, "Format")
 	assert.Contains(t, output, "Description")
 }
+
+func TestShowConvertersCommand(t *testing.T) {
+	var err error
+
+	ctx := config.CliTestContext()
+
+	output := capture.Output(func() {
+		err = ShowConvertersCommand.Run(ctx)
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, output, "converters")
+}
@@ -4,21 +4,31 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/tidwall/gjson"
 	"github.com/urfave/cli"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/report"
 )
 
 // StatusCommand configures the command name, flags, and action.
 var StatusCommand = cli.Command{
 	Name:   "status",
 	Usage:  "Checks if the Web server is running",
+	Flags:  statusFlags,
 	Action: statusAction,
 }
 
+var statusFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "json, j",
+		Usage: "print the server status as JSON",
+	},
+}
+
 // statusAction checks if the web server is running.
 func statusAction(ctx *cli.Context) error {
 	conf := config.NewConfig(ctx)
@@ -54,6 +64,17 @@ func statusAction(ctx *cli.Context) error {
 
 	message := gjson.Get(status, "status").String()
 
+	if ctx.Bool("json") {
+		if message == "" {
+			message = "unknown"
+		}
+
+		return report.Encode(os.Stdout, struct {
+			Status string `json:"Status"`
+			URL    string `json:"URL"`
+		}{message, url}, report.JSON)
+	}
+
 	if message != "" {
 		fmt.Println(message)
 	} else {
@@ -0,0 +1,216 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/report"
+)
+
+// TimeRulesCommand configures the command name, flags, and action.
+var TimeRulesCommand = cli.Command{
+	Name:  "time-rules",
+	Usage: "Camera time correction subcommands",
+	Subcommands: []cli.Command{
+		{
+			Name:   "ls",
+			Usage:  "Displays existing time correction rules",
+			Flags:  report.CliFlags,
+			Action: timeRulesListAction,
+		},
+		{
+			Name:  "add",
+			Usage: "Adds a rule for correcting the time of photos taken with a camera",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "make",
+					Usage: "camera `MAKE`, e.g. Canon",
+				},
+				cli.StringFlag{
+					Name:  "model",
+					Usage: "camera `MODEL`, e.g. EOS 6D",
+				},
+				cli.StringFlag{
+					Name:  "serial",
+					Usage: "camera `SERIAL` number",
+				},
+				cli.StringFlag{
+					Name:  "start",
+					Usage: "first local camera `TIME` the rule applies to, e.g. 2023-07-01",
+				},
+				cli.StringFlag{
+					Name:  "end",
+					Usage: "last local camera `TIME` the rule applies to, e.g. 2023-07-14",
+				},
+				cli.StringFlag{
+					Name:  "offset",
+					Usage: "time `DURATION` to add, e.g. -9h or 1h30m",
+				},
+				cli.StringFlag{
+					Name:  "zone",
+					Usage: "time `ZONE` the camera clock was set to, e.g. Europe/Berlin",
+				},
+				cli.StringFlag{
+					Name:  "note",
+					Usage: "optional `NOTE` describing the rule",
+				},
+			},
+			Action: timeRulesAddAction,
+		},
+		{
+			Name:      "rm",
+			Usage:     "Removes a time correction rule",
+			ArgsUsage: "[id]",
+			Action:    timeRulesRemoveAction,
+		},
+	},
+}
+
+// timeRulesListAction displays existing time correction rules.
+func timeRulesListAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		rules, err := entity.FindTimeRules()
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("found %s", english.Plural(len(rules), "time rule", "time rules"))
+
+		cols := []string{"ID", "Make", "Model", "Serial", "Start", "End", "Offset", "Time Zone", "Note"}
+		rows := make([][]string, len(rules))
+
+		for i, rule := range rules {
+			rows[i] = []string{
+				strconv.FormatUint(uint64(rule.ID), 10),
+				rule.CameraMake,
+				rule.CameraModel,
+				rule.CameraSerial,
+				timeRuleTime(rule.StartAt),
+				timeRuleTime(rule.EndAt),
+				rule.Offset().String(),
+				rule.TimeZone,
+				rule.RuleNote,
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
+
+// timeRulesAddAction adds a new time correction rule.
+func timeRulesAddAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		rule := entity.TimeRule{
+			CameraMake:   clean.Name(ctx.String("make")),
+			CameraModel:  clean.Name(ctx.String("model")),
+			CameraSerial: clean.Name(ctx.String("serial")),
+			TimeZone:     strings.TrimSpace(ctx.String("zone")),
+			RuleNote:     clean.Name(ctx.String("note")),
+		}
+
+		var err error
+
+		if rule.StartAt, err = parseTimeRuleTime(ctx.String("start"), false); err != nil {
+			return err
+		}
+
+		if rule.EndAt, err = parseTimeRuleTime(ctx.String("end"), true); err != nil {
+			return err
+		}
+
+		if s := strings.TrimSpace(ctx.String("offset")); s != "" {
+			if offset, err := time.ParseDuration(s); err != nil {
+				return fmt.Errorf("invalid offset %s", clean.LogQuote(s))
+			} else {
+				rule.TimeOffset = int64(offset / time.Second)
+			}
+		}
+
+		if err = rule.Create(); err != nil {
+			return err
+		}
+
+		log.Infof("added %s, run 'photoprism optimize --time-rules' to apply it to indexed photos", rule.String())
+
+		return nil
+	})
+}
+
+// timeRulesRemoveAction removes a time correction rule.
+func timeRulesRemoveAction(ctx *cli.Context) error {
+	id, err := strconv.ParseUint(ctx.Args().First(), 10, 32)
+
+	if err != nil || id == 0 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		rule := entity.FindTimeRule(uint(id))
+
+		if rule == nil {
+			return fmt.Errorf("time rule %d not found", id)
+		}
+
+		if err := rule.Delete(); err != nil {
+			return err
+		}
+
+		log.Infof("removed %s", rule.String())
+
+		return nil
+	})
+}
+
+// parseTimeRuleTime parses a local camera time that may also be a date only,
+// in which case the end of the day is returned if end is true.
+func parseTimeRuleTime(s string, end bool) (*time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	if s == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02T15:04", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return &t, nil
+		}
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", s, time.UTC)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid time %s", clean.LogQuote(s))
+	} else if end {
+		t = t.Add(24*time.Hour - time.Second)
+	}
+
+	return &t, nil
+}
+
+// timeRuleTime formats an optional local camera time for display.
+func timeRuleTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format("2006-01-02 15:04:05")
+}
@@ -155,7 +155,7 @@ func TestConfig_ClientRoleConfig(t *testing.T) {
 			Places:    true,
 			Private:   false,
 			Ratings:   false,
-			Reactions: false,
+			Reactions: true,
 			Review:    true,
 			Search:    false,
 			Settings:  false,
@@ -46,16 +46,17 @@ var crc32Castagnoli = crc32.MakeTable(crc32.Castagnoli)
 
 // Config holds database, cache and all parameters of photoprism
 type Config struct {
-	once     sync.Once
-	cliCtx   *cli.Context
-	options  *Options
-	settings *customize.Settings
-	db       *gorm.DB
-	hub      *hub.Config
-	token    string
-	serial   string
-	env      string
-	start    bool
+	once       sync.Once
+	cliCtx     *cli.Context
+	options    *Options
+	settings   *customize.Settings
+	db         *gorm.DB
+	hub        *hub.Config
+	converters Converters
+	token      string
+	serial     string
+	env        string
+	start      bool
 }
 
 func init() {
@@ -274,6 +275,8 @@ func (c *Config) Init() error {
 
 	c.initSettings()
 	c.initHub()
+	c.initConverters()
+	c.initFaces()
 
 	c.Propagate()
 
@@ -0,0 +1,74 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/backup"
+)
+
+// Default number of index backup archives to keep.
+const (
+	DefaultBackupDaily   = 7
+	DefaultBackupWeekly  = 4
+	DefaultBackupMonthly = 6
+)
+
+// MinBackupInterval is the shortest interval between scheduled index backups.
+const MinBackupInterval = time.Hour
+
+// BackupArchivePath returns the path for compressed and checksummed index backup archives.
+func (c *Config) BackupArchivePath() string {
+	return filepath.Join(c.BackupPath(), "archive")
+}
+
+// BackupInterval returns the interval between scheduled index backups, or zero if they are disabled.
+func (c *Config) BackupInterval() time.Duration {
+	s := strings.ToLower(strings.TrimSpace(c.options.BackupSchedule))
+
+	switch s {
+	case "", "false", "off", "never":
+		return 0
+	case "daily", "true", "on":
+		return 24 * time.Hour
+	case "weekly":
+		return 7 * 24 * time.Hour
+	}
+
+	d, err := time.ParseDuration(s)
+
+	if err != nil {
+		log.Warnf("config: invalid backup schedule %s", s)
+		return 0
+	} else if d <= 0 {
+		return 0
+	} else if d < MinBackupInterval {
+		return MinBackupInterval
+	}
+
+	return d
+}
+
+// BackupRetention returns the number of daily, weekly, and monthly index backup archives to keep.
+func (c *Config) BackupRetention() backup.Retention {
+	r := backup.Retention{
+		Daily:   c.options.BackupDaily,
+		Weekly:  c.options.BackupWeekly,
+		Monthly: c.options.BackupMonthly,
+	}
+
+	if r.Daily < 0 {
+		r.Daily = 0
+	}
+
+	if r.Weekly < 0 {
+		r.Weekly = 0
+	}
+
+	if r.Monthly < 0 {
+		r.Monthly = 0
+	}
+
+	return r
+}
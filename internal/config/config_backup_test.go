@@ -0,0 +1,45 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_BackupArchivePath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Contains(t, c.BackupArchivePath(), "/archive")
+}
+
+func TestConfig_BackupInterval(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, time.Duration(0), c.BackupInterval())
+	c.options.BackupSchedule = "daily"
+	assert.Equal(t, 24*time.Hour, c.BackupInterval())
+	c.options.BackupSchedule = "Weekly"
+	assert.Equal(t, 7*24*time.Hour, c.BackupInterval())
+	c.options.BackupSchedule = "12h"
+	assert.Equal(t, 12*time.Hour, c.BackupInterval())
+	c.options.BackupSchedule = "5m"
+	assert.Equal(t, MinBackupInterval, c.BackupInterval())
+	c.options.BackupSchedule = "foo"
+	assert.Equal(t, time.Duration(0), c.BackupInterval())
+	c.options.BackupSchedule = ""
+}
+
+func TestConfig_BackupRetention(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.BackupDaily = 7
+	c.options.BackupWeekly = -1
+	c.options.BackupMonthly = 6
+
+	r := c.BackupRetention()
+
+	assert.Equal(t, 7, r.Daily)
+	assert.Equal(t, 0, r.Weekly)
+	assert.Equal(t, 6, r.Monthly)
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ClassificationModel returns the image classification model settings.
+func (c *Config) ClassificationModel() classify.Model {
+	model := classify.NasnetModel
+
+	if name := strings.TrimSpace(c.options.ClassificationModel); name != "" {
+		if strings.ContainsAny(name, "/\\") {
+			model.Name = fs.Abs(name)
+		} else {
+			model.Name = name
+		}
+
+		// The feature layer of the default model does not exist in custom models.
+		if model.Name != classify.NasnetModel.Name {
+			model.Embedding = ""
+			model.EmbeddingSize = 0
+		}
+	}
+
+	if tags := strings.TrimSpace(c.options.ClassificationTags); tags != "" {
+		model.Tags = nil
+
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				model.Tags = append(model.Tags, tag)
+			}
+		}
+	}
+
+	if labels := strings.TrimSpace(c.options.ClassificationLabels); labels != "" {
+		model.Labels = labels
+	}
+
+	if input := strings.TrimSpace(c.options.ClassificationInput); input != "" {
+		model.Input = input
+	}
+
+	if output := strings.TrimSpace(c.options.ClassificationOutput); output != "" {
+		model.Output = output
+	}
+
+	if layer := strings.TrimSpace(c.options.ClassificationLayer); layer != "" {
+		model.Embedding = layer
+		model.EmbeddingSize = 0
+	}
+
+	if size := c.options.ClassificationSize; size >= 32 && size <= 1024 {
+		model.Size = size
+	}
+
+	model.Rules = c.ClassificationRules()
+
+	return model.Default()
+}
+
+// ClassificationRules returns the filename of custom label rules, if any.
+func (c *Config) ClassificationRules() string {
+	if c.options.ClassificationRules == "" {
+		return ""
+	}
+
+	return fs.Abs(c.options.ClassificationRules)
+}
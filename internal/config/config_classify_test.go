@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/classify"
+)
+
+func TestConfig_ClassificationModel(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, classify.NasnetModel, c.ClassificationModel())
+
+	c.options.ClassificationModel = "/models/birds"
+	c.options.ClassificationTags = "serve, gpu"
+	c.options.ClassificationLabels = "birds.txt"
+	c.options.ClassificationInput = "serving_default_input"
+	c.options.ClassificationOutput = "StatefulPartitionedCall"
+	c.options.ClassificationSize = 260
+
+	model := c.ClassificationModel()
+
+	assert.Equal(t, "/models/birds", model.Name)
+	assert.Equal(t, []string{"serve", "gpu"}, model.Tags)
+	assert.Equal(t, "birds.txt", model.Labels)
+	assert.Equal(t, "serving_default_input", model.Input)
+	assert.Equal(t, "StatefulPartitionedCall", model.Output)
+	assert.Equal(t, 260, model.Size)
+	assert.Equal(t, "", model.Embedding)
+	assert.Equal(t, 0, model.EmbeddingSize)
+	assert.Equal(t, "/models/birds", c.TensorFlowModelPath())
+
+	c.options.ClassificationSize = 5000
+	assert.Equal(t, 224, c.ClassificationModel().Size)
+
+	c.options.ClassificationLayer = "avg_pool/Mean"
+	assert.Equal(t, "avg_pool/Mean", c.ClassificationModel().Embedding)
+}
+
+func TestConfig_ClassificationRules(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "", c.ClassificationRules())
+
+	c.options.ClassificationRules = "/etc/photoprism/rules.yml"
+	assert.Equal(t, "/etc/photoprism/rules.yml", c.ClassificationRules())
+	assert.Equal(t, "/etc/photoprism/rules.yml", c.ClassificationModel().Rules)
+}
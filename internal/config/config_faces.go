@@ -1,6 +1,15 @@
 package config
 
-import "github.com/photoprism/photoprism/internal/face"
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
 
 // FaceSize returns the face size threshold in pixels.
 func (c *Config) FaceSize() int {
@@ -73,3 +82,97 @@ func (c *Config) FaceMatchDist() float64 {
 
 	return c.options.FaceMatchDist
 }
+
+// FaceThresholds represents face clustering and matching thresholds tuned with "photoprism faces evaluate".
+type FaceThresholds struct {
+	ClusterCore int     `yaml:"ClusterCore,omitempty"`
+	ClusterDist float64 `yaml:"ClusterDist,omitempty"`
+	MatchDist   float64 `yaml:"MatchDist,omitempty"`
+}
+
+// initFaces applies tuned face thresholds from faces.yml, unless they have been set as options.
+func (c *Config) initFaces() {
+	fileName := c.FacesYaml()
+
+	if !fs.FileExists(fileName) {
+		return
+	}
+
+	t := FaceThresholds{}
+
+	if data, err := os.ReadFile(fileName); err != nil {
+		log.Warnf("config: %s (read %s)", err, filepath.Base(fileName))
+		return
+	} else if err = yaml.Unmarshal(data, &t); err != nil {
+		log.Warnf("config: %s (parse %s)", err, filepath.Base(fileName))
+		return
+	}
+
+	if t.ClusterCore > 0 && !c.faceOverride("face-cluster-core", float64(c.options.FaceClusterCore)) {
+		c.options.FaceClusterCore = t.ClusterCore
+	}
+
+	if t.ClusterDist > 0 && !c.faceOverride("face-cluster-dist", c.options.FaceClusterDist) {
+		c.options.FaceClusterDist = t.ClusterDist
+	}
+
+	if t.MatchDist > 0 && !c.faceOverride("face-match-dist", c.options.FaceMatchDist) {
+		c.options.FaceMatchDist = t.MatchDist
+	}
+}
+
+// faceOverride tests if a face threshold has been set explicitly with a command-line flag or
+// environment variable, or otherwise differs from the flag default, so that faces.yml is ignored.
+func (c *Config) faceOverride(name string, value float64) bool {
+	if c.cliCtx != nil && (c.cliCtx.IsSet(name) || c.cliCtx.GlobalIsSet(name)) {
+		return true
+	}
+
+	return value != 0 && value != faceFlagDefault(name)
+}
+
+// faceFlagDefault returns the default value of a face threshold flag.
+func faceFlagDefault(name string) float64 {
+	for _, f := range Flags {
+		switch flag := f.Flag.(type) {
+		case cli.IntFlag:
+			if flag.Name == name {
+				return float64(flag.Value)
+			}
+		case cli.Float64Flag:
+			if flag.Name == name {
+				return flag.Value
+			}
+		}
+	}
+
+	return 0
+}
+
+// SaveFaceThresholds stores tuned face clustering and matching thresholds in faces.yml, so that
+// they are used unless other values have been set as options, and applies them to the current configuration.
+func (c *Config) SaveFaceThresholds(core int, clusterDist, matchDist float64) error {
+	fileName := c.FacesYaml()
+
+	data, err := yaml.Marshal(FaceThresholds{ClusterCore: core, ClusterDist: clusterDist, MatchDist: matchDist})
+
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+		return err
+	} else if err = os.WriteFile(fileName, data, fs.ModeFile); err != nil {
+		return err
+	}
+
+	c.options.FaceClusterCore = core
+	c.options.FaceClusterDist = clusterDist
+	c.options.FaceMatchDist = matchDist
+
+	face.ClusterCore = c.FaceClusterCore()
+	face.ClusterDist = c.FaceClusterDist()
+	face.MatchDist = c.FaceMatchDist()
+
+	return nil
+}
@@ -1,9 +1,14 @@
 package config
 
 import (
+	"flag"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+
+	"github.com/photoprism/photoprism/internal/face"
 )
 
 func TestConfig_FaceSize(t *testing.T) {
@@ -77,3 +82,101 @@ func TestConfig_FaceMatchDist(t *testing.T) {
 	c.options.FaceMatchDist = 0.01
 	assert.Equal(t, 0.46, c.FaceMatchDist())
 }
+
+func TestConfig_SaveFaceThresholds(t *testing.T) {
+	core, dist, match := face.ClusterCore, face.ClusterDist, face.MatchDist
+
+	defer func() {
+		face.ClusterCore, face.ClusterDist, face.MatchDist = core, dist, match
+	}()
+
+	c := NewConfig(CliTestContext())
+	c.options.ConfigPath = t.TempDir()
+
+	options := []byte("SiteTitle: Family\n")
+
+	if err := os.WriteFile(c.OptionsYaml(), options, 0o666); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.SaveFaceThresholds(5, 0.6, 0.4); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 5, face.ClusterCore)
+	assert.Equal(t, 0.6, face.ClusterDist)
+	assert.Equal(t, 0.4, face.MatchDist)
+
+	// The options file remains unchanged.
+	if data, err := os.ReadFile(c.OptionsYaml()); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Equal(t, options, data)
+	}
+
+	// Thresholds are loaded from faces.yml unless they have been set as options.
+	configPath := c.ConfigPath()
+
+	c = NewConfig(CliTestContext())
+	c.options.ConfigPath = configPath
+	c.options.FaceMatchDist = 0.5
+	c.initFaces()
+
+	assert.Equal(t, 5, c.FaceClusterCore())
+	assert.Equal(t, 0.6, c.FaceClusterDist())
+	assert.Equal(t, 0.5, c.FaceMatchDist())
+}
+
+func TestConfig_InitFaces(t *testing.T) {
+	app := cli.NewApp()
+	app.Flags = Flags.Cli()
+
+	set := flag.NewFlagSet("test", 0)
+
+	for _, f := range app.Flags {
+		f.Apply(set)
+	}
+
+	ctx := cli.NewContext(app, set, nil)
+
+	thresholds := []byte("ClusterCore: 5\nClusterDist: 0.6\nMatchDist: 0.4\n")
+
+	t.Run("Defaults", func(t *testing.T) {
+		c := &Config{cliCtx: ctx, options: NewOptions(ctx)}
+		c.options.ConfigPath = t.TempDir()
+		c.options.Sponsor = true
+
+		assert.Equal(t, face.ClusterCore, c.options.FaceClusterCore)
+		assert.Equal(t, face.ClusterDist, c.options.FaceClusterDist)
+		assert.Equal(t, face.MatchDist, c.options.FaceMatchDist)
+
+		if err := os.WriteFile(c.FacesYaml(), thresholds, 0o666); err != nil {
+			t.Fatal(err)
+		}
+
+		c.initFaces()
+
+		assert.Equal(t, 5, c.FaceClusterCore())
+		assert.Equal(t, 0.6, c.FaceClusterDist())
+		assert.Equal(t, 0.4, c.FaceMatchDist())
+	})
+	t.Run("Flag", func(t *testing.T) {
+		if err := ctx.Set("face-match-dist", "0.5"); err != nil {
+			t.Fatal(err)
+		}
+
+		c := &Config{cliCtx: ctx, options: NewOptions(ctx)}
+		c.options.ConfigPath = t.TempDir()
+		c.options.Sponsor = true
+
+		if err := os.WriteFile(c.FacesYaml(), thresholds, 0o666); err != nil {
+			t.Fatal(err)
+		}
+
+		c.initFaces()
+
+		assert.Equal(t, 5, c.FaceClusterCore())
+		assert.Equal(t, 0.6, c.FaceClusterDist())
+		assert.Equal(t, 0.5, c.FaceMatchDist())
+	})
+}
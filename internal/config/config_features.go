@@ -111,6 +111,11 @@ func (c *Config) DisableRawTherapee() bool {
 	return c.options.DisableRawTherapee
 }
 
+// DisableRawPreview checks if the extraction of embedded JPEG previews from RAW images is disabled.
+func (c *Config) DisableRawPreview() bool {
+	return c.DisableRaw() || c.options.DisableRawPreview
+}
+
 // DisableImageMagick checks if conversion of files with ImageMagick is disabled.
 func (c *Config) DisableImageMagick() bool {
 	if c.options.DisableImageMagick {
@@ -226,6 +226,11 @@ func (c *Config) DefaultsYaml() string {
 	return fs.Abs(c.options.DefaultsYaml)
 }
 
+// FacesYaml returns the filename of the tuned face recognition thresholds.
+func (c *Config) FacesYaml() string {
+	return filepath.Join(c.ConfigPath(), "faces.yml")
+}
+
 // HubConfigFile returns the backend api config file name.
 func (c *Config) HubConfigFile() string {
 	return filepath.Join(c.ConfigPath(), "hub.yml")
@@ -252,6 +257,11 @@ func (c *Config) SettingsYamlDefaults(settingsYml string) string {
 	return settingsYml
 }
 
+// ConvertersYaml returns the user-defined converters YAML filename.
+func (c *Config) ConvertersYaml() string {
+	return filepath.Join(c.ConfigPath(), "converters.yml")
+}
+
 // PIDFilename returns the filename for storing the server process id (pid).
 func (c *Config) PIDFilename() string {
 	if c.options.PIDFilename == "" {
@@ -86,6 +86,11 @@ func (c *Config) RawTherapeeEnabled() bool {
 	return !c.DisableRawTherapee()
 }
 
+// RawPreviewEnabled checks if embedded JPEG previews are extracted from RAW images before using external tools.
+func (c *Config) RawPreviewEnabled() bool {
+	return !c.DisableRawPreview()
+}
+
 // SipsEnabled checks if SIPS is enabled for RAW conversion.
 func (c *Config) SipsEnabled() bool {
 	return !c.DisableSips()
@@ -36,6 +36,19 @@ func TestConfig_RawTherapeeEnabled(t *testing.T) {
 	assert.False(t, c.RawTherapeeEnabled())
 }
 
+func TestConfig_RawPreviewEnabled(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.True(t, c.RawPreviewEnabled())
+
+	c.options.DisableRawPreview = true
+	assert.False(t, c.RawPreviewEnabled())
+	c.options.DisableRawPreview = false
+
+	c.options.DisableRaw = true
+	assert.False(t, c.RawPreviewEnabled())
+	c.options.DisableRaw = false
+}
+
 func TestConfig_DarktableBin(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -11,9 +11,9 @@ func (c *Config) TensorFlowVersion() string {
 	return tf.Version()
 }
 
-// TensorFlowModelPath returns the TensorFlow model path.
+// TensorFlowModelPath returns the TensorFlow image classification model path.
 func (c *Config) TensorFlowModelPath() string {
-	return filepath.Join(c.AssetsPath(), "nasnet")
+	return c.ClassificationModel().Path(c.AssetsPath())
 }
 
 // NSFWModelPath returns the "not safe for work" TensorFlow model path.
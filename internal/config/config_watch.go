@@ -0,0 +1,36 @@
+package config
+
+import "time"
+
+// DefaultWatchDelay is the default quiet period in seconds before changed files are indexed.
+const DefaultWatchDelay = 15
+
+// DefaultWatchRescan is the default full rescan interval in seconds if changes cannot be watched.
+const DefaultWatchRescan = int(60 * 60) // 1 Hour
+
+// Watch checks if the originals and import folders should be watched for changes.
+func (c *Config) Watch() bool {
+	return c.options.Watch
+}
+
+// WatchDelay returns the quiet period before changed files are indexed.
+func (c *Config) WatchDelay() time.Duration {
+	if c.options.WatchDelay <= 0 || c.options.WatchDelay > 86400 {
+		return time.Duration(DefaultWatchDelay) * time.Second
+	}
+
+	return time.Duration(c.options.WatchDelay) * time.Second
+}
+
+// WatchRescan returns the full rescan interval if changes cannot be watched, or zero if disabled.
+func (c *Config) WatchRescan() time.Duration {
+	if c.options.WatchRescan < 0 {
+		return time.Duration(0)
+	} else if c.options.WatchRescan == 0 {
+		return time.Duration(DefaultWatchRescan) * time.Second
+	} else if c.options.WatchRescan < 60 {
+		return time.Minute
+	}
+
+	return time.Duration(c.options.WatchRescan) * time.Second
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Watch(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.Watch())
+	c.options.Watch = true
+	assert.True(t, c.Watch())
+	c.options.Watch = false
+}
+
+func TestConfig_WatchDelay(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 15*time.Second, c.WatchDelay())
+	c.options.WatchDelay = 60
+	assert.Equal(t, time.Minute, c.WatchDelay())
+	c.options.WatchDelay = -1
+	assert.Equal(t, 15*time.Second, c.WatchDelay())
+	c.options.WatchDelay = 0
+}
+
+func TestConfig_WatchRescan(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.WatchRescan = 0
+	assert.Equal(t, time.Hour, c.WatchRescan())
+	c.options.WatchRescan = -1
+	assert.Equal(t, time.Duration(0), c.WatchRescan())
+	c.options.WatchRescan = 10
+	assert.Equal(t, time.Minute, c.WatchRescan())
+	c.options.WatchRescan = 7200
+	assert.Equal(t, 2*time.Hour, c.WatchRescan())
+	c.options.WatchRescan = 0
+}
@@ -0,0 +1,289 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"gopkg.in/yaml.v2"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Converter command placeholders.
+const (
+	ConverterInput   = "{input}"
+	ConverterOutput  = "{output}"
+	ConverterXmp     = "{xmp}"
+	ConverterSize    = "{size}"
+	ConverterQuality = "{quality}"
+	ConverterOffset  = "{offset}"
+)
+
+// DefaultConverterTimeout is the maximum run time of user-defined converters without a timeout.
+var DefaultConverterTimeout = 10 * time.Minute
+
+// Converter represents a user-defined command for creating JPEG or PNG previews of media files.
+type Converter struct {
+	Name        string        `yaml:"Name" json:"Name"`
+	Extensions  []string      `yaml:"Extensions" json:"Extensions"`
+	Command     string        `yaml:"Command" json:"Command"`
+	Timeout     time.Duration `yaml:"Timeout,omitempty" json:"Timeout,omitempty"`
+	Concurrency int           `yaml:"Concurrency,omitempty" json:"Concurrency,omitempty"`
+}
+
+// Converters represents an ordered list of user-defined converters.
+type Converters []Converter
+
+// patterns returns the lowercase extension patterns, e.g. ".fff" or "scan_*.tif".
+func (conv Converter) patterns() (result []string) {
+	for _, p := range conv.Extensions {
+		if p = strings.ToLower(strings.TrimSpace(p)); p == "" {
+			continue
+		} else if !strings.ContainsAny(p, "*?[") && !strings.HasPrefix(p, ".") {
+			p = "." + p
+		}
+
+		result = append(result, p)
+	}
+
+	return result
+}
+
+// Match checks if the converter supports the specified file.
+func (conv Converter) Match(fileName string) bool {
+	base := strings.ToLower(filepath.Base(fileName))
+	ext := filepath.Ext(base)
+
+	for _, p := range conv.patterns() {
+		if strings.HasPrefix(p, ".") && !strings.ContainsAny(p, "*?[") {
+			if p == ext {
+				return true
+			}
+		} else if ok, _ := filepath.Match(p, base); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FileExtensions returns the file extensions explicitly supported by the converter.
+func (conv Converter) FileExtensions() (result []string) {
+	for _, p := range conv.patterns() {
+		if ext := strings.TrimPrefix(p, "*"); strings.HasPrefix(ext, ".") && !strings.ContainsAny(ext, "*?[") {
+			result = append(result, ext)
+		}
+	}
+
+	return result
+}
+
+// Args returns the command arguments with the placeholders replaced by the specified values.
+func (conv Converter) Args(values map[string]string) ([]string, error) {
+	args, err := SplitArgs(conv.Command)
+
+	if err != nil {
+		return args, err
+	} else if len(args) == 0 {
+		return args, errors.New("command is empty")
+	}
+
+	pairs := make([]string, 0, len(values)*2)
+
+	for k, v := range values {
+		pairs = append(pairs, k, v)
+	}
+
+	r := strings.NewReplacer(pairs...)
+
+	for i := range args {
+		args[i] = r.Replace(args[i])
+	}
+
+	return args, nil
+}
+
+// RunTimeout returns the maximum run time of the converter command.
+func (conv Converter) RunTimeout() time.Duration {
+	if conv.Timeout <= 0 {
+		return DefaultConverterTimeout
+	}
+
+	return conv.Timeout
+}
+
+// Validate returns an error if the converter definition is invalid.
+func (conv Converter) Validate() error {
+	if conv.Name == "" {
+		return errors.New("name is missing")
+	} else if len(conv.patterns()) == 0 {
+		return fmt.Errorf("%s has no extensions", clean.Log(conv.Name))
+	} else if args, err := SplitArgs(conv.Command); err != nil {
+		return fmt.Errorf("%s has an invalid command (%s)", clean.Log(conv.Name), err)
+	} else if len(args) == 0 {
+		return fmt.Errorf("%s has no command", clean.Log(conv.Name))
+	} else if !strings.Contains(conv.Command, ConverterInput) {
+		return fmt.Errorf("%s command has no %s placeholder", clean.Log(conv.Name), ConverterInput)
+	} else if conv.Concurrency < 0 {
+		return fmt.Errorf("%s has a negative concurrency", clean.Log(conv.Name))
+	}
+
+	return nil
+}
+
+// Find returns the converters that support the specified file in the configured order.
+func (list Converters) Find(fileName string) (result Converters) {
+	for _, conv := range list {
+		if conv.Match(fileName) {
+			result = append(result, conv)
+		}
+	}
+
+	return result
+}
+
+// Load reads the converter definitions from a YAML file and skips invalid entries.
+func (list *Converters) Load(fileName string) error {
+	if fileName == "" {
+		return nil
+	} else if !fs.FileExists(fileName) {
+		return fmt.Errorf("%s not found", clean.Log(filepath.Base(fileName)))
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	var values Converters
+
+	if err = yaml.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	*list = make(Converters, 0, len(values))
+
+	for _, conv := range values {
+		if err = conv.Validate(); err != nil {
+			log.Warnf("config: converter %s", err)
+			continue
+		}
+
+		*list = append(*list, conv)
+	}
+
+	return nil
+}
+
+// Report returns the converters as table rows and columns.
+func (list Converters) Report() (rows [][]string, cols []string) {
+	cols = []string{"#", "Name", "Extensions", "Command", "Timeout", "Concurrency"}
+	rows = make([][]string, 0, len(list))
+
+	for i, conv := range list {
+		concurrency := "unlimited"
+
+		if conv.Concurrency > 0 {
+			concurrency = fmt.Sprintf("%d", conv.Concurrency)
+		}
+
+		rows = append(rows, []string{
+			fmt.Sprintf("%d", i+1),
+			conv.Name,
+			strings.Join(conv.patterns(), ", "),
+			conv.Command,
+			conv.RunTimeout().String(),
+			concurrency,
+		})
+	}
+
+	return rows, cols
+}
+
+// SplitArgs splits a command template into arguments, considering quotes and backslash escapes.
+func SplitArgs(s string) (args []string, err error) {
+	var arg strings.Builder
+	var quote rune
+	var escaped, inArg bool
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			arg.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return args, errors.New("unterminated quote")
+	} else if escaped {
+		return args, errors.New("unterminated escape")
+	} else if inArg {
+		args = append(args, arg.String())
+	}
+
+	return args, nil
+}
+
+// initConverters loads the user-defined converters and registers unknown file extensions as RAW images.
+func (c *Config) initConverters() {
+	if c.converters != nil {
+		return
+	}
+
+	c.converters = Converters{}
+
+	fileName := c.ConvertersYaml()
+
+	if !fs.FileExists(fileName) {
+		return
+	} else if err := c.converters.Load(fileName); err != nil {
+		log.Warnf("config: failed loading converters from %s (%s)", clean.Log(fileName), err)
+		return
+	}
+
+	for _, conv := range c.converters {
+		for _, ext := range conv.FileExtensions() {
+			if fs.RegisterExt(ext, fs.ImageRaw) {
+				log.Debugf("config: added %s extension for %s converter", clean.Log(ext), clean.Log(conv.Name))
+			}
+		}
+	}
+
+	log.Debugf("config: loaded %s from %s", english.Plural(len(c.converters), "converter", "converters"), clean.Log(fileName))
+}
+
+// Converters returns the user-defined converters in the configured order.
+func (c *Config) Converters() Converters {
+	c.initConverters()
+
+	return c.converters
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+const testConvertersYaml = `
+- Name: Scanner
+  Extensions: [".scx", "SCY", "*.scz"]
+  Command: scan-decode --quality {quality} --max "{size}" -o {output} {input}
+  Timeout: 2m
+  Concurrency: 1
+- Name: Tagged TIFF
+  Extensions: ["scan_*.tif"]
+  Command: tiff-decode {input} {output}
+- Name: Invalid
+  Extensions: [".foo"]
+  Command: foo-decode
+`
+
+func TestConverter_Match(t *testing.T) {
+	conv := Converter{Name: "Test", Extensions: []string{".scx", "SCY", "scan_*.tif"}}
+
+	assert.True(t, conv.Match("/photos/image.scx"))
+	assert.True(t, conv.Match("/photos/IMAGE.SCY"))
+	assert.True(t, conv.Match("/photos/Scan_0001.tif"))
+	assert.False(t, conv.Match("/photos/photo.tif"))
+	assert.False(t, conv.Match("/photos/image.scx.jpg"))
+	assert.Equal(t, []string{".scx", ".scy"}, conv.FileExtensions())
+}
+
+func TestConverter_Args(t *testing.T) {
+	conv := Converter{Command: `decode -q {quality} --out "{output}" {input}`}
+
+	args, err := conv.Args(map[string]string{
+		ConverterInput:   "/photos/My Scan.scx",
+		ConverterOutput:  "/sidecar/My Scan.scx.jpg",
+		ConverterQuality: "92",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"decode", "-q", "92", "--out", "/sidecar/My Scan.scx.jpg", "/photos/My Scan.scx"}, args)
+
+	_, err = Converter{}.Args(nil)
+	assert.Error(t, err)
+}
+
+func TestConverter_Validate(t *testing.T) {
+	assert.NoError(t, Converter{Name: "Test", Extensions: []string{"scx"}, Command: "decode {input}"}.Validate())
+	assert.Error(t, Converter{Extensions: []string{"scx"}, Command: "decode {input}"}.Validate())
+	assert.Error(t, Converter{Name: "Test", Command: "decode {input}"}.Validate())
+	assert.Error(t, Converter{Name: "Test", Extensions: []string{"scx"}, Command: "decode"}.Validate())
+	assert.Error(t, Converter{Name: "Test", Extensions: []string{"scx"}, Command: `decode "{input}`}.Validate())
+	assert.Error(t, Converter{Name: "Test", Extensions: []string{"scx"}, Command: "decode {input}", Concurrency: -1}.Validate())
+}
+
+func TestConverters_Load(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "converters.yml")
+
+	if err := os.WriteFile(fileName, []byte(testConvertersYaml), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	var list Converters
+
+	assert.NoError(t, list.Load(fileName))
+	assert.Len(t, list, 2)
+	assert.Equal(t, "Scanner", list[0].Name)
+	assert.Equal(t, 2*time.Minute, list[0].RunTimeout())
+	assert.Equal(t, 1, list[0].Concurrency)
+	assert.Equal(t, DefaultConverterTimeout, list[1].RunTimeout())
+	assert.Equal(t, []string{".scx", ".scy", ".scz"}, list[0].FileExtensions())
+
+	assert.Len(t, list.Find("/photos/scan.scz"), 1)
+	assert.Len(t, list.Find("/photos/scan_1.tif"), 1)
+	assert.Len(t, list.Find("/photos/photo.jpg"), 0)
+
+	rows, cols := list.Report()
+	assert.Len(t, rows, 2)
+	assert.Len(t, cols, 6)
+	assert.Equal(t, "2m0s", rows[0][4])
+	assert.Equal(t, "unlimited", rows[1][5])
+
+	assert.Error(t, list.Load(filepath.Join(t.TempDir(), "missing.yml")))
+}
+
+func TestSplitArgs(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		args, err := SplitArgs("  convert  {input}\t{output} ")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"convert", "{input}", "{output}"}, args)
+	})
+	t.Run("Quotes", func(t *testing.T) {
+		args, err := SplitArgs(`convert "a b" 'c "d"' "" e\ f`)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"convert", "a b", `c "d"`, "", "e f"}, args)
+	})
+	t.Run("Unterminated", func(t *testing.T) {
+		_, err := SplitArgs(`convert "a b`)
+		assert.Error(t, err)
+		_, err = SplitArgs(`convert a\`)
+		assert.Error(t, err)
+	})
+}
+
+func TestConfig_Converters(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, filepath.Join(c.ConfigPath(), "converters.yml"), c.ConvertersYaml())
+	assert.NotNil(t, c.Converters())
+}
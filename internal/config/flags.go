@@ -153,6 +153,29 @@ var Flags = CliFlags{
 			Usage:  "custom backup `PATH` for index backup files *optional*",
 			EnvVar: EnvVar("BACKUP_PATH"),
 		}}, {
+		Flag: cli.StringFlag{
+			Name:   "backup-schedule",
+			Usage:  "create index backup archives daily, weekly, or at a custom `INTERVAL` like 12h (empty to disable)",
+			EnvVar: EnvVar("BACKUP_SCHEDULE"),
+		}}, {
+		Flag: cli.IntFlag{
+			Name:   "backup-daily",
+			Usage:  "`NUMBER` of daily index backup archives to keep",
+			Value:  DefaultBackupDaily,
+			EnvVar: EnvVar("BACKUP_DAILY"),
+		}}, {
+		Flag: cli.IntFlag{
+			Name:   "backup-weekly",
+			Usage:  "`NUMBER` of weekly index backup archives to keep",
+			Value:  DefaultBackupWeekly,
+			EnvVar: EnvVar("BACKUP_WEEKLY"),
+		}}, {
+		Flag: cli.IntFlag{
+			Name:   "backup-monthly",
+			Usage:  "`NUMBER` of monthly index backup archives to keep",
+			Value:  DefaultBackupMonthly,
+			EnvVar: EnvVar("BACKUP_MONTHLY"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "cache-path, ca",
 			Usage:  "custom cache `PATH` for sessions and thumbnail files *optional*",
@@ -202,6 +225,23 @@ var Flags = CliFlags{
 			Value:  DefaultAutoImportDelay,
 			EnvVar: EnvVar("AUTO_IMPORT"),
 		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "watch",
+			Usage:  "watch the originals and import folders for changes and index them incrementally",
+			EnvVar: EnvVar("WATCH"),
+		}}, {
+		Flag: cli.IntFlag{
+			Name:   "watch-delay",
+			Usage:  "quiet period in `SECONDS` before changed files are indexed",
+			Value:  DefaultWatchDelay,
+			EnvVar: EnvVar("WATCH_DELAY"),
+		}}, {
+		Flag: cli.IntFlag{
+			Name:   "watch-rescan",
+			Usage:  "full rescan interval in `SECONDS` if changes cannot be watched (-1 to disable)",
+			Value:  DefaultWatchRescan,
+			EnvVar: EnvVar("WATCH_RESCAN"),
+		}}, {
 		Flag: cli.BoolFlag{
 			Name:   "read-only, r",
 			Usage:  "disable import, upload, delete, and all other operations that require write permissions",
@@ -277,6 +317,11 @@ var Flags = CliFlags{
 			Usage:  "disable conversion of RAW images with RawTherapee",
 			EnvVar: EnvVar("DISABLE_RAWTHERAPEE"),
 		}}, {
+		Flag: cli.BoolFlag{
+			Name:   "disable-rawpreview",
+			Usage:  "disable extraction of embedded JPEG previews from RAW images",
+			EnvVar: EnvVar("DISABLE_RAWPREVIEW"),
+		}}, {
 		Flag: cli.BoolFlag{
 			Name:   "disable-imagemagick",
 			Usage:  "disable conversion of image files with ImageMagick",
@@ -317,6 +362,46 @@ var Flags = CliFlags{
 			Usage:  "allow uploads that MAY be offensive (no effect without TensorFlow)",
 			EnvVar: EnvVar("UPLOAD_NSFW"),
 		}}, {
+		Flag: cli.StringFlag{
+			Name:   "classification-model",
+			Usage:  "custom TensorFlow SavedModel `PATH` for image classification (requires TensorFlow)",
+			EnvVar: EnvVar("CLASSIFICATION_MODEL"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "classification-tags",
+			Usage:  "comma-separated SavedModel `TAGS` of the custom classification model",
+			EnvVar: EnvVar("CLASSIFICATION_TAGS"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "classification-labels",
+			Usage:  "labels `FILE` of the custom classification model, one label per line",
+			EnvVar: EnvVar("CLASSIFICATION_LABELS"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "classification-input",
+			Usage:  "input operation `NAME` of the custom classification model",
+			EnvVar: EnvVar("CLASSIFICATION_INPUT"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "classification-output",
+			Usage:  "output operation `NAME` of the custom classification model",
+			EnvVar: EnvVar("CLASSIFICATION_OUTPUT"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "classification-layer",
+			Usage:  "feature layer operation `NAME` of the custom classification model, used to compare pets",
+			EnvVar: EnvVar("CLASSIFICATION_LAYER"),
+		}}, {
+		Flag: cli.IntFlag{
+			Name:   "classification-size",
+			Usage:  "input image size of the custom classification model in `PIXELS` (32-1024)",
+			EnvVar: EnvVar("CLASSIFICATION_SIZE"),
+		}}, {
+		Flag: cli.StringFlag{
+			Name:   "classification-rules",
+			Usage:  "YAML `FILE` with label rules that extend or override the built-in rules",
+			EnvVar: EnvVar("CLASSIFICATION_RULES"),
+		}}, {
 		Flag: cli.StringFlag{
 			Name:   "default-locale, lang",
 			Usage:  "standard user interface language `CODE`",
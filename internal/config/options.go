@@ -50,6 +50,10 @@ type Options struct {
 	StoragePath           string        `yaml:"StoragePath" json:"-" flag:"storage-path"`
 	SidecarPath           string        `yaml:"SidecarPath" json:"-" flag:"sidecar-path"`
 	BackupPath            string        `yaml:"BackupPath" json:"-" flag:"backup-path"`
+	BackupSchedule        string        `yaml:"BackupSchedule" json:"-" flag:"backup-schedule"`
+	BackupDaily           int           `yaml:"BackupDaily" json:"-" flag:"backup-daily"`
+	BackupWeekly          int           `yaml:"BackupWeekly" json:"-" flag:"backup-weekly"`
+	BackupMonthly         int           `yaml:"BackupMonthly" json:"-" flag:"backup-monthly"`
 	CachePath             string        `yaml:"CachePath" json:"-" flag:"cache-path"`
 	ImportPath            string        `yaml:"ImportPath" json:"-" flag:"import-path"`
 	ImportDest            string        `yaml:"ImportDest" json:"-" flag:"import-dest"`
@@ -60,6 +64,9 @@ type Options struct {
 	WakeupInterval        time.Duration `yaml:"WakeupInterval" json:"WakeupInterval" flag:"wakeup-interval"`
 	AutoIndex             int           `yaml:"AutoIndex" json:"AutoIndex" flag:"auto-index"`
 	AutoImport            int           `yaml:"AutoImport" json:"AutoImport" flag:"auto-import"`
+	Watch                 bool          `yaml:"Watch" json:"Watch" flag:"watch"`
+	WatchDelay            int           `yaml:"WatchDelay" json:"WatchDelay" flag:"watch-delay"`
+	WatchRescan           int           `yaml:"WatchRescan" json:"WatchRescan" flag:"watch-rescan"`
 	ReadOnly              bool          `yaml:"ReadOnly" json:"ReadOnly" flag:"read-only"`
 	Experimental          bool          `yaml:"Experimental" json:"Experimental" flag:"experimental"`
 	DisableSettings       bool          `yaml:"DisableSettings" json:"-" flag:"disable-settings"`
@@ -75,6 +82,7 @@ type Options struct {
 	DisableSips           bool          `yaml:"DisableSips" json:"DisableSips" flag:"disable-sips"`
 	DisableDarktable      bool          `yaml:"DisableDarktable" json:"DisableDarktable" flag:"disable-darktable"`
 	DisableRawTherapee    bool          `yaml:"DisableRawTherapee" json:"DisableRawTherapee" flag:"disable-rawtherapee"`
+	DisableRawPreview     bool          `yaml:"DisableRawPreview" json:"DisableRawPreview" flag:"disable-rawpreview"`
 	DisableImageMagick    bool          `yaml:"DisableImageMagick" json:"DisableImageMagick" flag:"disable-imagemagick"`
 	DisableHeifConvert    bool          `yaml:"DisableHeifConvert" json:"DisableHeifConvert" flag:"disable-heifconvert"`
 	DisableVectors        bool          `yaml:"DisableVectors" json:"DisableVectors" flag:"disable-vectors"`
@@ -84,6 +92,14 @@ type Options struct {
 	ExifBruteForce        bool          `yaml:"ExifBruteForce" json:"ExifBruteForce" flag:"exif-bruteforce"`
 	DetectNSFW            bool          `yaml:"DetectNSFW" json:"DetectNSFW" flag:"detect-nsfw"`
 	UploadNSFW            bool          `yaml:"UploadNSFW" json:"-" flag:"upload-nsfw"`
+	ClassificationModel   string        `yaml:"ClassificationModel" json:"-" flag:"classification-model"`
+	ClassificationTags    string        `yaml:"ClassificationTags" json:"-" flag:"classification-tags"`
+	ClassificationLabels  string        `yaml:"ClassificationLabels" json:"-" flag:"classification-labels"`
+	ClassificationInput   string        `yaml:"ClassificationInput" json:"-" flag:"classification-input"`
+	ClassificationOutput  string        `yaml:"ClassificationOutput" json:"-" flag:"classification-output"`
+	ClassificationLayer   string        `yaml:"ClassificationLayer" json:"-" flag:"classification-layer"`
+	ClassificationSize    int           `yaml:"ClassificationSize" json:"-" flag:"classification-size"`
+	ClassificationRules   string        `yaml:"ClassificationRules" json:"-" flag:"classification-rules"`
 	DefaultTheme          string        `yaml:"DefaultTheme" json:"DefaultTheme" flag:"default-theme"`
 	DefaultLocale         string        `yaml:"DefaultLocale" json:"DefaultLocale" flag:"default-locale"`
 	AppName               string        `yaml:"AppName" json:"AppName" flag:"app-name"`
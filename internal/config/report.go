@@ -65,6 +65,11 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"sidecar-path", c.SidecarPath()},
 		{"albums-path", c.AlbumsPath()},
 		{"backup-path", c.BackupPath()},
+		{"backup-archive-path", c.BackupArchivePath()},
+		{"backup-schedule", c.BackupInterval().String()},
+		{"backup-daily", fmt.Sprintf("%d", c.BackupRetention().Daily)},
+		{"backup-weekly", fmt.Sprintf("%d", c.BackupRetention().Weekly)},
+		{"backup-monthly", fmt.Sprintf("%d", c.BackupRetention().Monthly)},
 		{"cache-path", c.CachePath()},
 		{"cmd-cache-path", c.CmdCachePath()},
 		{"media-cache-path", c.MediaCachePath()},
@@ -83,6 +88,9 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"wakeup-interval", c.WakeupInterval().String()},
 		{"auto-index", fmt.Sprintf("%d", c.AutoIndex()/time.Second)},
 		{"auto-import", fmt.Sprintf("%d", c.AutoImport()/time.Second)},
+		{"watch", fmt.Sprintf("%t", c.Watch())},
+		{"watch-delay", fmt.Sprintf("%d", c.WatchDelay()/time.Second)},
+		{"watch-rescan", fmt.Sprintf("%d", c.WatchRescan()/time.Second)},
 
 		// Feature Flags.
 		{"read-only", fmt.Sprintf("%t", c.ReadOnly())},
@@ -99,6 +107,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"disable-exiftool", fmt.Sprintf("%t", c.DisableExifTool())},
 		{"disable-darktable", fmt.Sprintf("%t", c.DisableDarktable())},
 		{"disable-rawtherapee", fmt.Sprintf("%t", c.DisableRawTherapee())},
+		{"disable-rawpreview", fmt.Sprintf("%t", c.DisableRawPreview())},
 		{"disable-imagemagick", fmt.Sprintf("%t", c.DisableImageMagick())},
 		{"disable-heifconvert", fmt.Sprintf("%t", c.DisableHeifConvert())},
 		{"disable-rsvgconvert", fmt.Sprintf("%t", c.DisableRsvgConvert())},
@@ -115,6 +124,14 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"upload-nsfw", fmt.Sprintf("%t", c.UploadNSFW())},
 		{"tensorflow-version", c.TensorFlowVersion()},
 		{"tensorflow-model-path", c.TensorFlowModelPath()},
+		{"classification-model", c.ClassificationModel().Name},
+		{"classification-tags", strings.Join(c.ClassificationModel().Tags, ",")},
+		{"classification-labels", c.ClassificationModel().Labels},
+		{"classification-input", c.ClassificationModel().Input},
+		{"classification-output", c.ClassificationModel().Output},
+		{"classification-layer", c.ClassificationModel().Embedding},
+		{"classification-size", fmt.Sprintf("%d", c.ClassificationModel().Size)},
+		{"classification-rules", c.ClassificationRules()},
 
 		// Customization.
 		{"default-locale", c.DefaultLocale()},
@@ -74,7 +74,7 @@ func TestSettings_ApplyACL(t *testing.T) {
 			Places:    true,
 			Private:   false,
 			Ratings:   false,
-			Reactions: false,
+			Reactions: true,
 			Review:    true,
 			Search:    false,
 			Settings:  false,
@@ -17,6 +17,7 @@ type DownloadSettings struct {
 	Originals    bool         `json:"originals" yaml:"Originals"`
 	MediaRaw     bool         `json:"mediaRaw" yaml:"MediaRaw"`
 	MediaSidecar bool         `json:"mediaSidecar" yaml:"MediaSidecar"`
+	Metadata     bool         `json:"metadata" yaml:"Metadata"`
 }
 
 // NewDownloadSettings creates download settings with defaults.
@@ -27,5 +28,6 @@ func NewDownloadSettings() DownloadSettings {
 		Originals:    true,
 		MediaRaw:     false,
 		MediaSidecar: false,
+		Metadata:     false,
 	}
 }
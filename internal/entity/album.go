@@ -14,6 +14,7 @@ import (
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/maps"
 	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/react"
 	"github.com/photoprism/photoprism/pkg/rnd"
 	"github.com/photoprism/photoprism/pkg/sortby"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -617,6 +618,30 @@ func (m *Album) Updates(values interface{}) error {
 	return UnscopedDb().Model(m).Updates(values).Error
 }
 
+// React adds or updates a user reaction.
+func (m *Album) React(user *User, reaction react.Emoji) error {
+	if user == nil {
+		return fmt.Errorf("unknown user")
+	}
+
+	if reaction.Unknown() {
+		return m.UnReact(user)
+	}
+
+	_, err := SetReaction(m.AlbumUID, user.UID(), reaction)
+
+	return err
+}
+
+// UnReact deletes a previous user reaction, if any.
+func (m *Album) UnReact(user *User) error {
+	if user == nil {
+		return fmt.Errorf("unknown user")
+	}
+
+	return RemoveReaction(m.AlbumUID, user.UID())
+}
+
 // UpdateFolder updates the path, filter and slug for a folder album.
 func (m *Album) UpdateFolder(albumPath, albumFilter string) error {
 	if !m.HasID() {
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/react"
 	"github.com/photoprism/photoprism/pkg/sortby"
 	"github.com/photoprism/photoprism/pkg/txt"
 )
@@ -584,3 +585,25 @@ func TestFindFolderAlbum(t *testing.T) {
 		}
 	})
 }
+
+func TestAlbum_React(t *testing.T) {
+	m := AlbumFixtures.Get("christmas2030")
+	user := UserFixtures.Pointer("alice")
+
+	if err := m.React(user, react.Party); err != nil {
+		t.Fatal(err)
+	}
+
+	if r := FindReaction(m.AlbumUID, user.UserUID); r == nil {
+		t.Fatal("reaction must not be nil")
+	} else {
+		assert.Equal(t, react.Party, r.Emoji())
+	}
+
+	if err := m.UnReact(user); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, FindReaction(m.AlbumUID, user.UserUID))
+	assert.Error(t, m.React(nil, react.Party))
+}
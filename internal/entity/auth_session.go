@@ -464,6 +464,17 @@ func (m *Session) HasShare(uid string) bool {
 	}
 }
 
+// SharePerm returns the permissions granted for a shared uid.
+func (m *Session) SharePerm(uid string) uint {
+	if user := m.User(); user.IsRegistered() {
+		return user.SharePerm(uid)
+	} else if data := m.Data(); data == nil {
+		return PermNone
+	} else {
+		return data.SharePerm(uid)
+	}
+}
+
 // SharedUIDs returns shared entity UIDs.
 func (m *Session) SharedUIDs() UIDs {
 	if user := m.User(); user.IsRegistered() {
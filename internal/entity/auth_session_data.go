@@ -103,3 +103,18 @@ func (data SessionData) SharedUIDs() UIDs {
 
 	return data.Shares
 }
+
+// SharePerm returns the permissions granted by the share links redeemed for the specified uid.
+func (data SessionData) SharePerm(uid string) (perm uint) {
+	if !data.HasShare(uid) {
+		return PermNone
+	}
+
+	for _, token := range data.Tokens {
+		for _, link := range FindValidLinks(token, uid) {
+			perm |= link.Perm
+		}
+	}
+
+	return perm
+}
@@ -21,3 +21,13 @@ func TestData_HasShare(t *testing.T) {
 	assert.True(t, data.HasShare("def444"))
 	assert.False(t, data.HasShare("xxx"))
 }
+
+func TestData_SharePerm(t *testing.T) {
+	data := SessionData{Tokens: []string{"1jxf3jfn2k"}, Shares: []string{"at9lxuqxpogaaba8"}}
+	assert.True(t, HasPerm(data.SharePerm("at9lxuqxpogaaba8"), PermComment))
+	assert.False(t, HasPerm(data.SharePerm("at9lxuqxpogaaba7"), PermComment))
+
+	other := SessionData{Tokens: []string{"4jxf3jfn2k"}, Shares: []string{"at9lxuqxpogaaba7"}}
+	assert.True(t, other.HasShare("at9lxuqxpogaaba7"))
+	assert.False(t, HasPerm(other.SharePerm("at9lxuqxpogaaba7"), PermComment))
+}
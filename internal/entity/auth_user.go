@@ -957,6 +957,15 @@ func (m *User) SharedUIDs() UIDs {
 	return m.UserShares.UIDs()
 }
 
+// SharePerm returns the permissions granted to the user for a shared uid.
+func (m *User) SharePerm(uid string) uint {
+	if !m.HasShare(uid) {
+		return PermNone
+	}
+
+	return m.UserShares.Perm(uid)
+}
+
 // RedeemToken updates shared entity UIDs using the specified token.
 func (m *User) RedeemToken(token string) (n int) {
 	if !m.IsRegistered() {
@@ -22,6 +22,11 @@ const (
 	PermAll
 )
 
+// HasPerm checks if the permission flags include the required permission.
+func HasPerm(perm, required uint) bool {
+	return perm&required != 0 || perm&PermAll != 0
+}
+
 // SharePrefix for RefID.
 const (
 	SharePrefix = "share"
@@ -61,6 +66,17 @@ func (m UserShares) Contains(uid string) bool {
 	return false
 }
 
+// Perm returns the permissions granted for the specified uid.
+func (m UserShares) Perm(uid string) (perm uint) {
+	for _, share := range m {
+		if share.ShareUID == uid {
+			perm |= share.Perm
+		}
+	}
+
+	return perm
+}
+
 // UserShare represents content shared with a user.
 type UserShare struct {
 	UserUID   string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"-" yaml:"UserUID"`
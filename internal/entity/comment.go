@@ -0,0 +1,175 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// CommentUID is the unique ID prefix of comments.
+const (
+	CommentUID = byte('c')
+)
+
+// Comments represents a list of comments.
+type Comments []Comment
+
+// Comment represents a comment on a photo, or in the discussion thread of an album.
+type Comment struct {
+	ID            uint       `gorm:"primary_key" json:"-" yaml:"-"`
+	CommentUID    string     `gorm:"type:VARBINARY(42);unique_index;" json:"UID" yaml:"UID"`
+	EntityUID     string     `gorm:"type:VARBINARY(42);index;" json:"EntityUID" yaml:"EntityUID"`
+	UserUID       string     `gorm:"type:VARBINARY(42);index;" json:"UserUID,omitempty" yaml:"UserUID,omitempty"`
+	SessionRef    string     `gorm:"type:VARBINARY(16);" json:"-" yaml:"-"`
+	AuthorName    string     `gorm:"type:VARCHAR(200);" json:"AuthorName" yaml:"AuthorName,omitempty"`
+	CommentText   string     `gorm:"type:VARCHAR(4096);" json:"Text" yaml:"Text"`
+	CommentHidden bool       `json:"Hidden" yaml:"Hidden,omitempty"`
+	CreatedAt     time.Time  `json:"CreatedAt" yaml:"CreatedAt"`
+	UpdatedAt     time.Time  `json:"UpdatedAt" yaml:"UpdatedAt"`
+	EditedAt      *time.Time `json:"EditedAt,omitempty" yaml:"EditedAt,omitempty"`
+	DeletedAt     *time.Time `sql:"index" json:"-" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (Comment) TableName() string {
+	return "comments"
+}
+
+// NewComment returns a new comment written by the author of the session.
+func NewComment(entityUid, text string, author *Session) *Comment {
+	m := &Comment{
+		CommentUID: rnd.GenerateUID(CommentUID),
+		EntityUID:  entityUid,
+	}
+
+	if author != nil {
+		if user := author.User(); user.IsRegistered() {
+			m.UserUID = user.UserUID
+			m.AuthorName = user.FullName()
+		}
+
+		m.SessionRef = author.RefID
+	}
+
+	m.SetText(text)
+
+	return m
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Comment) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUnique(m.CommentUID, CommentUID) {
+		return nil
+	}
+
+	return scope.SetColumn("CommentUID", rnd.GenerateUID(CommentUID))
+}
+
+// FindComment returns the comment with the specified UID or nil if it was not found.
+func FindComment(uid string) *Comment {
+	if rnd.InvalidUID(uid, CommentUID) {
+		return nil
+	}
+
+	m := &Comment{}
+
+	if Db().Where("comment_uid = ?", uid).First(m).Error != nil {
+		return nil
+	}
+
+	return m
+}
+
+// SetText changes the comment text.
+func (m *Comment) SetText(text string) {
+	m.CommentText = txt.Clip(clean.Unicode(text), txt.ClipLongText)
+}
+
+// SetAuthorName sets the name of a comment author without a user account, e.g. a link visitor.
+func (m *Comment) SetAuthorName(name string) {
+	if m.UserUID != "" {
+		return
+	}
+
+	m.AuthorName = txt.Clip(clean.Name(name), txt.ClipName)
+}
+
+// IsPhoto checks if the comment belongs to a photo.
+func (m *Comment) IsPhoto() bool {
+	return rnd.IsUID(m.EntityUID, PhotoUID)
+}
+
+// IsAlbum checks if the comment belongs to the discussion thread of an album.
+func (m *Comment) IsAlbum() bool {
+	return rnd.IsUID(m.EntityUID, AlbumUID)
+}
+
+// IsAuthor checks if the comment was written by the user of the session, or in the same session
+// in case of link visitors without a user account.
+func (m *Comment) IsAuthor(s *Session) bool {
+	if s == nil {
+		return false
+	} else if user := s.User(); user.IsRegistered() {
+		return m.UserUID != "" && m.UserUID == user.UserUID
+	}
+
+	return m.UserUID == "" && m.SessionRef != "" && m.SessionRef == s.RefID
+}
+
+// Validate checks if the comment can be saved.
+func (m *Comment) Validate() error {
+	if m.CommentText == "" {
+		return fmt.Errorf("comment is empty")
+	} else if !m.IsPhoto() && !m.IsAlbum() {
+		return fmt.Errorf("comments are only supported for photos and albums")
+	}
+
+	return nil
+}
+
+// Create inserts a new comment into the database.
+func (m *Comment) Create() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	return Db().Create(m).Error
+}
+
+// Save updates the comment in the database.
+func (m *Comment) Save() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	return Db().Save(m).Error
+}
+
+// Edit changes the comment text and saves it.
+func (m *Comment) Edit(text string) error {
+	m.SetText(text)
+	m.EditedAt = TimePointer()
+
+	return m.Save()
+}
+
+// Hide hides or shows a comment, e.g. if it is inappropriate.
+func (m *Comment) Hide(hidden bool) error {
+	m.CommentHidden = hidden
+
+	return Db().Model(m).UpdateColumn("comment_hidden", hidden).Error
+}
+
+// Delete removes the comment.
+func (m *Comment) Delete() error {
+	if m.ID < 1 {
+		return fmt.Errorf("comment not found")
+	}
+
+	return Db().Delete(m).Error
+}
@@ -0,0 +1,61 @@
+package entity
+
+import "time"
+
+type CommentMap map[string]Comment
+
+func (m CommentMap) Get(name string) Comment {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return Comment{}
+}
+
+func (m CommentMap) Pointer(name string) *Comment {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &Comment{}
+}
+
+var CommentFixtures = CommentMap{
+	"PhotoAlice": Comment{
+		ID:          1000000,
+		CommentUID:  "cs6sg6bw45bn0001",
+		EntityUID:   PhotoFixtures.Get("Photo01").PhotoUID,
+		UserUID:     UserFixtures.Get("alice").UserUID,
+		AuthorName:  "Alice",
+		CommentText: "That's grandpa on the left.",
+		CreatedAt:   time.Date(2022, 4, 1, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2022, 4, 1, 10, 0, 0, 0, time.UTC),
+	},
+	"PhotoVisitor": Comment{
+		ID:          1000001,
+		CommentUID:  "cs6sg6bw45bn0002",
+		EntityUID:   PhotoFixtures.Get("Photo01").PhotoUID,
+		SessionRef:  "sessxkkcabce",
+		AuthorName:  "Aunt Mary",
+		CommentText: "And my cousin Tom next to him!",
+		CreatedAt:   time.Date(2022, 4, 2, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2022, 4, 2, 10, 0, 0, 0, time.UTC),
+	},
+	"AlbumHidden": Comment{
+		ID:            1000002,
+		CommentUID:    "cs6sg6bw45bn0003",
+		EntityUID:     AlbumFixtures.Get("holiday-2030").AlbumUID,
+		AuthorName:    "Spammer",
+		CommentText:   "Buy cheap watches",
+		CommentHidden: true,
+		CreatedAt:     time.Date(2022, 4, 3, 10, 0, 0, 0, time.UTC),
+		UpdatedAt:     time.Date(2022, 4, 3, 10, 0, 0, 0, time.UTC),
+	},
+}
+
+// CreateCommentFixtures inserts known entities into the database for testing.
+func CreateCommentFixtures() {
+	for _, entity := range CommentFixtures {
+		Db().Create(&entity)
+	}
+}
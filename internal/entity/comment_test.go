@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewComment(t *testing.T) {
+	t.Run("Visitor", func(t *testing.T) {
+		m := NewComment(PhotoFixtures.Get("Photo01").PhotoUID, "  Grandma in 1952 ", nil)
+
+		assert.True(t, m.IsPhoto())
+		assert.False(t, m.IsAlbum())
+		assert.Equal(t, "Grandma in 1952", m.CommentText)
+		assert.Equal(t, "", m.UserUID)
+
+		m.SetAuthorName(" Aunt Mary; ")
+		assert.Equal(t, "Aunt Mary", m.AuthorName)
+	})
+	t.Run("User", func(t *testing.T) {
+		s := SessionFixtures.Pointer("alice")
+		m := NewComment(AlbumFixtures.Get("holiday-2030").AlbumUID, "Great trip!", s)
+
+		assert.True(t, m.IsAlbum())
+		assert.Equal(t, UserFixtures.Get("alice").UserUID, m.UserUID)
+		assert.True(t, m.IsAuthor(s))
+		assert.False(t, m.IsAuthor(SessionFixtures.Pointer("bob")))
+
+		m.SetAuthorName("Someone Else")
+		assert.NotEqual(t, "Someone Else", m.AuthorName)
+	})
+}
+
+func TestComment_Validate(t *testing.T) {
+	assert.Error(t, NewComment(PhotoFixtures.Get("Photo01").PhotoUID, "", nil).Validate())
+	assert.Error(t, NewComment(UserFixtures.Get("alice").UserUID, "Hello", nil).Validate())
+	assert.NoError(t, NewComment(PhotoFixtures.Get("Photo01").PhotoUID, "Hello", nil).Validate())
+}
+
+func TestComment_IsAuthor(t *testing.T) {
+	m := CommentFixtures.Get("PhotoVisitor")
+
+	assert.True(t, m.IsAuthor(&Session{RefID: m.SessionRef}))
+	assert.False(t, m.IsAuthor(&Session{RefID: "sessxkkcxxxx"}))
+	assert.False(t, m.IsAuthor(nil))
+}
+
+func TestComment_Create(t *testing.T) {
+	m := NewComment(PhotoFixtures.Get("Photo02").PhotoUID, "Who is this?", nil)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	found := FindComment(m.CommentUID)
+
+	if found == nil {
+		t.Fatal("comment not found")
+	}
+
+	assert.Equal(t, "Who is this?", found.CommentText)
+
+	if err := found.Edit("Who is that?"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, found.EditedAt)
+	assert.Equal(t, "Who is that?", FindComment(m.CommentUID).CommentText)
+
+	if err := found.Hide(true); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, FindComment(m.CommentUID).CommentHidden)
+
+	if err := found.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, FindComment(m.CommentUID))
+}
+
+func TestFindComment(t *testing.T) {
+	assert.Nil(t, FindComment(""))
+	assert.Nil(t, FindComment("cs6sg6bw45bnxxxx"))
+
+	if m := FindComment("cs6sg6bw45bn0001"); m == nil {
+		t.Fatal("comment not found")
+	} else {
+		assert.Equal(t, "Alice", m.AuthorName)
+	}
+}
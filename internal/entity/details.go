@@ -12,23 +12,46 @@ var photoDetailsMutex = sync.Mutex{}
 
 // Details stores additional metadata fields for each photo to improve search performance.
 type Details struct {
-	PhotoID      uint      `gorm:"primary_key;auto_increment:false" yaml:"-"`
-	Keywords     string    `gorm:"type:VARCHAR(2048);" json:"Keywords" yaml:"Keywords"`
-	KeywordsSrc  string    `gorm:"type:VARBINARY(8);" json:"KeywordsSrc" yaml:"KeywordsSrc,omitempty"`
-	Notes        string    `gorm:"type:VARCHAR(2048);" json:"Notes" yaml:"Notes,omitempty"`
-	NotesSrc     string    `gorm:"type:VARBINARY(8);" json:"NotesSrc" yaml:"NotesSrc,omitempty"`
-	Subject      string    `gorm:"type:VARCHAR(1024);" json:"Subject" yaml:"Subject,omitempty"`
-	SubjectSrc   string    `gorm:"type:VARBINARY(8);" json:"SubjectSrc" yaml:"SubjectSrc,omitempty"`
-	Artist       string    `gorm:"type:VARCHAR(1024);" json:"Artist" yaml:"Artist,omitempty"`
-	ArtistSrc    string    `gorm:"type:VARBINARY(8);" json:"ArtistSrc" yaml:"ArtistSrc,omitempty"`
-	Copyright    string    `gorm:"type:VARCHAR(1024);" json:"Copyright" yaml:"Copyright,omitempty"`
-	CopyrightSrc string    `gorm:"type:VARBINARY(8);" json:"CopyrightSrc" yaml:"CopyrightSrc,omitempty"`
-	License      string    `gorm:"type:VARCHAR(1024);" json:"License" yaml:"License,omitempty"`
-	LicenseSrc   string    `gorm:"type:VARBINARY(8);" json:"LicenseSrc" yaml:"LicenseSrc,omitempty"`
-	Software     string    `gorm:"type:VARCHAR(1024);" json:"Software" yaml:"Software,omitempty"`
-	SoftwareSrc  string    `gorm:"type:VARBINARY(8);" json:"SoftwareSrc" yaml:"SoftwareSrc,omitempty"`
-	CreatedAt    time.Time `yaml:"-"`
-	UpdatedAt    time.Time `yaml:"-"`
+	PhotoID            uint      `gorm:"primary_key;auto_increment:false" yaml:"-"`
+	Keywords           string    `gorm:"type:VARCHAR(2048);" json:"Keywords" yaml:"Keywords"`
+	KeywordsSrc        string    `gorm:"type:VARBINARY(8);" json:"KeywordsSrc" yaml:"KeywordsSrc,omitempty"`
+	Notes              string    `gorm:"type:VARCHAR(2048);" json:"Notes" yaml:"Notes,omitempty"`
+	NotesSrc           string    `gorm:"type:VARBINARY(8);" json:"NotesSrc" yaml:"NotesSrc,omitempty"`
+	Subject            string    `gorm:"type:VARCHAR(1024);" json:"Subject" yaml:"Subject,omitempty"`
+	SubjectSrc         string    `gorm:"type:VARBINARY(8);" json:"SubjectSrc" yaml:"SubjectSrc,omitempty"`
+	Artist             string    `gorm:"type:VARCHAR(1024);" json:"Artist" yaml:"Artist,omitempty"`
+	ArtistSrc          string    `gorm:"type:VARBINARY(8);" json:"ArtistSrc" yaml:"ArtistSrc,omitempty"`
+	Copyright          string    `gorm:"type:VARCHAR(1024);" json:"Copyright" yaml:"Copyright,omitempty"`
+	CopyrightSrc       string    `gorm:"type:VARBINARY(8);" json:"CopyrightSrc" yaml:"CopyrightSrc,omitempty"`
+	License            string    `gorm:"type:VARCHAR(1024);" json:"License" yaml:"License,omitempty"`
+	LicenseSrc         string    `gorm:"type:VARBINARY(8);" json:"LicenseSrc" yaml:"LicenseSrc,omitempty"`
+	CreditLine         string    `gorm:"type:VARCHAR(512);" json:"CreditLine" yaml:"CreditLine,omitempty"`
+	CreatorAddress     string    `gorm:"type:VARCHAR(512);" json:"CreatorAddress" yaml:"CreatorAddress,omitempty"`
+	CreatorCity        string    `gorm:"type:VARCHAR(128);" json:"CreatorCity" yaml:"CreatorCity,omitempty"`
+	CreatorRegion      string    `gorm:"type:VARCHAR(128);" json:"CreatorRegion" yaml:"CreatorRegion,omitempty"`
+	CreatorPostalCode  string    `gorm:"type:VARCHAR(32);" json:"CreatorPostalCode" yaml:"CreatorPostalCode,omitempty"`
+	CreatorCountry     string    `gorm:"type:VARCHAR(128);" json:"CreatorCountry" yaml:"CreatorCountry,omitempty"`
+	CreatorPhone       string    `gorm:"type:VARCHAR(64);" json:"CreatorPhone" yaml:"CreatorPhone,omitempty"`
+	CreatorEmail       string    `gorm:"type:VARCHAR(255);" json:"CreatorEmail" yaml:"CreatorEmail,omitempty"`
+	CreatorUrl         string    `gorm:"type:VARCHAR(255);" json:"CreatorUrl" yaml:"CreatorUrl,omitempty"`
+	CreatedSublocation string    `gorm:"type:VARCHAR(255);" json:"CreatedSublocation" yaml:"CreatedSublocation,omitempty"`
+	CreatedCity        string    `gorm:"type:VARCHAR(128);" json:"CreatedCity" yaml:"CreatedCity,omitempty"`
+	CreatedState       string    `gorm:"type:VARCHAR(128);" json:"CreatedState" yaml:"CreatedState,omitempty"`
+	CreatedCountry     string    `gorm:"type:VARCHAR(128);" json:"CreatedCountry" yaml:"CreatedCountry,omitempty"`
+	CreatedCountryCode string    `gorm:"type:VARBINARY(3);" json:"CreatedCountryCode" yaml:"CreatedCountryCode,omitempty"`
+	ShownSublocation   string    `gorm:"type:VARCHAR(255);" json:"ShownSublocation" yaml:"ShownSublocation,omitempty"`
+	ShownCity          string    `gorm:"type:VARCHAR(128);" json:"ShownCity" yaml:"ShownCity,omitempty"`
+	ShownState         string    `gorm:"type:VARCHAR(128);" json:"ShownState" yaml:"ShownState,omitempty"`
+	ShownCountry       string    `gorm:"type:VARCHAR(128);" json:"ShownCountry" yaml:"ShownCountry,omitempty"`
+	ShownCountryCode   string    `gorm:"type:VARBINARY(3);" json:"ShownCountryCode" yaml:"ShownCountryCode,omitempty"`
+	PersonsShown       string    `gorm:"type:VARCHAR(1024);" json:"PersonsShown" yaml:"PersonsShown,omitempty"`
+	Event              string    `gorm:"type:VARCHAR(512);" json:"Event" yaml:"Event,omitempty"`
+	DigitalSourceType  string    `gorm:"type:VARBINARY(255);" json:"DigitalSourceType" yaml:"DigitalSourceType,omitempty"`
+	IptcSrc            string    `gorm:"type:VARBINARY(8);" json:"IptcSrc" yaml:"IptcSrc,omitempty"`
+	Software           string    `gorm:"type:VARCHAR(1024);" json:"Software" yaml:"Software,omitempty"`
+	SoftwareSrc        string    `gorm:"type:VARBINARY(8);" json:"SoftwareSrc" yaml:"SoftwareSrc,omitempty"`
+	CreatedAt          time.Time `yaml:"-"`
+	UpdatedAt          time.Time `yaml:"-"`
 }
 
 // TableName returns the entity table name.
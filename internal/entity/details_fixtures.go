@@ -63,6 +63,11 @@ var DetailsFixtures = DetailsMap{
 		Artist:       "Jens Mander",
 		Copyright:    "Copyright 2020",
 		License:      "n/a",
+		CreditLine:   "Jens Mander / PhotoPrism",
+		ShownCity:    "Heidelberg",
+		ShownCountry: "Germany",
+		Event:        "Bridge Opening",
+		IptcSrc:      "meta",
 		CreatedAt:    TimeStamp(),
 		UpdatedAt:    TimeStamp(),
 		KeywordsSrc:  "meta",
@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// iptcField maps a photo details field to the matching metadata field and its column size.
+type iptcField struct {
+	value *string
+	data  *string
+	size  int
+}
+
+// iptcFields returns the IPTC Core and Extension fields that are stored in the photo details,
+// see https://iptc.org/std/photometadata/specification/IPTC-PhotoMetadata.
+func (m *Details) iptcFields(data *meta.Data) []iptcField {
+	return []iptcField{
+		{&m.CreditLine, &data.CreditLine, 512},
+		{&m.CreatorAddress, &data.CreatorAddress, 512},
+		{&m.CreatorCity, &data.CreatorCity, 128},
+		{&m.CreatorRegion, &data.CreatorRegion, 128},
+		{&m.CreatorPostalCode, &data.CreatorPostalCode, 32},
+		{&m.CreatorCountry, &data.CreatorCountry, 128},
+		{&m.CreatorPhone, &data.CreatorPhone, 64},
+		{&m.CreatorEmail, &data.CreatorEmail, 255},
+		{&m.CreatorUrl, &data.CreatorUrl, 255},
+		{&m.CreatedSublocation, &data.CreatedSublocation, 255},
+		{&m.CreatedCity, &data.CreatedCity, 128},
+		{&m.CreatedState, &data.CreatedState, 128},
+		{&m.CreatedCountry, &data.CreatedCountry, 128},
+		{&m.CreatedCountryCode, &data.CreatedCountryCode, 3},
+		{&m.ShownSublocation, &data.ShownSublocation, 255},
+		{&m.ShownCity, &data.ShownCity, 128},
+		{&m.ShownState, &data.ShownState, 128},
+		{&m.ShownCountry, &data.ShownCountry, 128},
+		{&m.ShownCountryCode, &data.ShownCountryCode, 3},
+		{&m.PersonsShown, &data.PersonsShown, 1024},
+		{&m.Event, &data.Event, 512},
+		{&m.DigitalSourceType, &data.DigitalSourceType, 255},
+	}
+}
+
+// NoIptc tests if the photo has no IPTC metadata such as creator contact info, locations, or event.
+func (m *Details) NoIptc() bool {
+	for _, f := range m.iptcFields(&meta.Data{}) {
+		if *f.value != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasIptc tests if the photo has IPTC metadata such as creator contact info, locations, or event.
+func (m *Details) HasIptc() bool {
+	return !m.NoIptc()
+}
+
+// SetIptc updates the IPTC metadata fields with the non-empty values, unless the existing values
+// have a higher source priority.
+func (m *Details) SetIptc(data meta.Data, src string) {
+	if (SrcPriority[src] < SrcPriority[m.IptcSrc]) && m.HasIptc() {
+		return
+	}
+
+	changed := false
+
+	for _, f := range m.iptcFields(&data) {
+		if val := txt.Clip(*f.data, f.size); val != "" {
+			*f.value = val
+			changed = true
+		}
+	}
+
+	if changed {
+		m.IptcSrc = src
+	}
+}
+
+// IptcData returns the IPTC metadata fields, e.g. for embedding them in downloaded files.
+func (m *Details) IptcData() (data meta.Data) {
+	for _, f := range m.iptcFields(&data) {
+		*f.data = *f.value
+	}
+
+	return data
+}
+
+// IptcString returns the IPTC metadata fields as string to detect changes.
+func (m *Details) IptcString() string {
+	values := make([]string, 0, 24)
+
+	for _, f := range m.iptcFields(&meta.Data{}) {
+		values = append(values, *f.value)
+	}
+
+	return strings.Join(values, "\n")
+}
@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/meta"
+)
+
+func TestDetails_NoIptc(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		m := &Details{PhotoID: 123, Artist: "Jane Doe"}
+
+		assert.True(t, m.NoIptc())
+		assert.False(t, m.HasIptc())
+	})
+	t.Run("Event", func(t *testing.T) {
+		m := &Details{PhotoID: 123, Event: "Berlin Marathon 2020"}
+
+		assert.False(t, m.NoIptc())
+		assert.True(t, m.HasIptc())
+	})
+}
+
+func TestDetails_SetIptc(t *testing.T) {
+	t.Run("Meta", func(t *testing.T) {
+		m := &Details{PhotoID: 123}
+
+		m.SetIptc(meta.Data{
+			CreditLine:         " Example Press Agency ",
+			CreatorEmail:       "jane@example.com",
+			CreatedCountryCode: "DE",
+			PersonsShown:       "John Runner, Max Mustermann",
+			Event:              "Berlin Marathon 2020",
+		}, SrcMeta)
+
+		assert.Equal(t, "Example Press Agency", m.CreditLine)
+		assert.Equal(t, "jane@example.com", m.CreatorEmail)
+		assert.Equal(t, "DE", m.CreatedCountryCode)
+		assert.Equal(t, "John Runner, Max Mustermann", m.PersonsShown)
+		assert.Equal(t, "Berlin Marathon 2020", m.Event)
+		assert.Equal(t, SrcMeta, m.IptcSrc)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		m := &Details{PhotoID: 123, Event: "Berlin Marathon 2020", IptcSrc: SrcMeta}
+
+		m.SetIptc(meta.Data{}, SrcXmp)
+
+		assert.Equal(t, "Berlin Marathon 2020", m.Event)
+		assert.Equal(t, SrcMeta, m.IptcSrc)
+	})
+	t.Run("LowerPriority", func(t *testing.T) {
+		m := &Details{PhotoID: 123, Event: "Berlin Marathon 2020", IptcSrc: SrcManual}
+
+		m.SetIptc(meta.Data{Event: "Unknown", CreditLine: "Example Press Agency"}, SrcMeta)
+
+		assert.Equal(t, "Berlin Marathon 2020", m.Event)
+		assert.Equal(t, "", m.CreditLine)
+		assert.Equal(t, SrcManual, m.IptcSrc)
+	})
+	t.Run("HigherPriority", func(t *testing.T) {
+		m := &Details{PhotoID: 123, Event: "Unknown", ShownCity: "Berlin", IptcSrc: SrcMeta}
+
+		m.SetIptc(meta.Data{Event: "Berlin Marathon 2020"}, SrcXmp)
+
+		assert.Equal(t, "Berlin Marathon 2020", m.Event)
+		assert.Equal(t, "Berlin", m.ShownCity)
+		assert.Equal(t, SrcXmp, m.IptcSrc)
+	})
+	t.Run("Clip", func(t *testing.T) {
+		m := &Details{PhotoID: 123}
+
+		m.SetIptc(meta.Data{CreatorPostalCode: strings.Repeat("1", 40), ShownCountryCode: "DEUX"}, SrcMeta)
+
+		assert.Equal(t, strings.Repeat("1", 32), m.CreatorPostalCode)
+		assert.Equal(t, "DEU", m.ShownCountryCode)
+	})
+}
+
+func TestDetails_IptcData(t *testing.T) {
+	m := DetailsFixtures.Get("bridge", 1000003)
+	data := m.IptcData()
+
+	assert.Equal(t, "Jens Mander / PhotoPrism", data.CreditLine)
+	assert.Equal(t, "Heidelberg", data.ShownCity)
+	assert.Equal(t, "Germany", data.ShownCountry)
+	assert.Equal(t, "Bridge Opening", data.Event)
+	assert.Equal(t, "", data.Artist)
+}
+
+func TestDetails_IptcString(t *testing.T) {
+	m := &Details{PhotoID: 123}
+	empty := m.IptcString()
+
+	m.Event = "Berlin Marathon 2020"
+
+	assert.NotEqual(t, empty, m.IptcString())
+	assert.Contains(t, m.IptcString(), "Berlin Marathon 2020")
+}
@@ -84,7 +84,7 @@ func UpdateSubjectCounts() (err error) {
 	filesTable := File{}.TableName()
 	markerTable := Marker{}.TableName()
 
-	condition := gorm.Expr("subj_type = ?", SubjPerson)
+	condition := gorm.Expr("subj_type IN (?)", []string{SubjPerson, SubjPet})
 
 	switch DbDialect() {
 	case MySQL:
@@ -2,6 +2,8 @@ package entity
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -28,6 +30,7 @@ var Entities = Tables{
 	File{}.TableName():              &File{},
 	FileShare{}.TableName():         &FileShare{},
 	FileSync{}.TableName():          &FileSync{},
+	FileEdit{}.TableName():          &FileEdit{},
 	Photo{}.TableName():             &Photo{},
 	PhotoUser{}.TableName():         &PhotoUser{},
 	Details{}.TableName():           &Details{},
@@ -41,6 +44,7 @@ var Entities = Tables{
 	PhotoAlbum{}.TableName():        &PhotoAlbum{},
 	Label{}.TableName():             &Label{},
 	Category{}.TableName():          &Category{},
+	LabelSynonym{}.TableName():      &LabelSynonym{},
 	PhotoLabel{}.TableName():        &PhotoLabel{},
 	Keyword{}.TableName():           &Keyword{},
 	PhotoKeyword{}.TableName():      &PhotoKeyword{},
@@ -49,6 +53,9 @@ var Entities = Tables{
 	Face{}.TableName():              &Face{},
 	Marker{}.TableName():            &Marker{},
 	Reaction{}.TableName():          &Reaction{},
+	Comment{}.TableName():           &Comment{},
+	TimeRule{}.TableName():          &TimeRule{},
+	Job{}.TableName():               &Job{},
 	UserShare{}.TableName():         &UserShare{},
 }
 
@@ -145,3 +152,66 @@ func (list Tables) Drop(db *gorm.DB) {
 		}
 	}
 }
+
+// Names returns the sorted table names of all registered entities.
+func (list Tables) Names() []string {
+	result := make([]string, 0, len(list))
+
+	for name := range list {
+		result = append(result, name)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// Schema returns the columns and indexes of all registered entities as expected by the ORM.
+func (list Tables) Schema(db *gorm.DB) migrate.Schema {
+	result := make(migrate.Schema, 0, len(list))
+
+	for _, name := range list.Names() {
+		scope := db.NewScope(list[name])
+		dialect := scope.Dialect()
+		table := migrate.Table{Name: name}
+
+		for _, field := range scope.GetModelStruct().StructFields {
+			if !field.IsNormal || field.IsIgnored {
+				continue
+			}
+
+			table.Columns = append(table.Columns, field.DBName)
+
+			if s, ok := field.TagSettingsGet("INDEX"); ok {
+				table.Indexes = append(table.Indexes, indexNames(dialect, s, "idx", name, field.DBName)...)
+			}
+
+			if s, ok := field.TagSettingsGet("UNIQUE_INDEX"); ok {
+				table.Indexes = append(table.Indexes, indexNames(dialect, s, "uix", name, field.DBName)...)
+			}
+		}
+
+		result = append(result, table)
+	}
+
+	return result
+}
+
+// Check compares the database schema with the registered entities and returns the differences found.
+func (list Tables) Check(db *gorm.DB) (migrate.Drifts, error) {
+	return migrate.Check(db, list.Schema(db))
+}
+
+// indexNames returns the index names for a column based on its tag setting, like the ORM does.
+func indexNames(dialect gorm.Dialect, setting, kind, table, column string) (result []string) {
+	for _, name := range strings.Split(setting, ",") {
+		if name == "" || strings.EqualFold(name, "INDEX") || strings.EqualFold(name, "UNIQUE_INDEX") {
+			name = dialect.BuildKeyName(kind, table, column)
+		}
+
+		name, _ = dialect.NormalizeIndexAndColumn(name, column)
+		result = append(result, name)
+	}
+
+	return result
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/migrate"
+)
+
+func TestTables_Schema(t *testing.T) {
+	schema := Entities.Schema(Db())
+
+	assert.Len(t, schema, len(Entities))
+
+	var files migrate.Table
+
+	for _, table := range schema {
+		if table.Name == (File{}).TableName() {
+			files = table
+		}
+	}
+
+	assert.Contains(t, files.Columns, "file_uid")
+	assert.Contains(t, files.Columns, "photo_taken_at")
+	assert.NotContains(t, files.Columns, "markers")
+	assert.Contains(t, files.Indexes, "idx_files_photo_id")
+	assert.Contains(t, files.Indexes, "idx_files_name_root")
+	assert.Contains(t, files.Indexes, "uix_files_file_uid")
+}
+
+func TestTables_Check(t *testing.T) {
+	result, err := Entities.Check(Db())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range result {
+		assert.NotEqual(t, migrate.DriftTable, d.Type, d.String())
+	}
+}
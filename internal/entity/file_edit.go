@@ -0,0 +1,164 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/thumb"
+)
+
+// FileEdit represents non-destructive adjustments of a file, which are applied when generating
+// thumbnails and downloads, so that originals never change.
+type FileEdit struct {
+	FileID      uint      `gorm:"primary_key;auto_increment:false" json:"-" yaml:"-"`
+	FileUID     string    `gorm:"type:VARBINARY(42);index;" json:"FileUID" yaml:"FileUID"`
+	FileHash    string    `gorm:"type:VARBINARY(128);index;" json:"Hash" yaml:"Hash"`
+	CropX       float32   `gorm:"type:FLOAT;" json:"CropX" yaml:"CropX,omitempty"`
+	CropY       float32   `gorm:"type:FLOAT;" json:"CropY" yaml:"CropY,omitempty"`
+	CropW       float32   `gorm:"type:FLOAT;" json:"CropW" yaml:"CropW,omitempty"`
+	CropH       float32   `gorm:"type:FLOAT;" json:"CropH" yaml:"CropH,omitempty"`
+	Angle       float32   `gorm:"type:FLOAT;" json:"Angle" yaml:"Angle,omitempty"`
+	Exposure    float32   `gorm:"type:FLOAT;" json:"Exposure" yaml:"Exposure,omitempty"`
+	Contrast    int       `json:"Contrast" yaml:"Contrast,omitempty"`
+	Saturation  int       `json:"Saturation" yaml:"Saturation,omitempty"`
+	Temperature int       `json:"Temperature" yaml:"Temperature,omitempty"`
+	Tint        int       `json:"Tint" yaml:"Tint,omitempty"`
+	CreatedAt   time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt   time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (FileEdit) TableName() string {
+	return "files_edits"
+}
+
+// NewFileEdit returns new, empty adjustments for the file.
+func NewFileEdit(file *File) *FileEdit {
+	return &FileEdit{
+		FileID:   file.ID,
+		FileUID:  file.FileUID,
+		FileHash: file.FileHash,
+	}
+}
+
+// FindFileEdit returns the adjustments of the file or nil if it has not been edited.
+func FindFileEdit(fileUid string) *FileEdit {
+	if fileUid == "" {
+		return nil
+	}
+
+	m := &FileEdit{}
+
+	if Db().First(m, "file_uid = ?", fileUid).Error != nil {
+		return nil
+	}
+
+	return m
+}
+
+// FindFileEditByHash returns the adjustments of the file with the specified hash or nil if it has not been edited.
+// Results are cached, as this is called for each thumbnail request.
+func FindFileEditByHash(fileHash string) *FileEdit {
+	if fileHash == "" {
+		return nil
+	}
+
+	if cacheData, ok := fileEditCache.Get(fileHash); ok {
+		if m := cacheData.(FileEdit); m.FileID > 0 {
+			return &m
+		}
+
+		return nil
+	}
+
+	m := FileEdit{}
+
+	if Db().First(&m, "file_hash = ?", fileHash).Error != nil {
+		fileEditCache.SetDefault(fileHash, FileEdit{})
+		return nil
+	}
+
+	fileEditCache.SetDefault(fileHash, m)
+
+	return &m
+}
+
+// ThumbKey returns the thumbnail cache key of the file, which includes a checksum of the adjustments if it was edited.
+func (m *File) ThumbKey() string {
+	if edit := FindFileEditByHash(m.FileHash); edit != nil {
+		return edit.Edit().Key(m.FileHash)
+	}
+
+	return m.FileHash
+}
+
+// FileEditKeys returns the thumbnail cache keys of edited files with the specified hashes,
+// so that clients request a new thumbnail URL after each change.
+func FileEditKeys(hashes []string) map[string]string {
+	result := make(map[string]string)
+
+	if len(hashes) == 0 {
+		return result
+	}
+
+	var edits []FileEdit
+
+	if err := Db().Where("file_hash IN (?)", hashes).Find(&edits).Error; err != nil {
+		log.Warnf("files: %s (find edits)", err)
+		return result
+	}
+
+	for _, m := range edits {
+		result[m.FileHash] = m.Edit().Key(m.FileHash)
+	}
+
+	return result
+}
+
+// SetValues updates the adjustments with the form values.
+func (m *FileEdit) SetValues(f form.FileEdit) {
+	m.CropX = f.CropX
+	m.CropY = f.CropY
+	m.CropW = f.CropW
+	m.CropH = f.CropH
+	m.Angle = f.Angle
+	m.Exposure = f.Exposure
+	m.Contrast = f.Contrast
+	m.Saturation = f.Saturation
+	m.Temperature = f.Temperature
+	m.Tint = f.Tint
+}
+
+// Edit returns the adjustments that are applied to thumbnails and downloads.
+func (m *FileEdit) Edit() thumb.Edit {
+	if m == nil {
+		return thumb.Edit{}
+	}
+
+	return thumb.Edit{
+		CropX:       float64(m.CropX),
+		CropY:       float64(m.CropY),
+		CropW:       float64(m.CropW),
+		CropH:       float64(m.CropH),
+		Angle:       float64(m.Angle),
+		Exposure:    float64(m.Exposure),
+		Contrast:    float64(m.Contrast),
+		Saturation:  float64(m.Saturation),
+		Temperature: float64(m.Temperature),
+		Tint:        float64(m.Tint),
+	}
+}
+
+// Save updates the record in the database or inserts a new record if it does not already exist.
+func (m *FileEdit) Save() error {
+	defer fileEditCache.Delete(m.FileHash)
+
+	return Db().Save(m).Error
+}
+
+// Delete removes the adjustments, so that the original is used again.
+func (m *FileEdit) Delete() error {
+	defer fileEditCache.Delete(m.FileHash)
+
+	return Db().Delete(m).Error
+}
@@ -0,0 +1,14 @@
+package entity
+
+import (
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+)
+
+var fileEditCache = gc.New(time.Minute, 5*time.Minute)
+
+// FlushFileEditCache resets the file adjustments cache.
+func FlushFileEditCache() {
+	fileEditCache.Flush()
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestFileEdit(t *testing.T) {
+	file := FileFixtures.Get("exampleFileName.jpg")
+
+	assert.Nil(t, FindFileEdit(file.FileUID))
+	assert.Nil(t, FindFileEditByHash(file.FileHash))
+
+	m := NewFileEdit(&file)
+	m.SetValues(form.FileEdit{CropW: 0.5, CropH: 0.5, Contrast: 20})
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if found := FindFileEditByHash(file.FileHash); found == nil {
+		t.Fatal("edit not found")
+	} else {
+		assert.Equal(t, file.FileUID, found.FileUID)
+		assert.Equal(t, float64(20), found.Edit().Contrast)
+		assert.False(t, found.Edit().Empty())
+	}
+
+	key := m.Edit().Key(file.FileHash)
+
+	assert.NotEqual(t, file.FileHash, key)
+	assert.Equal(t, key, file.ThumbKey())
+	assert.Equal(t, map[string]string{file.FileHash: key}, FileEditKeys([]string{file.FileHash}))
+
+	if err := m.Delete(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, FindFileEdit(file.FileUID))
+	assert.Nil(t, FindFileEditByHash(file.FileHash))
+	assert.Equal(t, file.FileHash, file.ThumbKey())
+	assert.Empty(t, FileEditKeys([]string{file.FileHash}))
+	assert.True(t, (*FileEdit)(nil).Edit().Empty())
+}
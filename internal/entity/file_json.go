@@ -7,12 +7,20 @@ import (
 
 // MarshalJSON returns the JSON encoding.
 func (m *File) MarshalJSON() ([]byte, error) {
+	// Include the thumbnail cache key only if it differs from the file hash.
+	thumbKey := m.ThumbKey()
+
+	if thumbKey == m.FileHash {
+		thumbKey = ""
+	}
+
 	return json.Marshal(&struct {
 		UID            string
 		PhotoUID       string
 		Name           string
 		Root           string
 		Hash           string
+		Thumb          string `json:",omitempty"`
 		Size           int64
 		Primary        bool
 		TimeIndex      *string       `json:",omitempty"`
@@ -60,6 +68,7 @@ func (m *File) MarshalJSON() ([]byte, error) {
 		Name:           m.FileName,
 		Root:           m.FileRoot,
 		Hash:           m.FileHash,
+		Thumb:          thumbKey,
 		Size:           m.FileSize,
 		Primary:        m.FilePrimary,
 		MediaUTC:       m.MediaUTC,
@@ -30,6 +30,7 @@ func CreateTestFixtures() {
 	CreateUserFixtures()
 	CreateSessionFixtures()
 	CreateReactionFixtures()
+	CreateCommentFixtures()
 	CreatePasswordFixtures()
 	CreateUserShareFixtures()
 }
@@ -0,0 +1,376 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Job types.
+const (
+	JobIndex   = "index"
+	JobConvert = "convert"
+	JobThumbs  = "thumbs"
+)
+
+// JobTypes contains the supported job types.
+var JobTypes = []string{JobIndex, JobConvert, JobThumbs}
+
+// Job states.
+const (
+	JobQueued   = "queued"
+	JobRunning  = "running"
+	JobPaused   = "paused"
+	JobDone     = "done"
+	JobFailed   = "failed"
+	JobCanceled = "canceled"
+)
+
+// Job priorities, jobs with a higher priority are processed first.
+const (
+	JobPriorityLow     = -10
+	JobPriorityDefault = 0
+	JobPriorityHigh    = 10
+)
+
+// DefaultJobAttempts is the default maximum number of attempts before a job fails.
+const DefaultJobAttempts = 3
+
+// JobBackoff is the delay before a failed job is retried for the first time, it doubles with each attempt.
+var JobBackoff = 30 * time.Second
+
+// JobBackoffMax is the maximum delay before a failed job is retried.
+var JobBackoffMax = 6 * time.Hour
+
+// JobRetention is the time after which finished jobs are removed from the queue.
+var JobRetention = 7 * 24 * time.Hour
+
+// Job represents a persistent index, convert, or thumbnail job for a file or folder in originals,
+// so that work can be resumed after a restart.
+type Job struct {
+	ID          uint       `gorm:"primary_key" json:"ID" yaml:"-"`
+	JobType     string     `gorm:"type:VARBINARY(16);index:idx_jobs_queue;" json:"Type" yaml:"Type"`
+	JobStatus   string     `gorm:"type:VARBINARY(16);index:idx_jobs_queue;" json:"Status" yaml:"Status"`
+	JobPriority int        `gorm:"index:idx_jobs_queue;" json:"Priority" yaml:"Priority,omitempty"`
+	JobPath     string     `gorm:"type:VARBINARY(1024);" json:"Path" yaml:"Path"`
+	JobForce    bool       `json:"Force" yaml:"Force,omitempty"`
+	JobAttempts int        `json:"Attempts" yaml:"Attempts,omitempty"`
+	MaxAttempts int        `json:"MaxAttempts" yaml:"MaxAttempts,omitempty"`
+	JobError    string     `gorm:"type:VARBINARY(512);" json:"Error" yaml:"Error,omitempty"`
+	RunAfter    time.Time  `json:"RunAfter" yaml:"RunAfter"`
+	StartedAt   *time.Time `json:"StartedAt" yaml:"StartedAt,omitempty"`
+	FinishedAt  *time.Time `json:"FinishedAt" yaml:"FinishedAt,omitempty"`
+	CreatedAt   time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt   time.Time  `json:"UpdatedAt" yaml:"-"`
+}
+
+// Jobs represents a list of jobs.
+type Jobs []Job
+
+// TableName returns the entity table name.
+func (Job) TableName() string {
+	return "jobs"
+}
+
+// NewJob returns a new queued job for a file or folder path relative to originals.
+func NewJob(jobType, jobPath string, priority int, force bool) *Job {
+	return &Job{
+		JobType:     jobType,
+		JobStatus:   JobQueued,
+		JobPriority: priority,
+		JobPath:     strings.Trim(clean.UserPath(jobPath), "/"),
+		JobForce:    force,
+		MaxAttempts: DefaultJobAttempts,
+		RunAfter:    TimeStamp(),
+	}
+}
+
+// Validate returns an error if the job type or status is invalid.
+func (m *Job) Validate() error {
+	switch m.JobType {
+	case JobIndex, JobConvert, JobThumbs:
+	default:
+		return fmt.Errorf("invalid job type %s", clean.Log(m.JobType))
+	}
+
+	switch m.JobStatus {
+	case JobQueued, JobRunning, JobPaused, JobDone, JobFailed, JobCanceled:
+	default:
+		return fmt.Errorf("invalid job status %s", clean.Log(m.JobStatus))
+	}
+
+	return nil
+}
+
+// Create inserts a new job, unless a job of the same type is already pending for the path.
+func (m *Job) Create() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	if found := FindPendingJob(m.JobType, m.JobPath); found != nil {
+		// Raise the priority of the existing job if needed.
+		if m.JobPriority > found.JobPriority {
+			found.JobPriority = m.JobPriority
+			_ = found.Update("job_priority", m.JobPriority)
+		}
+
+		*m = *found
+
+		return nil
+	}
+
+	return Db().Create(m).Error
+}
+
+// Save updates the record in the database or inserts a new record if it does not already exist.
+func (m *Job) Save() error {
+	return Db().Save(m).Error
+}
+
+// Update updates a column in the database.
+func (m *Job) Update(attr string, value interface{}) error {
+	return UnscopedDb().Model(m).UpdateColumn(attr, value).Error
+}
+
+// Updates updates multiple columns in the database.
+func (m *Job) Updates(values interface{}) error {
+	return UnscopedDb().Model(m).UpdateColumns(values).Error
+}
+
+// Pending tests if the job has not been processed yet.
+func (m *Job) Pending() bool {
+	return m.JobStatus == JobQueued || m.JobStatus == JobRunning || m.JobStatus == JobPaused
+}
+
+// Start marks the job as running and counts the attempt.
+func (m *Job) Start() error {
+	now := TimeStamp()
+
+	m.JobStatus = JobRunning
+	m.JobAttempts++
+	m.StartedAt = &now
+
+	return m.Updates(Values{"job_status": m.JobStatus, "job_attempts": m.JobAttempts, "started_at": m.StartedAt})
+}
+
+// Done marks the job as successfully processed.
+func (m *Job) Done() error {
+	now := TimeStamp()
+
+	m.JobStatus = JobDone
+	m.JobError = ""
+	m.FinishedAt = &now
+
+	return m.Updates(Values{"job_status": m.JobStatus, "job_error": m.JobError, "finished_at": m.FinishedAt})
+}
+
+// Fail captures the error and queues the job for another attempt with exponential backoff,
+// or marks it as failed if the maximum number of attempts has been reached.
+func (m *Job) Fail(err error) error {
+	if err == nil {
+		err = errors.New("unknown error")
+	}
+
+	m.JobError = strings.TrimSpace(err.Error())
+
+	if len(m.JobError) > 512 {
+		m.JobError = m.JobError[:512]
+	}
+
+	if m.JobAttempts >= m.MaxAttempts {
+		now := TimeStamp()
+		m.JobStatus = JobFailed
+		m.FinishedAt = &now
+	} else {
+		m.JobStatus = JobQueued
+		m.RunAfter = TimeStamp().Add(m.Backoff())
+	}
+
+	return m.Updates(Values{"job_status": m.JobStatus, "job_error": m.JobError, "run_after": m.RunAfter, "finished_at": m.FinishedAt})
+}
+
+// Requeue queues a running job again without counting the attempt, e.g. if the worker was interrupted
+// by a shutdown. Jobs that have been canceled in the meantime remain canceled.
+func (m *Job) Requeue() error {
+	if m.JobAttempts > 0 {
+		m.JobAttempts--
+	}
+
+	m.JobStatus = JobQueued
+	m.RunAfter = TimeStamp()
+
+	return UnscopedDb().Model(m).Where("job_status = ?", JobRunning).
+		UpdateColumns(Values{"job_status": m.JobStatus, "job_attempts": m.JobAttempts, "run_after": m.RunAfter}).Error
+}
+
+// Backoff returns the delay before the job is retried after the current attempt failed.
+func (m *Job) Backoff() time.Duration {
+	if m.JobAttempts < 1 {
+		return 0
+	}
+
+	d := time.Duration(float64(JobBackoff) * math.Pow(2, float64(m.JobAttempts-1)))
+
+	if d > JobBackoffMax || d <= 0 {
+		return JobBackoffMax
+	}
+
+	return d
+}
+
+// Cancel cancels the job if it has not been processed yet.
+func (m *Job) Cancel() error {
+	if !m.Pending() {
+		return fmt.Errorf("job %d is %s", m.ID, m.JobStatus)
+	}
+
+	now := TimeStamp()
+
+	m.JobStatus = JobCanceled
+	m.FinishedAt = &now
+
+	return m.Updates(Values{"job_status": m.JobStatus, "finished_at": m.FinishedAt})
+}
+
+// Retry queues a failed or canceled job again.
+func (m *Job) Retry() error {
+	if m.Pending() || m.JobStatus == JobDone {
+		return fmt.Errorf("job %d is %s", m.ID, m.JobStatus)
+	}
+
+	m.JobStatus = JobQueued
+	m.JobAttempts = 0
+	m.RunAfter = TimeStamp()
+	m.FinishedAt = nil
+
+	return m.Updates(Values{"job_status": m.JobStatus, "job_attempts": m.JobAttempts, "run_after": m.RunAfter, "finished_at": nil})
+}
+
+// Canceled reloads the job status and tests if the job has been canceled in the meantime.
+func (m *Job) Canceled() bool {
+	var status []string
+
+	if err := UnscopedDb().Model(&Job{}).Where("id = ?", m.ID).Pluck("job_status", &status).Error; err != nil || len(status) == 0 {
+		return false
+	}
+
+	return status[0] == JobCanceled
+}
+
+// String returns a human-readable job description for logging.
+func (m *Job) String() string {
+	return fmt.Sprintf("%s job %d for %s", m.JobType, m.ID, clean.Log("/"+m.JobPath))
+}
+
+// FindJob returns the job with the specified ID or nil if it was not found.
+func FindJob(id uint) *Job {
+	m := &Job{}
+
+	if Db().First(m, "id = ?", id).Error != nil {
+		return nil
+	}
+
+	return m
+}
+
+// FindPendingJob returns the pending job of the specified type for a path or nil if there is none.
+func FindPendingJob(jobType, jobPath string) *Job {
+	m := &Job{}
+
+	if Db().Where("job_type = ? AND job_path = ? AND job_status IN (?)", jobType, jobPath,
+		[]string{JobQueued, JobRunning, JobPaused}).First(m).Error != nil {
+		return nil
+	}
+
+	return m
+}
+
+// NextJob returns the queued job that should be processed next or nil if there is none.
+func NextJob() *Job {
+	m := &Job{}
+
+	if Db().Where("job_status = ? AND run_after <= ?", JobQueued, TimeStamp()).
+		Order("job_priority DESC, id").First(m).Error != nil {
+		return nil
+	}
+
+	return m
+}
+
+// PauseJobs pauses queued jobs of the specified type, or of all types if empty, and returns their number.
+func PauseJobs(jobType string) (int64, error) {
+	return updateJobStatus(jobType, JobQueued, JobPaused)
+}
+
+// ResumeJobs resumes paused jobs of the specified type, or of all types if empty, and returns their number.
+func ResumeJobs(jobType string) (int64, error) {
+	return updateJobStatus(jobType, JobPaused, JobQueued)
+}
+
+// ResetRunningJobs queues jobs that were interrupted, e.g. by a restart, so that they are processed again.
+func ResetRunningJobs() (int64, error) {
+	return updateJobStatus("", JobRunning, JobQueued)
+}
+
+// CancelJobs cancels pending jobs of the specified type, or of all types if empty, and returns their number.
+func CancelJobs(jobType string) (int64, error) {
+	stmt := UnscopedDb().Model(&Job{}).Where("job_status IN (?)", []string{JobQueued, JobRunning, JobPaused})
+
+	if jobType != "" {
+		stmt = stmt.Where("job_type = ?", jobType)
+	}
+
+	now := TimeStamp()
+	res := stmt.UpdateColumns(Values{"job_status": JobCanceled, "finished_at": &now, "updated_at": now})
+
+	return res.RowsAffected, res.Error
+}
+
+// updateJobStatus changes the status of jobs of the specified type, or of all types if empty.
+func updateJobStatus(jobType, from, to string) (int64, error) {
+	stmt := UnscopedDb().Model(&Job{}).Where("job_status = ?", from)
+
+	if jobType != "" {
+		stmt = stmt.Where("job_type = ?", jobType)
+	}
+
+	res := stmt.UpdateColumns(Values{"job_status": to, "updated_at": TimeStamp()})
+
+	return res.RowsAffected, res.Error
+}
+
+// DeleteFinishedJobs removes jobs that have been processed successfully or canceled before the specified time.
+func DeleteFinishedJobs(before time.Time) (int64, error) {
+	res := UnscopedDb().Where("job_status IN (?) AND finished_at < ?", []string{JobDone, JobCanceled}, before).Delete(&Job{})
+
+	return res.RowsAffected, res.Error
+}
+
+// JobCounts represents the number of jobs by status.
+type JobCounts map[string]int
+
+// CountJobs returns the number of jobs by status.
+func CountJobs() (JobCounts, error) {
+	var rows []struct {
+		JobStatus string
+		Count     int
+	}
+
+	result := make(JobCounts)
+
+	if err := UnscopedDb().Model(&Job{}).Select("job_status, COUNT(*) AS count").
+		Group("job_status").Scan(&rows).Error; err != nil {
+		return result, err
+	}
+
+	for _, row := range rows {
+		result[row.JobStatus] = row.Count
+	}
+
+	return result, nil
+}
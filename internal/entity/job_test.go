@@ -0,0 +1,135 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJob(t *testing.T) {
+	m := NewJob(JobIndex, "/2023/July/", JobPriorityHigh, true)
+
+	assert.Equal(t, JobIndex, m.JobType)
+	assert.Equal(t, JobQueued, m.JobStatus)
+	assert.Equal(t, "2023/July", m.JobPath)
+	assert.Equal(t, JobPriorityHigh, m.JobPriority)
+	assert.True(t, m.JobForce)
+	assert.NoError(t, m.Validate())
+	assert.Error(t, NewJob("foo", "", 0, false).Validate())
+}
+
+func TestJob_Backoff(t *testing.T) {
+	m := &Job{}
+	assert.Equal(t, time.Duration(0), m.Backoff())
+
+	m.JobAttempts = 1
+	assert.Equal(t, JobBackoff, m.Backoff())
+
+	m.JobAttempts = 3
+	assert.Equal(t, 4*JobBackoff, m.Backoff())
+
+	m.JobAttempts = 100
+	assert.Equal(t, JobBackoffMax, m.Backoff())
+}
+
+func TestJob_Lifecycle(t *testing.T) {
+	m := NewJob(JobThumbs, "2790/07/27900704_070228_D6D51B6C.jpg", JobPriorityDefault, false)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Duplicate", func(t *testing.T) {
+		dup := NewJob(JobThumbs, "2790/07/27900704_070228_D6D51B6C.jpg", JobPriorityHigh, false)
+		assert.NoError(t, dup.Create())
+		assert.Equal(t, m.ID, dup.ID)
+		assert.Equal(t, JobPriorityHigh, dup.JobPriority)
+	})
+	t.Run("Next", func(t *testing.T) {
+		next := NextJob()
+
+		if assert.NotNil(t, next) {
+			assert.Equal(t, m.ID, next.ID)
+		}
+	})
+	t.Run("PauseResume", func(t *testing.T) {
+		n, err := PauseJobs(JobThumbs)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, n, int64(1))
+		assert.Nil(t, NextJob())
+
+		n, err = ResumeJobs("")
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, n, int64(1))
+		assert.NotNil(t, NextJob())
+	})
+	t.Run("Fail", func(t *testing.T) {
+		assert.NoError(t, m.Start())
+		assert.Equal(t, 1, m.JobAttempts)
+		assert.NoError(t, m.Fail(errors.New("file not found")))
+		assert.Equal(t, JobQueued, m.JobStatus)
+		assert.Equal(t, "file not found", m.JobError)
+		assert.True(t, m.RunAfter.After(time.Now()))
+
+		m.JobAttempts = m.MaxAttempts
+		assert.NoError(t, m.Fail(errors.New("file not found")))
+		assert.Equal(t, JobFailed, m.JobStatus)
+		assert.Error(t, m.Cancel())
+	})
+	t.Run("Retry", func(t *testing.T) {
+		assert.NoError(t, m.Retry())
+		assert.Equal(t, JobQueued, m.JobStatus)
+		assert.Equal(t, 0, m.JobAttempts)
+		assert.Error(t, m.Retry())
+	})
+	t.Run("Cancel", func(t *testing.T) {
+		assert.NoError(t, m.Cancel())
+		assert.True(t, m.Canceled())
+		assert.Nil(t, FindPendingJob(JobThumbs, m.JobPath))
+	})
+	t.Run("Count", func(t *testing.T) {
+		counts, err := CountJobs()
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, counts[JobCanceled], 1)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		n, err := DeleteFinishedJobs(time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, n, int64(1))
+		assert.Nil(t, FindJob(m.ID))
+	})
+}
+
+func TestJob_Requeue(t *testing.T) {
+	m := NewJob(JobConvert, "2790/07/27900704_070228_D6D51B6C.jpg", JobPriorityDefault, false)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer UnscopedDb().Delete(m)
+
+	t.Run("Interrupted", func(t *testing.T) {
+		assert.NoError(t, m.Start())
+		assert.NoError(t, m.Requeue())
+		assert.Equal(t, JobQueued, m.JobStatus)
+		assert.Equal(t, 0, m.JobAttempts)
+
+		if found := FindPendingJob(JobConvert, m.JobPath); assert.NotNil(t, found) {
+			assert.Equal(t, JobQueued, found.JobStatus)
+		}
+	})
+	t.Run("Canceled", func(t *testing.T) {
+		assert.NoError(t, m.Start())
+
+		n, err := CancelJobs(JobConvert)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, n, int64(1))
+
+		assert.NoError(t, m.Requeue())
+		assert.True(t, m.Canceled())
+		assert.Nil(t, FindPendingJob(JobConvert, m.JobPath))
+	})
+}
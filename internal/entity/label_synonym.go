@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+type LabelSynonyms []LabelSynonym
+
+// LabelSynonym represents an alternative label name that is used as search term.
+type LabelSynonym struct {
+	SynonymSlug string    `gorm:"type:VARBINARY(160);primary_key;auto_increment:false" json:"Slug" yaml:"Slug"`
+	SynonymName string    `gorm:"type:VARCHAR(160);" json:"Name" yaml:"Name"`
+	LabelID     uint      `gorm:"index;" json:"-" yaml:"-"`
+	CreatedAt   time.Time `json:"CreatedAt" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (LabelSynonym) TableName() string {
+	return "labels_synonyms"
+}
+
+// NewLabelSynonym returns a new label synonym.
+func NewLabelSynonym(labelID uint, name string) *LabelSynonym {
+	name = txt.Clip(clean.NameCapitalized(name), txt.ClipName)
+
+	return &LabelSynonym{
+		SynonymSlug: txt.Slug(name),
+		SynonymName: name,
+		LabelID:     labelID,
+	}
+}
+
+// Create inserts a new row to the database.
+func (m *LabelSynonym) Create() error {
+	return Db().Create(m).Error
+}
+
+// Delete removes the synonym from the database.
+func (m *LabelSynonym) Delete() error {
+	return Db().Delete(m, "synonym_slug = ?", m.SynonymSlug).Error
+}
+
+// FindLabelSynonym returns the synonym matching the name, or nil if it was not found.
+func FindLabelSynonym(name string) *LabelSynonym {
+	slug := txt.Slug(name)
+
+	if slug == "" {
+		return nil
+	}
+
+	result := LabelSynonym{}
+
+	if err := Db().Where("synonym_slug = ?", slug).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// FindSynonymLabel returns the label with a matching synonym, or nil if it was not found.
+func FindSynonymLabel(name string) *Label {
+	s := FindLabelSynonym(name)
+
+	if s == nil {
+		return nil
+	}
+
+	result := Label{}
+
+	if err := Db().Where("id = ?", s.LabelID).First(&result).Error; err != nil {
+		return nil
+	}
+
+	return &result
+}
+
+// Synonyms returns the synonyms of the label.
+func (m *Label) Synonyms() (result LabelSynonyms) {
+	if m.ID == 0 {
+		return result
+	}
+
+	if err := Db().Where("label_id = ?", m.ID).Order("synonym_slug").Find(&result).Error; err != nil {
+		log.Errorf("label: %s (find synonyms)", err)
+	}
+
+	return result
+}
+
+// AddSynonym adds an alternative name that can be used to find the label.
+func (m *Label) AddSynonym(name string) (*LabelSynonym, error) {
+	if m.ID == 0 {
+		return nil, errors.New("label: cannot add synonym, id is empty")
+	}
+
+	s := NewLabelSynonym(m.ID, name)
+
+	if s.SynonymSlug == "" {
+		return nil, errors.New("label: synonym must not be empty")
+	} else if s.SynonymSlug == m.LabelSlug || s.SynonymSlug == m.CustomSlug {
+		return nil, fmt.Errorf("label: synonym %s matches label name", clean.Log(s.SynonymName))
+	} else if l := FindLabel(s.SynonymSlug); l != nil {
+		return nil, fmt.Errorf("label: synonym %s is already a label name", clean.Log(s.SynonymName))
+	} else if existing := FindLabelSynonym(s.SynonymSlug); existing == nil {
+		// Create new synonym.
+	} else if existing.LabelID == m.ID {
+		return existing, nil
+	} else {
+		return nil, fmt.Errorf("label: synonym %s is already in use", clean.Log(s.SynonymName))
+	}
+
+	if err := s.Create(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// RemoveSynonym removes an alternative label name.
+func (m *Label) RemoveSynonym(name string) error {
+	s := FindLabelSynonym(name)
+
+	if s == nil || s.LabelID != m.ID {
+		return fmt.Errorf("label: synonym %s not found", clean.Log(name))
+	}
+
+	return s.Delete()
+}
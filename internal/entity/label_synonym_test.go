@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLabelSynonym(t *testing.T) {
+	s := NewLabelSynonym(1000001, "blue tit")
+	assert.Equal(t, "blue-tit", s.SynonymSlug)
+	assert.Equal(t, "Blue Tit", s.SynonymName)
+	assert.Equal(t, uint(1000001), s.LabelID)
+}
+
+func TestLabel_AddSynonym(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		label := FirstOrCreateLabel(NewLabel("Synonym Test Bird", 0))
+
+		s, err := label.AddSynonym("Synonym Test Birdie")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "synonym-test-birdie", s.SynonymSlug)
+		assert.Equal(t, label.ID, s.LabelID)
+
+		// Adding the same synonym again must not fail.
+		again, err := label.AddSynonym("synonym test birdie")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, s.SynonymSlug, again.SynonymSlug)
+
+		if found := FindSynonymLabel("Synonym Test Birdie"); found == nil {
+			t.Fatal("label should not be nil")
+		} else {
+			assert.Equal(t, label.ID, found.ID)
+		}
+
+		assert.Len(t, label.Synonyms(), 1)
+
+		if err = label.RemoveSynonym("Synonym Test Birdie"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindSynonymLabel("Synonym Test Birdie"))
+		assert.Len(t, label.Synonyms(), 0)
+	})
+	t.Run("LabelName", func(t *testing.T) {
+		label := LabelFixtures.Get("flower")
+		_, err := label.AddSynonym("Landscape")
+		assert.Error(t, err)
+		_, err = label.AddSynonym("Flower")
+		assert.Error(t, err)
+	})
+	t.Run("InUse", func(t *testing.T) {
+		label := FirstOrCreateLabel(NewLabel("Synonym Test Cat", 0))
+		other := FirstOrCreateLabel(NewLabel("Synonym Test Dog", 0))
+
+		if _, err := label.AddSynonym("Synonym Test Pet"); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := other.AddSynonym("Synonym Test Pet")
+		assert.Error(t, err)
+		assert.Error(t, other.RemoveSynonym("Synonym Test Pet"))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		label := LabelFixtures.Get("flower")
+		_, err := label.AddSynonym("  ")
+		assert.Error(t, err)
+	})
+	t.Run("NoID", func(t *testing.T) {
+		label := Label{}
+		_, err := label.AddSynonym("Foo")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,235 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// LabelTreeDepth limits the number of levels that are traversed in the label hierarchy.
+const LabelTreeDepth = 16
+
+// Parents returns the labels this label is a child of.
+func (m *Label) Parents() (result Labels) {
+	if m.ID == 0 {
+		return result
+	}
+
+	if err := Db().Where("id IN (SELECT category_id FROM categories WHERE label_id = ?)", m.ID).
+		Order("label_slug").Find(&result).Error; err != nil {
+		log.Errorf("label: %s (find parents)", err)
+	}
+
+	return result
+}
+
+// Children returns the direct child labels.
+func (m *Label) Children() (result Labels) {
+	if m.ID == 0 {
+		return result
+	}
+
+	if err := Db().Where("id IN (SELECT label_id FROM categories WHERE category_id = ?)", m.ID).
+		Order("label_slug").Find(&result).Error; err != nil {
+		log.Errorf("label: %s (find children)", err)
+	}
+
+	return result
+}
+
+// AddParent makes the label a child of the specified parent label.
+func (m *Label) AddParent(parent *Label) error {
+	if m.ID == 0 || parent == nil || parent.ID == 0 {
+		return errors.New("label: cannot add parent, id is empty")
+	} else if m.ID == parent.ID {
+		return errors.New("label: cannot be its own parent")
+	}
+
+	// Prevent cycles in the hierarchy.
+	for _, id := range LabelDescendantIDs([]uint{m.ID}) {
+		if id == parent.ID {
+			return fmt.Errorf("label: %s is a descendant of %s", clean.Log(parent.LabelName), clean.Log(m.LabelName))
+		}
+	}
+
+	labelCategoriesMutex.Lock()
+	defer labelCategoriesMutex.Unlock()
+
+	if err := Db().Model(m).Association("LabelCategories").Append(parent).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RemoveParent removes the relationship with the specified parent label.
+func (m *Label) RemoveParent(parent *Label) error {
+	if m.ID == 0 || parent == nil || parent.ID == 0 {
+		return errors.New("label: cannot remove parent, id is empty")
+	}
+
+	return Db().Where("label_id = ? AND category_id = ?", m.ID, parent.ID).Delete(&Category{}).Error
+}
+
+// LabelDescendantIDs returns the label IDs passed along with the IDs of all descendant labels.
+func LabelDescendantIDs(ids []uint) []uint {
+	result := make([]uint, 0, len(ids))
+	found := make(map[uint]bool, len(ids))
+
+	for _, id := range ids {
+		if !found[id] {
+			found[id] = true
+			result = append(result, id)
+		}
+	}
+
+	parents := result
+
+	for i := 0; i < LabelTreeDepth && len(parents) > 0; i++ {
+		var categories []Category
+
+		if err := Db().Where("category_id IN (?)", parents).Find(&categories).Error; err != nil {
+			log.Errorf("label: %s (find descendants)", err)
+			break
+		}
+
+		parents = nil
+
+		for _, c := range categories {
+			if !found[c.LabelID] {
+				found[c.LabelID] = true
+				result = append(result, c.LabelID)
+				parents = append(parents, c.LabelID)
+			}
+		}
+	}
+
+	return result
+}
+
+// Merge moves the photos, hierarchy relationships, and synonyms of another label to this label,
+// adds the name of the other label as synonym, and finally deletes it. All changes are made in a
+// single transaction. Labels rejected by a user remain rejected.
+func (m *Label) Merge(other *Label) error {
+	if m.ID == 0 || other == nil || other.ID == 0 {
+		return errors.New("label: cannot merge, id is empty")
+	} else if m.ID == other.ID {
+		return errors.New("label: cannot be merged with itself")
+	}
+
+	// Find hierarchy relationships to move, except those that would create a cycle.
+	descendants := make(map[uint]bool)
+
+	for _, id := range LabelDescendantIDs([]uint{m.ID}) {
+		descendants[id] = true
+	}
+
+	var parents, children []uint
+
+	for _, parent := range other.Parents() {
+		if parent.ID != m.ID && !descendants[parent.ID] {
+			parents = append(parents, parent.ID)
+		}
+	}
+
+	for _, child := range other.Children() {
+		if child.ID == m.ID {
+			continue
+		}
+
+		cycle := false
+
+		for _, id := range LabelDescendantIDs([]uint{child.ID}) {
+			if id == m.ID {
+				cycle = true
+				break
+			}
+		}
+
+		if !cycle {
+			children = append(children, child.ID)
+		}
+	}
+
+	return Db().Transaction(func(tx *gorm.DB) error {
+		// Move photo labels and keep the lowest uncertainty if a photo has both labels, unless it was rejected.
+		var photoLabels PhotoLabels
+
+		if err := tx.Where("label_id = ?", other.ID).Find(&photoLabels).Error; err != nil {
+			return err
+		}
+
+		for _, pl := range photoLabels {
+			existing := PhotoLabel{}
+
+			if err := tx.Where("photo_id = ? AND label_id = ?", pl.PhotoID, m.ID).First(&existing).Error; gorm.IsRecordNotFoundError(err) {
+				if err = tx.Model(&PhotoLabel{}).
+					Where("photo_id = ? AND label_id = ?", pl.PhotoID, other.ID).
+					UpdateColumn("label_id", m.ID).Error; err != nil {
+					return err
+				}
+
+				continue
+			} else if err != nil {
+				return err
+			} else if existing.Uncertainty < 100 && (pl.Uncertainty >= 100 || pl.Uncertainty < existing.Uncertainty) {
+				if err = tx.Model(&existing).UpdateColumns(Values{"Uncertainty": pl.Uncertainty, "LabelSrc": pl.LabelSrc}).Error; err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Where("photo_id = ? AND label_id = ?", pl.PhotoID, other.ID).Delete(&PhotoLabel{}).Error; err != nil {
+				return err
+			}
+		}
+
+		// Move hierarchy relationships.
+		for _, id := range parents {
+			if err := tx.Where(Category{LabelID: m.ID, CategoryID: id}).FirstOrCreate(&Category{}).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, id := range children {
+			if err := tx.Where(Category{LabelID: id, CategoryID: m.ID}).FirstOrCreate(&Category{}).Error; err != nil {
+				return err
+			}
+		}
+
+		// Move synonyms.
+		if err := tx.Model(&LabelSynonym{}).Where("label_id = ?", other.ID).UpdateColumn("label_id", m.ID).Error; err != nil {
+			return err
+		}
+
+		// Delete merged label.
+		if err := tx.Where("label_id = ? OR category_id = ?", other.ID, other.ID).Delete(&Category{}).Error; err != nil {
+			return err
+		} else if err = tx.Where("label_id = ?", other.ID).Delete(&PhotoLabel{}).Error; err != nil {
+			return err
+		} else if err = tx.Delete(other).Error; err != nil {
+			return err
+		}
+
+		// Keep the name of the merged label as synonym.
+		s := NewLabelSynonym(m.ID, other.LabelName)
+
+		if s.SynonymSlug == "" || s.SynonymSlug == m.LabelSlug || s.SynonymSlug == m.CustomSlug {
+			return nil
+		} else if err := tx.Where("label_slug = ? OR custom_slug = ?", s.SynonymSlug, s.SynonymSlug).First(&Label{}).Error; err == nil {
+			log.Debugf("label: synonym %s is already a label name (merge)", clean.Log(s.SynonymName))
+			return nil
+		} else if !gorm.IsRecordNotFoundError(err) {
+			return err
+		} else if err = tx.Where("synonym_slug = ?", s.SynonymSlug).First(&LabelSynonym{}).Error; err == nil {
+			log.Debugf("label: synonym %s is already in use (merge)", clean.Log(s.SynonymName))
+			return nil
+		} else if !gorm.IsRecordNotFoundError(err) {
+			return err
+		}
+
+		return tx.Create(s).Error
+	})
+}
@@ -0,0 +1,146 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/classify"
+)
+
+func TestLabel_AddParent(t *testing.T) {
+	animal := FirstOrCreateLabel(NewLabel("Tree Test Animal", 0))
+	bird := FirstOrCreateLabel(NewLabel("Tree Test Bird", 0))
+	robin := FirstOrCreateLabel(NewLabel("Tree Test Robin", 0))
+
+	t.Run("Success", func(t *testing.T) {
+		if err := bird.AddParent(animal); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := robin.AddParent(bird); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, robin.Parents(), 1)
+		assert.Len(t, animal.Children(), 1)
+
+		ids := LabelDescendantIDs([]uint{animal.ID})
+
+		assert.ElementsMatch(t, []uint{animal.ID, bird.ID, robin.ID}, ids)
+	})
+	t.Run("Cycle", func(t *testing.T) {
+		assert.Error(t, animal.AddParent(robin))
+		assert.Error(t, animal.AddParent(animal))
+	})
+	t.Run("Remove", func(t *testing.T) {
+		if err := robin.RemoveParent(bird); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, robin.Parents(), 0)
+		assert.ElementsMatch(t, []uint{animal.ID, bird.ID}, LabelDescendantIDs([]uint{animal.ID}))
+	})
+	t.Run("NoID", func(t *testing.T) {
+		label := Label{}
+		assert.Error(t, label.AddParent(animal))
+		assert.Error(t, label.RemoveParent(animal))
+	})
+}
+
+func TestLabelDescendantIDs(t *testing.T) {
+	landscape := LabelFixtures.Get("landscape")
+	flower := LabelFixtures.Get("flower")
+
+	ids := LabelDescendantIDs([]uint{landscape.ID, landscape.ID})
+
+	assert.Equal(t, landscape.ID, ids[0])
+	assert.Contains(t, ids, flower.ID)
+	assert.Len(t, LabelDescendantIDs(nil), 0)
+}
+
+func TestLabel_Merge(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		photo := PhotoFixtures.Get("19800101_000002_D640C559")
+		photo.AddLabels(classify.Labels{
+			{Name: "Merge Test Kitten", Uncertainty: 20, Source: SrcImage, Priority: 0},
+			{Name: "Merge Test Cat", Uncertainty: 60, Source: SrcImage, Priority: 0},
+		})
+
+		cat := FindLabel("Merge Test Cat")
+		kitten := FindLabel("Merge Test Kitten")
+
+		if cat == nil || kitten == nil {
+			t.Fatal("labels should not be nil")
+		}
+
+		if err := cat.Merge(kitten); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindLabel("Merge Test Kitten"))
+
+		if l := FindSynonymLabel("Merge Test Kitten"); l == nil {
+			t.Fatal("synonym label should not be nil")
+		} else {
+			assert.Equal(t, cat.ID, l.ID)
+		}
+
+		photoLabel := PhotoLabel{}
+
+		if err := Db().Where("photo_id = ? AND label_id = ?", photo.ID, cat.ID).First(&photoLabel).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 20, photoLabel.Uncertainty)
+
+		var count int
+		Db().Model(&PhotoLabel{}).Where("label_id = ?", kitten.ID).Count(&count)
+		assert.Equal(t, 0, count)
+
+		// Classification results using the merged label name are mapped to the remaining label.
+		photo.AddLabels(classify.Labels{{Name: "Merge Test Kitten", Uncertainty: 10, Source: SrcImage, Priority: 0}})
+		Db().Model(&PhotoLabel{}).Where("label_id = ?", kitten.ID).Count(&count)
+		assert.Equal(t, 0, count)
+	})
+	t.Run("Rejected", func(t *testing.T) {
+		photo := PhotoFixtures.Get("19800101_000002_D640C559")
+		photo.AddLabels(classify.Labels{
+			{Name: "Merge Test Puppy", Uncertainty: 10, Source: SrcImage, Priority: 0},
+			{Name: "Merge Test Dog", Uncertainty: 50, Source: SrcImage, Priority: 0},
+		})
+
+		dog := FindLabel("Merge Test Dog")
+		puppy := FindLabel("Merge Test Puppy")
+
+		if dog == nil || puppy == nil {
+			t.Fatal("labels should not be nil")
+		}
+
+		// Reject the remaining label.
+		if err := Db().Model(&PhotoLabel{}).Where("photo_id = ? AND label_id = ?", photo.ID, dog.ID).
+			UpdateColumn("uncertainty", 100).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if err := dog.Merge(puppy); err != nil {
+			t.Fatal(err)
+		}
+
+		photoLabel := PhotoLabel{}
+
+		if err := Db().Where("photo_id = ? AND label_id = ?", photo.ID, dog.ID).First(&photoLabel).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 100, photoLabel.Uncertainty)
+	})
+	t.Run("Itself", func(t *testing.T) {
+		label := LabelFixtures.Get("flower")
+		assert.Error(t, label.Merge(&label))
+	})
+	t.Run("NoID", func(t *testing.T) {
+		label := LabelFixtures.Get("flower")
+		assert.Error(t, label.Merge(&Label{}))
+	})
+}
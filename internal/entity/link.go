@@ -59,7 +59,10 @@ func (m *Link) BeforeCreate(scope *gorm.Scope) error {
 
 // NewLink creates a sharing link.
 func NewLink(shareUid string, canComment, canEdit bool) Link {
-	return NewUserLink(shareUid, OwnerUnknown)
+	result := NewUserLink(shareUid, OwnerUnknown)
+	result.SetPerm(canComment, canEdit)
+
+	return result
 }
 
 // NewUserLink creates a sharing link owned by a user.
@@ -78,6 +81,29 @@ func NewUserLink(shareUid, userUid string) Link {
 	return result
 }
 
+// SetPerm sets the permissions granted to link visitors in addition to viewing the shared content.
+func (m *Link) SetPerm(canComment, canEdit bool) {
+	m.Perm = PermDefault
+
+	if canComment {
+		m.Perm |= PermComment
+	}
+
+	if canEdit {
+		m.Perm |= PermEdit
+	}
+}
+
+// CanComment checks if link visitors may comment on the shared content.
+func (m *Link) CanComment() bool {
+	return HasPerm(m.Perm, PermComment)
+}
+
+// CanEdit checks if link visitors may edit the shared content.
+func (m *Link) CanEdit() bool {
+	return HasPerm(m.Perm, PermEdit)
+}
+
 // Redeem increases the number of link visitors by one.
 func (m *Link) Redeem() *Link {
 	m.LinkViews += 1
@@ -159,7 +185,16 @@ func (m *Link) Save() error {
 
 	m.ModifiedAt = TimeStamp()
 
-	return Db().Save(m).Error
+	if err := Db().Save(m).Error; err != nil {
+		return err
+	}
+
+	// Update permissions of related user shares.
+	if err := UnscopedDb().Model(UserShare{}).Where("link_uid = ?", m.LinkUID).UpdateColumn("perm", m.Perm).Error; err != nil {
+		event.AuditErr([]string{"link %s", "failed to update related user shares", "%s"}, clean.Log(m.RefID), err)
+	}
+
+	return nil
 }
 
 // Delete permanently deletes the link.
@@ -16,6 +16,7 @@ var LinkFixtures = LinkMap{
 		LinkViews:   12,
 		MaxViews:    0,
 		HasPassword: false,
+		Perm:        PermComment,
 		CreatedAt:   time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
 		ModifiedAt:  time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
 	},
@@ -12,6 +12,22 @@ func TestNewLink(t *testing.T) {
 	assert.Equal(t, "st9lxuqxpogaaba1", link.ShareUID)
 	assert.Equal(t, 10, len(link.LinkToken))
 	assert.Equal(t, 16, len(link.LinkUID))
+	assert.True(t, link.CanComment())
+	assert.False(t, link.CanEdit())
+}
+
+func TestLink_SetPerm(t *testing.T) {
+	link := NewLink("st9lxuqxpogaaba1", false, false)
+	assert.Equal(t, PermDefault, link.Perm)
+	assert.False(t, link.CanComment())
+
+	link.SetPerm(true, true)
+	assert.True(t, link.CanComment())
+	assert.True(t, link.CanEdit())
+
+	link.SetPerm(false, true)
+	assert.False(t, link.CanComment())
+	assert.True(t, link.CanEdit())
 }
 
 func TestLink_Expired(t *testing.T) {
@@ -20,6 +20,7 @@ import (
 const (
 	MarkerUnknown = ""
 	MarkerFace    = "face"  // MarkerType for faces (implemented).
+	MarkerPet     = "pet"   // MarkerType for pets and other animals.
 	MarkerLabel   = "label" // MarkerType for labels (todo).
 )
 
@@ -313,8 +314,8 @@ func (m *Marker) SetFace(f *Face, dist float64) (updated bool, err error) {
 
 // SyncSubject maintains the marker subject relationship.
 func (m *Marker) SyncSubject(updateRelated bool) (err error) {
-	// Face marker? If not, return.
-	if m.MarkerType != MarkerFace {
+	// Face or pet marker? If not, return.
+	if m.MarkerType != MarkerFace && m.MarkerType != MarkerPet {
 		return nil
 	}
 
@@ -336,6 +337,11 @@ func (m *Marker) SyncSubject(updateRelated bool) (err error) {
 		m.MarkerName = subj.SubjName
 	}
 
+	// Pets are matched based on marker embeddings only.
+	if m.MarkerType == MarkerPet {
+		return nil
+	}
+
 	// Create known face for subject?
 	if m.FaceID != "" {
 		// Do nothing.
@@ -367,7 +373,7 @@ func (m *Marker) SyncSubject(updateRelated bool) (err error) {
 
 // InvalidArea tests if the marker area is invalid or out of range.
 func (m *Marker) InvalidArea() error {
-	if m.MarkerType != MarkerFace {
+	if m.MarkerType != MarkerFace && m.MarkerType != MarkerPet {
 		return nil
 	}
 
@@ -401,6 +407,25 @@ func (m *Marker) Create() error {
 	return Db().Create(m).Error
 }
 
+// Reject marks a detected face as invalid, so that the area is not detected again when the
+// file is re-indexed. Markers that were added manually are deleted instead.
+func (m *Marker) Reject() (deleted bool, err error) {
+	if m.MarkerUID == "" {
+		return false, fmt.Errorf("marker uid is empty")
+	}
+
+	UpdateFaces.Store(true)
+
+	if m.MarkerSrc == SrcManual {
+		return true, UnscopedDb().Delete(m, "marker_uid = ?", m.MarkerUID).Error
+	}
+
+	m.MarkerInvalid = true
+	m.MarkerReview = false
+
+	return false, m.Updates(Values{"MarkerInvalid": true, "MarkerReview": false})
+}
+
 // Embeddings returns parsed marker embeddings.
 func (m *Marker) Embeddings() face.Embeddings {
 	if len(m.EmbeddingsJSON) == 0 {
@@ -425,6 +450,15 @@ func (m *Marker) SubjectName() string {
 	return ""
 }
 
+// SubjType returns the type of subject the marker can be assigned to.
+func (m *Marker) SubjType() string {
+	if m.MarkerType == MarkerPet {
+		return SubjPet
+	}
+
+	return SubjPerson
+}
+
 // Subject returns the matching subject or nil.
 func (m *Marker) Subject() (subj *Subject) {
 	if m.subject != nil {
@@ -435,7 +469,7 @@ func (m *Marker) Subject() (subj *Subject) {
 
 	// Create subject?
 	if m.SubjSrc != SrcAuto && m.MarkerName != "" && m.SubjUID == "" {
-		if subj = NewSubject(m.MarkerName, SubjPerson, m.SubjSrc); subj == nil {
+		if subj = NewSubject(m.MarkerName, m.SubjType(), m.SubjSrc); subj == nil {
 			log.Errorf("faces: marker %s has invalid subject %s", clean.Log(m.MarkerUID), clean.Log(m.MarkerName))
 			return nil
 		} else if subj = FirstOrCreateSubject(subj); subj == nil {
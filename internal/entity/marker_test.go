@@ -662,3 +662,84 @@ func TestMarker_OverlapPercent(t *testing.T) {
 	assert.Equal(t, 0, m1.OverlapPercent(m3))
 	assert.Equal(t, 96, m1.OverlapPercent(m4))
 }
+
+func TestMarker_Reject(t *testing.T) {
+	t.Run("Detected", func(t *testing.T) {
+		m := NewMarker(FileFixtures.Get("exampleFileName.jpg"), testArea, "", SrcImage, MarkerFace, 100, 50)
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		deleted, err := m.Reject()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, deleted)
+
+		if found := FindMarker(m.MarkerUID); found == nil {
+			t.Fatal("marker should exist")
+		} else {
+			assert.True(t, found.MarkerInvalid)
+			assert.False(t, found.MarkerReview)
+		}
+	})
+	t.Run("Manual", func(t *testing.T) {
+		m := NewMarker(FileFixtures.Get("exampleFileName.jpg"), testArea, "", SrcManual, MarkerFace, 100, 50)
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		deleted, err := m.Reject()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, deleted)
+		assert.Nil(t, FindMarker(m.MarkerUID))
+	})
+	t.Run("EmptyUID", func(t *testing.T) {
+		m := Marker{}
+		_, err := m.Reject()
+		assert.Error(t, err)
+	})
+}
+
+func TestMarker_SubjType(t *testing.T) {
+	t.Run("Face", func(t *testing.T) {
+		m := Marker{MarkerType: MarkerFace}
+		assert.Equal(t, SubjPerson, m.SubjType())
+	})
+	t.Run("Pet", func(t *testing.T) {
+		m := Marker{MarkerType: MarkerPet}
+		assert.Equal(t, SubjPet, m.SubjType())
+	})
+}
+
+func TestMarker_SetName_Pet(t *testing.T) {
+	m := NewMarker(FileFixtures.Get("exampleFileName.jpg"), testArea, "", SrcManual, MarkerPet, 100, 100)
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	if changed, err := m.SetName("Fluffy Pet", SrcManual); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.True(t, changed)
+	}
+
+	subj := m.Subject()
+
+	if subj == nil {
+		t.Fatal("subject should not be nil")
+	}
+
+	assert.Equal(t, SubjPet, subj.SubjType)
+	assert.Equal(t, "Fluffy Pet", subj.SubjName)
+	assert.Empty(t, m.FaceID)
+}
@@ -69,6 +69,9 @@ type Photo struct {
 	OriginalName     string        `gorm:"type:VARBINARY(755);" json:"OriginalName" yaml:"OriginalName,omitempty"`
 	PhotoStack       int8          `json:"Stack" yaml:"Stack,omitempty"`
 	PhotoFavorite    bool          `json:"Favorite" yaml:"Favorite,omitempty"`
+	PhotoRating      int8          `gorm:"type:SMALLINT;index;" json:"Rating" yaml:"Rating,omitempty"`
+	PhotoColorLabel  string        `gorm:"type:VARBINARY(16);" json:"ColorLabel" yaml:"ColorLabel,omitempty"`
+	RatingSrc        string        `gorm:"type:VARBINARY(8);" json:"RatingSrc" yaml:"RatingSrc,omitempty"`
 	PhotoPrivate     bool          `json:"Private" yaml:"Private,omitempty"`
 	PhotoScan        bool          `json:"Scan" yaml:"Scan,omitempty"`
 	PhotoPanorama    bool          `json:"Panorama" yaml:"Panorama,omitempty"`
@@ -155,6 +158,7 @@ func NewUserPhoto(stackable bool, userUid string) Photo {
 // SavePhotoForm saves a model in the database using form data.
 func SavePhotoForm(model Photo, form form.Photo) error {
 	locChanged := model.PhotoLat != form.PhotoLat || model.PhotoLng != form.PhotoLng || model.PhotoCountry != form.PhotoCountry
+	ratingChanged := model.PhotoRating != form.PhotoRating || model.PhotoColorLabel != form.PhotoColorLabel
 
 	if err := deepcopier.Copy(&model).From(form); err != nil {
 		return err
@@ -173,14 +177,26 @@ func SavePhotoForm(model Photo, form form.Photo) error {
 
 	model.UpdateDateFields()
 
+	if ratingChanged {
+		model.SetRating(int(form.PhotoRating), SrcManual)
+		model.SetColorLabel(form.PhotoColorLabel, SrcManual)
+	}
+
 	details := model.GetDetails()
 
 	if form.Details.PhotoID == model.ID {
+		iptc := details.IptcString()
+
 		if err := deepcopier.Copy(details).From(form.Details); err != nil {
 			return err
 		}
 
 		details.Keywords = strings.Join(txt.UniqueWords(txt.Words(details.Keywords)), ", ")
+
+		// Prevent manually edited IPTC metadata from being overwritten when files are indexed.
+		if details.IptcString() != iptc {
+			details.IptcSrc = SrcManual
+		}
 	}
 
 	if locChanged && model.PlaceSrc == SrcManual {
@@ -427,23 +443,39 @@ func (m *Photo) RemoveKeyword(w string) error {
 
 // SyncKeywordLabels maintains the label / photo relationship for existing labels and keywords.
 func (m *Photo) SyncKeywordLabels() error {
+	return m.syncKeywordLabels(Db())
+}
+
+// syncKeywordLabels maintains the label / photo relationship for existing labels and keywords using the
+// specified database connection or transaction.
+func (m *Photo) syncKeywordLabels(db *gorm.DB) error {
 	details := m.GetDetails()
 	keywords := txt.UniqueKeywords(details.Keywords)
 
 	var labelIds []uint
 
 	for _, w := range keywords {
-		if label := FindLabel(w); label != nil {
-			if label.Deleted() {
-				continue
-			}
+		label := Label{}
+		slug := txt.Slug(w)
 
-			labelIds = append(labelIds, label.ID)
-			FirstOrCreatePhotoLabel(NewPhotoLabel(m.ID, label.ID, 25, classify.SrcKeyword))
+		if err := db.Where("label_slug = ? OR custom_slug = ?", slug, slug).First(&label).Error; gorm.IsRecordNotFoundError(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		labelIds = append(labelIds, label.ID)
+
+		if err := db.Where("photo_id = ? AND label_id = ?", m.ID, label.ID).First(&PhotoLabel{}).Error; err == nil {
+			continue
+		} else if !gorm.IsRecordNotFoundError(err) {
+			return err
+		} else if err = db.Create(NewPhotoLabel(m.ID, label.ID, 25, classify.SrcKeyword)).Error; err != nil {
+			return err
 		}
 	}
 
-	return Db().Where("label_src = ? AND photo_id = ? AND label_id NOT IN (?)", classify.SrcKeyword, m.ID, labelIds).Delete(&PhotoLabel{}).Error
+	return db.Where("label_src = ? AND photo_id = ? AND label_id NOT IN (?)", classify.SrcKeyword, m.ID, labelIds).Delete(&PhotoLabel{}).Error
 }
 
 // IndexKeywords adds given keywords to the photo entry
@@ -461,6 +493,10 @@ func (m *Photo) IndexKeywords() error {
 	keywords = append(keywords, txt.Words(details.Keywords)...)
 	keywords = append(keywords, txt.Keywords(details.Subject)...)
 	keywords = append(keywords, txt.Keywords(details.Artist)...)
+	keywords = append(keywords, txt.Keywords(details.PersonsShown)...)
+	keywords = append(keywords, txt.Keywords(details.Event)...)
+	keywords = append(keywords, txt.Keywords(details.CreatedSublocation)...)
+	keywords = append(keywords, txt.Keywords(details.ShownSublocation)...)
 
 	keywords = txt.UniqueWords(keywords)
 
@@ -592,15 +628,18 @@ func (m *Photo) AddLabels(labels classify.Labels) {
 			continue
 		}
 
-		if labelEntity.Deleted() {
+		if !labelEntity.Deleted() {
+			if err := labelEntity.UpdateClassify(classifyLabel); err != nil {
+				log.Errorf("index: failed updating label %s (%s)", clean.Log(classifyLabel.Title()), err)
+			}
+		} else if synonymLabel := FindSynonymLabel(labelEntity.LabelName); synonymLabel != nil {
+			// Use the label that the deleted label was merged into.
+			labelEntity = synonymLabel
+		} else {
 			log.Debugf("index: skipping deleted label %s (%s)", clean.Log(classifyLabel.Title()), m)
 			continue
 		}
 
-		if err := labelEntity.UpdateClassify(classifyLabel); err != nil {
-			log.Errorf("index: failed updating label %s (%s)", clean.Log(classifyLabel.Title()), err)
-		}
-
 		photoLabel := FirstOrCreatePhotoLabel(NewPhotoLabel(m.ID, labelEntity.ID, classifyLabel.Uncertainty, classifyLabel.Source))
 
 		if photoLabel == nil {
@@ -621,6 +660,31 @@ func (m *Photo) AddLabels(labels classify.Labels) {
 	Db().Set("gorm:auto_preload", true).Model(m).Related(&m.Labels)
 }
 
+// RemoveLabels removes labels from the specified source, except those that have been rejected by a user.
+func (m *Photo) RemoveLabels(source string) (removed int, err error) {
+	if !m.HasID() {
+		return 0, errors.New("photo: cannot remove labels, id is empty")
+	}
+
+	res := Db().Delete(PhotoLabel{}, "photo_id = ? AND label_src = ? AND uncertainty < 100", m.ID, source)
+
+	if res.Error != nil {
+		return 0, res.Error
+	}
+
+	labels := make([]PhotoLabel, 0, len(m.Labels))
+
+	for _, l := range m.Labels {
+		if l.LabelSrc != source || l.Uncertainty >= 100 {
+			labels = append(labels, l)
+		}
+	}
+
+	m.Labels = labels
+
+	return int(res.RowsAffected), nil
+}
+
 // SetDescription changes the photo description if not empty and from the same source.
 func (m *Photo) SetDescription(desc, source string) {
 	newDesc := txt.Clip(desc, txt.ClipLongText)
@@ -828,7 +892,9 @@ func (m *Photo) React(user *User, reaction react.Emoji) error {
 		return m.UnReact(user)
 	}
 
-	return NewReaction(m.PhotoUID, user.UID()).React(reaction).Save()
+	_, err := SetReaction(m.PhotoUID, user.UID(), reaction)
+
+	return err
 }
 
 // UnReact deletes a previous user reaction, if any.
@@ -837,11 +903,7 @@ func (m *Photo) UnReact(user *User) error {
 		return fmt.Errorf("unknown user")
 	}
 
-	if r := FindReaction(m.PhotoUID, user.UID()); r != nil {
-		return r.Delete()
-	}
-
-	return nil
+	return RemoveReaction(m.PhotoUID, user.UID())
 }
 
 // SetFavorite updates the favorite flag of a photo.
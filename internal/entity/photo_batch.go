@@ -0,0 +1,308 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// BatchResult represents the result of a batch edit for a single photo.
+type BatchResult struct {
+	PhotoUID string   `json:"PhotoUID"`
+	Changed  []string `json:"Changed"`
+	Error    string   `json:"Error,omitempty"`
+}
+
+// BatchEdit applies the changes requested in a batch edit form with source SrcManual. The photo must have
+// been loaded with its details and labels. Changes to the photo, its labels, and albums are made in a single
+// transaction, so that either all or none of them are applied. Only the search index is updated afterwards,
+// as it can be regenerated. Album UIDs must refer to existing albums.
+func (m *Photo) BatchEdit(f form.BatchEdit) (result BatchResult, err error) {
+	result = BatchResult{PhotoUID: m.PhotoUID, Changed: []string{}}
+
+	if !m.HasID() {
+		return result, errors.New("photo: cannot save to database, id is empty")
+	}
+
+	details := m.GetDetails()
+
+	// Change date and time.
+	if f.TakenAtLocal != nil && !f.TakenAtLocal.IsZero() {
+		t := f.TakenAtLocal
+		m.TakenAtLocal = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+		m.TakenAt = m.GetTakenAt()
+		result.Changed = append(result.Changed, "TakenAt")
+	} else if f.TakenOffset != 0 {
+		offset := time.Duration(f.TakenOffset) * time.Second
+		m.TakenAt = m.TakenAt.Add(offset)
+		m.TakenAtLocal = m.TakenAtLocal.Add(offset)
+		result.Changed = append(result.Changed, "TakenAt")
+	}
+
+	// Change time zone while keeping the local time.
+	if f.TimeZone != "" && f.TimeZone != m.TimeZone {
+		m.TimeZone = f.TimeZone
+		m.TakenAt = m.GetTakenAt()
+		result.Changed = append(result.Changed, "TimeZone")
+	}
+
+	if f.ChangesTime() {
+		m.TakenSrc = SrcManual
+		m.PhotoYear = m.TakenAtLocal.Year()
+		m.PhotoMonth = int(m.TakenAtLocal.Month())
+		m.PhotoDay = m.TakenAtLocal.Day()
+	}
+
+	// Change location.
+	var locLabels classify.Labels
+
+	if f.ChangesLocation() {
+		m.PhotoLat = *f.Lat
+		m.PhotoLng = *f.Lng
+		m.PlaceSrc = SrcManual
+
+		if f.Altitude != nil {
+			m.PhotoAltitude = *f.Altitude
+		}
+
+		var locKeywords []string
+
+		locKeywords, locLabels = m.UpdateLocation()
+
+		w := txt.UniqueWords(txt.Words(details.Keywords))
+		w = append(w, locKeywords...)
+		details.Keywords = strings.Join(txt.UniqueWords(w), ", ")
+
+		result.Changed = append(result.Changed, "Location")
+	}
+
+	// Change artist, copyright, and license.
+	if f.Artist != "" {
+		details.SetArtist(f.Artist, SrcManual)
+		result.Changed = append(result.Changed, "Artist")
+	}
+
+	if f.Copyright != "" {
+		details.SetCopyright(f.Copyright, SrcManual)
+		result.Changed = append(result.Changed, "Copyright")
+	}
+
+	if f.License != "" {
+		details.SetLicense(f.License, SrcManual)
+		result.Changed = append(result.Changed, "License")
+	}
+
+	// Add and remove keywords.
+	if len(f.AddKeywords) > 0 || len(f.RemoveKeywords) > 0 {
+		w := txt.Words(details.Keywords)
+		w = append(w, txt.Words(strings.Join(f.AddKeywords, ", "))...)
+
+		for _, s := range f.RemoveKeywords {
+			w = txt.RemoveFromWords(w, s)
+		}
+
+		details.Keywords = strings.Join(txt.UniqueWords(w), ", ")
+		details.KeywordsSrc = SrcManual
+
+		result.Changed = append(result.Changed, "Keywords")
+	}
+
+	// Remove keywords of labels to remove.
+	for _, name := range f.RemoveLabels {
+		if err = m.RemoveKeyword(name); err != nil {
+			return result, err
+		}
+	}
+
+	if len(f.AddLabels) > 0 || len(f.RemoveLabels) > 0 {
+		result.Changed = append(result.Changed, "Labels")
+	}
+
+	if len(f.AddAlbums) > 0 {
+		result.Changed = append(result.Changed, "Albums")
+	}
+
+	editedAt := TimeStamp()
+	m.EditedAt = &editedAt
+
+	// Labels created in the transaction.
+	var created []*Label
+
+	// Save changes in a single transaction.
+	err = Db().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(m).UpdateColumns(Values{
+			"TakenAt":       m.TakenAt,
+			"TakenAtLocal":  m.TakenAtLocal,
+			"TakenSrc":      m.TakenSrc,
+			"TimeZone":      m.TimeZone,
+			"PhotoYear":     m.PhotoYear,
+			"PhotoMonth":    m.PhotoMonth,
+			"PhotoDay":      m.PhotoDay,
+			"PhotoLat":      m.PhotoLat,
+			"PhotoLng":      m.PhotoLng,
+			"PhotoAltitude": m.PhotoAltitude,
+			"PhotoCountry":  m.PhotoCountry,
+			"PlaceSrc":      m.PlaceSrc,
+			"CellID":        m.CellID,
+			"PlaceID":       m.PlaceID,
+			"EditedAt":      m.EditedAt,
+		}).Error; err != nil {
+			return err
+		}
+
+		if f.ChangesDetails() || f.ChangesLocation() || len(f.RemoveLabels) > 0 {
+			details.PhotoID = m.ID
+
+			if err := tx.Save(details).Error; err != nil {
+				return err
+			}
+		}
+
+		// Add labels, restoring them if they were deleted.
+		for _, name := range f.AddLabels {
+			label, isNew, err := batchLabel(tx, name, 0)
+
+			if err != nil {
+				return fmt.Errorf("photo: %s (add label %s)", err, clean.Log(name))
+			} else if isNew {
+				created = append(created, label)
+			} else if label.Deleted() {
+				if err := tx.Unscoped().Model(label).UpdateColumn("deleted_at", nil).Error; err != nil {
+					return err
+				}
+			}
+
+			if err := batchPhotoLabel(tx, NewPhotoLabel(m.ID, label.ID, 0, SrcManual), true); err != nil {
+				return err
+			}
+		}
+
+		// Add location labels, except deleted labels.
+		for _, l := range locLabels {
+			label, isNew, err := batchLabel(tx, l.Title(), l.Priority)
+
+			if err != nil {
+				return fmt.Errorf("photo: %s (add label %s)", err, clean.Log(l.Title()))
+			} else if isNew {
+				created = append(created, label)
+			} else if label.Deleted() {
+				// Use the label that the deleted label was merged into, if any.
+				if label = FindSynonymLabel(label.LabelName); label == nil {
+					continue
+				}
+			}
+
+			if err := batchPhotoLabel(tx, NewPhotoLabel(m.ID, label.ID, l.Uncertainty, l.Source), false); err != nil {
+				return err
+			}
+		}
+
+		// Reject labels to remove, unless they were added manually or from keywords.
+		for _, name := range f.RemoveLabels {
+			label := &Label{}
+
+			if slug := txt.Slug(name); tx.Where("label_slug = ? OR custom_slug = ?", slug, slug).First(label).Error != nil {
+				continue
+			} else if err := tx.Where("photo_id = ? AND label_id = ? AND label_src IN (?)", m.ID, label.ID, []string{SrcManual, classify.SrcKeyword}).
+				Delete(&PhotoLabel{}).Error; err != nil {
+				return err
+			} else if err := tx.Model(&PhotoLabel{}).Where("photo_id = ? AND label_id = ?", m.ID, label.ID).
+				UpdateColumn("uncertainty", 100).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, albumUid := range f.AddAlbums {
+			if err := tx.Save(&PhotoAlbum{AlbumUID: albumUid, PhotoUID: m.PhotoUID, Hidden: false}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := m.syncKeywordLabels(tx); err != nil {
+			return err
+		}
+
+		// Update title and quality based on the new labels.
+		if err := tx.Where("photo_id = ? AND uncertainty < 100", m.ID).Preload("Label").Find(&m.Labels).Error; err != nil {
+			return err
+		}
+
+		if err := m.UpdateTitle(m.ClassifyLabels()); err != nil {
+			log.Info(err)
+		}
+
+		m.PhotoQuality = m.QualityScore()
+
+		return tx.Unscoped().Model(m).UpdateColumns(Values{
+			"PhotoTitle":       m.PhotoTitle,
+			"TitleSrc":         m.TitleSrc,
+			"PhotoDescription": m.PhotoDescription,
+			"DescriptionSrc":   m.DescriptionSrc,
+			"PhotoQuality":     m.PhotoQuality,
+		}).Error
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	if len(created) > 0 {
+		event.EntitiesCreated("labels", created)
+
+		event.Publish("count.labels", event.Data{
+			"count": len(created),
+		})
+	}
+
+	// Update search index.
+	if f.ChangesTime() {
+		File{PhotoID: m.ID}.RegenerateIndex()
+	}
+
+	if err = m.IndexKeywords(); err != nil {
+		log.Errorf("photo: %s (index keywords)", err)
+	}
+
+	return result, nil
+}
+
+// batchLabel finds or creates a label in a transaction. Deleted labels are returned as well.
+func batchLabel(tx *gorm.DB, name string, priority int) (label *Label, isNew bool, err error) {
+	label = NewLabel(name, priority)
+	result := &Label{}
+
+	if err = tx.Unscoped().Where("label_slug = ? OR custom_slug = ?", label.LabelSlug, label.CustomSlug).First(result).Error; err == nil {
+		return result, false, nil
+	} else if !gorm.IsRecordNotFoundError(err) {
+		return nil, false, err
+	} else if err = tx.Create(label).Error; err != nil {
+		return nil, false, err
+	}
+
+	return label, true, nil
+}
+
+// batchPhotoLabel adds a label to a photo in a transaction. The uncertainty and source of an existing label
+// are replaced if update is true, or the new uncertainty is lower and the label was not rejected.
+func batchPhotoLabel(tx *gorm.DB, m *PhotoLabel, update bool) error {
+	var existing PhotoLabel
+
+	if err := tx.Where("photo_id = ? AND label_id = ?", m.PhotoID, m.LabelID).First(&existing).Error; gorm.IsRecordNotFoundError(err) {
+		return tx.Create(m).Error
+	} else if err != nil {
+		return err
+	} else if !update && (existing.Uncertainty <= m.Uncertainty || existing.Uncertainty >= 100) {
+		return nil
+	}
+
+	return tx.Model(&existing).UpdateColumns(Values{"Uncertainty": m.Uncertainty, "LabelSrc": m.LabelSrc}).Error
+}
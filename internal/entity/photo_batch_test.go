@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhoto_BatchEdit(t *testing.T) {
+	t.Run("Offset", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo19")
+		takenAt := m.TakenAt
+
+		result, err := m.BatchEdit(form.BatchEdit{TakenOffset: 3600, Artist: "Batch Artist", AddKeywords: []string{"batch"}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.PhotoUID, result.PhotoUID)
+		assert.Equal(t, []string{"TakenAt", "Artist", "Keywords"}, result.Changed)
+		assert.Equal(t, takenAt.Add(time.Hour), m.TakenAt)
+		assert.Equal(t, SrcManual, m.TakenSrc)
+
+		found := FindPhoto(Photo{PhotoUID: m.PhotoUID})
+
+		if found == nil {
+			t.Fatal("photo not found")
+		}
+
+		assert.Equal(t, takenAt.Add(time.Hour).UTC(), found.TakenAt.UTC())
+		assert.Equal(t, "Batch Artist", found.GetDetails().Artist)
+		assert.Contains(t, found.GetDetails().Keywords, "batch")
+	})
+	t.Run("TimeZone", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo08")
+		takenAtLocal := m.TakenAtLocal
+
+		if _, err := m.BatchEdit(form.BatchEdit{TimeZone: "Europe/Berlin"}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Europe/Berlin", m.TimeZone)
+		assert.Equal(t, takenAtLocal, m.TakenAtLocal)
+		assert.Equal(t, m.GetTakenAt(), m.TakenAt)
+	})
+	t.Run("Labels", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo19")
+
+		result, err := m.BatchEdit(form.BatchEdit{AddLabels: []string{"Batch Label"}})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"Labels"}, result.Changed)
+
+		label := FindLabel("Batch Label")
+
+		if label == nil {
+			t.Fatal("label not found")
+		}
+
+		var photoLabel PhotoLabel
+
+		if err = Db().Where("photo_id = ? AND label_id = ?", m.ID, label.ID).First(&photoLabel).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, photoLabel.Uncertainty)
+		assert.Equal(t, SrcManual, photoLabel.LabelSrc)
+
+		if _, err = m.BatchEdit(form.BatchEdit{RemoveLabels: []string{"Batch Label"}}); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, Db().Where("photo_id = ? AND label_id = ?", m.ID, label.ID).First(&PhotoLabel{}).RecordNotFound())
+	})
+	t.Run("NoID", func(t *testing.T) {
+		m := Photo{}
+
+		_, err := m.BatchEdit(form.BatchEdit{TakenOffset: 60})
+
+		assert.Error(t, err)
+	})
+}
@@ -111,6 +111,9 @@ var PhotoFixtures = PhotoMap{
 		PhotoName:        "Photo01",
 		OriginalName:     "",
 		PhotoFavorite:    true,
+		PhotoRating:      4,
+		PhotoColorLabel:  "red",
+		RatingSrc:        "xmp",
 		PhotoPrivate:     false,
 		PhotoScan:        false,
 		PhotoPanorama:    false,
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"github.com/photoprism/photoprism/internal/meta"
+)
+
+// RatingMax is the highest star rating a photo can have.
+const RatingMax = 5
+
+// SetRating changes the star rating if the source has the same or a higher priority.
+func (m *Photo) SetRating(rating int, source string) {
+	if rating <= 0 && source != SrcManual {
+		return
+	} else if SrcPriority[source] < SrcPriority[m.RatingSrc] && m.HasRating() {
+		return
+	}
+
+	if rating < 0 {
+		rating = 0
+	} else if rating > RatingMax {
+		rating = RatingMax
+	}
+
+	m.PhotoRating = int8(rating)
+	m.RatingSrc = source
+}
+
+// SetColorLabel changes the color label if the source has the same or a higher priority.
+func (m *Photo) SetColorLabel(label, source string) {
+	label = meta.SanitizeColorLabel(label)
+
+	if label == "" && source != SrcManual {
+		return
+	} else if SrcPriority[source] < SrcPriority[m.RatingSrc] && m.HasRating() {
+		return
+	}
+
+	m.PhotoColorLabel = label
+	m.RatingSrc = source
+}
+
+// HasRating tests if the photo has a star rating or a color label.
+func (m *Photo) HasRating() bool {
+	return m.PhotoRating > 0 || m.PhotoColorLabel != ""
+}
+
+// Rate updates the star rating and color label of a photo in the database.
+func (m *Photo) Rate(rating int, label string) error {
+	m.SetRating(rating, SrcManual)
+	m.SetColorLabel(label, SrcManual)
+
+	return m.Updates(Values{"PhotoRating": m.PhotoRating, "PhotoColorLabel": m.PhotoColorLabel, "RatingSrc": m.RatingSrc})
+}
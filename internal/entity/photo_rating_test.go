@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_SetRating(t *testing.T) {
+	t.Run("Meta", func(t *testing.T) {
+		m := Photo{}
+
+		m.SetRating(3, SrcMeta)
+		assert.Equal(t, int8(3), m.PhotoRating)
+		assert.Equal(t, SrcMeta, m.RatingSrc)
+
+		m.SetRating(9, SrcXmp)
+		assert.Equal(t, int8(RatingMax), m.PhotoRating)
+		assert.Equal(t, SrcXmp, m.RatingSrc)
+
+		m.SetRating(0, SrcXmp)
+		assert.Equal(t, int8(RatingMax), m.PhotoRating)
+	})
+	t.Run("LowerPriority", func(t *testing.T) {
+		m := Photo{PhotoRating: 2, RatingSrc: SrcManual}
+
+		m.SetRating(5, SrcXmp)
+		assert.Equal(t, int8(2), m.PhotoRating)
+		assert.Equal(t, SrcManual, m.RatingSrc)
+	})
+	t.Run("Reset", func(t *testing.T) {
+		m := Photo{PhotoRating: 2, RatingSrc: SrcXmp}
+
+		m.SetRating(-1, SrcManual)
+		assert.Equal(t, int8(0), m.PhotoRating)
+		assert.Equal(t, SrcManual, m.RatingSrc)
+	})
+}
+
+func TestPhoto_SetColorLabel(t *testing.T) {
+	t.Run("Xmp", func(t *testing.T) {
+		m := Photo{}
+
+		m.SetColorLabel("Green", SrcXmp)
+		assert.Equal(t, "green", m.PhotoColorLabel)
+		assert.True(t, m.HasRating())
+
+		m.SetColorLabel("Unknown", SrcXmp)
+		assert.Equal(t, "green", m.PhotoColorLabel)
+	})
+	t.Run("LowerPriority", func(t *testing.T) {
+		m := Photo{PhotoColorLabel: "red", RatingSrc: SrcManual}
+
+		m.SetColorLabel("blue", SrcMeta)
+		assert.Equal(t, "red", m.PhotoColorLabel)
+	})
+	t.Run("Remove", func(t *testing.T) {
+		m := Photo{PhotoColorLabel: "red", RatingSrc: SrcXmp}
+
+		m.SetColorLabel("", SrcManual)
+		assert.Equal(t, "", m.PhotoColorLabel)
+		assert.False(t, m.HasRating())
+	})
+}
+
+func TestPhoto_Rate(t *testing.T) {
+	m := PhotoFixtures.Get("Photo02")
+
+	if err := m.Rate(5, "Blue"); err != nil {
+		t.Fatal(err)
+	}
+
+	found := FindPhoto(m)
+
+	if found == nil {
+		t.Fatal("photo not found")
+	}
+
+	assert.Equal(t, int8(5), found.PhotoRating)
+	assert.Equal(t, "blue", found.PhotoColorLabel)
+	assert.Equal(t, SrcManual, found.RatingSrc)
+}
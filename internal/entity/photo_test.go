@@ -253,6 +253,34 @@ func TestPhoto_AddLabels(t *testing.T) {
 	})
 }
 
+func TestPhoto_RemoveLabels(t *testing.T) {
+	t.Run("Image", func(t *testing.T) {
+		m := PhotoFixtures.Get("19800101_000002_D640C559")
+		m.AddLabels(classify.Labels{{Name: "tulip", Uncertainty: 40, Source: SrcImage, Priority: 1}})
+		len1 := len(m.Labels)
+		removed, err := m.RemoveLabels(SrcImage)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, removed, 1)
+		assert.Less(t, len(m.Labels), len1)
+
+		for _, l := range m.Labels {
+			if l.LabelSrc == SrcImage {
+				assert.GreaterOrEqual(t, l.Uncertainty, 100)
+			}
+		}
+	})
+	t.Run("NoID", func(t *testing.T) {
+		m := Photo{}
+		removed, err := m.RemoveLabels(SrcImage)
+		assert.Error(t, err)
+		assert.Equal(t, 0, removed)
+	})
+}
+
 func TestPhoto_SetDescription(t *testing.T) {
 	t.Run("empty description", func(t *testing.T) {
 		m := PhotoFixtures.Get("Photo15")
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// MetaData returns the photo metadata with IPTC fields, e.g. for creating XMP sidecar files.
+func (m *Photo) MetaData() meta.Data {
+	details := m.GetDetails()
+	data := details.IptcData()
+
+	data.DocumentID = m.UUID
+	data.Title = m.PhotoTitle
+	data.Description = m.PhotoDescription
+	data.Keywords = txt.Words(details.Keywords)
+	data.Notes = details.Notes
+	data.Subject = details.Subject
+	data.Artist = details.Artist
+	data.Copyright = details.Copyright
+	data.License = details.License
+
+	if m.TakenSrc != SrcAuto {
+		data.TakenAt = m.TakenAt
+		data.TakenAtLocal = m.TakenAtLocal
+		data.TimeZone = m.TimeZone
+	}
+
+	if m.PhotoRating > 0 {
+		data.Rating = int(m.PhotoRating)
+	}
+
+	return data
+}
+
+// Xmp returns the photo metadata as XMP packet, which can be saved as sidecar file or embedded in JPEG images.
+func (m *Photo) Xmp() []byte {
+	return m.MetaData().XmpPacket()
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoto_MetaData(t *testing.T) {
+	m := PhotoFixtures.Get("Photo03")
+	data := m.MetaData()
+
+	assert.Equal(t, "Jens Mander", data.Artist)
+	assert.Equal(t, "Copyright 2020", data.Copyright)
+	assert.Equal(t, "n/a", data.License)
+	assert.Equal(t, "Jens Mander / PhotoPrism", data.CreditLine)
+	assert.Equal(t, "Bridge Opening", data.Event)
+	assert.Equal(t, "Heidelberg", data.ShownCity)
+	assert.Equal(t, "1990-04-18 01:00:00 +0000 UTC", data.TakenAtLocal.String())
+}
+
+func TestPhoto_Xmp(t *testing.T) {
+	m := PhotoFixtures.Get("Photo03")
+	result := string(m.Xmp())
+
+	assert.Contains(t, result, "<rdf:li>Jens Mander</rdf:li>")
+	assert.Contains(t, result, "<photoshop:Credit>Jens Mander / PhotoPrism</photoshop:Credit>")
+	assert.Contains(t, result, "<rdf:li xml:lang=\"x-default\">Bridge Opening</rdf:li>")
+	assert.Contains(t, result, "<Iptc4xmpExt:City>Heidelberg</Iptc4xmpExt:City>")
+}
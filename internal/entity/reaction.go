@@ -18,6 +18,9 @@ type Reaction struct {
 	ReactedAt *time.Time `sql:"index" json:"ReactedAt,omitempty" yaml:"ReactedAt,omitempty"`
 }
 
+// Reactions represents a list of reactions.
+type Reactions []Reaction
+
 // TableName returns the entity table name.
 func (Reaction) TableName() string {
 	return "reactions"
@@ -46,6 +49,30 @@ func FindReaction(uid, userUid string) (m *Reaction) {
 	return m
 }
 
+// SetReaction adds or changes the reaction of a user or link visitor, who can only have one reaction per entity.
+func SetReaction(uid, userUid string, emo react.Emoji) (*Reaction, error) {
+	if emo.Unknown() {
+		return nil, fmt.Errorf("unknown reaction")
+	}
+
+	if m := FindReaction(uid, userUid); m != nil {
+		return m, m.React(emo).Save()
+	}
+
+	m := NewReaction(uid, userUid).React(emo)
+
+	return m, m.Create()
+}
+
+// RemoveReaction deletes the reaction of a user or link visitor, if any.
+func RemoveReaction(uid, userUid string) error {
+	if m := FindReaction(uid, userUid); m != nil {
+		return m.Delete()
+	}
+
+	return nil
+}
+
 // React adds a react.Emoji reaction.
 func (m *Reaction) React(emo react.Emoji) *Reaction {
 	m.Reaction = emo.String()
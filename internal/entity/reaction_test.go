@@ -41,3 +41,46 @@ func TestFindReaction(t *testing.T) {
 		}
 	})
 }
+
+func TestSetReaction(t *testing.T) {
+	t.Run("ChangeAndRemove", func(t *testing.T) {
+		uid := PhotoFixtures.Get("Photo02").PhotoUID
+		userUid := UserFixtures.Pointer("bob").UserUID
+
+		if _, err := SetReaction(uid, userUid, react.Party); err != nil {
+			t.Fatal(err)
+		}
+
+		if m, err := SetReaction(uid, userUid, react.Sparkles); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Equal(t, react.Sparkles, m.Emoji())
+		}
+
+		if m := FindReaction(uid, userUid); m == nil {
+			t.Fatal("result must not be nil")
+		} else {
+			assert.Equal(t, react.Sparkles, m.Emoji())
+		}
+
+		if err := RemoveReaction(uid, userUid); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindReaction(uid, userUid))
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := SetReaction(PhotoFixtures.Get("Photo02").PhotoUID, UserFixtures.Pointer("bob").UserUID, react.Unknown)
+		assert.Error(t, err)
+	})
+	t.Run("Visitor", func(t *testing.T) {
+		uid := AlbumFixtures.Get("holiday-2030").AlbumUID
+
+		if _, err := SetReaction(uid, "sessxkkcabcd0001", react.Cheers); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotNil(t, FindReaction(uid, "sessxkkcabcd0001"))
+		assert.NoError(t, RemoveReaction(uid, "sessxkkcabcd0001"))
+	})
+}
@@ -17,6 +17,7 @@ const (
 	SrcImage    = classify.SrcImage    // Prio 8
 	SrcKeyword  = classify.SrcKeyword  // Prio 16
 	SrcMeta     = "meta"               // Prio 16
+	SrcRule     = "rule"               // Prio 16
 	SrcXmp      = "xmp"                // Prio 32
 	SrcManual   = "manual"             // Prio 64
 	SrcAdmin    = "admin"              // Prio 128
@@ -44,6 +45,7 @@ var SrcPriority = Priorities{
 	SrcImage:    8,
 	SrcKeyword:  16,
 	SrcMeta:     16,
+	SrcRule:     16,
 	SrcXmp:      32,
 	SrcManual:   64,
 	SrcAdmin:    128,
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubject_IsPet(t *testing.T) {
+	t.Run("Pet", func(t *testing.T) {
+		m := NewSubject("Bello", SubjPet, SrcManual)
+		assert.True(t, m.IsPet())
+	})
+	t.Run("Person", func(t *testing.T) {
+		m := NewSubject("Jens Mander", "", SrcManual)
+		assert.False(t, m.IsPet())
+	})
+}
@@ -0,0 +1,224 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// TimeRule represents a rule for correcting the time of photos taken with a camera whose clock
+// was set incorrectly, e.g. because the time zone was not changed when traveling.
+type TimeRule struct {
+	ID           uint       `gorm:"primary_key" json:"ID" yaml:"ID"`
+	CameraMake   string     `gorm:"type:VARCHAR(160);" json:"CameraMake" yaml:"CameraMake,omitempty"`
+	CameraModel  string     `gorm:"type:VARCHAR(160);" json:"CameraModel" yaml:"CameraModel,omitempty"`
+	CameraSerial string     `gorm:"type:VARBINARY(160);" json:"CameraSerial" yaml:"CameraSerial,omitempty"`
+	StartAt      *time.Time `json:"StartAt" yaml:"StartAt,omitempty"`
+	EndAt        *time.Time `json:"EndAt" yaml:"EndAt,omitempty"`
+	TimeOffset   int64      `json:"TimeOffset" yaml:"TimeOffset,omitempty"`
+	TimeZone     string     `gorm:"type:VARBINARY(64);" json:"TimeZone" yaml:"TimeZone,omitempty"`
+	RuleNote     string     `gorm:"type:VARCHAR(255);" json:"Note" yaml:"Note,omitempty"`
+	CreatedAt    time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt    time.Time  `json:"UpdatedAt" yaml:"-"`
+}
+
+// TimeRules represents a list of time correction rules.
+type TimeRules []TimeRule
+
+// TableName returns the entity table name.
+func (TimeRule) TableName() string {
+	return "time_rules"
+}
+
+// FindTimeRules returns all time correction rules ordered by ID.
+func FindTimeRules() (result TimeRules, err error) {
+	err = Db().Order("id").Find(&result).Error
+	return result, err
+}
+
+// FindTimeRule returns the time correction rule with the specified ID or nil if it was not found.
+func FindTimeRule(id uint) *TimeRule {
+	m := &TimeRule{}
+
+	if Db().First(m, "id = ?", id).Error != nil {
+		return nil
+	}
+
+	return m
+}
+
+// Validate returns an error if the rule would not change any photos or is otherwise invalid.
+func (m *TimeRule) Validate() error {
+	if m.TimeOffset == 0 && m.TimeZone == "" {
+		return errors.New("time offset or time zone required")
+	}
+
+	if m.TimeZone != "" {
+		if _, err := time.LoadLocation(m.TimeZone); err != nil {
+			return fmt.Errorf("invalid time zone %s", txt.Quote(m.TimeZone))
+		}
+	}
+
+	if m.StartAt != nil && m.EndAt != nil && m.EndAt.Before(*m.StartAt) {
+		return errors.New("end must not be before start")
+	}
+
+	return nil
+}
+
+// Create inserts a new row to the database.
+func (m *TimeRule) Create() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	defer FlushTimeRuleCache()
+
+	return Db().Create(m).Error
+}
+
+// Save updates the record in the database or inserts a new record if it does not already exist.
+func (m *TimeRule) Save() error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+
+	defer FlushTimeRuleCache()
+
+	return Db().Save(m).Error
+}
+
+// Delete removes the rule from the database.
+func (m *TimeRule) Delete() error {
+	defer FlushTimeRuleCache()
+
+	return Db().Delete(m).Error
+}
+
+// String returns a human-readable rule description for logging.
+func (m *TimeRule) String() string {
+	var camera []string
+
+	for _, s := range []string{m.CameraMake, m.CameraModel, m.CameraSerial} {
+		if s != "" {
+			camera = append(camera, s)
+		}
+	}
+
+	if len(camera) == 0 {
+		camera = []string{"any camera"}
+	}
+
+	return fmt.Sprintf("rule %d (%s)", m.ID, strings.Join(camera, " "))
+}
+
+// Offset returns the time offset as duration.
+func (m *TimeRule) Offset() time.Duration {
+	return time.Duration(m.TimeOffset) * time.Second
+}
+
+// Matches tests if the rule applies to the photo based on its camera and the local time set in the camera.
+func (m *TimeRule) Matches(p *Photo) bool {
+	if p == nil || p.TakenAtLocal.IsZero() {
+		return false
+	}
+
+	if m.CameraSerial != "" && !strings.EqualFold(m.CameraSerial, p.CameraSerial) {
+		return false
+	}
+
+	if m.CameraMake != "" || m.CameraModel != "" {
+		camera := p.Camera
+
+		if camera == nil && p.CameraID > 0 {
+			camera = &Camera{}
+
+			if Db().First(camera, "id = ?", p.CameraID).Error != nil {
+				camera = nil
+			}
+		}
+
+		if camera == nil {
+			return false
+		} else if m.CameraMake != "" && !strings.EqualFold(m.CameraMake, camera.CameraMake) {
+			return false
+		} else if m.CameraModel != "" && !strings.EqualFold(m.CameraModel, camera.CameraModel) {
+			return false
+		}
+	}
+
+	if m.StartAt != nil && p.TakenAtLocal.Before(*m.StartAt) {
+		return false
+	}
+
+	if m.EndAt != nil && p.TakenAtLocal.After(*m.EndAt) {
+		return false
+	}
+
+	return true
+}
+
+// Apply corrects the time and date fields of the photo without saving it. The offset is added first. If a time zone is
+// set, the local time is assumed to be in the time zone of the camera clock and converted to the time zone
+// of the photo, if known.
+func (m *TimeRule) Apply(p *Photo) {
+	if p == nil {
+		return
+	}
+
+	if m.TimeOffset != 0 {
+		p.TakenAt = p.TakenAt.Add(m.Offset())
+		p.TakenAtLocal = p.TakenAtLocal.Add(m.Offset())
+	}
+
+	if m.TimeZone != "" {
+		if loc, err := time.LoadLocation(m.TimeZone); err != nil {
+			log.Warnf("time: %s in %s", err, m.String())
+		} else if takenAt, err := time.ParseInLocation("2006-01-02T15:04:05", p.TakenAtLocal.Format("2006-01-02T15:04:05"), loc); err != nil {
+			log.Warnf("time: %s in %s", err, m.String())
+		} else {
+			p.TakenAt = takenAt.UTC()
+
+			if p.TimeZone == "" || p.TimeZoneUTC() {
+				p.TimeZone = m.TimeZone
+			} else {
+				p.TakenAtLocal = p.GetTakenAtLocal()
+			}
+		}
+	}
+
+	p.TakenSrc = SrcRule
+	p.PhotoYear = p.TakenAtLocal.Year()
+	p.PhotoMonth = int(p.TakenAtLocal.Month())
+	p.PhotoDay = p.TakenAtLocal.Day()
+}
+
+// Match returns the first rule that applies to the photo or nil if there is none.
+func (rules TimeRules) Match(p *Photo) *TimeRule {
+	for i := range rules {
+		if rules[i].Matches(p) {
+			return &rules[i]
+		}
+	}
+
+	return nil
+}
+
+// ApplyTimeRules corrects the time of a photo with a time from its camera metadata if a rule matches
+// and returns the rule applied, or nil if there is none. The photo is not saved.
+func ApplyTimeRules(p *Photo) *TimeRule {
+	if p == nil || p.TakenSrc != SrcMeta {
+		return nil
+	}
+
+	rule := CachedTimeRules().Match(p)
+
+	if rule != nil {
+		rule.Apply(p)
+	}
+
+	return rule
+}
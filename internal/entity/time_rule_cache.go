@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+)
+
+var timeRuleCache = gc.New(time.Minute, 5*time.Minute)
+
+const timeRuleCacheKey = "rules"
+
+// FlushTimeRuleCache resets the time correction rule cache.
+func FlushTimeRuleCache() {
+	timeRuleCache.Flush()
+}
+
+// CachedTimeRules returns all time correction rules, using a short-lived cache to avoid database queries
+// for every file that is indexed.
+func CachedTimeRules() TimeRules {
+	if cacheData, ok := timeRuleCache.Get(timeRuleCacheKey); ok {
+		return cacheData.(TimeRules)
+	}
+
+	rules, err := FindTimeRules()
+
+	if err != nil {
+		log.Errorf("time: %s (find rules)", err)
+		return TimeRules{}
+	}
+
+	timeRuleCache.SetDefault(timeRuleCacheKey, rules)
+
+	return rules
+}
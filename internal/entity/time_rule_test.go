@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeRule_Validate(t *testing.T) {
+	start := time.Date(2023, 7, 14, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Error(t, (&TimeRule{}).Validate())
+	assert.Error(t, (&TimeRule{TimeZone: "Mars/Olympus"}).Validate())
+	assert.Error(t, (&TimeRule{TimeOffset: 3600, StartAt: &start, EndAt: &end}).Validate())
+	assert.NoError(t, (&TimeRule{TimeOffset: 3600, StartAt: &end, EndAt: &start}).Validate())
+	assert.NoError(t, (&TimeRule{TimeZone: "Europe/Berlin"}).Validate())
+}
+
+func TestTimeRule_Matches(t *testing.T) {
+	start := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 7, 14, 23, 59, 59, 0, time.UTC)
+	camera := &Camera{CameraMake: "Canon", CameraModel: "EOS 6D"}
+
+	rule := TimeRule{CameraMake: "canon", CameraSerial: "123", StartAt: &start, EndAt: &end, TimeOffset: 3600}
+
+	t.Run("Match", func(t *testing.T) {
+		p := &Photo{Camera: camera, CameraSerial: "123", TakenAtLocal: time.Date(2023, 7, 5, 10, 0, 0, 0, time.UTC)}
+		assert.True(t, rule.Matches(p))
+	})
+	t.Run("OtherSerial", func(t *testing.T) {
+		p := &Photo{Camera: camera, CameraSerial: "456", TakenAtLocal: time.Date(2023, 7, 5, 10, 0, 0, 0, time.UTC)}
+		assert.False(t, rule.Matches(p))
+	})
+	t.Run("OtherMake", func(t *testing.T) {
+		p := &Photo{Camera: &Camera{CameraMake: "Nikon"}, CameraSerial: "123", TakenAtLocal: time.Date(2023, 7, 5, 10, 0, 0, 0, time.UTC)}
+		assert.False(t, rule.Matches(p))
+	})
+	t.Run("OutOfRange", func(t *testing.T) {
+		p := &Photo{Camera: camera, CameraSerial: "123", TakenAtLocal: time.Date(2023, 7, 15, 10, 0, 0, 0, time.UTC)}
+		assert.False(t, rule.Matches(p))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		assert.False(t, rule.Matches(nil))
+	})
+}
+
+func TestTimeRule_Apply(t *testing.T) {
+	t.Run("Offset", func(t *testing.T) {
+		taken := time.Date(2023, 7, 5, 23, 30, 0, 0, time.UTC)
+		p := &Photo{TakenAt: taken, TakenAtLocal: taken, TakenSrc: SrcMeta}
+
+		(&TimeRule{TimeOffset: 3600}).Apply(p)
+
+		assert.Equal(t, taken.Add(time.Hour), p.TakenAt)
+		assert.Equal(t, taken.Add(time.Hour), p.TakenAtLocal)
+		assert.Equal(t, SrcRule, p.TakenSrc)
+		assert.Equal(t, 6, p.PhotoDay)
+	})
+	t.Run("TimeZone", func(t *testing.T) {
+		local := time.Date(2023, 7, 5, 12, 0, 0, 0, time.UTC)
+		p := &Photo{TakenAt: local, TakenAtLocal: local, TakenSrc: SrcMeta}
+
+		(&TimeRule{TimeZone: "Europe/Berlin"}).Apply(p)
+
+		assert.Equal(t, time.Date(2023, 7, 5, 10, 0, 0, 0, time.UTC), p.TakenAt)
+		assert.Equal(t, local, p.TakenAtLocal)
+		assert.Equal(t, "Europe/Berlin", p.TimeZone)
+	})
+	t.Run("KnownTimeZone", func(t *testing.T) {
+		local := time.Date(2023, 7, 5, 12, 0, 0, 0, time.UTC)
+		p := &Photo{TakenAt: local, TakenAtLocal: local, TakenSrc: SrcMeta, TimeZone: "Asia/Tokyo"}
+
+		(&TimeRule{TimeZone: "Europe/Berlin"}).Apply(p)
+
+		assert.Equal(t, time.Date(2023, 7, 5, 10, 0, 0, 0, time.UTC), p.TakenAt)
+		assert.Equal(t, time.Date(2023, 7, 5, 19, 0, 0, 0, time.UTC), p.TakenAtLocal)
+		assert.Equal(t, "Asia/Tokyo", p.TimeZone)
+	})
+}
+
+func TestTimeRules_Match(t *testing.T) {
+	rules := TimeRules{{ID: 1, CameraSerial: "123", TimeOffset: 60}, {ID: 2, TimeOffset: 120}}
+
+	assert.Equal(t, uint(1), rules.Match(&Photo{CameraSerial: "123", TakenAtLocal: time.Now()}).ID)
+	assert.Equal(t, uint(2), rules.Match(&Photo{CameraSerial: "456", TakenAtLocal: time.Now()}).ID)
+	assert.Nil(t, TimeRules{}.Match(&Photo{TakenAtLocal: time.Now()}))
+}
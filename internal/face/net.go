@@ -66,6 +66,32 @@ func (t *Net) Detect(fileName string, minSize int, cacheCrop bool, expected int)
 	return faces, nil
 }
 
+// Embeddings returns the face embeddings for an area of the provided source image,
+// e.g. for a face that was marked manually.
+func (t *Net) Embeddings(fileName string, area crop.Area, cacheCrop bool) (Embeddings, error) {
+	if t.disabled {
+		return Embeddings{}, fmt.Errorf("facenet is disabled")
+	} else if area.W <= 0 || area.H <= 0 {
+		return Embeddings{}, fmt.Errorf("invalid crop area")
+	}
+
+	if err := t.loadModel(); err != nil {
+		return Embeddings{}, err
+	}
+
+	img, err := crop.ImageFromThumb(fileName, area, CropSize, cacheCrop)
+
+	if err != nil {
+		return Embeddings{}, err
+	}
+
+	if embeddings := t.getEmbeddings(img); embeddings.Empty() {
+		return Embeddings{}, fmt.Errorf("found no embeddings")
+	} else {
+		return embeddings, nil
+	}
+}
+
 // ModelLoaded tests if the TensorFlow model is loaded.
 func (t *Net) ModelLoaded() bool {
 	return t.model != nil
@@ -0,0 +1,72 @@
+package form
+
+import (
+	"errors"
+	"time"
+)
+
+// BatchEdit represents a form for changing the metadata of selected photos,
+// values that are not set remain unchanged.
+type BatchEdit struct {
+	Selection
+	TakenAtLocal   *time.Time `json:"TakenAtLocal"`
+	TakenOffset    int64      `json:"TakenOffset"`
+	TimeZone       string     `json:"TimeZone"`
+	Lat            *float32   `json:"Lat"`
+	Lng            *float32   `json:"Lng"`
+	Altitude       *int       `json:"Altitude"`
+	AddKeywords    []string   `json:"AddKeywords"`
+	RemoveKeywords []string   `json:"RemoveKeywords"`
+	AddLabels      []string   `json:"AddLabels"`
+	RemoveLabels   []string   `json:"RemoveLabels"`
+	Artist         string     `json:"Artist"`
+	Copyright      string     `json:"Copyright"`
+	License        string     `json:"License"`
+	AddAlbums      []string   `json:"AddAlbums"`
+}
+
+// ChangesTime tests if the photo date and time should be changed.
+func (f BatchEdit) ChangesTime() bool {
+	return f.TakenAtLocal != nil && !f.TakenAtLocal.IsZero() || f.TakenOffset != 0 || f.TimeZone != ""
+}
+
+// ChangesLocation tests if the photo location should be changed.
+func (f BatchEdit) ChangesLocation() bool {
+	return f.Lat != nil && f.Lng != nil
+}
+
+// ChangesDetails tests if the photo details should be changed.
+func (f BatchEdit) ChangesDetails() bool {
+	return f.Artist != "" || f.Copyright != "" || f.License != "" || len(f.AddKeywords) > 0 || len(f.RemoveKeywords) > 0
+}
+
+// Empty tests if no changes were requested.
+func (f BatchEdit) Empty() bool {
+	return !f.ChangesTime() && !f.ChangesLocation() && !f.ChangesDetails() &&
+		len(f.AddLabels) == 0 && len(f.RemoveLabels) == 0 && len(f.AddAlbums) == 0
+}
+
+// Validate returns an error if the requested changes are invalid.
+func (f BatchEdit) Validate() error {
+	if f.Empty() {
+		return errors.New("no changes requested")
+	}
+
+	if f.TakenAtLocal != nil && !f.TakenAtLocal.IsZero() && f.TakenOffset != 0 {
+		return errors.New("time and offset cannot be changed at the same time")
+	}
+
+	if f.TimeZone != "" {
+		if _, err := time.LoadLocation(f.TimeZone); err != nil {
+			return errors.New("invalid time zone")
+		}
+	}
+
+	if (f.Lat == nil) != (f.Lng == nil) {
+		return errors.New("latitude and longitude are both required")
+	} else if f.Lat != nil && (*f.Lat < -90 || *f.Lat > 90 || *f.Lng < -180 || *f.Lng > 180) {
+		return errors.New("invalid coordinates")
+	}
+
+	return nil
+}
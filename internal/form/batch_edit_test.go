@@ -0,0 +1,55 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchEdit_Empty(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		f := BatchEdit{Selection: Selection{Photos: []string{"pt9jtdre2lvl0yh8"}}}
+		assert.True(t, f.Empty())
+	})
+	t.Run("Offset", func(t *testing.T) {
+		f := BatchEdit{TakenOffset: 3600}
+		assert.False(t, f.Empty())
+		assert.True(t, f.ChangesTime())
+	})
+	t.Run("Albums", func(t *testing.T) {
+		f := BatchEdit{AddAlbums: []string{"at9lxuqxpogaaba7"}}
+		assert.False(t, f.Empty())
+		assert.False(t, f.ChangesDetails())
+	})
+}
+
+func TestBatchEdit_Validate(t *testing.T) {
+	lat, lng := float32(48.5), float32(9.1)
+	invalid := float32(200)
+	takenAt := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Valid", func(t *testing.T) {
+		f := BatchEdit{TakenOffset: -7200, TimeZone: "Europe/Berlin", Lat: &lat, Lng: &lng, Artist: "Jane Doe"}
+		assert.NoError(t, f.Validate())
+	})
+	t.Run("NoChanges", func(t *testing.T) {
+		assert.Error(t, BatchEdit{}.Validate())
+	})
+	t.Run("TimeAndOffset", func(t *testing.T) {
+		f := BatchEdit{TakenAtLocal: &takenAt, TakenOffset: 60}
+		assert.Error(t, f.Validate())
+	})
+	t.Run("InvalidTimeZone", func(t *testing.T) {
+		f := BatchEdit{TimeZone: "Mars/Olympus"}
+		assert.Error(t, f.Validate())
+	})
+	t.Run("LatOnly", func(t *testing.T) {
+		f := BatchEdit{Lat: &lat}
+		assert.Error(t, f.Validate())
+	})
+	t.Run("InvalidCoordinates", func(t *testing.T) {
+		f := BatchEdit{Lat: &lat, Lng: &invalid}
+		assert.Error(t, f.Validate())
+	})
+}
@@ -0,0 +1,12 @@
+package form
+
+// Comment represents a comment form.
+type Comment struct {
+	CommentText string `json:"Text"`
+	AuthorName  string `json:"Name"`
+}
+
+// CommentModeration represents a form for hiding inappropriate comments.
+type CommentModeration struct {
+	CommentHidden bool `json:"Hidden"`
+}
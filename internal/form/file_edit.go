@@ -0,0 +1,42 @@
+package form
+
+import (
+	"errors"
+)
+
+// FileEdit represents a form for changing the non-destructive adjustments of a file.
+type FileEdit struct {
+	CropX       float32 `json:"CropX"`
+	CropY       float32 `json:"CropY"`
+	CropW       float32 `json:"CropW"`
+	CropH       float32 `json:"CropH"`
+	Angle       float32 `json:"Angle"`
+	Exposure    float32 `json:"Exposure"`
+	Contrast    int     `json:"Contrast"`
+	Saturation  int     `json:"Saturation"`
+	Temperature int     `json:"Temperature"`
+	Tint        int     `json:"Tint"`
+}
+
+// Validate returns an error if a value is out of range.
+func (f FileEdit) Validate() error {
+	switch {
+	case f.CropX < 0 || f.CropY < 0 || f.CropW < 0 || f.CropH < 0:
+		return errors.New("crop area must not be negative")
+	case f.CropX+f.CropW > 1.0001 || f.CropY+f.CropH > 1.0001:
+		return errors.New("crop area exceeds image")
+	case f.Angle < -45 || f.Angle > 45:
+		return errors.New("angle must be between -45 and 45 degrees")
+	case f.Exposure < -5 || f.Exposure > 5:
+		return errors.New("exposure must be between -5 and 5")
+	case outOfRange(f.Contrast) || outOfRange(f.Saturation) || outOfRange(f.Temperature) || outOfRange(f.Tint):
+		return errors.New("adjustments must be between -100 and 100")
+	}
+
+	return nil
+}
+
+// outOfRange tests if an adjustment value is not between -100 and 100.
+func outOfRange(v int) bool {
+	return v < -100 || v > 100
+}
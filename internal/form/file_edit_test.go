@@ -0,0 +1,17 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileEdit_Validate(t *testing.T) {
+	assert.NoError(t, FileEdit{}.Validate())
+	assert.NoError(t, FileEdit{CropX: 0.5, CropY: 0.5, CropW: 0.5, CropH: 0.5, Angle: -10, Exposure: 1.5, Contrast: 100, Tint: -100}.Validate())
+	assert.Error(t, FileEdit{CropX: -0.1}.Validate())
+	assert.Error(t, FileEdit{CropX: 0.6, CropW: 0.5}.Validate())
+	assert.Error(t, FileEdit{Angle: 46}.Validate())
+	assert.Error(t, FileEdit{Exposure: -6}.Validate())
+	assert.Error(t, FileEdit{Saturation: 101}.Validate())
+}
@@ -0,0 +1,9 @@
+package form
+
+// Job represents a request to queue an index, convert, or thumbnail job for a file or folder in originals.
+type Job struct {
+	Type     string `json:"type"`
+	Path     string `json:"path"`
+	Priority int    `json:"priority"`
+	Force    bool   `json:"force"`
+}
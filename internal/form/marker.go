@@ -15,3 +15,29 @@ func NewMarker(m interface{}) (f Marker, err error) {
 
 	return f, err
 }
+
+// MarkerCreate represents a form for adding a face or pet marker that was not detected automatically.
+type MarkerCreate struct {
+	FileUID    string  `json:"FileUID"`
+	MarkerType string  `json:"Type"`
+	X          float32 `json:"X"`
+	Y          float32 `json:"Y"`
+	W          float32 `json:"W"`
+	H          float32 `json:"H"`
+	MarkerName string  `json:"Name"`
+}
+
+// Valid tests if the marker area is within the image bounds.
+func (f MarkerCreate) Valid() bool {
+	if f.FileUID == "" || f.W <= 0 || f.H <= 0 || f.X < 0 || f.Y < 0 {
+		return false
+	}
+
+	switch f.MarkerType {
+	case "", "face", "pet":
+	default:
+		return false
+	}
+
+	return f.X+f.W <= 1 && f.Y+f.H <= 1
+}
@@ -32,3 +32,30 @@ func TestNewMarker(t *testing.T) {
 		assert.Equal(t, true, f.MarkerInvalid)
 	})
 }
+
+func TestMarkerCreate_Valid(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		f := MarkerCreate{FileUID: "ft8es39w45bnlqdw", X: 0.2, Y: 0.3, W: 0.2, H: 0.25}
+		assert.True(t, f.Valid())
+	})
+	t.Run("NoFile", func(t *testing.T) {
+		f := MarkerCreate{X: 0.2, Y: 0.3, W: 0.2, H: 0.25}
+		assert.False(t, f.Valid())
+	})
+	t.Run("EmptyArea", func(t *testing.T) {
+		f := MarkerCreate{FileUID: "ft8es39w45bnlqdw", X: 0.2, Y: 0.3}
+		assert.False(t, f.Valid())
+	})
+	t.Run("Pet", func(t *testing.T) {
+		f := MarkerCreate{FileUID: "ft8es39w45bnlqdw", MarkerType: "pet", X: 0.2, Y: 0.3, W: 0.2, H: 0.25}
+		assert.True(t, f.Valid())
+	})
+	t.Run("UnknownType", func(t *testing.T) {
+		f := MarkerCreate{FileUID: "ft8es39w45bnlqdw", MarkerType: "label", X: 0.2, Y: 0.3, W: 0.2, H: 0.25}
+		assert.False(t, f.Valid())
+	})
+	t.Run("OutOfBounds", func(t *testing.T) {
+		f := MarkerCreate{FileUID: "ft8es39w45bnlqdw", X: 0.9, Y: 0.3, W: 0.2, H: 0.25}
+		assert.False(t, f.Valid())
+	})
+}
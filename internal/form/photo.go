@@ -8,19 +8,42 @@ import (
 
 // Details contains detailed photo information
 type Details struct {
-	PhotoID      uint   `json:"PhotoID" deepcopier:"skip"`
-	Keywords     string `json:"Keywords"`
-	KeywordsSrc  string `json:"KeywordsSrc"`
-	Notes        string `json:"Notes"`
-	NotesSrc     string `json:"NotesSrc"`
-	Subject      string `json:"Subject"`
-	SubjectSrc   string `json:"SubjectSrc"`
-	Artist       string `json:"Artist"`
-	ArtistSrc    string `json:"ArtistSrc"`
-	Copyright    string `json:"Copyright"`
-	CopyrightSrc string `json:"CopyrightSrc"`
-	License      string `json:"License"`
-	LicenseSrc   string `json:"LicenseSrc"`
+	PhotoID            uint   `json:"PhotoID" deepcopier:"skip"`
+	Keywords           string `json:"Keywords"`
+	KeywordsSrc        string `json:"KeywordsSrc"`
+	Notes              string `json:"Notes"`
+	NotesSrc           string `json:"NotesSrc"`
+	Subject            string `json:"Subject"`
+	SubjectSrc         string `json:"SubjectSrc"`
+	Artist             string `json:"Artist"`
+	ArtistSrc          string `json:"ArtistSrc"`
+	Copyright          string `json:"Copyright"`
+	CopyrightSrc       string `json:"CopyrightSrc"`
+	License            string `json:"License"`
+	LicenseSrc         string `json:"LicenseSrc"`
+	CreditLine         string `json:"CreditLine"`
+	CreatorAddress     string `json:"CreatorAddress"`
+	CreatorCity        string `json:"CreatorCity"`
+	CreatorRegion      string `json:"CreatorRegion"`
+	CreatorPostalCode  string `json:"CreatorPostalCode"`
+	CreatorCountry     string `json:"CreatorCountry"`
+	CreatorPhone       string `json:"CreatorPhone"`
+	CreatorEmail       string `json:"CreatorEmail"`
+	CreatorUrl         string `json:"CreatorUrl"`
+	CreatedSublocation string `json:"CreatedSublocation"`
+	CreatedCity        string `json:"CreatedCity"`
+	CreatedState       string `json:"CreatedState"`
+	CreatedCountry     string `json:"CreatedCountry"`
+	CreatedCountryCode string `json:"CreatedCountryCode"`
+	ShownSublocation   string `json:"ShownSublocation"`
+	ShownCity          string `json:"ShownCity"`
+	ShownState         string `json:"ShownState"`
+	ShownCountry       string `json:"ShownCountry"`
+	ShownCountryCode   string `json:"ShownCountryCode"`
+	PersonsShown       string `json:"PersonsShown"`
+	Event              string `json:"Event"`
+	DigitalSourceType  string `json:"DigitalSourceType"`
+	IptcSrc            string `json:"IptcSrc"`
 }
 
 // Photo represents a photo edit form.
@@ -41,6 +64,9 @@ type Photo struct {
 	Details          Details   `json:"Details"`
 	PhotoStack       int8      `json:"Stack"`
 	PhotoFavorite    bool      `json:"Favorite"`
+	PhotoRating      int8      `json:"Rating"`
+	PhotoColorLabel  string    `json:"ColorLabel"`
+	RatingSrc        string    `json:"RatingSrc"`
 	PhotoPrivate     bool      `json:"Private"`
 	PhotoScan        bool      `json:"Scan"`
 	PhotoPanorama    bool      `json:"Panorama"`
@@ -0,0 +1,10 @@
+package form
+
+// PhotoBook represents printable PDF export options.
+type PhotoBook struct {
+	Title      string `form:"title"`
+	Layout     string `form:"layout"`
+	Size       string `form:"size"`
+	Landscape  bool   `form:"landscape"`
+	NoCaptions bool   `form:"nocaptions"`
+}
@@ -0,0 +1,7 @@
+package form
+
+// PhotoExport represents metadata export options.
+type PhotoExport struct {
+	Format  string `form:"format"`
+	Columns string `form:"columns"`
+}
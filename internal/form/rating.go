@@ -0,0 +1,14 @@
+package form
+
+// Rating represents a form for changing the star rating and color label of selected photos,
+// values that are not set remain unchanged.
+type Rating struct {
+	Selection
+	Rating     *int    `json:"Rating"`
+	ColorLabel *string `json:"ColorLabel"`
+}
+
+// Empty tests if neither a star rating nor a color label was set.
+func (f Rating) Empty() bool {
+	return f.Rating == nil && f.ColorLabel == nil
+}
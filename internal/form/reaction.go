@@ -0,0 +1,6 @@
+package form
+
+// Reaction represents a form for reacting to a photo or album, either with the emoji or its name.
+type Reaction struct {
+	Reaction string `json:"Reaction"`
+}
@@ -0,0 +1,29 @@
+package form
+
+// SearchComments represents search form fields for "/api/v1/comments".
+type SearchComments struct {
+	Query  string `form:"q"`
+	UID    string `form:"uid"`
+	Entity string `form:"entity"`
+	User   string `form:"user"`
+	Hidden string `form:"hidden"`
+	Count  int    `form:"count" binding:"required" serialize:"-"`
+	Offset int    `form:"offset" serialize:"-"`
+	Order  string `form:"order" serialize:"-"`
+}
+
+func (f *SearchComments) GetQuery() string {
+	return f.Query
+}
+
+func (f *SearchComments) SetQuery(q string) {
+	f.Query = q
+}
+
+func (f *SearchComments) ParseQueryString() error {
+	return ParseQueryString(f)
+}
+
+func NewCommentSearch(query string) SearchComments {
+	return SearchComments{Query: query}
+}
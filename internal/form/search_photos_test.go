@@ -25,6 +25,29 @@ func TestParseQueryString(t *testing.T) {
 
 		assert.Equal(t, "Jens & Mander", form.Subjects)
 	})
+	t.Run("pet", func(t *testing.T) {
+		form := &SearchPhotos{Query: "pet:\"Bello|Luna\""}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Bello|Luna", form.Pet)
+	})
+	t.Run("rating", func(t *testing.T) {
+		form := &SearchPhotos{Query: "rating:>=4 colorlabel:\"red|green\""}
+
+		err := form.ParseQueryString()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, ">=4", form.Rating)
+		assert.Equal(t, "red|green", form.ColorLabel)
+	})
 	t.Run("aliases", func(t *testing.T) {
 		form := &SearchPhotos{Query: "people:\"Jens & Mander\" folder:Foo person:Bar"}
 
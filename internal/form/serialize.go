@@ -3,6 +3,7 @@ package form
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -14,6 +15,9 @@ import (
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// CompareRegexp matches numeric comparisons like >=4 that must not be sanitized as search string.
+var CompareRegexp = regexp.MustCompile(`^[<>]=?\d+$`)
+
 // Serialize returns a string containing all non-empty fields and values of a struct.
 func Serialize(f interface{}, all bool) string {
 	v := reflect.ValueOf(f)
@@ -150,7 +154,12 @@ func Unserialize(f SearchForm, q string) (result error) {
 							field.SetUint(uint64(intValue))
 						}
 					case string:
-						field.SetString(clean.SearchString(stringValue))
+						if CompareRegexp.MatchString(stringValue) {
+							// Keep comparison operators, e.g. rating:>=4.
+							field.SetString(stringValue)
+						} else {
+							field.SetString(clean.SearchString(stringValue))
+						}
 					case bool:
 						field.SetBool(txt.Bool(stringValue))
 					default:
@@ -9,7 +9,7 @@ import (
 var onceClassify sync.Once
 
 func initClassify() {
-	services.Classify = classify.New(Config().AssetsPath(), Config().DisableClassification())
+	services.Classify = classify.NewModel(Config().AssetsPath(), Config().ClassificationModel(), Config().DisableClassification())
 }
 
 func Classify() *classify.TensorFlow {
@@ -0,0 +1,19 @@
+package get
+
+import (
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+var onceJobs sync.Once
+
+func initJobs() {
+	services.Jobs = photoprism.NewJobs(Config(), Index(), Convert())
+}
+
+func Jobs() *photoprism.Jobs {
+	onceJobs.Do(initJobs)
+
+	return services.Jobs
+}
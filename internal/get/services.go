@@ -48,6 +48,7 @@ var services struct {
 	Photos      *photoprism.Photos
 	Import      *photoprism.Import
 	Index       *photoprism.Index
+	Jobs        *photoprism.Jobs
 	Moments     *photoprism.Moments
 	Faces       *photoprism.Faces
 	Places      *photoprism.Places
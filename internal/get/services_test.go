@@ -45,6 +45,10 @@ func TestIndex(t *testing.T) {
 	assert.IsType(t, &photoprism.Index{}, Index())
 }
 
+func TestJobs(t *testing.T) {
+	assert.IsType(t, &photoprism.Jobs{}, Jobs())
+}
+
 func TestMoments(t *testing.T) {
 	assert.IsType(t, &photoprism.Moments{}, Moments())
 }
@@ -0,0 +1,137 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// AppleAdjustments represents the image adjustments stored in an Apple AAE sidecar file.
+type AppleAdjustments struct {
+	Format        string    `json:"Format"`
+	FormatVersion string    `json:"FormatVersion"`
+	Editor        string    `json:"Editor"`
+	BaseVersion   int       `json:"BaseVersion"`
+	Timestamp     time.Time `json:"Timestamp"`
+	Data          []byte    `json:"-"`
+}
+
+// Edited tests if the sidecar contains adjustments.
+func (a AppleAdjustments) Edited() bool {
+	return a.Format != "" && len(a.Data) > 0
+}
+
+// AAE parses an Apple AAE sidecar file, which is an XML property list.
+func AAE(fileName string) (result AppleAdjustments, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("metadata: %s in %s (aae panic)\nstack: %s", e, clean.Log(filepath.Base(fileName)), debug.Stack())
+		}
+	}()
+
+	// Resolve file name e.g. in case it's a symlink.
+	if fileName, err = fs.Resolve(fileName); err != nil {
+		return result, fmt.Errorf("metadata: %s %s (aae)", err, clean.Log(filepath.Base(fileName)))
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return result, fmt.Errorf("metadata: cannot read %s (aae)", clean.Log(filepath.Base(fileName)))
+	}
+
+	values, err := plistDict(data)
+
+	if err != nil {
+		return result, fmt.Errorf("metadata: %s in %s (aae)", err, clean.Log(filepath.Base(fileName)))
+	}
+
+	result.Format = values["adjustmentFormatIdentifier"]
+	result.FormatVersion = values["adjustmentFormatVersion"]
+	result.Editor = values["adjustmentEditorBundleID"]
+
+	if s := values["adjustmentBaseVersion"]; s != "" {
+		_, _ = fmt.Sscanf(s, "%d", &result.BaseVersion)
+	}
+
+	if s := values["adjustmentTimestamp"]; s != "" {
+		if t, timeErr := time.Parse(time.RFC3339, s); timeErr == nil {
+			result.Timestamp = t.UTC()
+		}
+	}
+
+	if s := values["adjustmentData"]; s != "" {
+		s = strings.Join(strings.Fields(s), "")
+
+		if result.Data, err = base64.StdEncoding.DecodeString(s); err != nil {
+			return result, fmt.Errorf("metadata: invalid adjustment data in %s (aae)", clean.Log(filepath.Base(fileName)))
+		}
+	}
+
+	return result, nil
+}
+
+// plistDict returns the keys and values of the top-level dictionary in an XML property list.
+func plistDict(data []byte) (map[string]string, error) {
+	result := make(map[string]string)
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	depth := 0
+	key := ""
+
+	for {
+		tok, err := dec.Token()
+
+		if err != nil {
+			if len(result) == 0 {
+				return result, fmt.Errorf("invalid property list")
+			}
+
+			return result, nil
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			depth++
+
+			// Top-level dictionary elements are at depth 3: plist > dict > element.
+			if depth != 3 {
+				continue
+			}
+
+			var s string
+
+			if t.Name.Local == "dict" || t.Name.Local == "array" {
+				if err = dec.Skip(); err != nil {
+					return result, err
+				}
+
+				depth--
+				key = ""
+
+				continue
+			} else if err = dec.DecodeElement(&s, &t); err != nil {
+				return result, err
+			}
+
+			depth--
+
+			if t.Name.Local == "key" {
+				key = s
+			} else if key != "" {
+				result[key] = strings.TrimSpace(s)
+				key = ""
+			}
+		case xml.EndElement:
+			depth--
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package meta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAAE(t *testing.T) {
+	t.Run("IMG_4120.AAE", func(t *testing.T) {
+		result, err := AAE("../../assets/examples/IMG_4120.AAE")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, result.Edited())
+		assert.Equal(t, "com.apple.photo", result.Format)
+		assert.Equal(t, "1.4", result.FormatVersion)
+		assert.Equal(t, "com.apple.camera", result.Editor)
+		assert.Equal(t, 0, result.BaseVersion)
+		assert.Equal(t, time.Date(2019, 6, 9, 10, 59, 22, 0, time.UTC), result.Timestamp)
+		assert.NotEmpty(t, result.Data)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := AAE("testdata/not-found.aae")
+		assert.Error(t, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := AAE("testdata/apple-test-2.xmp")
+		assert.Error(t, err)
+	})
+}
@@ -14,59 +14,83 @@ const (
 
 // Data represents image metadata.
 type Data struct {
-	FileName      string        `meta:"FileName"`
-	DocumentID    string        `meta:"BurstUUID,MediaGroupUUID,ImageUniqueID,OriginalDocumentID,DocumentID,DigitalImageGUID"`
-	InstanceID    string        `meta:"InstanceID,DocumentID"`
-	CreatedAt     time.Time     `meta:"SubSecCreateDate,CreationDate,CreateDate,MediaCreateDate,ContentCreateDate,TrackCreateDate"`
-	TakenAt       time.Time     `meta:"SubSecDateTimeOriginal,SubSecDateTimeCreated,DateTimeOriginal,CreationDate,DateTimeCreated,DateTime,DateTimeDigitized" xmp:"DateCreated"`
-	TakenAtLocal  time.Time     `meta:"SubSecDateTimeOriginal,SubSecDateTimeCreated,DateTimeOriginal,CreationDate,DateTimeCreated,DateTime,DateTimeDigitized"`
-	TakenGps      time.Time     `meta:"GPSDateTime,GPSDateStamp"`
-	TakenNs       int           `meta:"-"`
-	TimeZone      string        `meta:"-"`
-	Duration      time.Duration `meta:"Duration,MediaDuration,TrackDuration,PreviewDuration"`
-	FPS           float64       `meta:"VideoFrameRate,VideoAvgFrameRate"`
-	Frames        int           `meta:"FrameCount,AnimationFrames"`
-	Codec         string        `meta:"CompressorID,VideoCodecID,CodecID,OtherFormat,FileType"`
-	Title         string        `meta:"Headline,Title" xmp:"dc:title" dc:"title,title.Alt"`
-	Subject       string        `meta:"Subject,PersonInImage,ObjectName,HierarchicalSubject,CatalogSets" xmp:"Subject"`
-	Keywords      Keywords      `meta:"Keywords"`
-	Notes         string        `meta:"Comment,UserComment"`
-	Artist        string        `meta:"Artist,Creator,By-line,OwnerName,Owner" xmp:"Creator"`
-	Description   string        `meta:"Description,Caption-Abstract" xmp:"Description,Description.Alt"`
-	Copyright     string        `meta:"Rights,Copyright,CopyrightNotice,WebStatement" xmp:"Rights,Rights.Alt"`
-	License       string        `meta:"UsageTerms,License"`
-	Projection    string        `meta:"ProjectionType"`
-	ColorProfile  string        `meta:"ICCProfileName,ProfileDescription"`
-	CameraMake    string        `meta:"CameraMake,Make" xmp:"Make"`
-	CameraModel   string        `meta:"CameraModel,Model" xmp:"Model"`
-	CameraOwner   string        `meta:"OwnerName"`
-	CameraSerial  string        `meta:"SerialNumber"`
-	LensMake      string        `meta:"LensMake"`
-	LensModel     string        `meta:"Lens,LensModel" xmp:"LensModel"`
-	Software      string        `meta:"Software,CreatorTool,HistorySoftwareAgent,ProcessingSoftware"`
-	Flash         bool          `meta:"FlashFired"`
-	FocalLength   int           `meta:"FocalLength,FocalLengthIn35mmFormat"`
-	FocalDistance float64       `meta:"HyperfocalDistance"`
-	Exposure      string        `meta:"ExposureTime,ShutterSpeedValue,ShutterSpeed,TargetExposureTime"`
-	Aperture      float32       `meta:"ApertureValue,Aperture"`
-	FNumber       float32       `meta:"FNumber"`
-	Iso           int           `meta:"ISO"`
-	ImageType     int           `meta:"HDRImageType"`
-	GPSPosition   string        `meta:"GPSPosition"`
-	GPSLatitude   string        `meta:"GPSLatitude"`
-	GPSLongitude  string        `meta:"GPSLongitude"`
-	Lat           float32       `meta:"-"`
-	Lng           float32       `meta:"-"`
-	Altitude      float64       `meta:"GlobalAltitude,GPSAltitude"`
-	Width         int           `meta:"ImageWidth,PixelXDimension,ExifImageWidth,SourceImageWidth"`
-	Height        int           `meta:"ImageHeight,ImageLength,PixelYDimension,ExifImageHeight,SourceImageHeight"`
-	Orientation   int           `meta:"-"`
-	Rotation      int           `meta:"Rotation"`
-	Views         int           `meta:"-"`
-	Albums        []string      `meta:"-"`
-	Error         error         `meta:"-"`
-	json          map[string]string
-	exif          map[string]string
+	FileName           string        `meta:"FileName"`
+	DocumentID         string        `meta:"BurstUUID,MediaGroupUUID,ContentIdentifier,ImageUniqueID,OriginalDocumentID,DocumentID,DigitalImageGUID"`
+	InstanceID         string        `meta:"InstanceID,DocumentID"`
+	CreatedAt          time.Time     `meta:"SubSecCreateDate,CreationDate,CreateDate,MediaCreateDate,ContentCreateDate,TrackCreateDate"`
+	TakenAt            time.Time     `meta:"SubSecDateTimeOriginal,SubSecDateTimeCreated,DateTimeOriginal,CreationDate,DateTimeCreated,DateTime,DateTimeDigitized" xmp:"DateCreated"`
+	TakenAtLocal       time.Time     `meta:"SubSecDateTimeOriginal,SubSecDateTimeCreated,DateTimeOriginal,CreationDate,DateTimeCreated,DateTime,DateTimeDigitized"`
+	TakenGps           time.Time     `meta:"GPSDateTime,GPSDateStamp"`
+	TakenNs            int           `meta:"-"`
+	TimeZone           string        `meta:"-"`
+	Duration           time.Duration `meta:"Duration,MediaDuration,TrackDuration,PreviewDuration"`
+	FPS                float64       `meta:"VideoFrameRate,VideoAvgFrameRate"`
+	Frames             int           `meta:"FrameCount,AnimationFrames"`
+	Codec              string        `meta:"CompressorID,VideoCodecID,CodecID,OtherFormat,FileType"`
+	Title              string        `meta:"Headline,Title" xmp:"dc:title" dc:"title,title.Alt"`
+	Subject            string        `meta:"Subject,PersonInImage,ObjectName,HierarchicalSubject,CatalogSets" xmp:"Subject"`
+	Keywords           Keywords      `meta:"Keywords"`
+	Notes              string        `meta:"Comment,UserComment"`
+	Artist             string        `meta:"Artist,Creator,By-line,OwnerName,Owner" xmp:"Creator"`
+	Description        string        `meta:"Description,Caption-Abstract" xmp:"Description,Description.Alt"`
+	Copyright          string        `meta:"Rights,Copyright,CopyrightNotice,WebStatement" xmp:"Rights,Rights.Alt"`
+	License            string        `meta:"UsageTerms,License" xmp:"UsageTerms"`
+	CreditLine         string        `meta:"Credit" xmp:"Credit"`
+	CreatorAddress     string        `meta:"CreatorAddress" xmp:"CiAdrExtadr"`
+	CreatorCity        string        `meta:"CreatorCity" xmp:"CiAdrCity"`
+	CreatorRegion      string        `meta:"CreatorRegion" xmp:"CiAdrRegion"`
+	CreatorPostalCode  string        `meta:"CreatorPostalCode" xmp:"CiAdrPcode"`
+	CreatorCountry     string        `meta:"CreatorCountry" xmp:"CiAdrCtry"`
+	CreatorPhone       string        `meta:"CreatorWorkTelephone" xmp:"CiTelWork"`
+	CreatorEmail       string        `meta:"CreatorWorkEmail" xmp:"CiEmailWork"`
+	CreatorUrl         string        `meta:"CreatorWorkURL" xmp:"CiUrlWork"`
+	CreatedSublocation string        `meta:"LocationCreatedSublocation" xmp:"LocationCreated.Sublocation"`
+	CreatedCity        string        `meta:"LocationCreatedCity" xmp:"LocationCreated.City"`
+	CreatedState       string        `meta:"LocationCreatedProvinceState" xmp:"LocationCreated.ProvinceState"`
+	CreatedCountry     string        `meta:"LocationCreatedCountryName" xmp:"LocationCreated.CountryName"`
+	CreatedCountryCode string        `meta:"LocationCreatedCountryCode" xmp:"LocationCreated.CountryCode"`
+	ShownSublocation   string        `meta:"LocationShownSublocation,Sub-location" xmp:"LocationShown.Sublocation,Location"`
+	ShownCity          string        `meta:"LocationShownCity,City" xmp:"LocationShown.City,City"`
+	ShownState         string        `meta:"LocationShownProvinceState,Province-State,State" xmp:"LocationShown.ProvinceState,State"`
+	ShownCountry       string        `meta:"LocationShownCountryName,Country-PrimaryLocationName,Country" xmp:"LocationShown.CountryName,Country"`
+	ShownCountryCode   string        `meta:"LocationShownCountryCode,Country-PrimaryLocationCode,CountryCode" xmp:"LocationShown.CountryCode,CountryCode"`
+	PersonsShown       string        `meta:"PersonInImage" xmp:"PersonInImage"`
+	Event              string        `meta:"Event" xmp:"Event"`
+	DigitalSourceType  string        `meta:"DigitalSourceType" xmp:"DigitalSourceType"`
+	Rating             int           `meta:"Rating"`
+	ColorLabel         string        `meta:"Label"`
+	Projection         string        `meta:"ProjectionType"`
+	ColorProfile       string        `meta:"ICCProfileName,ProfileDescription"`
+	CameraMake         string        `meta:"CameraMake,Make" xmp:"Make"`
+	CameraModel        string        `meta:"CameraModel,Model" xmp:"Model"`
+	CameraOwner        string        `meta:"OwnerName"`
+	CameraSerial       string        `meta:"SerialNumber"`
+	LensMake           string        `meta:"LensMake"`
+	LensModel          string        `meta:"Lens,LensModel" xmp:"LensModel"`
+	Software           string        `meta:"Software,CreatorTool,HistorySoftwareAgent,ProcessingSoftware"`
+	Flash              bool          `meta:"FlashFired"`
+	FocalLength        int           `meta:"FocalLength,FocalLengthIn35mmFormat"`
+	FocalDistance      float64       `meta:"HyperfocalDistance"`
+	Exposure           string        `meta:"ExposureTime,ShutterSpeedValue,ShutterSpeed,TargetExposureTime"`
+	Aperture           float32       `meta:"ApertureValue,Aperture"`
+	FNumber            float32       `meta:"FNumber"`
+	Iso                int           `meta:"ISO"`
+	ImageType          int           `meta:"HDRImageType"`
+	GPSPosition        string        `meta:"GPSPosition"`
+	GPSLatitude        string        `meta:"GPSLatitude"`
+	GPSLongitude       string        `meta:"GPSLongitude"`
+	Lat                float32       `meta:"-"`
+	Lng                float32       `meta:"-"`
+	Altitude           float64       `meta:"GlobalAltitude,GPSAltitude"`
+	Width              int           `meta:"ImageWidth,PixelXDimension,ExifImageWidth,SourceImageWidth"`
+	Height             int           `meta:"ImageHeight,ImageLength,PixelYDimension,ExifImageHeight,SourceImageHeight"`
+	Orientation        int           `meta:"-"`
+	Rotation           int           `meta:"Rotation"`
+	Views              int           `meta:"-"`
+	Albums             []string      `meta:"-"`
+	Error              error         `meta:"-"`
+	json               map[string]string
+	exif               map[string]string
 }
 
 // New returns a new metadata struct.
@@ -219,6 +219,10 @@ func (data *Data) Exif(fileName string, fileFormat fs.Type, bruteForce bool) (er
 		}
 	}
 
+	if value, ok := data.exif["Rating"]; ok {
+		data.Rating = SanitizeRating(value)
+	}
+
 	if value, ok := data.exif["ImageUniqueID"]; ok {
 		if id := rnd.SanitizeUUID(value); id != "" {
 			data.DocumentID = id
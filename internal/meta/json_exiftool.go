@@ -364,6 +364,11 @@ func (data *Data) Exiftool(jsonData []byte, originalName string) (err error) {
 	data.Title = SanitizeTitle(data.Title)
 	data.Subject = SanitizeMeta(data.Subject)
 	data.Artist = SanitizeMeta(data.Artist)
+	data.PersonsShown = SanitizeMeta(data.PersonsShown)
+	data.CreatedCountryCode = SanitizeCountryCode(data.CreatedCountryCode)
+	data.ShownCountryCode = SanitizeCountryCode(data.ShownCountryCode)
+	data.Rating = SanitizeRating(strconv.Itoa(data.Rating))
+	data.ColorLabel = SanitizeColorLabel(data.ColorLabel)
 
 	return nil
 }
@@ -1,6 +1,7 @@
 package meta
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"runtime/debug"
@@ -10,13 +11,27 @@ import (
 )
 
 type GPhoto struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Views       int    `json:"imageViews,string"`
-	Geo         GGeo   `json:"geoData"`
-	TakenAt     GTime  `json:"photoTakenTime"`
-	CreatedAt   GTime  `json:"creationTime"`
-	UpdatedAt   GTime  `json:"modificationTime"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Views       int     `json:"imageViews,string"`
+	Geo         GGeo    `json:"geoData"`
+	TakenAt     GTime   `json:"photoTakenTime"`
+	CreatedAt   GTime   `json:"creationTime"`
+	UpdatedAt   GTime   `json:"modificationTime"`
+	Favorited   bool    `json:"favorited"`
+	Archived    bool    `json:"archived"`
+	Origin      GOrigin `json:"googlePhotosOrigin"`
+}
+
+// GOrigin describes where a Google Photos item came from, e.g. a shared album.
+type GOrigin struct {
+	FromSharedAlbum *struct{} `json:"fromSharedAlbum,omitempty"`
+	FromPartner     *struct{} `json:"fromPartnerSharing,omitempty"`
+}
+
+// Shared tests if the item was contributed to a shared album or by a sharing partner.
+func (m GOrigin) Shared() bool {
+	return m.FromSharedAlbum != nil || m.FromPartner != nil
 }
 
 func (m GPhoto) SanitizedTitle() string {
@@ -44,6 +59,44 @@ func (m GAlbum) Exists() bool {
 	return m.Title != ""
 }
 
+// Shared tests if the album was shared with other users.
+func (m GAlbum) Shared() bool {
+	return m.Access == "protected" || m.Access == "shared"
+}
+
+// SanitizedTitle returns the sanitized album title.
+func (m GAlbum) SanitizedTitle() string {
+	return SanitizeTitle(m.Title)
+}
+
+// SanitizedDescription returns the sanitized album description.
+func (m GAlbum) SanitizedDescription() string {
+	return SanitizeDescription(m.Description)
+}
+
+// ParseGAlbum parses album metadata as created by Google Photos, both with and without "albumData" wrapper.
+func ParseGAlbum(jsonData []byte) (result GAlbum, err error) {
+	if bytes.Contains(jsonData, []byte("photoTakenTime")) {
+		return result, fmt.Errorf("metadata: photo sidecar is not an album")
+	}
+
+	p := GMeta{}
+
+	if err = json.Unmarshal(jsonData, &p); err != nil {
+		return result, err
+	} else if p.Album.Exists() {
+		return p.Album, nil
+	}
+
+	if err = json.Unmarshal(jsonData, &result); err != nil {
+		return result, err
+	} else if !result.Exists() {
+		return result, fmt.Errorf("metadata: album title is missing")
+	}
+
+	return result, nil
+}
+
 type GGeo struct {
 	Lat      float64 `json:"latitude"`
 	Lng      float64 `json:"longitude"`
@@ -0,0 +1,79 @@
+package meta
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGAlbum(t *testing.T) {
+	t.Run("AlbumData", func(t *testing.T) {
+		jsonData, err := os.ReadFile("testdata/gphotos-album.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		album, err := ParseGAlbum(jsonData)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "iPhone", album.SanitizedTitle())
+		assert.True(t, album.Shared())
+		assert.Equal(t, int64(1320701674), album.Date.Unix)
+	})
+	t.Run("TopLevel", func(t *testing.T) {
+		jsonData, err := os.ReadFile("testdata/gphotos-album-2.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		album, err := ParseGAlbum(jsonData)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Summer in Berlin", album.SanitizedTitle())
+		assert.Equal(t, "Family trip", album.SanitizedDescription())
+		assert.True(t, album.Shared())
+	})
+	t.Run("Photo", func(t *testing.T) {
+		jsonData, err := os.ReadFile("testdata/gphotos-1.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = ParseGAlbum(jsonData)
+
+		assert.Error(t, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ParseGAlbum([]byte(`{"foo": "bar"}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestGOrigin_Shared(t *testing.T) {
+	jsonData, err := os.ReadFile("testdata/gphotos-shared.json")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := GPhoto{}
+
+	if err = json.Unmarshal(jsonData, &p); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, p.Origin.Shared())
+	assert.True(t, p.Favorited)
+	assert.False(t, GOrigin{}.Shared())
+}
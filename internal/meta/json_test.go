@@ -246,6 +246,14 @@ func TestJSON(t *testing.T) {
 		assert.Equal(t, "ELE-L29", data.CameraModel)
 		assert.Equal(t, "HUAWEI P30 Rear Main Camera", data.LensModel)
 		assert.Equal(t, 1, data.Orientation)
+		assert.Equal(t, "Zimmermannstr. 37", data.CreatorAddress)
+		assert.Equal(t, "Berlin", data.CreatorCity)
+		assert.Equal(t, "12163", data.CreatorPostalCode)
+		assert.Equal(t, "Germany", data.CreatorCountry)
+		assert.Equal(t, "+49123456789", data.CreatorPhone)
+		assert.Equal(t, "hello@photoprism.org", data.CreatorEmail)
+		assert.Equal(t, "https://photoprism.org/", data.CreatorUrl)
+		assert.Equal(t, "Gopher", data.PersonsShown)
 	})
 
 	t.Run("canon_eos_6d.json", func(t *testing.T) {
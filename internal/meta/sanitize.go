@@ -2,7 +2,9 @@ package meta
 
 import (
 	"encoding/json"
+	"math"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -138,6 +140,61 @@ func SanitizeDescription(s string) string {
 	}
 }
 
+// ColorLabels maps supported color label names, e.g. as used by Adobe Lightroom, to their normalized name.
+var ColorLabels = map[string]string{
+	"red":     "red",
+	"orange":  "orange",
+	"yellow":  "yellow",
+	"green":   "green",
+	"blue":    "blue",
+	"purple":  "purple",
+	"magenta": "purple",
+	"violet":  "purple",
+	"gray":    "gray",
+	"grey":    "gray",
+}
+
+// SanitizeRating returns a valid star rating from 0 to 5, treating rejected (-1) and invalid values as unrated.
+func SanitizeRating(s string) int {
+	s = SanitizeString(s)
+
+	if s == "" {
+		return 0
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+
+	if err != nil || f <= 0 {
+		return 0
+	} else if f > 5 {
+		return 5
+	}
+
+	return int(math.Round(f))
+}
+
+// SanitizeCountryCode returns the normalized ISO country code, or an empty string if it is invalid.
+func SanitizeCountryCode(s string) string {
+	s = strings.ToUpper(SanitizeString(s))
+
+	if l := len(s); l < 2 || l > 3 {
+		return ""
+	}
+
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return ""
+		}
+	}
+
+	return s
+}
+
+// SanitizeColorLabel returns the normalized name of a supported color label, or an empty string if unknown.
+func SanitizeColorLabel(s string) string {
+	return ColorLabels[strings.ToLower(SanitizeString(s))]
+}
+
 // SanitizeMeta normalizes metadata fields that may contain JSON arrays like keywords and subject.
 func SanitizeMeta(s string) string {
 	if s == "" {
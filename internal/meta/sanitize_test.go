@@ -173,3 +173,29 @@ func TestSanitizeUID(t *testing.T) {
 	})
 
 }
+
+func TestSanitizeRating(t *testing.T) {
+	assert.Equal(t, 0, SanitizeRating(""))
+	assert.Equal(t, 0, SanitizeRating("-1"))
+	assert.Equal(t, 0, SanitizeRating("foo"))
+	assert.Equal(t, 1, SanitizeRating("1"))
+	assert.Equal(t, 4, SanitizeRating(" 4 "))
+	assert.Equal(t, 3, SanitizeRating("2.5"))
+	assert.Equal(t, 5, SanitizeRating("99"))
+}
+
+func TestSanitizeCountryCode(t *testing.T) {
+	assert.Equal(t, "", SanitizeCountryCode(""))
+	assert.Equal(t, "", SanitizeCountryCode("Germany"))
+	assert.Equal(t, "", SanitizeCountryCode("D1"))
+	assert.Equal(t, "DE", SanitizeCountryCode(" de "))
+	assert.Equal(t, "DEU", SanitizeCountryCode("DEU"))
+}
+
+func TestSanitizeColorLabel(t *testing.T) {
+	assert.Equal(t, "", SanitizeColorLabel(""))
+	assert.Equal(t, "", SanitizeColorLabel("Approved"))
+	assert.Equal(t, "red", SanitizeColorLabel("Red"))
+	assert.Equal(t, "purple", SanitizeColorLabel("Magenta"))
+	assert.Equal(t, "gray", SanitizeColorLabel(" grey "))
+}
@@ -53,6 +53,63 @@ func (data *Data) XMP(fileName string) (err error) {
 		data.Copyright = doc.Copyright()
 	}
 
+	if doc.License() != "" {
+		data.License = doc.License()
+	}
+
+	if doc.CreditLine() != "" {
+		data.CreditLine = doc.CreditLine()
+	}
+
+	// IPTC creator contact info.
+	contact := doc.RDF.Description.CreatorContactInfo
+
+	xmpString(&data.CreatorAddress, contact.CiAdrExtadr)
+	xmpString(&data.CreatorCity, contact.CiAdrCity)
+	xmpString(&data.CreatorRegion, contact.CiAdrRegion)
+	xmpString(&data.CreatorPostalCode, contact.CiAdrPcode)
+	xmpString(&data.CreatorCountry, contact.CiAdrCtry)
+	xmpString(&data.CreatorPhone, contact.CiTelWork)
+	xmpString(&data.CreatorEmail, contact.CiEmailWork)
+	xmpString(&data.CreatorUrl, contact.CiUrlWork)
+
+	// IPTC location created and shown.
+	created := doc.LocationCreated()
+
+	xmpString(&data.CreatedSublocation, created.Sublocation)
+	xmpString(&data.CreatedCity, created.City)
+	xmpString(&data.CreatedState, created.ProvinceState)
+	xmpString(&data.CreatedCountry, created.CountryName)
+	xmpString(&data.CreatedCountryCode, SanitizeCountryCode(created.CountryCode))
+
+	shown := doc.LocationShown()
+
+	xmpString(&data.ShownSublocation, shown.Sublocation)
+	xmpString(&data.ShownCity, shown.City)
+	xmpString(&data.ShownState, shown.ProvinceState)
+	xmpString(&data.ShownCountry, shown.CountryName)
+	xmpString(&data.ShownCountryCode, SanitizeCountryCode(shown.CountryCode))
+
+	if doc.PersonsShown() != "" {
+		data.PersonsShown = doc.PersonsShown()
+	}
+
+	if doc.Event() != "" {
+		data.Event = doc.Event()
+	}
+
+	if doc.DigitalSourceType() != "" {
+		data.DigitalSourceType = doc.DigitalSourceType()
+	}
+
+	if rating := doc.Rating(); rating > 0 {
+		data.Rating = rating
+	}
+
+	if doc.ColorLabel() != "" {
+		data.ColorLabel = doc.ColorLabel()
+	}
+
 	if doc.CameraMake() != "" {
 		data.CameraMake = doc.CameraMake()
 	}
@@ -78,3 +135,10 @@ func (data *Data) XMP(fileName string) (err error) {
 
 	return nil
 }
+
+// xmpString sets the value if the sanitized string is not empty.
+func xmpString(value *string, s string) {
+	if s = SanitizeString(s); s != "" {
+		*value = s
+	}
+}
@@ -37,6 +37,9 @@ type XmpDocument struct {
 			CreateDate      string `xml:"CreateDate"`      // 2020-01-01T17:28:23
 			MetadataDate    string `xml:"MetadataDate"`    // 2020-01-01T17:28:23.89961...
 			Rating          string `xml:"Rating"`          // 4
+			RatingAttr      string `xml:"Rating,attr"`     // 4
+			Label           string `xml:"Label"`           // Red
+			LabelAttr       string `xml:"Label,attr"`      // Red
 			Lens            string `xml:"Lens"`            // HUAWEI P30 Rear Main Came...
 			LensModel       string `xml:"LensModel"`       // HUAWEI P30 Rear Main Came...
 			DateCreated     string `xml:"DateCreated"`     // 2020-01-01T17:28:25.72962...
@@ -183,6 +186,7 @@ type XmpDocument struct {
 				ParseType   string `xml:"parseType,attr" json:"parsetype,omitempty"`
 				CiAdrExtadr string `xml:"CiAdrExtadr"` // Zimmermannstr. 37
 				CiAdrCity   string `xml:"CiAdrCity"`   // Berlin
+				CiAdrRegion string `xml:"CiAdrRegion"` // Berlin
 				CiAdrPcode  string `xml:"CiAdrPcode"`  // 12163
 				CiAdrCtry   string `xml:"CiAdrCtry"`   // Germany
 				CiTelWork   string `xml:"CiTelWork"`   // +49123456789
@@ -192,14 +196,64 @@ type XmpDocument struct {
 			PersonInImage struct {
 				Text string `xml:",chardata" json:"text,omitempty"`
 				Bag  struct {
-					Text string `xml:",chardata" json:"text,omitempty"`
-					Li   string `xml:"li"` // Gopher
+					Text string   `xml:",chardata" json:"text,omitempty"`
+					Li   []string `xml:"li"` // Gopher
 				} `xml:"Bag" json:"bag,omitempty"`
 			} `xml:"PersonInImage" json:"personinimage,omitempty"`
+			UsageTerms struct {
+				Text string `xml:",chardata" json:"text,omitempty"`
+				Alt  struct {
+					Text string `xml:",chardata" json:"text,omitempty"`
+					Li   struct {
+						Text string `xml:",chardata" json:"text,omitempty"` // Editorial use only
+						Lang string `xml:"lang,attr" json:"lang,omitempty"`
+					} `xml:"li" json:"li,omitempty"`
+				} `xml:"Alt" json:"alt,omitempty"`
+			} `xml:"UsageTerms" json:"usageterms,omitempty"`
+			Event struct {
+				Text string `xml:",chardata" json:"text,omitempty"`
+				Alt  struct {
+					Text string `xml:",chardata" json:"text,omitempty"`
+					Li   struct {
+						Text string `xml:",chardata" json:"text,omitempty"` // Berlin Marathon 2020
+						Lang string `xml:"lang,attr" json:"lang,omitempty"`
+					} `xml:"li" json:"li,omitempty"`
+				} `xml:"Alt" json:"alt,omitempty"`
+			} `xml:"Event" json:"event,omitempty"`
+			Credit            string `xml:"Credit"`            // PhotoPrism
+			Location          string `xml:"Location"`          // Kreuzberg
+			City              string `xml:"City"`              // Berlin
+			State             string `xml:"State"`             // Berlin
+			Country           string `xml:"Country"`           // Germany
+			CountryCode       string `xml:"CountryCode"`       // DE
+			DigitalSourceType string `xml:"DigitalSourceType"` // http://cv.iptc.org/newscodes/digitalsourcetype/digitalCapture
+			LocationCreated   struct {
+				Text string `xml:",chardata" json:"text,omitempty"`
+				Bag  struct {
+					Text string        `xml:",chardata" json:"text,omitempty"`
+					Li   []XmpLocation `xml:"li"`
+				} `xml:"Bag" json:"bag,omitempty"`
+			} `xml:"LocationCreated" json:"locationcreated,omitempty"`
+			LocationShown struct {
+				Text string `xml:",chardata" json:"text,omitempty"`
+				Bag  struct {
+					Text string        `xml:",chardata" json:"text,omitempty"`
+					Li   []XmpLocation `xml:"li"`
+				} `xml:"Bag" json:"bag,omitempty"`
+			} `xml:"LocationShown" json:"locationshown,omitempty"`
 		} `xml:"Description" json:"description,omitempty"`
 	} `xml:"RDF" json:"rdf,omitempty"`
 }
 
+// XmpLocation represents a location structure as defined in the IPTC Photo Metadata Extension.
+type XmpLocation struct {
+	Sublocation   string `xml:"Sublocation"`   // Kreuzberg
+	City          string `xml:"City"`          // Berlin
+	ProvinceState string `xml:"ProvinceState"` // Berlin
+	CountryName   string `xml:"CountryName"`   // Germany
+	CountryCode   string `xml:"CountryCode"`   // DE
+}
+
 // Load parses an XMP file and populates document values with its contents.
 func (doc *XmpDocument) Load(filename string) error {
 	data, err := os.ReadFile(filename)
@@ -245,6 +299,28 @@ func (doc *XmpDocument) Copyright() string {
 	return SanitizeString(doc.RDF.Description.Rights.Alt.Li.Text)
 }
 
+// Rating returns the XMP document star rating from 0 to 5.
+func (doc *XmpDocument) Rating() int {
+	s := doc.RDF.Description.Rating
+
+	if s == "" {
+		s = doc.RDF.Description.RatingAttr
+	}
+
+	return SanitizeRating(s)
+}
+
+// ColorLabel returns the XMP document color label, e.g. as set in Adobe Lightroom.
+func (doc *XmpDocument) ColorLabel() string {
+	s := doc.RDF.Description.Label
+
+	if s == "" {
+		s = doc.RDF.Description.LabelAttr
+	}
+
+	return SanitizeColorLabel(s)
+}
+
 // CameraMake returns the XMP document camera make name.
 func (doc *XmpDocument) CameraMake() string {
 	return SanitizeString(doc.RDF.Description.Make)
@@ -277,9 +353,66 @@ func (doc *XmpDocument) TakenAt(timeZone string) time.Time {
 	return taken
 }
 
-// Keywords returns the XMP document keywords.
+// Keywords returns the XMP document keywords, which may be stored as ordered or unordered list.
 func (doc *XmpDocument) Keywords() string {
-	s := doc.RDF.Description.Subject.Seq.Li
+	s := append(doc.RDF.Description.Subject.Seq.Li, doc.RDF.Description.Subject.Bag.Li...)
 
 	return strings.Join(s, ", ")
 }
+
+// License returns the XMP document rights usage terms.
+func (doc *XmpDocument) License() string {
+	return SanitizeString(doc.RDF.Description.UsageTerms.Alt.Li.Text)
+}
+
+// CreditLine returns the XMP document credit line.
+func (doc *XmpDocument) CreditLine() string {
+	return SanitizeString(doc.RDF.Description.Credit)
+}
+
+// PersonsShown returns the names of the persons shown in the image as comma-separated list.
+func (doc *XmpDocument) PersonsShown() string {
+	names := make([]string, 0, len(doc.RDF.Description.PersonInImage.Bag.Li))
+
+	for _, s := range doc.RDF.Description.PersonInImage.Bag.Li {
+		if s = SanitizeString(s); s != "" {
+			names = append(names, s)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Event returns the name of the event the image shows.
+func (doc *XmpDocument) Event() string {
+	return SanitizeString(doc.RDF.Description.Event.Alt.Li.Text)
+}
+
+// DigitalSourceType returns the IPTC digital source type, e.g. digital capture or scan.
+func (doc *XmpDocument) DigitalSourceType() string {
+	return SanitizeString(doc.RDF.Description.DigitalSourceType)
+}
+
+// LocationCreated returns the location where the image was created.
+func (doc *XmpDocument) LocationCreated() (loc XmpLocation) {
+	if l := doc.RDF.Description.LocationCreated.Bag.Li; len(l) > 0 {
+		loc = l[0]
+	}
+
+	return loc
+}
+
+// LocationShown returns the location shown in the image, with a fallback to the legacy IPTC Core fields.
+func (doc *XmpDocument) LocationShown() (loc XmpLocation) {
+	if l := doc.RDF.Description.LocationShown.Bag.Li; len(l) > 0 {
+		return l[0]
+	}
+
+	return XmpLocation{
+		Sublocation:   doc.RDF.Description.Location,
+		City:          doc.RDF.Description.City,
+		ProvinceState: doc.RDF.Description.State,
+		CountryName:   doc.RDF.Description.Country,
+		CountryCode:   doc.RDF.Description.CountryCode,
+	}
+}
@@ -0,0 +1,127 @@
+package meta
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// JPEG markers and XMP segment signatures, see https://www.adobe.com/devnet/xmp.html (part 3).
+const (
+	jpegMarkerSOI  = 0xD8
+	jpegMarkerEOI  = 0xD9
+	jpegMarkerSOS  = 0xDA
+	jpegMarkerAPP0 = 0xE0
+	jpegMarkerAPP1 = 0xE1
+
+	xmpJpegHeader    = "http://ns.adobe.com/xap/1.0/\x00"
+	xmpJpegExtHeader = "http://ns.adobe.com/xmp/extension/\x00"
+	xmpJpegMaxSize   = 0xFFFF - 2 - len(xmpJpegHeader)
+)
+
+// JpegWithXmp returns a copy of the JPEG image data with the XMP packet embedded in an APP1 segment.
+// If the image already contains XMP metadata, the properties are merged so that other metadata, e.g. for
+// panoramas or HDR gain maps, is preserved. Extended XMP segments and the image data remain unchanged.
+func JpegWithXmp(img, packet []byte) ([]byte, error) {
+	segments, data, err := jpegSegments(img)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the position of the standard XMP segment, or insert it after the JFIF and Exif segments.
+	index := -1
+	insert := len(segments)
+
+	for i, segment := range segments {
+		if segment[1] == jpegMarkerAPP1 && bytes.HasPrefix(segment[4:], []byte(xmpJpegHeader)) {
+			if packet, err = MergeXmp(segment[4+len(xmpJpegHeader):], packet); err != nil {
+				return nil, fmt.Errorf("%s in existing xmp", err)
+			}
+
+			index = i
+			break
+		} else if insert == len(segments) && segment[1] != jpegMarkerAPP0 && segment[1] != jpegMarkerAPP1 {
+			insert = i
+		}
+	}
+
+	if len(packet) > xmpJpegMaxSize {
+		return nil, fmt.Errorf("xmp packet exceeds %d bytes", xmpJpegMaxSize)
+	}
+
+	size := 2 + len(xmpJpegHeader) + len(packet)
+
+	xmpSegment := make([]byte, 0, size+2)
+	xmpSegment = append(xmpSegment, 0xFF, jpegMarkerAPP1, byte(size>>8), byte(size))
+	xmpSegment = append(xmpSegment, xmpJpegHeader...)
+	xmpSegment = append(xmpSegment, packet...)
+
+	if index >= 0 {
+		segments[index] = xmpSegment
+	} else {
+		segments = append(segments[:insert], append([][]byte{xmpSegment}, segments[insert:]...)...)
+	}
+
+	result := bytes.NewBuffer(make([]byte, 0, len(img)+size))
+	result.Write(img[:2])
+
+	for _, segment := range segments {
+		result.Write(segment)
+	}
+
+	result.Write(data)
+
+	return result.Bytes(), nil
+}
+
+// jpegSegments returns the marker segments of a JPEG image before the image data, and the remaining data
+// starting with the start of scan or end of image marker.
+func jpegSegments(img []byte) (segments [][]byte, data []byte, err error) {
+	if len(img) < 4 || img[0] != 0xFF || img[1] != jpegMarkerSOI {
+		return nil, nil, errors.New("invalid jpeg image")
+	}
+
+	pos := 2
+
+	for pos+1 < len(img) {
+		if img[pos] != 0xFF {
+			return nil, nil, fmt.Errorf("invalid jpeg marker at offset %d", pos)
+		}
+
+		marker := img[pos+1]
+
+		// Skip fill bytes.
+		if marker == 0xFF {
+			pos++
+			continue
+		}
+
+		// Start of scan or end of image.
+		if marker == jpegMarkerSOS || marker == jpegMarkerEOI {
+			return segments, img[pos:], nil
+		}
+
+		if pos+4 > len(img) {
+			return nil, nil, errors.New("unexpected end of jpeg image")
+		}
+
+		// The segment length includes the two length bytes.
+		length := int(img[pos+2])<<8 + int(img[pos+3])
+
+		if length < 2 {
+			return nil, nil, fmt.Errorf("invalid jpeg segment length at offset %d", pos)
+		}
+
+		end := pos + 2 + length
+
+		if end > len(img) {
+			return nil, nil, errors.New("unexpected end of jpeg image")
+		}
+
+		segments = append(segments, img[pos:end])
+		pos = end
+	}
+
+	return segments, img[pos:], nil
+}
@@ -0,0 +1,91 @@
+package meta
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJpegWithXmp(t *testing.T) {
+	img, err := os.ReadFile("testdata/iptc-2021.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	packet := Data{Title: "Finish Line", Event: "Berlin Marathon 2020"}.XmpPacket()
+
+	t.Run("Merge", func(t *testing.T) {
+		result, err := JpegWithXmp(img, packet)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, bytes.Count(result, []byte(xmpJpegHeader)))
+		assert.True(t, bytes.Contains(result, []byte("Finish Line")))
+		assert.True(t, bytes.Contains(result, []byte("Berlin Marathon 2020")))
+
+		// Replaced properties are removed, other properties and the IPTC-IIM data are preserved.
+		assert.Equal(t, 2, bytes.Count(img, []byte("The Title (ref2021.1)")))
+		assert.Equal(t, 1, bytes.Count(result, []byte("The Title (ref2021.1)")))
+		assert.True(t, bytes.Contains(result, []byte("A Genre (ref2021.1)")))
+
+		if _, err = jpeg.DecodeConfig(bytes.NewReader(result)); err != nil {
+			t.Fatal(err)
+		}
+
+		// Properties that are not set again remain unchanged.
+		updated, err := JpegWithXmp(result, Data{Title: "Updated"}.XmpPacket())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, bytes.Count(updated, []byte(xmpJpegHeader)))
+		assert.False(t, bytes.Contains(updated, []byte("Finish Line")))
+		assert.True(t, bytes.Contains(updated, []byte("Updated")))
+		assert.True(t, bytes.Contains(updated, []byte("Berlin Marathon 2020")))
+		assert.True(t, bytes.Contains(updated, []byte("A Genre (ref2021.1)")))
+	})
+	t.Run("Embed", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, 8, 8)), nil); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := JpegWithXmp(buf.Bytes(), packet)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, bytes.Count(result, []byte(xmpJpegHeader)))
+		assert.True(t, bytes.Contains(result, packet))
+
+		if _, err = jpeg.Decode(bytes.NewReader(result)); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := JpegWithXmp([]byte("foo"), packet)
+		assert.Error(t, err)
+	})
+	t.Run("InvalidSegmentLength", func(t *testing.T) {
+		_, err := JpegWithXmp([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x01, 0xFF, 0xD9}, packet)
+		assert.Error(t, err)
+
+		_, err = JpegWithXmp([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0x00, 0x00}, packet)
+		assert.Error(t, err)
+	})
+	t.Run("TooLarge", func(t *testing.T) {
+		_, err := JpegWithXmp(img, Data{Description: strings.Repeat("x", 70000)}.XmpPacket())
+		assert.Error(t, err)
+	})
+}
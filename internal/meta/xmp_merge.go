@@ -0,0 +1,294 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+)
+
+// rdfNamespace is the namespace of RDF elements and attributes in XMP packets.
+const rdfNamespace = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+
+// xmpName represents the name of an XMP property with the namespace URI resolved.
+type xmpName struct {
+	Space string
+	Local string
+}
+
+// xmpRange represents the byte range of a property or tag in an XMP packet.
+type xmpRange struct {
+	Name  xmpName
+	Start int64
+	End   int64
+}
+
+// xmpDescription represents an rdf:Description element in an XMP packet.
+type xmpDescription struct {
+	Start    int64 // Start of the opening tag.
+	TagEnd   int64 // End of the opening tag.
+	End      int64 // End of the element.
+	Tag      xml.StartElement
+	Attrs    []xmpName
+	Closed   bool
+	Children []xmpRange
+}
+
+// xmpPacketInfo contains the rdf:Description elements of an XMP packet and the offset of the closing rdf:RDF tag.
+type xmpPacketInfo struct {
+	Descriptions []xmpDescription
+	RdfEnd       int64
+}
+
+// Properties returns the names of all properties set in the packet.
+func (info xmpPacketInfo) Properties() map[xmpName]bool {
+	result := make(map[xmpName]bool)
+
+	for _, desc := range info.Descriptions {
+		for _, name := range desc.Attrs {
+			if name.Space != "" && name.Space != rdfNamespace {
+				result[name] = true
+			}
+		}
+
+		for _, child := range desc.Children {
+			result[child.Name] = true
+		}
+	}
+
+	return result
+}
+
+// MergeXmp adds the properties of an XMP packet to an existing packet, so that other metadata such as
+// panorama or HDR gain map properties are preserved. Existing properties with the same name are replaced.
+func MergeXmp(existing, packet []byte) ([]byte, error) {
+	src, err := scanXmp(packet)
+
+	if err != nil {
+		return nil, err
+	} else if len(src.Descriptions) == 0 {
+		return existing, nil
+	}
+
+	dst, err := scanXmp(existing)
+
+	if err != nil {
+		return nil, err
+	} else if dst.RdfEnd < 0 {
+		return nil, errors.New("invalid xmp packet")
+	}
+
+	replace := src.Properties()
+
+	type edit struct {
+		start, end int64
+		data       []byte
+	}
+
+	var edits []edit
+
+	// Remove properties that are replaced.
+	for _, desc := range dst.Descriptions {
+		var attrs []xml.Attr
+
+		removed := false
+
+		for i, attr := range desc.Tag.Attr {
+			if replace[desc.Attrs[i]] {
+				removed = true
+			} else {
+				attrs = append(attrs, attr)
+			}
+		}
+
+		if removed {
+			edits = append(edits, edit{desc.Start, desc.TagEnd, xmpStartTag(desc.Tag.Name, attrs, desc.Closed)})
+		}
+
+		for _, child := range desc.Children {
+			if replace[child.Name] {
+				edits = append(edits, edit{child.Start, child.End, nil})
+			}
+		}
+	}
+
+	// Add the new descriptions at the end, declaring the rdf prefix in case the existing packet uses another.
+	var add bytes.Buffer
+
+	for _, desc := range src.Descriptions {
+		tag := packet[desc.Start:desc.End]
+
+		if i := bytes.IndexAny(tag, " \t\r\n/>"); i > 0 && desc.Tag.Name.Space == "rdf" {
+			add.Write(tag[:i])
+			add.WriteString(" xmlns:rdf=\"" + rdfNamespace + "\"")
+			add.Write(tag[i:])
+		} else {
+			add.Write(tag)
+		}
+
+		add.WriteString("\n ")
+	}
+
+	edits = append(edits, edit{dst.RdfEnd, dst.RdfEnd, add.Bytes()})
+
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].start < edits[j].start
+	})
+
+	result := bytes.NewBuffer(make([]byte, 0, len(existing)+len(packet)))
+
+	var pos int64
+
+	for _, e := range edits {
+		if e.start < pos {
+			continue
+		}
+
+		result.Write(existing[pos:e.start])
+		result.Write(e.data)
+		pos = e.end
+	}
+
+	result.Write(existing[pos:])
+
+	return result.Bytes(), nil
+}
+
+// scanXmp returns the rdf:Description elements of an XMP packet with the byte ranges of their properties.
+func scanXmp(packet []byte) (info xmpPacketInfo, err error) {
+	info.RdfEnd = -1
+
+	d := xml.NewDecoder(bytes.NewReader(packet))
+
+	// Namespace declarations in scope, as raw tokens are not translated.
+	scopes := []map[string]string{{"xml": "http://www.w3.org/XML/1998/namespace"}}
+
+	resolve := func(name xml.Name, attr bool) xmpName {
+		if name.Space == "" && attr {
+			return xmpName{Local: name.Local}
+		}
+
+		return xmpName{Space: scopes[len(scopes)-1][name.Space], Local: name.Local}
+	}
+
+	depth := 0
+	desc := -1
+	child := xmpRange{Start: -1}
+
+	for {
+		start := d.InputOffset()
+		tok, tokErr := d.RawToken()
+
+		if tokErr == io.EOF {
+			break
+		} else if tokErr != nil {
+			return info, tokErr
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			scope := make(map[string]string, len(scopes[len(scopes)-1])+len(t.Attr))
+
+			for k, v := range scopes[len(scopes)-1] {
+				scope[k] = v
+			}
+
+			for _, attr := range t.Attr {
+				if attr.Name.Space == "xmlns" {
+					scope[attr.Name.Local] = attr.Value
+				} else if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+					scope[""] = attr.Value
+				}
+			}
+
+			scopes = append(scopes, scope)
+			depth++
+
+			name := resolve(t.Name, false)
+
+			if desc < 0 && name == (xmpName{rdfNamespace, "Description"}) {
+				end := d.InputOffset()
+				attrs := make([]xmpName, len(t.Attr))
+
+				for i, attr := range t.Attr {
+					if attr.Name.Space == "xmlns" || attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+						continue
+					}
+
+					attrs[i] = resolve(attr.Name, true)
+				}
+
+				info.Descriptions = append(info.Descriptions, xmpDescription{
+					Start:  start,
+					TagEnd: end,
+					Tag:    t.Copy(),
+					Attrs:  attrs,
+					Closed: bytes.HasSuffix(bytes.TrimSpace(packet[start:end]), []byte("/>")),
+				})
+
+				desc = depth
+			} else if desc > 0 && depth == desc+1 {
+				child = xmpRange{Name: name, Start: start}
+			}
+		case xml.EndElement:
+			if len(scopes) < 2 {
+				return info, errors.New("invalid xmp packet")
+			}
+
+			name := resolve(t.Name, false)
+			end := d.InputOffset()
+
+			if desc > 0 && depth == desc+1 && child.Start >= 0 {
+				child.End = end
+				info.Descriptions[len(info.Descriptions)-1].Children = append(info.Descriptions[len(info.Descriptions)-1].Children, child)
+				child = xmpRange{Start: -1}
+			} else if depth == desc {
+				info.Descriptions[len(info.Descriptions)-1].End = end
+				desc = -1
+			}
+
+			if name == (xmpName{rdfNamespace, "RDF"}) {
+				info.RdfEnd = start
+			}
+
+			scopes = scopes[:len(scopes)-1]
+			depth--
+		}
+	}
+
+	return info, nil
+}
+
+// xmpStartTag returns an opening tag with the specified name and attributes.
+func xmpStartTag(name xml.Name, attrs []xml.Attr, closed bool) []byte {
+	var b bytes.Buffer
+
+	b.WriteString("<")
+	b.WriteString(xmpQName(name))
+
+	for _, attr := range attrs {
+		b.WriteString("\n   ")
+		b.WriteString(xmpQName(attr.Name))
+		b.WriteString("=\"")
+		_ = xml.EscapeText(&b, []byte(attr.Value))
+		b.WriteString("\"")
+	}
+
+	if closed {
+		b.WriteString("/>")
+	} else {
+		b.WriteString(">")
+	}
+
+	return b.Bytes()
+}
+
+// xmpQName returns the qualified name with prefix.
+func xmpQName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+
+	return name.Space + ":" + name.Local
+}
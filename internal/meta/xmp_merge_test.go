@@ -0,0 +1,63 @@
+package meta
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeXmp(t *testing.T) {
+	existing := []byte(`<?xpacket begin="` + "\ufeff" + `" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <RDF:RDF xmlns:RDF="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <RDF:Description RDF:about=""
+    xmlns:GPano="http://ns.google.com/photos/1.0/panorama/"
+    xmlns:xmp="http://ns.adobe.com/xap/1.0/"
+    GPano:ProjectionType="equirectangular"
+    xmp:Rating="2"/>
+  <RDF:Description RDF:about="" xmlns:d="http://purl.org/dc/elements/1.1/">
+   <d:title><RDF:Alt><RDF:li xml:lang="x-default">Camera Title</RDF:li></RDF:Alt></d:title>
+   <d:format>image/jpeg</d:format>
+  </RDF:Description>
+ </RDF:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>`)
+
+	packet := Data{Title: "Panorama", Rating: 5}.XmpPacket()
+
+	result, err := MergeXmp(existing, packet)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := string(result)
+
+	// Panorama metadata and properties not set by the packet are preserved.
+	assert.Contains(t, s, `GPano:ProjectionType="equirectangular"`)
+	assert.Contains(t, s, `<d:format>image/jpeg</d:format>`)
+
+	// Properties set by the packet are replaced, even if another prefix is used.
+	assert.NotContains(t, s, "Camera Title")
+	assert.NotContains(t, s, `xmp:Rating="2"`)
+	assert.Contains(t, s, "Panorama")
+	assert.Contains(t, s, `xmp:Rating="5"`)
+	assert.Equal(t, 1, strings.Count(s, "</RDF:RDF>"))
+
+	// The result must be a valid packet.
+	info, err := scanXmp(result)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, info.Descriptions, 3)
+	assert.True(t, info.Properties()[xmpName{"http://ns.google.com/photos/1.0/panorama/", "ProjectionType"}])
+	assert.True(t, info.Properties()[xmpName{"http://purl.org/dc/elements/1.1/", "title"}])
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := MergeXmp([]byte("<x:xmpmeta><foo>"), packet)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,165 @@
+package meta
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// XmpPacket returns the metadata as XMP packet with Dublin Core, XMP Rights, Photoshop, and IPTC Core
+// and Extension properties, so that it can be saved as sidecar file or embedded in JPEG images.
+// See https://iptc.org/std/photometadata/specification/IPTC-PhotoMetadata#xmp-namespaces-and-identifiers.
+func (data Data) XmpPacket() []byte {
+	var b bytes.Buffer
+
+	b.WriteString("<?xpacket begin=\"\ufeff\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n")
+	b.WriteString("<x:xmpmeta xmlns:x=\"adobe:ns:meta/\">\n")
+	b.WriteString(" <rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">\n")
+	b.WriteString("  <rdf:Description rdf:about=\"\"\n")
+	b.WriteString("   xmlns:xmp=\"http://ns.adobe.com/xap/1.0/\"\n")
+	b.WriteString("   xmlns:xmpMM=\"http://ns.adobe.com/xap/1.0/mm/\"\n")
+	b.WriteString("   xmlns:xmpRights=\"http://ns.adobe.com/xap/1.0/rights/\"\n")
+	b.WriteString("   xmlns:dc=\"http://purl.org/dc/elements/1.1/\"\n")
+	b.WriteString("   xmlns:photoshop=\"http://ns.adobe.com/photoshop/1.0/\"\n")
+	b.WriteString("   xmlns:Iptc4xmpCore=\"http://iptc.org/std/Iptc4xmpCore/1.0/xmlns/\"\n")
+	b.WriteString("   xmlns:Iptc4xmpExt=\"http://iptc.org/std/Iptc4xmpExt/2008-02-29/\"")
+
+	if data.DocumentID != "" {
+		fmt.Fprintf(&b, "\n   xmpMM:DocumentID=\"%s\"", html.EscapeString(data.DocumentID))
+	}
+
+	if data.Rating > 0 {
+		fmt.Fprintf(&b, "\n   xmp:Rating=\"%d\"", data.Rating)
+	}
+
+	b.WriteString(">\n")
+
+	// Dublin Core.
+	xmpAlt(&b, "dc:title", data.Title)
+	xmpAlt(&b, "dc:description", data.Description)
+	xmpList(&b, "dc:creator", "Seq", []string{data.Artist})
+	xmpAlt(&b, "dc:rights", data.Copyright)
+	xmpList(&b, "dc:subject", "Bag", data.Keywords)
+
+	// XMP Rights.
+	xmpAlt(&b, "xmpRights:UsageTerms", data.License)
+
+	// Photoshop and legacy IPTC Core location shown.
+	if !data.TakenAtLocal.IsZero() {
+		xmpValue(&b, "photoshop:DateCreated", data.TakenAtLocal.Format("2006-01-02T15:04:05"))
+	} else if !data.TakenAt.IsZero() {
+		xmpValue(&b, "photoshop:DateCreated", data.TakenAt.UTC().Format("2006-01-02T15:04:05Z"))
+	}
+
+	xmpValue(&b, "photoshop:Credit", data.CreditLine)
+	xmpValue(&b, "photoshop:City", data.ShownCity)
+	xmpValue(&b, "photoshop:State", data.ShownState)
+	xmpValue(&b, "photoshop:Country", data.ShownCountry)
+	xmpValue(&b, "Iptc4xmpCore:Location", data.ShownSublocation)
+	xmpValue(&b, "Iptc4xmpCore:CountryCode", data.ShownCountryCode)
+
+	// IPTC Core creator contact info.
+	contact := [][2]string{
+		{"CiAdrExtadr", data.CreatorAddress},
+		{"CiAdrCity", data.CreatorCity},
+		{"CiAdrRegion", data.CreatorRegion},
+		{"CiAdrPcode", data.CreatorPostalCode},
+		{"CiAdrCtry", data.CreatorCountry},
+		{"CiTelWork", data.CreatorPhone},
+		{"CiEmailWork", data.CreatorEmail},
+		{"CiUrlWork", data.CreatorUrl},
+	}
+
+	xmpStruct(&b, "Iptc4xmpCore:CreatorContactInfo", "Iptc4xmpCore", contact, false)
+
+	// IPTC Extension.
+	xmpList(&b, "Iptc4xmpExt:PersonInImage", "Bag", strings.Split(data.PersonsShown, ","))
+	xmpAlt(&b, "Iptc4xmpExt:Event", data.Event)
+	xmpValue(&b, "Iptc4xmpExt:DigitalSourceType", data.DigitalSourceType)
+
+	xmpStruct(&b, "Iptc4xmpExt:LocationCreated", "Iptc4xmpExt", [][2]string{
+		{"Sublocation", data.CreatedSublocation},
+		{"City", data.CreatedCity},
+		{"ProvinceState", data.CreatedState},
+		{"CountryName", data.CreatedCountry},
+		{"CountryCode", data.CreatedCountryCode},
+	}, true)
+
+	xmpStruct(&b, "Iptc4xmpExt:LocationShown", "Iptc4xmpExt", [][2]string{
+		{"Sublocation", data.ShownSublocation},
+		{"City", data.ShownCity},
+		{"ProvinceState", data.ShownState},
+		{"CountryName", data.ShownCountry},
+		{"CountryCode", data.ShownCountryCode},
+	}, true)
+
+	b.WriteString("  </rdf:Description>\n")
+	b.WriteString(" </rdf:RDF>\n")
+	b.WriteString("</x:xmpmeta>\n")
+	b.WriteString("<?xpacket end=\"w\"?>\n")
+
+	return b.Bytes()
+}
+
+// xmpValue writes a simple property if the value is not empty.
+func xmpValue(b *bytes.Buffer, name, value string) {
+	if value = strings.TrimSpace(value); value == "" {
+		return
+	}
+
+	fmt.Fprintf(b, "   <%s>%s</%s>\n", name, html.EscapeString(value), name)
+}
+
+// xmpAlt writes a language alternative property with the default language if the value is not empty.
+func xmpAlt(b *bytes.Buffer, name, value string) {
+	if value = strings.TrimSpace(value); value == "" {
+		return
+	}
+
+	fmt.Fprintf(b, "   <%s>\n    <rdf:Alt>\n", name)
+	fmt.Fprintf(b, "     <rdf:li xml:lang=\"x-default\">%s</rdf:li>\n", html.EscapeString(value))
+	fmt.Fprintf(b, "    </rdf:Alt>\n   </%s>\n", name)
+}
+
+// xmpList writes an ordered (Seq) or unordered (Bag) array property if it contains values.
+func xmpList(b *bytes.Buffer, name, kind string, values []string) {
+	items := make([]string, 0, len(values))
+
+	for _, s := range values {
+		if s = strings.TrimSpace(s); s != "" {
+			items = append(items, s)
+		}
+	}
+
+	if len(items) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "   <%s>\n    <rdf:%s>\n", name, kind)
+
+	for _, s := range items {
+		fmt.Fprintf(b, "     <rdf:li>%s</rdf:li>\n", html.EscapeString(s))
+	}
+
+	fmt.Fprintf(b, "    </rdf:%s>\n   </%s>\n", kind, name)
+}
+
+// xmpStruct writes a structure property, optionally as the only item of a Bag, if it has values.
+func xmpStruct(b *bytes.Buffer, name, ns string, fields [][2]string, bag bool) {
+	var inner bytes.Buffer
+
+	for _, f := range fields {
+		if value := strings.TrimSpace(f[1]); value != "" {
+			fmt.Fprintf(&inner, "<%s:%s>%s</%s:%s>", ns, f[0], html.EscapeString(value), ns, f[0])
+		}
+	}
+
+	if inner.Len() == 0 {
+		return
+	} else if bag {
+		fmt.Fprintf(b, "   <%s>\n    <rdf:Bag>\n     <rdf:li rdf:parseType=\"Resource\">%s</rdf:li>\n    </rdf:Bag>\n   </%s>\n", name, inner.String(), name)
+	} else {
+		fmt.Fprintf(b, "   <%s rdf:parseType=\"Resource\">%s</%s>\n", name, inner.String(), name)
+	}
+}
@@ -0,0 +1,97 @@
+package meta
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestData_XmpPacket(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		result := string(Data{}.XmpPacket())
+
+		assert.Contains(t, result, "<?xpacket begin=")
+		assert.NotContains(t, result, "<dc:title>")
+		assert.NotContains(t, result, "Iptc4xmpExt:LocationShown>")
+	})
+	t.Run("RoundTrip", func(t *testing.T) {
+		data := Data{
+			DocumentID:         "fe5d4c24-4f81-4d5a-b5d1-6b4c8e09b6c1",
+			TakenAtLocal:       time.Date(2020, 9, 27, 9, 15, 0, 0, time.UTC),
+			Title:              "Finish Line",
+			Description:        "Runners <crossing> the finish line",
+			Keywords:           Keywords{"marathon", "sports"},
+			Artist:             "Jane Doe",
+			Copyright:          "© 2020 Example Press Agency",
+			License:            "Editorial use only",
+			CreditLine:         "Example Press Agency & Jane Doe",
+			CreatorAddress:     "Example Street 1",
+			CreatorCity:        "Berlin",
+			CreatorRegion:      "Berlin",
+			CreatorPostalCode:  "10117",
+			CreatorCountry:     "Germany",
+			CreatorPhone:       "+49 30 1234567",
+			CreatorEmail:       "jane@example.com",
+			CreatorUrl:         "https://example.com/",
+			CreatedSublocation: "Pariser Platz",
+			CreatedCity:        "Berlin",
+			CreatedCountry:     "Germany",
+			CreatedCountryCode: "DE",
+			ShownSublocation:   "Brandenburger Tor",
+			ShownCity:          "Berlin",
+			ShownState:         "Berlin",
+			ShownCountry:       "Germany",
+			ShownCountryCode:   "DE",
+			PersonsShown:       "John Runner, Max Mustermann",
+			Event:              "Berlin Marathon 2020",
+			DigitalSourceType:  "http://cv.iptc.org/newscodes/digitalsourcetype/digitalCapture",
+			Rating:             4,
+		}
+
+		fileName := filepath.Join(t.TempDir(), "packet.xmp")
+
+		if err := os.WriteFile(fileName, data.XmpPacket(), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, data.Title, result.Title)
+		assert.Equal(t, data.Description, result.Description)
+		assert.Equal(t, data.Keywords, result.Keywords)
+		assert.Equal(t, data.Artist, result.Artist)
+		assert.Equal(t, data.Copyright, result.Copyright)
+		assert.Equal(t, data.License, result.License)
+		assert.Equal(t, data.CreditLine, result.CreditLine)
+		assert.Equal(t, data.CreatorAddress, result.CreatorAddress)
+		assert.Equal(t, data.CreatorCity, result.CreatorCity)
+		assert.Equal(t, data.CreatorRegion, result.CreatorRegion)
+		assert.Equal(t, data.CreatorPostalCode, result.CreatorPostalCode)
+		assert.Equal(t, data.CreatorCountry, result.CreatorCountry)
+		assert.Equal(t, data.CreatorPhone, result.CreatorPhone)
+		assert.Equal(t, data.CreatorEmail, result.CreatorEmail)
+		assert.Equal(t, data.CreatorUrl, result.CreatorUrl)
+		assert.Equal(t, data.CreatedSublocation, result.CreatedSublocation)
+		assert.Equal(t, data.CreatedCity, result.CreatedCity)
+		assert.Equal(t, "", result.CreatedState)
+		assert.Equal(t, data.CreatedCountry, result.CreatedCountry)
+		assert.Equal(t, data.CreatedCountryCode, result.CreatedCountryCode)
+		assert.Equal(t, data.ShownSublocation, result.ShownSublocation)
+		assert.Equal(t, data.ShownCity, result.ShownCity)
+		assert.Equal(t, data.ShownState, result.ShownState)
+		assert.Equal(t, data.ShownCountry, result.ShownCountry)
+		assert.Equal(t, data.ShownCountryCode, result.ShownCountryCode)
+		assert.Equal(t, data.PersonsShown, result.PersonsShown)
+		assert.Equal(t, data.Event, result.Event)
+		assert.Equal(t, data.DigitalSourceType, result.DigitalSourceType)
+		assert.Equal(t, data.Rating, result.Rating)
+		assert.Equal(t, "2020-09-27 09:15:00 +0000 UTC", result.TakenAt.String())
+	})
+}
@@ -21,6 +21,18 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, Keywords{"blume", "krokus", "schöne", "wiese"}, data.Keywords)
 	})
 
+	t.Run("apple-keywords", func(t *testing.T) {
+		data, err := XMP("testdata/apple-keywords.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Harbour", data.Title)
+		assert.Equal(t, "Boats at sunset", data.Description)
+		assert.Equal(t, Keywords{"boat", "sunset"}, data.Keywords)
+	})
+
 	t.Run("photoshop", func(t *testing.T) {
 		data, err := XMP("testdata/photoshop.xmp")
 
@@ -36,6 +48,20 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, "HUAWEI", data.CameraMake)
 		assert.Equal(t, "ELE-L29", data.CameraModel)
 		assert.Equal(t, "HUAWEI P30 Rear Main Camera", data.LensModel)
+		assert.Equal(t, 4, data.Rating)
+		assert.Equal(t, "", data.ColorLabel)
+	})
+
+	t.Run("lightroom", func(t *testing.T) {
+		data, err := XMP("testdata/lightroom.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Lake at Sunset", data.Title)
+		assert.Equal(t, 3, data.Rating)
+		assert.Equal(t, "green", data.ColorLabel)
 	})
 
 	t.Run("canon_eos_6d", func(t *testing.T) {
@@ -81,4 +107,39 @@ func TestXMP(t *testing.T) {
 		assert.True(t, data.TakenAtLocal.IsZero())
 		assert.Equal(t, "UTC", data.TimeZone)
 	})
+
+	t.Run("iptc-extension", func(t *testing.T) {
+		data, err := XMP("testdata/iptc-extension.xmp")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Finish Line", data.Title)
+		assert.Equal(t, "Jane Doe", data.Artist)
+		assert.Equal(t, "© 2020 Example Press Agency", data.Copyright)
+		assert.Equal(t, "Editorial use only", data.License)
+		assert.Equal(t, "Example Press Agency / Jane Doe", data.CreditLine)
+		assert.Equal(t, "Example Street 1", data.CreatorAddress)
+		assert.Equal(t, "Berlin", data.CreatorCity)
+		assert.Equal(t, "Berlin", data.CreatorRegion)
+		assert.Equal(t, "10117", data.CreatorPostalCode)
+		assert.Equal(t, "Germany", data.CreatorCountry)
+		assert.Equal(t, "+49 30 1234567", data.CreatorPhone)
+		assert.Equal(t, "jane@example.com", data.CreatorEmail)
+		assert.Equal(t, "https://example.com/", data.CreatorUrl)
+		assert.Equal(t, "Pariser Platz", data.CreatedSublocation)
+		assert.Equal(t, "Berlin", data.CreatedCity)
+		assert.Equal(t, "Berlin", data.CreatedState)
+		assert.Equal(t, "Germany", data.CreatedCountry)
+		assert.Equal(t, "DE", data.CreatedCountryCode)
+		assert.Equal(t, "Brandenburger Tor", data.ShownSublocation)
+		assert.Equal(t, "Berlin", data.ShownCity)
+		assert.Equal(t, "Berlin", data.ShownState)
+		assert.Equal(t, "Germany", data.ShownCountry)
+		assert.Equal(t, "DE", data.ShownCountryCode)
+		assert.Equal(t, "John Runner, Max Mustermann", data.PersonsShown)
+		assert.Equal(t, "Berlin Marathon 2020", data.Event)
+		assert.Equal(t, "http://cv.iptc.org/newscodes/digitalsourcetype/digitalCapture", data.DigitalSourceType)
+	})
 }
@@ -44,6 +44,7 @@ var DialectMySQL = Migrations{
 		Dialect:    "mysql",
 		Stage:      "main",
 		Statements: []string{"CREATE OR REPLACE INDEX idx_files_photo_id ON files (photo_id, file_primary);"},
+		Down:       []string{"CREATE OR REPLACE INDEX idx_files_photo_id ON files (photo_id);"},
 	},
 	{
 		ID:         "20220329-070000",
@@ -68,6 +69,7 @@ var DialectMySQL = Migrations{
 		Dialect:    "mysql",
 		Stage:      "main",
 		Statements: []string{"CREATE OR REPLACE UNIQUE INDEX idx_files_search_media ON files (media_id);"},
+		Down:       []string{"DROP INDEX IF EXISTS idx_files_search_media ON files;"},
 	},
 	{
 		ID:         "20220329-083000",
@@ -86,6 +88,7 @@ var DialectMySQL = Migrations{
 		Dialect:    "mysql",
 		Stage:      "main",
 		Statements: []string{"CREATE OR REPLACE UNIQUE INDEX idx_files_search_timeline ON files (time_index);"},
+		Down:       []string{"DROP INDEX IF EXISTS idx_files_search_timeline ON files;"},
 	},
 	{
 		ID:         "20220329-093000",
@@ -98,6 +101,7 @@ var DialectMySQL = Migrations{
 		Dialect:    "mysql",
 		Stage:      "main",
 		Statements: []string{"CREATE OR REPLACE INDEX idx_files_missing_root ON files (file_missing, file_root);"},
+		Down:       []string{"DROP INDEX IF EXISTS idx_files_missing_root ON files;"},
 	},
 	{
 		ID:         "20220521-000001",
@@ -134,12 +138,14 @@ var DialectMySQL = Migrations{
 		Dialect:    "mysql",
 		Stage:      "pre",
 		Statements: []string{"RENAME TABLE IF EXISTS `accounts` TO `services`;"},
+		Down:       []string{"RENAME TABLE IF EXISTS `services` TO `accounts`;"},
 	},
 	{
 		ID:         "20221015-100100",
 		Dialect:    "mysql",
 		Stage:      "pre",
 		Statements: []string{"ALTER IGNORE TABLE files_sync CHANGE account_id service_id INT UNSIGNED NOT NULL;", "ALTER IGNORE TABLE files_share CHANGE account_id service_id INT UNSIGNED NOT NULL;"},
+		Down:       []string{"ALTER IGNORE TABLE files_sync CHANGE service_id account_id INT UNSIGNED NOT NULL;", "ALTER IGNORE TABLE files_share CHANGE service_id account_id INT UNSIGNED NOT NULL;"},
 	},
 	{
 		ID:         "20230102-000001",
@@ -164,5 +170,6 @@ var DialectMySQL = Migrations{
 		Dialect:    "mysql",
 		Stage:      "main",
 		Statements: []string{"UPDATE auth_users SET user_role = 'contributor' WHERE user_role = 'uploader';", "UPDATE auth_sessions SET auth_provider = 'link' WHERE auth_provider = 'token';"},
+		Down:       []string{"UPDATE auth_users SET user_role = 'uploader' WHERE user_role = 'contributor';", "UPDATE auth_sessions SET auth_provider = 'token' WHERE auth_provider = 'link';"},
 	},
 }
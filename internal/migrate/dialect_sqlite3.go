@@ -32,6 +32,7 @@ var DialectSQLite3 = Migrations{
 		Dialect:    "sqlite3",
 		Stage:      "main",
 		Statements: []string{"CREATE INDEX IF NOT EXISTS idx_files_photo_id ON files (photo_id, file_primary);"},
+		Down:       []string{"DROP INDEX IF EXISTS idx_files_photo_id;", "CREATE INDEX IF NOT EXISTS idx_files_photo_id ON files (photo_id);"},
 	},
 	{
 		ID:         "20220329-071000",
@@ -44,6 +45,7 @@ var DialectSQLite3 = Migrations{
 		Dialect:    "sqlite3",
 		Stage:      "main",
 		Statements: []string{"CREATE UNIQUE INDEX IF NOT EXISTS idx_files_search_media ON files (media_id);"},
+		Down:       []string{"DROP INDEX IF EXISTS idx_files_search_media;"},
 	},
 	{
 		ID:         "20220329-083000",
@@ -56,6 +58,7 @@ var DialectSQLite3 = Migrations{
 		Dialect:    "sqlite3",
 		Stage:      "main",
 		Statements: []string{"CREATE UNIQUE INDEX IF NOT EXISTS idx_files_search_timeline ON files (time_index);"},
+		Down:       []string{"DROP INDEX IF EXISTS idx_files_search_timeline;"},
 	},
 	{
 		ID:         "20220329-093000",
@@ -68,18 +71,21 @@ var DialectSQLite3 = Migrations{
 		Dialect:    "sqlite3",
 		Stage:      "main",
 		Statements: []string{"CREATE INDEX IF NOT EXISTS idx_files_missing_root ON files (file_missing, file_root);"},
+		Down:       []string{"DROP INDEX IF EXISTS idx_files_missing_root;"},
 	},
 	{
 		ID:         "20221015-100000",
 		Dialect:    "sqlite3",
 		Stage:      "pre",
 		Statements: []string{"ALTER TABLE accounts RENAME TO services;"},
+		Down:       []string{"ALTER TABLE services RENAME TO accounts;"},
 	},
 	{
 		ID:         "20221015-100100",
 		Dialect:    "sqlite3",
 		Stage:      "pre",
 		Statements: []string{"ALTER TABLE files_sync RENAME COLUMN account_id TO service_id;", "ALTER TABLE files_share RENAME COLUMN account_id TO service_id;"},
+		Down:       []string{"ALTER TABLE files_sync RENAME COLUMN service_id TO account_id;", "ALTER TABLE files_share RENAME COLUMN service_id TO account_id;"},
 	},
 	{
 		ID:         "20230309-000001",
@@ -92,5 +98,6 @@ var DialectSQLite3 = Migrations{
 		Dialect:    "sqlite3",
 		Stage:      "main",
 		Statements: []string{"UPDATE auth_users SET user_role = 'contributor' WHERE user_role = 'uploader';", "UPDATE auth_sessions SET auth_provider = 'link' WHERE auth_provider = 'token';"},
+		Down:       []string{"UPDATE auth_users SET user_role = 'uploader' WHERE user_role = 'contributor';", "UPDATE auth_sessions SET auth_provider = 'token' WHERE auth_provider = 'link';"},
 	},
 }
@@ -27,10 +27,14 @@ func gen_migrations(name string) {
 		Stage      string
 		Dialect    string
 		Statements []string
+		Down       []string
 	}
 
 	var migrations []Migration
 
+	// Rollback statements by migration ID.
+	down := make(map[string][]string)
+
 	// Folder in which migration files are stored.
 	folder := "./" + dialect
 
@@ -58,41 +62,52 @@ func gen_migrations(name string) {
 
 	// Read migrations from files.
 	for _, file := range files {
-		stage := ""
+		stage := migrate.StageMain
+		rollback := false
 		filePath := filepath.Join(folder, file.Name())
-		fileName := strings.SplitN(filepath.Base(file.Name()), ".", 3)
+		fileName := strings.Split(filepath.Base(file.Name()), ".")
 
 		if file.IsDir() {
 			// Skip directory.
 			continue
-		} else if len(fileName) < 2 || fileName[0] == "" {
+		} else if len(fileName) < 2 || len(fileName) > 4 || fileName[0] == "" || fileName[len(fileName)-1] != "sql" {
 			// Invalid filename.
 			fmt.Printf("e")
 			continue
-		} else if fileName[1] != "sql" && fileName[2] != "sql" {
-			// Invalid filename.
-			fmt.Printf("e")
-			continue
-		} else if fileName[1] != "sql" {
-			// Stage, if any.
-			stage = fileName[1]
-		} else {
-			stage = migrate.StageMain
+		}
+
+		// Stage and rollback flag, if any, e.g. "20221015-100000.pre.down.sql".
+		for _, s := range fileName[1 : len(fileName)-1] {
+			if s == "down" {
+				rollback = true
+			} else {
+				stage = s
+			}
 		}
 
 		// Migration ID.
 		id := fileName[0]
 
 		// Extract SQL from file.
-		if s, err := os.ReadFile(filePath); err == nil && len(s) > 0 {
-			fmt.Printf(".")
-			migrations = append(migrations, Migration{ID: id, Stage: stage, Dialect: dialect, Statements: strToStmts(s)})
-		} else {
+		if s, err := os.ReadFile(filePath); err != nil {
 			fmt.Printf("f")
 			fmt.Println(err.Error())
+		} else if len(s) == 0 {
+			fmt.Printf("f")
+		} else if rollback {
+			fmt.Printf("d")
+			down[id] = strToStmts(s)
+		} else {
+			fmt.Printf(".")
+			migrations = append(migrations, Migration{ID: id, Stage: stage, Dialect: dialect, Statements: strToStmts(s)})
 		}
 	}
 
+	// Add rollback statements.
+	for i := range migrations {
+		migrations[i].Down = down[migrations[i].ID]
+	}
+
 	fmt.Printf(" found %d migrations\n", len(migrations))
 
 	// Create source file from migrations.
@@ -131,6 +146,9 @@ var Dialect{{ print .Name }} = Migrations{
 		Dialect:   {{ printf "%q" .Dialect }},
 		Stage:     {{ printf "%q" .Stage }},
 		Statements: []string{ {{ range $index, $s := .Statements}}{{if $index}},{{end}}{{ printf "%q" $s }}{{end}} },
+		{{- if .Down }}
+		Down: []string{ {{ range $index, $s := .Down}}{{if $index}},{{end}}{{ printf "%q" $s }}{{end}} },
+		{{- end }}
 	},	
 {{- end }}
 }`))
@@ -15,6 +15,7 @@ type Migration struct {
 	Error      string     `gorm:"size:255;" json:"Error" yaml:"Error,omitempty"`
 	Source     string     `gorm:"size:16;" json:"Source" yaml:"Source,omitempty"`
 	Statements []string   `gorm:"-" json:"Statements" yaml:"Statements,omitempty"`
+	Down       []string   `gorm:"-" json:"Down,omitempty" yaml:"Down,omitempty"`
 	StartedAt  time.Time  `json:"StartedAt" yaml:"StartedAt,omitempty"`
 	FinishedAt *time.Time `json:"FinishedAt" yaml:"FinishedAt,omitempty"`
 }
@@ -135,3 +136,34 @@ func (m *Migration) Execute(db *gorm.DB) error {
 
 	return nil
 }
+
+// Reversible tests if the migration can be rolled back.
+func (m *Migration) Reversible() bool {
+	return len(m.Down) > 0
+}
+
+// Lossy tests if rolling back the migration may change data that existed before it was executed.
+func (m *Migration) Lossy() bool {
+	return Lossy[m.ID]
+}
+
+// Revert runs the rollback statements of the migration.
+func (m *Migration) Revert(db *gorm.DB) error {
+	if db == nil {
+		return fmt.Errorf("db is nil")
+	} else if !m.Reversible() {
+		return fmt.Errorf("%s cannot be rolled back", m.ID)
+	}
+
+	for _, s := range m.Down {
+		if err := db.Exec(s).Error; err != nil {
+			if IgnoreErr.Matches(s, err.Error()) {
+				log.Tracef("migrate: ignored %s", err)
+			} else {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -118,3 +118,14 @@ func (m *Migrations) Start(db *gorm.DB, opt Options) {
 		}
 	}
 }
+
+// Find returns the migration with the specified ID, if it exists.
+func (m Migrations) Find(id string) (Migration, bool) {
+	for _, migration := range m {
+		if migration.ID == id {
+			return migration, true
+		}
+	}
+
+	return Migration{}, false
+}
@@ -0,0 +1 @@
+RENAME TABLE IF EXISTS `services` TO `accounts`;
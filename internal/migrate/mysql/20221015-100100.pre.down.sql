@@ -0,0 +1,2 @@
+ALTER IGNORE TABLE files_sync CHANGE service_id account_id INT UNSIGNED NOT NULL;
+ALTER IGNORE TABLE files_share CHANGE service_id account_id INT UNSIGNED NOT NULL;
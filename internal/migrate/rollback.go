@@ -0,0 +1,81 @@
+package migrate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Lossy lists migrations whose rollback cannot fully restore the previous data,
+// e.g. because values were merged and the original rows can no longer be told apart.
+var Lossy = map[string]bool{
+	"20230313-000001": true, // Merges the uploader role into contributor and token sessions into link.
+}
+
+// Rollback reverts the specified, previously executed migrations in reverse order
+// and removes them from the migrations table, so that they can be run again later.
+// Lossy migrations are only rolled back if force is true.
+func Rollback(db *gorm.DB, ids []string, force bool) (result Migrations, err error) {
+	result = Migrations{}
+
+	if db == nil {
+		return result, fmt.Errorf("migrate: no database connection")
+	} else if len(ids) == 0 {
+		return result, fmt.Errorf("migrate: no migrations specified")
+	}
+
+	// Get SQL dialect name.
+	name := db.Dialect().GetName()
+
+	if name == "" {
+		return result, fmt.Errorf("migrate: failed to determine sql dialect")
+	}
+
+	migrations, ok := Dialects[name]
+
+	if !ok || len(migrations) == 0 {
+		return result, fmt.Errorf("migrate: no migrations found for %s", name)
+	}
+
+	// Find previously executed migrations.
+	executed := Existing(db, "")
+
+	// Make sure all migrations exist, have been executed, and can be rolled back.
+	for _, id := range ids {
+		m, found := migrations.Find(id)
+
+		if !found {
+			return Migrations{}, fmt.Errorf("migrate: %s not found", id)
+		} else if _, done := executed[id]; !done {
+			return Migrations{}, fmt.Errorf("migrate: %s has not been executed yet", id)
+		} else if !m.Reversible() {
+			return Migrations{}, fmt.Errorf("migrate: %s cannot be rolled back", id)
+		} else if m.Lossy() && !force {
+			return Migrations{}, fmt.Errorf("migrate: rolling back %s may change existing data, use force to proceed", id)
+		}
+
+		result = append(result, m)
+	}
+
+	// Revert the most recent migrations first.
+	sort.Slice(result, func(i, j int) bool {
+		return strings.Compare(result[i].ID, result[j].ID) > 0
+	})
+
+	for i, m := range result {
+		start := time.Now()
+
+		if err = m.Revert(db); err != nil {
+			return result[:i], fmt.Errorf("migrate: rolling back %s failed with %s", m.ID, err)
+		} else if err = db.Delete(&Migration{ID: m.ID}).Error; err != nil {
+			return result[:i], fmt.Errorf("migrate: removing %s failed with %s", m.ID, err)
+		}
+
+		log.Infof("migrate: %s rolled back [%s]", m.ID, time.Since(start))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package migrate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollback(t *testing.T) {
+	db := testSchemaDb(t)
+
+	if err := db.AutoMigrate(&Migration{}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	finished := time.Now().UTC()
+
+	for _, id := range []string{"20220421-200000", "20230309-000001", "20230313-000001"} {
+		if err := db.Create(&Migration{ID: id, Dialect: SQLite3, Stage: StageMain, StartedAt: finished, FinishedAt: &finished}).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("NotExecuted", func(t *testing.T) {
+		_, err := Rollback(db, []string{"20220329-091000"}, false)
+		assert.Error(t, err)
+	})
+	t.Run("Irreversible", func(t *testing.T) {
+		_, err := Rollback(db, []string{"20230309-000001"}, false)
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Rollback(db, []string{"19700101-000000"}, false)
+		assert.Error(t, err)
+	})
+	t.Run("Lossy", func(t *testing.T) {
+		_, err := Rollback(db, []string{"20230313-000001"}, false)
+		assert.Error(t, err)
+		assert.Contains(t, Existing(db, ""), "20230313-000001")
+	})
+	t.Run("Force", func(t *testing.T) {
+		for _, s := range []string{
+			"CREATE TABLE IF NOT EXISTS auth_users (user_role VARCHAR(64))",
+			"CREATE TABLE IF NOT EXISTS auth_sessions (auth_provider VARCHAR(128))",
+		} {
+			if err := db.Exec(s).Error; err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		result, err := Rollback(db, []string{"20230313-000001"}, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+		assert.NotContains(t, Existing(db, ""), "20230313-000001")
+	})
+	t.Run("Success", func(t *testing.T) {
+		result, err := Rollback(db, []string{"20220421-200000"}, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, result, 1)
+
+		table, err := ReadTable(db, "files")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotContains(t, table.Indexes, "idx_files_missing_root")
+
+		existing := Existing(db, "")
+		assert.NotContains(t, existing, "20220421-200000")
+		assert.Contains(t, existing, "20230309-000001")
+	})
+}
+
+func TestMigration_Reversible(t *testing.T) {
+	m, found := DialectSQLite3.Find("20220421-200000")
+
+	assert.True(t, found)
+	assert.True(t, m.Reversible())
+
+	m, found = DialectSQLite3.Find("20230309-000001")
+
+	assert.True(t, found)
+	assert.False(t, m.Reversible())
+}
+
+func TestMigration_Lossy(t *testing.T) {
+	m, found := DialectSQLite3.Find("20230313-000001")
+
+	assert.True(t, found)
+	assert.True(t, m.Reversible())
+	assert.True(t, m.Lossy())
+
+	m, found = DialectSQLite3.Find("20220421-200000")
+
+	assert.True(t, found)
+	assert.False(t, m.Lossy())
+}
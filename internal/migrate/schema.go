@@ -0,0 +1,176 @@
+package migrate
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/list"
+)
+
+// Drift types and problems.
+const (
+	DriftTable   = "table"
+	DriftColumn  = "column"
+	DriftIndex   = "index"
+	DriftMissing = "missing"
+	DriftExtra   = "extra"
+)
+
+// Table represents the columns and indexes of a database table.
+type Table struct {
+	Name    string
+	Columns []string
+	Indexes []string
+}
+
+// Schema represents a list of database tables.
+type Schema []Table
+
+// Drift represents a difference between the expected and the actual database schema.
+type Drift struct {
+	Table   string `json:"Table"`
+	Type    string `json:"Type"`
+	Name    string `json:"Name"`
+	Problem string `json:"Problem"`
+}
+
+// Drifts represents a list of schema differences.
+type Drifts []Drift
+
+// String returns a human-readable description of the difference.
+func (d Drift) String() string {
+	if d.Type == DriftTable {
+		return fmt.Sprintf("table %s is %s", d.Table, d.Problem)
+	}
+
+	return fmt.Sprintf("%s %s in table %s is %s", d.Type, d.Name, d.Table, d.Problem)
+}
+
+var createIndexRegexp = regexp.MustCompile("(?i)create\\s+(?:or\\s+replace\\s+)?(?:unique\\s+)?index\\s+(?:if\\s+not\\s+exists\\s+)?`?(\\w+)`?\\s+on\\s+`?(\\w+)`?")
+var dropIndexRegexp = regexp.MustCompile("(?i)drop\\s+index\\s+(?:if\\s+exists\\s+)?`?(\\w+)`?")
+
+// Indexes returns the names of the indexes that are created by the migrations, grouped by table name.
+func (m Migrations) Indexes() map[string][]string {
+	tables := make(map[string]string)
+
+	for _, migration := range m {
+		for _, s := range migration.Statements {
+			if match := createIndexRegexp.FindStringSubmatch(s); len(match) == 3 {
+				tables[match[1]] = match[2]
+			} else if match = dropIndexRegexp.FindStringSubmatch(s); len(match) == 2 {
+				delete(tables, match[1])
+			}
+		}
+	}
+
+	result := make(map[string][]string)
+
+	for index, table := range tables {
+		result[table] = append(result[table], index)
+	}
+
+	return result
+}
+
+// ReadTable returns the columns and indexes of an existing database table.
+func ReadTable(db *gorm.DB, name string) (table Table, err error) {
+	table = Table{Name: name}
+
+	if db == nil {
+		return table, fmt.Errorf("migrate: no database connection")
+	}
+
+	var columnsQuery, indexesQuery string
+
+	switch db.Dialect().GetName() {
+	case MySQL:
+		columnsQuery = "SELECT COLUMN_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?"
+		indexesQuery = "SELECT DISTINCT INDEX_NAME FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_NAME <> 'PRIMARY'"
+	case SQLite3:
+		columnsQuery = "SELECT name FROM pragma_table_info(?)"
+		indexesQuery = "SELECT name FROM pragma_index_list(?) WHERE origin = 'c'"
+	default:
+		return table, fmt.Errorf("migrate: unsupported sql dialect %s", db.Dialect().GetName())
+	}
+
+	if err = db.Raw(columnsQuery, name).Pluck("name", &table.Columns).Error; err != nil {
+		return table, err
+	} else if err = db.Raw(indexesQuery, name).Pluck("name", &table.Indexes).Error; err != nil {
+		return table, err
+	}
+
+	sort.Strings(table.Columns)
+	sort.Strings(table.Indexes)
+
+	return table, nil
+}
+
+// Check compares the expected schema with the actual database schema, taking into account
+// the indexes created by dialect migrations, and returns the differences found.
+func Check(db *gorm.DB, expected Schema) (result Drifts, err error) {
+	result = Drifts{}
+
+	if db == nil {
+		return result, fmt.Errorf("migrate: no database connection")
+	}
+
+	managed := Dialects[db.Dialect().GetName()].Indexes()
+
+	for _, t := range expected {
+		if !db.Dialect().HasTable(t.Name) {
+			result = append(result, Drift{Table: t.Name, Type: DriftTable, Name: t.Name, Problem: DriftMissing})
+			continue
+		}
+
+		actual, err := ReadTable(db, t.Name)
+
+		if err != nil {
+			return result, fmt.Errorf("migrate: %s (read %s)", err, t.Name)
+		}
+
+		result = append(result, compare(t.Name, DriftColumn, t.Columns, actual.Columns)...)
+		result = append(result, compare(t.Name, DriftIndex, append(append([]string{}, t.Indexes...), managed[t.Name]...), actual.Indexes)...)
+	}
+
+	return result, nil
+}
+
+// compare returns the names that are missing or extra in the actual list.
+func compare(table, kind string, expected, actual []string) (result Drifts) {
+	var names []string
+
+	for _, name := range expected {
+		names = list.Add(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !containsFold(actual, name) {
+			result = append(result, Drift{Table: table, Type: kind, Name: name, Problem: DriftMissing})
+		}
+	}
+
+	for _, name := range actual {
+		if !containsFold(names, name) {
+			result = append(result, Drift{Table: table, Type: kind, Name: name, Problem: DriftExtra})
+		}
+	}
+
+	return result
+}
+
+// containsFold tests if the list contains the name, ignoring case.
+func containsFold(names []string, name string) bool {
+	for _, s := range names {
+		if strings.EqualFold(s, name) {
+			return true
+		}
+	}
+
+	return false
+}
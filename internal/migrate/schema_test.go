@@ -0,0 +1,79 @@
+package migrate
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSchemaDb returns a new sqlite3 database with a files table for testing.
+func testSchemaDb(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(SQLite3, filepath.Join(t.TempDir(), "schema.db"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = db.Close() })
+
+	if err = db.Exec("CREATE TABLE files (id INTEGER PRIMARY KEY, file_uid VARCHAR(42), file_root VARCHAR(16), file_missing BOOL, obsolete_col INT)").Error; err != nil {
+		t.Fatal(err)
+	} else if err = db.Exec("CREATE UNIQUE INDEX uix_files_file_uid ON files (file_uid)").Error; err != nil {
+		t.Fatal(err)
+	} else if err = db.Exec("CREATE INDEX idx_files_obsolete ON files (obsolete_col)").Error; err != nil {
+		t.Fatal(err)
+	} else if err = db.Exec("CREATE INDEX idx_files_missing_root ON files (file_missing, file_root)").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestMigrations_Indexes(t *testing.T) {
+	indexes := DialectSQLite3.Indexes()
+
+	assert.Contains(t, indexes["files"], "idx_files_missing_root")
+	assert.Contains(t, indexes["files"], "idx_files_search_media")
+	assert.Contains(t, indexes["albums"], "idx_albums_album_filter")
+	assert.NotContains(t, indexes["places"], "idx_places_place_label")
+}
+
+func TestReadTable(t *testing.T) {
+	db := testSchemaDb(t)
+
+	table, err := ReadTable(db, "files")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "files", table.Name)
+	assert.Equal(t, []string{"file_missing", "file_root", "file_uid", "id", "obsolete_col"}, table.Columns)
+	assert.Equal(t, []string{"idx_files_missing_root", "idx_files_obsolete", "uix_files_file_uid"}, table.Indexes)
+}
+
+func TestCheck(t *testing.T) {
+	db := testSchemaDb(t)
+
+	expected := Schema{
+		{Name: "files", Columns: []string{"id", "file_uid", "file_root", "file_missing", "file_name"}, Indexes: []string{"uix_files_file_uid", "idx_files_name"}},
+		{Name: "photos", Columns: []string{"id"}},
+	}
+
+	result, err := Check(db, expected)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, result, Drift{Table: "files", Type: DriftColumn, Name: "file_name", Problem: DriftMissing})
+	assert.Contains(t, result, Drift{Table: "files", Type: DriftColumn, Name: "obsolete_col", Problem: DriftExtra})
+	assert.Contains(t, result, Drift{Table: "files", Type: DriftIndex, Name: "idx_files_name", Problem: DriftMissing})
+	assert.Contains(t, result, Drift{Table: "files", Type: DriftIndex, Name: "idx_files_obsolete", Problem: DriftExtra})
+	assert.Contains(t, result, Drift{Table: "files", Type: DriftIndex, Name: "idx_files_search_media", Problem: DriftMissing})
+	assert.Contains(t, result, Drift{Table: "photos", Type: DriftTable, Name: "photos", Problem: DriftMissing})
+	assert.NotContains(t, result, Drift{Table: "files", Type: DriftIndex, Name: "idx_files_missing_root", Problem: DriftExtra})
+	assert.Equal(t, "column obsolete_col in table files is extra", Drift{Table: "files", Type: DriftColumn, Name: "obsolete_col", Problem: DriftExtra}.String())
+}
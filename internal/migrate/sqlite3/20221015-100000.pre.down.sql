@@ -0,0 +1 @@
+ALTER TABLE services RENAME TO accounts;
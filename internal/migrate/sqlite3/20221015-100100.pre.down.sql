@@ -0,0 +1,2 @@
+ALTER TABLE files_sync RENAME COLUMN service_id TO account_id;
+ALTER TABLE files_share RENAME COLUMN service_id TO account_id;
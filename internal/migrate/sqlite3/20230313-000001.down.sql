@@ -0,0 +1,2 @@
+UPDATE auth_users SET user_role = 'uploader' WHERE user_role = 'contributor';
+UPDATE auth_sessions SET auth_provider = 'token' WHERE auth_provider = 'link';
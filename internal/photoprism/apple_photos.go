@@ -0,0 +1,236 @@
+package photoprism
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/media"
+)
+
+// ApplePhotosOptions represents Apple Photos export import options.
+type ApplePhotosOptions struct {
+	UID        string
+	Path       string
+	DestFolder string
+	Results    *Results
+}
+
+// ApplePhotosAlbum represents an album folder in an Apple Photos export.
+type ApplePhotosAlbum struct {
+	Title string `json:"Title"`
+	Path  string `json:"Path"`
+	UID   string `json:"UID,omitempty"`
+	Files int    `json:"Files"`
+}
+
+// ApplePhotosReport represents the results of an Apple Photos export import.
+type ApplePhotosReport struct {
+	Path        string             `json:"Path"`
+	Albums      []ApplePhotosAlbum `json:"Albums"`
+	Files       int                `json:"Files"`
+	Edited      int                `json:"Edited"`
+	LivePhotos  int                `json:"LivePhotos"`
+	Adjustments int                `json:"Adjustments"`
+	Xmp         int                `json:"Xmp"`
+	Imported    int                `json:"Imported"`
+	Unpaired    []string           `json:"Unpaired"`
+}
+
+// NewApplePhotosReport returns a new, empty import report.
+func NewApplePhotosReport(path string) *ApplePhotosReport {
+	return &ApplePhotosReport{
+		Path:     path,
+		Albums:   []ApplePhotosAlbum{},
+		Unpaired: []string{},
+	}
+}
+
+// ApplePhotos imports files exported from Apple Photos, stacking originals with their "IMG_E"
+// edits, AAE adjustments, and Live Photo videos, and adding files in album folders to albums.
+type ApplePhotos struct {
+	conf *config.Config
+	imp  *Import
+}
+
+// NewApplePhotos returns a new Apple Photos importer and expects the config and importer as argument.
+func NewApplePhotos(conf *config.Config, imp *Import) *ApplePhotos {
+	return &ApplePhotos{conf: conf, imp: imp}
+}
+
+// Start imports an Apple Photos export and returns the import report.
+func (w *ApplePhotos) Start(opt ApplePhotosOptions) (*ApplePhotosReport, error) {
+	report := NewApplePhotosReport(opt.Path)
+
+	if !fs.PathExists(opt.Path) {
+		return report, fmt.Errorf("apple: %s not found", clean.Log(opt.Path))
+	}
+
+	var dirs []string
+
+	err := filepath.WalkDir(opt.Path, func(fileName string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		} else if !d.IsDir() {
+			return nil
+		} else if fileName != opt.Path && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		dirs = append(dirs, fileName)
+
+		return nil
+	})
+
+	if err != nil {
+		return report, err
+	}
+
+	var albums []ApplePhotosAlbum
+
+	for _, dir := range dirs {
+		files, prepareErr := w.Prepare(dir, opt.Path, report)
+
+		if prepareErr != nil {
+			log.Warnf("apple: %s", prepareErr)
+		} else if files > 0 && dir != opt.Path {
+			albums = append(albums, ApplePhotosAlbum{
+				Title: clean.Name(filepath.Base(dir)),
+				Path:  fs.RelName(dir, opt.Path),
+				Files: files,
+			})
+		}
+	}
+
+	// Import nested album folders first, so that their files are not added to parent albums.
+	sort.SliceStable(albums, func(i, j int) bool {
+		return strings.Count(albums[i].Path, string(os.PathSeparator)) > strings.Count(albums[j].Path, string(os.PathSeparator))
+	})
+
+	for i := range albums {
+		if albums[i].UID, err = ExportAlbum(albums[i].Title, "", "", opt.UID); err != nil {
+			log.Errorf("apple: %s", err)
+			continue
+		}
+
+		impOpt := ExportImportOptions(filepath.Join(opt.Path, albums[i].Path), opt.DestFolder, opt.UID, opt.Results)
+		impOpt.Albums = []string{albums[i].UID}
+
+		report.Imported += ProcessedFiles(w.imp.Start(impOpt))
+	}
+
+	report.Imported += ProcessedFiles(w.imp.Start(ExportImportOptions(opt.Path, opt.DestFolder, opt.UID, opt.Results)))
+	report.Albums = albums
+
+	return report, nil
+}
+
+// Prepare counts the originals, edits, Live Photos, and sidecar files in an export folder,
+// and returns the number of originals. Edits and sidecars that cannot be matched with an
+// original are added to the report.
+func (w *ApplePhotos) Prepare(dir, root string, report *ApplePhotosReport) (originals int, err error) {
+	entries, err := os.ReadDir(dir)
+
+	if err != nil {
+		return 0, err
+	}
+
+	// Group files by base name without extension, e.g. "IMG_1234".
+	groups := make(map[string][]string)
+
+	for _, e := range entries {
+		if name := e.Name(); !e.IsDir() && !strings.HasPrefix(name, ".") {
+			prefix := fs.BasePrefix(name, false)
+			groups[prefix] = append(groups[prefix], name)
+		}
+	}
+
+	for prefix, names := range groups {
+		original := AppleOriginalPrefix(prefix)
+
+		// Edits and original adjustments, e.g. "IMG_E1234.JPG" or "IMG_O1234.AAE".
+		if original != "" {
+			if _, ok := groups[original]; !ok {
+				for _, name := range names {
+					report.Unpaired = append(report.Unpaired, fs.RelName(filepath.Join(dir, name), root))
+				}
+			} else if containsMedia(names) {
+				report.Edited++
+			}
+
+			continue
+		}
+
+		hasImage, hasVideo := false, false
+
+		for _, name := range names {
+			fileName := filepath.Join(dir, name)
+
+			switch fs.FileType(fileName) {
+			case fs.SidecarAAE:
+				if a, aaeErr := meta.AAE(fileName); aaeErr != nil {
+					log.Debugf("apple: %s", aaeErr)
+				} else if a.Edited() {
+					report.Adjustments++
+				}
+			case fs.SidecarXMP:
+				report.Xmp++
+			}
+
+			switch media.FromName(name) {
+			case media.Image, media.Raw:
+				hasImage = true
+			case media.Video:
+				hasVideo = true
+			}
+		}
+
+		if hasImage || hasVideo {
+			originals++
+			report.Files++
+		} else {
+			for _, name := range names {
+				report.Unpaired = append(report.Unpaired, fs.RelName(filepath.Join(dir, name), root))
+			}
+		}
+
+		if hasImage && hasVideo {
+			report.LivePhotos++
+		}
+	}
+
+	sort.Strings(report.Unpaired)
+
+	return originals, nil
+}
+
+// AppleOriginalPrefix returns the base name of the original if prefix belongs to an edited
+// version or adjustment sidecar as created by Apple devices, e.g. "IMG_1234" for "IMG_E1234".
+func AppleOriginalPrefix(prefix string) string {
+	if len(prefix) < 6 || strings.ToUpper(prefix[:4]) != "IMG_" {
+		return ""
+	} else if c := prefix[4]; c != 'E' && c != 'O' {
+		return ""
+	} else if c = prefix[5]; c < '0' || c > '9' {
+		return ""
+	}
+
+	return prefix[:4] + prefix[5:]
+}
+
+// containsMedia tests if the list of file names contains a main media file.
+func containsMedia(names []string) bool {
+	for _, name := range names {
+		if media.MainFile(name) {
+			return true
+		}
+	}
+
+	return false
+}
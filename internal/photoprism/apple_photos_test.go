@@ -0,0 +1,67 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestAppleOriginalPrefix(t *testing.T) {
+	assert.Equal(t, "IMG_4120", AppleOriginalPrefix("IMG_E4120"))
+	assert.Equal(t, "IMG_4120", AppleOriginalPrefix("IMG_O4120"))
+	assert.Equal(t, "", AppleOriginalPrefix("IMG_4120"))
+	assert.Equal(t, "", AppleOriginalPrefix("IMG_Edit"))
+	assert.Equal(t, "", AppleOriginalPrefix("fern_green"))
+	assert.Equal(t, "", AppleOriginalPrefix(""))
+}
+
+func TestApplePhotos_Prepare(t *testing.T) {
+	conf := config.TestConfig()
+	root := filepath.Join(conf.TempPath(), "apple")
+	dir := filepath.Join(root, "Vacation")
+
+	if err := os.MkdirAll(dir, fs.ModeDir); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(root)
+
+	files := map[string]string{
+		"IMG_4120.JPG":  "jpeg",
+		"IMG_4120.MOV":  "mov",
+		"IMG_E4120.JPG": "jpeg",
+		"IMG_5000.HEIC": "heic",
+		"IMG_5000.xmp":  "xmp",
+		"IMG_E9999.JPG": "jpeg",
+	}
+
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := fs.Copy(conf.ExamplesPath()+"/IMG_4120.AAE", filepath.Join(dir, "IMG_4120.AAE")); err != nil {
+		t.Fatal(err)
+	}
+
+	report := NewApplePhotosReport(root)
+	originals, err := NewApplePhotos(conf, nil).Prepare(dir, root, report)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 2, originals)
+	assert.Equal(t, 2, report.Files)
+	assert.Equal(t, 1, report.Edited)
+	assert.Equal(t, 1, report.LivePhotos)
+	assert.Equal(t, 1, report.Adjustments)
+	assert.Equal(t, 1, report.Xmp)
+	assert.Equal(t, []string{"Vacation/IMG_E9999.JPG"}, report.Unpaired)
+}
@@ -0,0 +1,139 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/backup"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// BackupIndex writes an SQL dump of the index database to w.
+func BackupIndex(w io.Writer) error {
+	c := Config()
+
+	var cmd *exec.Cmd
+
+	switch c.DatabaseDriver() {
+	case config.MySQL, config.MariaDB:
+		cmd = exec.Command(
+			c.MysqldumpBin(),
+			"--protocol", "tcp",
+			"-h", c.DatabaseHost(),
+			"-P", c.DatabasePortString(),
+			"-u", c.DatabaseUser(),
+			"-p"+c.DatabasePassword(),
+			c.DatabaseName(),
+		)
+	case config.SQLite3:
+		cmd = exec.Command(
+			c.SqliteBin(),
+			c.DatabaseFile(),
+			".dump",
+		)
+	default:
+		return fmt.Errorf("unsupported database type: %s", c.DatabaseDriver())
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Stdout = w
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	// Run backup command.
+	if err := cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			return errors.New(stderr.String())
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// BackupArchive creates a compressed and checksummed archive in the specified path that contains
+// an index dump as well as album and photo YAML files, and returns its file name.
+func BackupArchive(archivePath string) (fileName string, err error) {
+	c := Config()
+
+	if err = os.MkdirAll(archivePath, fs.ModeDir); err != nil {
+		return "", err
+	}
+
+	// Dump the index to a temporary file first, so that it can be checked before it is added.
+	dumpFile := filepath.Join(archivePath, fmt.Sprintf(".%s.sql", c.DatabaseDriver()))
+
+	defer os.Remove(dumpFile)
+
+	if f, err := os.OpenFile(dumpFile, os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeFile); err != nil {
+		return "", err
+	} else if err = BackupIndex(f); err != nil {
+		_ = f.Close()
+		return "", err
+	} else if err = f.Close(); err != nil {
+		return "", err
+	}
+
+	if info, err := backup.ReadDumpFile(dumpFile); err != nil {
+		return "", err
+	} else if err = info.Complete(c.DatabaseDriver()); err != nil {
+		return "", err
+	}
+
+	a, err := backup.NewArchive(backup.FileName(archivePath, time.Now()), c.DatabaseDriver())
+
+	if err != nil {
+		return "", err
+	}
+
+	if err = a.AddFile(backup.IndexName, dumpFile); err != nil {
+		a.Abort()
+		return "", err
+	}
+
+	if count, err := a.AddDir(backup.AlbumsDir, c.AlbumsPath(), fs.ExtYAML); err != nil {
+		a.Abort()
+		return "", err
+	} else {
+		log.Debugf("backup: added %s", english.Plural(count, "album file", "album files"))
+	}
+
+	if count, err := a.AddDir(backup.SidecarDir, c.SidecarPath(), fs.ExtYAML); err != nil {
+		a.Abort()
+		return "", err
+	} else {
+		log.Debugf("backup: added %s", english.Plural(count, "sidecar file", "sidecar files"))
+	}
+
+	if err = a.Close(); err != nil {
+		return "", err
+	}
+
+	log.Infof("backup: created %s", clean.Log(filepath.Base(a.FileName())))
+
+	return a.FileName(), nil
+}
+
+// RotateBackups removes index backup archives that are not kept by the configured retention policy.
+func RotateBackups(archivePath string) (removed int, err error) {
+	entries, err := backup.Rotate(archivePath, Config().BackupRetention())
+
+	for _, e := range entries {
+		log.Infof("backup: removed %s", clean.Log(filepath.Base(e.FileName)))
+	}
+
+	return len(entries), err
+}
@@ -0,0 +1,430 @@
+package photoprism
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/catalog"
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// CatalogOptions represents catalog import options.
+type CatalogOptions struct {
+	UID    string
+	DryRun bool
+}
+
+// CatalogChange represents a metadata change of an indexed photo.
+type CatalogChange struct {
+	FileName string `json:"FileName"`
+	PhotoUID string `json:"PhotoUID"`
+	Field    string `json:"Field"`
+	From     string `json:"From"`
+	To       string `json:"To"`
+}
+
+// CatalogReport represents the results of a catalog import.
+type CatalogReport struct {
+	Type      string          `json:"Type"`
+	FileName  string          `json:"FileName"`
+	DryRun    bool            `json:"DryRun"`
+	Images    int             `json:"Images"`
+	Matched   int             `json:"Matched"`
+	ByHash    int             `json:"ByHash"`
+	Updated   int             `json:"Updated"`
+	Albums    []string        `json:"Albums"`
+	Changes   []CatalogChange `json:"Changes"`
+	Unmatched []string        `json:"Unmatched"`
+}
+
+// NewCatalogReport returns a new, empty catalog import report.
+func NewCatalogReport(cat *catalog.Catalog, dryRun bool) *CatalogReport {
+	return &CatalogReport{
+		Type:      cat.Type,
+		FileName:  cat.FileName,
+		DryRun:    dryRun,
+		Images:    len(cat.Images),
+		Albums:    []string{},
+		Changes:   []CatalogChange{},
+		Unmatched: []string{},
+	}
+}
+
+// CatalogImport imports ratings, keywords, labels, captions, locations, people, and albums from
+// external photo catalogs into the index, using the same priority as XMP sidecar files.
+type CatalogImport struct {
+	conf  *config.Config
+	index *Index
+}
+
+// NewCatalogImport returns a new catalog importer and expects the config and indexer as argument.
+func NewCatalogImport(conf *config.Config, index *Index) *CatalogImport {
+	return &CatalogImport{conf: conf, index: index}
+}
+
+// Start imports the catalog metadata of matching photos and returns the report. If DryRun is set,
+// the changes are reported without updating the index.
+func (w *CatalogImport) Start(cat *catalog.Catalog, opt CatalogOptions) (*CatalogReport, error) {
+	if cat == nil {
+		return nil, fmt.Errorf("catalog is nil")
+	}
+
+	report := NewCatalogReport(cat, opt.DryRun)
+	albums := make(map[string]*entity.Album)
+	faces := 0
+
+	for _, img := range cat.Images {
+		file, byHash := w.Match(img)
+
+		if file == nil {
+			report.Unmatched = append(report.Unmatched, img.FileName)
+			continue
+		}
+
+		report.Matched++
+
+		if byHash {
+			report.ByHash++
+		}
+
+		photo, err := query.PhotoByUID(file.PhotoUID)
+
+		if err != nil {
+			log.Warnf("catalog: %s (find photo for %s)", err, clean.Log(file.FileName))
+			continue
+		}
+
+		changes := CatalogApply(&photo, img)
+		changes = append(changes, CatalogLabels(&photo, img.Labels)...)
+
+		if len(img.Faces) > 0 {
+			faceChanges := w.faces(photo.PhotoUID, img.Faces, opt.DryRun)
+			faces += len(faceChanges)
+			changes = append(changes, faceChanges...)
+		}
+
+		for _, title := range img.Albums {
+			album, ok := albums[title]
+
+			if !ok {
+				album = w.album(title, opt)
+				albums[title] = album
+			}
+
+			if album != nil && album.HasID() && query.PhotoInAlbum(photo.PhotoUID, album.AlbumUID) {
+				continue
+			}
+
+			changes = append(changes, CatalogChange{Field: "Album", To: title})
+
+			if !opt.DryRun && album != nil {
+				album.AddPhotos([]string{photo.PhotoUID})
+			}
+		}
+
+		if len(changes) == 0 {
+			continue
+		}
+
+		report.Updated++
+
+		for _, c := range changes {
+			c.FileName = file.FileName
+			c.PhotoUID = photo.PhotoUID
+			report.Changes = append(report.Changes, c)
+		}
+
+		if opt.DryRun {
+			continue
+		} else if err = w.save(&photo, changes); err != nil {
+			log.Errorf("catalog: %s (update %s)", err, photo.String())
+		}
+	}
+
+	for title := range albums {
+		report.Albums = append(report.Albums, title)
+	}
+
+	if faces > 0 && !opt.DryRun {
+		if err := query.UpdateSubjectCovers(); err != nil {
+			log.Warnf("catalog: %s (update covers)", err)
+		} else if err = entity.UpdateSubjectCounts(); err != nil {
+			log.Warnf("catalog: %s (update counts)", err)
+		}
+	}
+
+	sort.Strings(report.Albums)
+
+	return report, nil
+}
+
+// Match returns the indexed file matching the catalog image, first by path and then by file hash
+// if the catalog image is accessible. As file names like IMG_0001.JPG are common, a file whose path
+// only matches by name must also have the same hash. The second return value is true if the hash was matched.
+func (w *CatalogImport) Match(img catalog.Image) (*entity.File, bool) {
+	catalogName := strings.ReplaceAll(img.FileName, "\\", "/")
+
+	var best *entity.File
+
+	if files, err := query.FilesByBaseName(path.Base(catalogName)); err == nil {
+		bestScore, ties := 0, 0
+
+		for i := range files {
+			if score := CatalogPathScore(catalogName, files[i].FileName); score > bestScore {
+				best, bestScore, ties = &files[i], score, 0
+			} else if score == bestScore && score > 0 {
+				ties++
+			}
+		}
+
+		if ties > 0 {
+			best = nil
+		} else if bestScore > 1 {
+			return best, false
+		}
+	}
+
+	if !fs.FileExists(img.FileName) {
+		return nil, false
+	}
+
+	hash := fs.Hash(img.FileName)
+
+	if best != nil && best.FileHash == hash {
+		return best, true
+	} else if file, err := query.FileByHash(hash); err == nil && file.FileRoot == entity.RootOriginals {
+		return file, true
+	}
+
+	return nil, false
+}
+
+// album returns the album with the specified title, it is created unless this is a dry run.
+func (w *CatalogImport) album(title string, opt CatalogOptions) *entity.Album {
+	uid := opt.UID
+
+	if uid == "" {
+		uid = entity.Admin.UID()
+	}
+
+	m := entity.NewUserAlbum(title, entity.AlbumManual, uid)
+
+	if found := m.Find(); found != nil {
+		return found
+	} else if opt.DryRun {
+		return nil
+	} else if err := m.Create(); err != nil {
+		log.Errorf("catalog: %s (create album %s)", err, clean.Log(title))
+		return nil
+	}
+
+	return m
+}
+
+// faces assigns the names of face regions to matching markers of the primary file and creates
+// markers for regions that have not been detected, so that their embeddings can be used for recognition.
+func (w *CatalogImport) faces(photoUid string, regions catalog.Faces, dryRun bool) (changes []CatalogChange) {
+	file, err := query.FileByPhotoUID(photoUid)
+
+	if err != nil {
+		log.Warnf("catalog: %s (find primary file of %s)", err, clean.Log(photoUid))
+		return changes
+	}
+
+	markers := file.Markers()
+
+	for _, region := range regions {
+		name := clean.Name(region.Name)
+		area := crop.NewArea("face", region.X, region.Y, region.W, region.H)
+		probe := entity.Marker{X: area.X, Y: area.Y, W: area.W, H: area.H}
+
+		var marker *entity.Marker
+
+		for i := range *markers {
+			if m := &(*markers)[i]; m.MarkerType == entity.MarkerFace && !m.MarkerInvalid && m.OverlapPercent(probe) > face.OverlapThreshold {
+				marker = m
+				break
+			}
+		}
+
+		if name == "" {
+			continue
+		} else if marker == nil {
+			changes = append(changes, CatalogChange{Field: "Face", To: name})
+		} else if marker.MarkerName == name || entity.SrcPriority[entity.SrcXmp] < entity.SrcPriority[marker.SubjSrc] {
+			continue
+		} else {
+			changes = append(changes, CatalogChange{Field: "Face", From: marker.MarkerName, To: name})
+		}
+
+		if dryRun {
+			continue
+		} else if marker == nil {
+			if w.index == nil {
+				continue
+			} else if marker, err = w.index.FaceMarker(*file, area); err != nil {
+				log.Warnf("catalog: %s (add face %s to %s)", err, clean.Log(name), clean.Log(file.FileName))
+				continue
+			}
+		}
+
+		if _, err = marker.SetName(name, entity.SrcXmp); err != nil {
+			log.Warnf("catalog: %s (set face name %s)", err, clean.Log(name))
+		} else if err = marker.Save(); err != nil {
+			log.Warnf("catalog: %s (save face %s)", err, clean.Log(name))
+		}
+	}
+
+	if len(changes) > 0 && !dryRun {
+		if _, err = file.UpdatePhotoFaceCount(); err != nil {
+			log.Warnf("catalog: %s (update face count)", err)
+		}
+	}
+
+	return changes
+}
+
+// save updates the photo in the index after catalog metadata has been applied.
+func (w *CatalogImport) save(photo *entity.Photo, changes []CatalogChange) error {
+	var labels classify.Labels
+
+	for _, c := range changes {
+		if c.Field == "Label" {
+			labels = append(labels, classify.Label{Name: c.To, Source: entity.SrcXmp})
+		}
+	}
+
+	if len(labels) > 0 {
+		photo.AddLabels(labels)
+	}
+
+	for _, c := range changes {
+		switch c.Field {
+		case "Favorite":
+			if err := photo.SetFavorite(true); err != nil {
+				return err
+			}
+		case "Archived":
+			if err := photo.Archive(); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, c := range changes {
+		if c.Field == "Location" {
+			return photo.SaveLocation()
+		}
+	}
+
+	if err := photo.SyncKeywordLabels(); err != nil {
+		log.Warnf("catalog: %s (sync keywords of %s)", err, photo.String())
+	}
+
+	if err := photo.IndexKeywords(); err != nil {
+		log.Warnf("catalog: %s (index keywords of %s)", err, photo.String())
+	}
+
+	return photo.Save()
+}
+
+// CatalogApply applies the metadata of a catalog image to the photo with XMP sidecar priority
+// and returns the resulting changes, the photo is not saved.
+func CatalogApply(photo *entity.Photo, img catalog.Image) (changes []CatalogChange) {
+	details := photo.GetDetails()
+
+	change := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, CatalogChange{Field: field, From: from, To: to})
+		}
+	}
+
+	location := func() string {
+		if !photo.HasLatLng() {
+			return ""
+		}
+
+		return fmt.Sprintf("%f,%f", photo.PhotoLat, photo.PhotoLng)
+	}
+
+	title, desc, rating, label, loc, keywords := photo.PhotoTitle, photo.PhotoDescription,
+		photo.PhotoRating, photo.PhotoColorLabel, location(), details.Keywords
+
+	photo.SetTitle(img.Title, entity.SrcXmp)
+	photo.SetDescription(img.Caption, entity.SrcXmp)
+	photo.SetRating(img.Rating, entity.SrcXmp)
+	photo.SetColorLabel(img.ColorLabel, entity.SrcXmp)
+
+	if img.HasLatLng() {
+		photo.SetCoordinates(float32(img.Lat), float32(img.Lng), img.Altitude, entity.SrcXmp)
+	}
+
+	details.SetKeywords(strings.Join(img.Keywords, ", "), entity.SrcXmp)
+
+	change("Title", title, photo.PhotoTitle)
+	change("Description", desc, photo.PhotoDescription)
+	change("Rating", fmt.Sprintf("%d", rating), fmt.Sprintf("%d", photo.PhotoRating))
+	change("ColorLabel", label, photo.PhotoColorLabel)
+	change("Location", loc, location())
+	change("Keywords", keywords, details.Keywords)
+
+	// Picks are added to favorites and rejects are archived.
+	switch img.Pick {
+	case catalog.Picked:
+		if !photo.PhotoFavorite {
+			change("Favorite", "false", "true")
+		}
+	case catalog.Rejected:
+		if photo.DeletedAt == nil {
+			change("Archived", "false", "true")
+		}
+	}
+
+	return changes
+}
+
+// CatalogLabels returns the labels that are not assigned to the photo yet.
+func CatalogLabels(photo *entity.Photo, labels []string) (changes []CatalogChange) {
+	for _, name := range labels {
+		title := classify.Label{Name: name}.Title()
+		found := title == ""
+
+		for _, l := range photo.Labels {
+			if l.Label != nil && strings.EqualFold(l.Label.LabelName, title) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			changes = append(changes, CatalogChange{Field: "Label", To: title})
+		}
+	}
+
+	return changes
+}
+
+// CatalogPathScore returns the number of trailing path segments that match, or 0 if the file names differ.
+func CatalogPathScore(catalogName, fileName string) (score int) {
+	a := strings.Split(strings.Trim(catalogName, "/"), "/")
+	b := strings.Split(strings.Trim(fileName, "/"), "/")
+
+	for i, j := len(a)-1, len(b)-1; i >= 0 && j >= 0; i, j = i-1, j-1 {
+		if a[i] != b[j] {
+			break
+		}
+
+		score++
+	}
+
+	return score
+}
@@ -0,0 +1,89 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/catalog"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestCatalogPathScore(t *testing.T) {
+	assert.Equal(t, 3, CatalogPathScore("/Users/jane/Pictures/2023/Berlin/IMG_1234.CR2", "2023/Berlin/IMG_1234.CR2"))
+	assert.Equal(t, 2, CatalogPathScore("C:/Photos/Berlin/IMG_1234.CR2", "2023/Berlin/IMG_1234.CR2"))
+	assert.Equal(t, 1, CatalogPathScore("/Volumes/Photos/IMG_1234.CR2", "IMG_1234.CR2"))
+	assert.Equal(t, 0, CatalogPathScore("/Volumes/Photos/IMG_1234.CR2", "IMG_1234.jpg"))
+}
+
+func TestCatalogApply(t *testing.T) {
+	t.Run("Changes", func(t *testing.T) {
+		photo := entity.Photo{PhotoTitle: "Berlin / 2023", TitleSrc: entity.SrcAuto, Details: &entity.Details{}}
+
+		changes := CatalogApply(&photo, catalog.Image{
+			Title:    "Spree",
+			Caption:  "Sunset at the river",
+			Keywords: []string{"sunset", "river"},
+			Rating:   4,
+			Pick:     catalog.Picked,
+			Lat:      52.52,
+			Lng:      13.405,
+		})
+
+		fields := make([]string, len(changes))
+
+		for i, c := range changes {
+			fields[i] = c.Field
+		}
+
+		assert.Equal(t, []string{"Title", "Description", "Rating", "Location", "Keywords", "Favorite"}, fields)
+		assert.Equal(t, "Spree", photo.PhotoTitle)
+		assert.Equal(t, entity.SrcXmp, photo.TitleSrc)
+		assert.Equal(t, "Berlin / 2023", changes[0].From)
+		assert.Equal(t, int8(4), photo.PhotoRating)
+		assert.Equal(t, "sunset, river", photo.Details.Keywords)
+	})
+	t.Run("ManualPriority", func(t *testing.T) {
+		photo := entity.Photo{PhotoTitle: "My Title", TitleSrc: entity.SrcManual, Details: &entity.Details{}}
+
+		changes := CatalogApply(&photo, catalog.Image{Title: "Spree", Pick: catalog.Rejected})
+
+		if assert.Len(t, changes, 1) {
+			assert.Equal(t, "Archived", changes[0].Field)
+		}
+
+		assert.Equal(t, "My Title", photo.PhotoTitle)
+	})
+}
+
+func TestCatalogLabels(t *testing.T) {
+	photo := entity.Photo{Labels: []entity.PhotoLabel{{Label: &entity.Label{LabelName: "Berlin"}}}}
+
+	changes := CatalogLabels(&photo, []string{"berlin", "river", ""})
+
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, "Label", changes[0].Field)
+		assert.Equal(t, "River", changes[0].To)
+	}
+}
+
+func TestCatalogImport_Match(t *testing.T) {
+	w := NewCatalogImport(config.TestConfig(), nil)
+
+	t.Run("Path", func(t *testing.T) {
+		file, byHash := w.Match(catalog.Image{FileName: "/Users/jane/Pictures/2790/07/27900704_070228_D6D51B6C.jpg"})
+
+		if assert.NotNil(t, file) {
+			assert.Equal(t, "2790/07/27900704_070228_D6D51B6C.jpg", file.FileName)
+		}
+
+		assert.False(t, byHash)
+	})
+	t.Run("NameOnly", func(t *testing.T) {
+		file, byHash := w.Match(catalog.Image{FileName: "/Volumes/Photos/27900704_070228_D6D51B6C.jpg"})
+
+		assert.Nil(t, file)
+		assert.False(t, byHash)
+	})
+}
@@ -1,15 +1,17 @@
 package photoprism
 
 import (
-	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"runtime/debug"
+	"strings"
 	"sync"
 
 	"github.com/karrick/godirwalk"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -24,6 +26,8 @@ type Convert struct {
 	darktableBlacklist   fs.Blacklist
 	rawtherapeeBlacklist fs.Blacklist
 	imagemagickBlacklist fs.Blacklist
+	converterMutex       sync.Mutex
+	converterSlots       map[string]chan struct{}
 }
 
 // NewConvert returns a new converter and expects the config as argument.
@@ -34,6 +38,7 @@ func NewConvert(conf *config.Config) *Convert {
 		darktableBlacklist:   fs.NewBlacklist(conf.DarktableBlacklist()),
 		rawtherapeeBlacklist: fs.NewBlacklist(conf.RawTherapeeBlacklist()),
 		imagemagickBlacklist: fs.NewBlacklist(conf.ImageMagickBlacklist()),
+		converterSlots:       make(map[string]chan struct{}),
 	}
 
 	return c
@@ -54,6 +59,15 @@ func (c *Convert) Start(dir string, ext []string, force bool) (err error) {
 
 	defer mutex.MainWorker.Stop()
 
+	// Add a persistent job for folders in originals, so that the remaining files are converted
+	// by the job queue if it gets interrupted. Jobs are not limited to file extensions.
+	var job *entity.Job
+
+	if originalsPath := c.conf.OriginalsPath(); len(ext) == 0 && fs.PathExists(dir) &&
+		(dir == originalsPath || strings.HasPrefix(dir, originalsPath+string(os.PathSeparator))) {
+		job = TrackJob(entity.JobConvert, fs.RelName(dir, originalsPath), force)
+	}
+
 	jobs := make(chan ConvertJob)
 
 	// Start a fixed number of goroutines to convert files.
@@ -90,7 +104,7 @@ func (c *Convert) Start(dir string, ext []string, force bool) (err error) {
 			}()
 
 			if mutex.MainWorker.Canceled() {
-				return errors.New("canceled")
+				return ErrCanceled
 			}
 
 			isDir, _ := info.IsDirOrSymlinkToDir()
@@ -129,5 +143,7 @@ func (c *Convert) Start(dir string, ext []string, force bool) (err error) {
 	close(jobs)
 	wg.Wait()
 
+	FinishJob(job, err)
+
 	return err
 }
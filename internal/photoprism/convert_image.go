@@ -81,6 +81,16 @@ func (c *Convert) ToImage(f *MediaFile, force bool) (*MediaFile, error) {
 
 	start := time.Now()
 
+	// Try user-defined converters first, see converters.yml in the config path.
+	if len(c.UserConverters(f)) > 0 {
+		if name, err := c.UserConvert(f, imageName, xmpName); err == nil {
+			log.Infof("convert: %s created in %s (%s)", clean.Log(filepath.Base(imageName)), time.Since(start), clean.Log(name))
+			return NewMediaFile(imageName)
+		} else {
+			log.Warnf("convert: %s in %s (user-defined converters)", err, clean.Log(f.RootRelName()))
+		}
+	}
+
 	// PNG, GIF, BMP, TIFF, and WebP can be handled natively.
 	if f.IsImageOther() {
 		log.Infof("convert: converting %s to %s (%s)", clean.Log(filepath.Base(fileName)), clean.Log(filepath.Base(imageName)), f.FileType())
@@ -106,6 +116,16 @@ func (c *Convert) ToImage(f *MediaFile, force bool) (*MediaFile, error) {
 		}
 	}
 
+	// Extract embedded JPEG preview from RAW files before trying external commands.
+	if f.IsRaw() && fs.LowerExt(imageName) == fs.ExtJPEG && c.conf.RawPreviewEnabled() {
+		if err = c.RawPreview(f, imageName); err == nil {
+			log.Infof("convert: %s created in %s (embedded preview)", clean.Log(filepath.Base(imageName)), time.Since(start))
+			return NewMediaFile(imageName)
+		} else {
+			log.Debugf("convert: %s in %s (embedded preview)", err, clean.Log(f.RootRelName()))
+		}
+	}
+
 	// Run external commands for other formats.
 	var cmds []*exec.Cmd
 	var useMutex bool
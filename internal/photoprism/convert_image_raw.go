@@ -0,0 +1,48 @@
+package photoprism
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/raw"
+)
+
+// RawPreview saves the largest embedded JPEG preview of a RAW file with the orientation applied,
+// so that no external tools are needed if the preview is large enough.
+func (c *Convert) RawPreview(f *MediaFile, jpegName string) error {
+	if f == nil {
+		return fmt.Errorf("file is nil - possible bug")
+	} else if !f.IsRaw() {
+		return fmt.Errorf("%s is not a raw file", clean.Log(f.RootRelName()))
+	}
+
+	preview, err := raw.Extract(f.FileName())
+
+	if err != nil {
+		return err
+	} else if preview.Size() < raw.MinSize {
+		return fmt.Errorf("embedded preview is too small (%dx%d)", preview.Width, preview.Height)
+	}
+
+	// Save preview as is if no rotation is required.
+	if preview.Orientation <= 1 {
+		err = os.WriteFile(jpegName, preview.Data, fs.ModeFile)
+	} else if img, decodeErr := imaging.Decode(bytes.NewReader(preview.Data)); decodeErr != nil {
+		return decodeErr
+	} else {
+		err = imaging.Save(thumb.Rotate(img, preview.Orientation), jpegName, thumb.JpegQuality.EncodeOption())
+	}
+
+	// Remove incomplete files.
+	if err != nil {
+		_ = os.Remove(jpegName)
+	}
+
+	return err
+}
@@ -0,0 +1,60 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/raw"
+)
+
+func TestConvert_RawPreview(t *testing.T) {
+	cnf := config.TestConfig()
+	convert := NewConvert(cnf)
+
+	t.Run("Dng", func(t *testing.T) {
+		mf, err := NewMediaFile(filepath.Join(cnf.ExamplesPath(), "canon_eos_6d.dng"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jpegName := filepath.Join(t.TempDir(), "canon_eos_6d.dng.jpg")
+
+		// The embedded preview is 1024 pixels wide.
+		assert.Error(t, convert.RawPreview(mf, jpegName))
+		assert.False(t, fs.FileExists(jpegName))
+
+		minSize := raw.MinSize
+		raw.MinSize = 1024
+		defer func() { raw.MinSize = minSize }()
+
+		if err = convert.RawPreview(mf, jpegName); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := NewMediaFile(jpegName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1024, result.Width())
+		assert.Equal(t, 683, result.Height())
+
+		_ = os.Remove(jpegName)
+	})
+	t.Run("NotRaw", func(t *testing.T) {
+		mf, err := NewMediaFile(filepath.Join(cnf.ExamplesPath(), "beach_sand.jpg"))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, convert.RawPreview(mf, filepath.Join(t.TempDir(), "beach_sand.jpg")))
+	})
+}
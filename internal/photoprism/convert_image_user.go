@@ -0,0 +1,162 @@
+package photoprism
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// UserConverters returns the user-defined converters for a media file in the configured order.
+func (c *Convert) UserConverters(f *MediaFile) config.Converters {
+	if f == nil {
+		return nil
+	}
+
+	return c.conf.Converters().Find(f.FileName())
+}
+
+// UserConvert creates a JPEG or PNG image with the first user-defined converter that succeeds,
+// and returns the converter name.
+func (c *Convert) UserConvert(f *MediaFile, imageName, xmpName string) (string, error) {
+	converters := c.UserConverters(f)
+
+	if len(converters) == 0 {
+		return "", fmt.Errorf("no user-defined converter for %s", clean.Log(f.BaseName()))
+	}
+
+	var err error
+
+	for _, conv := range converters {
+		if err = c.RunConverter(conv, f, imageName, xmpName); err == nil {
+			return conv.Name, nil
+		}
+
+		log.Debugf("convert: %s in %s (%s)", err, clean.Log(f.RootRelName()), clean.Log(conv.Name))
+	}
+
+	return "", err
+}
+
+// RunConverter runs a user-defined converter command to create a JPEG or PNG image from a media file.
+// Existing output is removed first, and incomplete output is removed if the command fails.
+func (c *Convert) RunConverter(conv config.Converter, f *MediaFile, imageName, xmpName string) (err error) {
+	if f == nil {
+		return fmt.Errorf("file is nil - possible bug")
+	}
+
+	args, err := conv.Args(map[string]string{
+		config.ConverterInput:   f.FileName(),
+		config.ConverterOutput:  imageName,
+		config.ConverterXmp:     xmpName,
+		config.ConverterSize:    strconv.Itoa(c.conf.JpegSize()),
+		config.ConverterQuality: c.conf.JpegQuality().String(),
+		config.ConverterOffset:  ffmpeg.PreviewTimeOffset(f.Duration()),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// Limit the number of commands running in parallel, if configured.
+	if slot := c.converterSlot(conv); slot != nil {
+		slot <- struct{}{}
+		defer func() { <-slot }()
+	}
+
+	// Remove the output of previous attempts, so that it cannot be mistaken for the result.
+	if err = os.Remove(imageName); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = os.Remove(imageName)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), conv.RunTimeout())
+	defer cancel()
+
+	// Fetch command output.
+	var out bytes.Buffer
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	cmd.Env = []string{
+		fmt.Sprintf("HOME=%s", c.conf.CmdCachePath()),
+		fmt.Sprintf("LD_LIBRARY_PATH=%s", c.conf.CmdLibPath()),
+		fmt.Sprintf("PATH=%s", os.Getenv("PATH")),
+	}
+
+	log.Infof("convert: converting %s to %s (%s)", clean.Log(f.BaseName()), clean.Log(filepath.Base(imageName)), clean.Log(conv.Name))
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	// Run convert command.
+	if err = cmd.Run(); ctx.Err() != nil {
+		return fmt.Errorf("timeout after %s", conv.RunTimeout())
+	} else if err != nil {
+		if errStr := strings.TrimSpace(stderr.String()); errStr != "" {
+			return errors.New(errStr)
+		}
+
+		return err
+	}
+
+	expectedMime := fs.MimeTypeJPEG
+
+	if fs.LowerExt(imageName) == fs.ExtPNG {
+		expectedMime = fs.MimeTypePNG
+	}
+
+	// Use output file, or write the image data from stdout otherwise.
+	if fs.FileExistsNotEmpty(imageName) {
+		return nil
+	} else if res := out.Bytes(); len(res) < 512 || !mimetype.Detect(res).Is(expectedMime) {
+		return fmt.Errorf("%s not created", clean.Log(filepath.Base(imageName)))
+	} else if err = os.WriteFile(imageName, res, fs.ModeFile); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// converterSlot returns a buffered channel that limits parallel runs of the converter,
+// or nil if its concurrency is unlimited.
+func (c *Convert) converterSlot(conv config.Converter) chan struct{} {
+	if conv.Concurrency < 1 {
+		return nil
+	}
+
+	c.converterMutex.Lock()
+	defer c.converterMutex.Unlock()
+
+	if c.converterSlots == nil {
+		c.converterSlots = make(map[string]chan struct{})
+	}
+
+	slot, ok := c.converterSlots[conv.Name]
+
+	if !ok {
+		slot = make(chan struct{}, conv.Concurrency)
+		c.converterSlots[conv.Name] = slot
+	}
+
+	return slot
+}
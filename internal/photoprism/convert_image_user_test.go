@@ -0,0 +1,88 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestConvert_RunConverter(t *testing.T) {
+	cnf := config.TestConfig()
+	convert := NewConvert(cnf)
+
+	mf, err := NewMediaFile(filepath.Join(cnf.ExamplesPath(), "beach_sand.jpg"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Output", func(t *testing.T) {
+		imageName := filepath.Join(t.TempDir(), "output.jpg")
+		conv := config.Converter{Name: "Copy", Extensions: []string{".jpg"}, Command: "cp {input} {output}"}
+
+		assert.NoError(t, convert.RunConverter(conv, mf, imageName, ""))
+		assert.True(t, fs.FileExistsNotEmpty(imageName))
+	})
+	t.Run("Stdout", func(t *testing.T) {
+		imageName := filepath.Join(t.TempDir(), "stdout.jpg")
+		conv := config.Converter{Name: "Cat", Extensions: []string{".jpg"}, Command: "cat {input}", Concurrency: 1}
+
+		assert.NoError(t, convert.RunConverter(conv, mf, imageName, ""))
+		assert.True(t, fs.FileExistsNotEmpty(imageName))
+	})
+	t.Run("NoOutput", func(t *testing.T) {
+		imageName := filepath.Join(t.TempDir(), "none.jpg")
+		conv := config.Converter{Name: "True", Extensions: []string{".jpg"}, Command: "true {input}"}
+
+		assert.Error(t, convert.RunConverter(conv, mf, imageName, ""))
+		assert.False(t, fs.FileExists(imageName))
+	})
+	t.Run("PartialOutput", func(t *testing.T) {
+		imageName := filepath.Join(t.TempDir(), "partial.jpg")
+		conv := config.Converter{Name: "Fail", Extensions: []string{".jpg"}, Command: "sh -c 'head -c 1024 \"$0\" > \"$1\"; exit 1' {input} {output}"}
+
+		assert.Error(t, convert.RunConverter(conv, mf, imageName, ""))
+		assert.False(t, fs.FileExists(imageName))
+
+		// A converter that succeeds without output must not use a file left by a previous attempt.
+		if err := os.WriteFile(imageName, []byte("incomplete"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		conv = config.Converter{Name: "True", Extensions: []string{".jpg"}, Command: "true {input}"}
+
+		assert.Error(t, convert.RunConverter(conv, mf, imageName, ""))
+		assert.False(t, fs.FileExists(imageName))
+	})
+	t.Run("Timeout", func(t *testing.T) {
+		imageName := filepath.Join(t.TempDir(), "timeout.jpg")
+		conv := config.Converter{Name: "Sleep", Extensions: []string{".jpg"}, Command: "sh -c 'exec sleep 2' {input}", Timeout: 100 * time.Millisecond}
+
+		err := convert.RunConverter(conv, mf, imageName, "")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "timeout")
+	})
+}
+
+func TestConvert_UserConverters(t *testing.T) {
+	cnf := config.TestConfig()
+	convert := NewConvert(cnf)
+
+	mf, err := NewMediaFile(filepath.Join(cnf.ExamplesPath(), "beach_sand.jpg"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, convert.UserConverters(mf), 0)
+	assert.Len(t, convert.UserConverters(nil), 0)
+
+	_, err = convert.UserConvert(mf, filepath.Join(t.TempDir(), "beach_sand.jpg"), "")
+	assert.Error(t, err)
+}
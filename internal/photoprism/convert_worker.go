@@ -24,21 +24,32 @@ func ConvertWorker(jobs <-chan ConvertJob) {
 			continue
 		case job.convert == nil:
 			continue
-		case job.file.IsAnimated():
-			_, _ = job.convert.ToJson(job.file, false)
-
-			// Create JPEG preview and AVC encoded version for videos.
-			if _, err := job.convert.ToImage(job.file, job.force); err != nil {
-				logError(err, job)
-			} else if metaData := job.file.MetaData(); metaData.CodecAvc() {
-				continue
-			} else if _, err := job.convert.ToAvc(job.file, job.convert.conf.FFmpegEncoder(), false, false); err != nil {
-				logError(err, job)
-			}
 		default:
-			if _, err := job.convert.ToImage(job.file, job.force); err != nil {
+			if err := job.convert.File(job.file, job.force); err != nil {
 				logError(err, job)
 			}
 		}
 	}
 }
+
+// File creates a JPEG preview for the media file if needed and, in case of videos, an AVC encoded version.
+func (c *Convert) File(f *MediaFile, force bool) error {
+	if f.IsAnimated() {
+		_, _ = c.ToJson(f, false)
+
+		// Create JPEG preview and AVC encoded version for videos.
+		if _, err := c.ToImage(f, force); err != nil {
+			return err
+		} else if metaData := f.MetaData(); metaData.CodecAvc() {
+			return nil
+		} else if _, err = c.ToAvc(f, c.conf.FFmpegEncoder(), false, false); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
+	_, err := c.ToImage(f, force)
+
+	return err
+}
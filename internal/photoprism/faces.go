@@ -59,7 +59,9 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 	start = time.Now()
 	if removed, err := query.RemoveOrphanMarkers(); err != nil {
 		log.Errorf("faces: %s (remove orphan markers)", err)
+		opt.Results.Fail("", err)
 	} else if removed > 0 {
+		opt.Results.Add("RemovedMarkers", int(removed))
 		log.Infof("faces: removed %d orphan markers [%s]", removed, time.Since(start))
 	} else {
 		log.Debugf("faces: found no orphan markers [%s]", time.Since(start))
@@ -69,7 +71,9 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 	start = time.Now()
 	if removed, err := query.FixMarkerReferences(); err != nil {
 		log.Errorf("markers: %s (fix references)", err)
+		opt.Results.Fail("", err)
 	} else if removed > 0 {
+		opt.Results.Add("FixedReferences", int(removed))
 		log.Infof("markers: fixed %d references [%s]", removed, time.Since(start))
 	} else {
 		log.Debugf("markers: found no invalid references [%s]", time.Since(start))
@@ -79,7 +83,9 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 	start = time.Now()
 	if affected, err := query.CreateMarkerSubjects(); err != nil {
 		log.Errorf("markers: %s (create subjects)", err)
+		opt.Results.Fail("", err)
 	} else if affected > 0 {
+		opt.Results.Add("AddedSubjects", int(affected))
 		log.Infof("markers: added %d known subjects [%s]", affected, time.Since(start))
 	} else {
 		log.Debugf("markers: found no missing subjects [%s]", time.Since(start))
@@ -89,7 +95,9 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 	start = time.Now()
 	if c, r, err := query.ResolveFaceCollisions(); err != nil {
 		log.Errorf("faces: %s (resolve ambiguous subjects)", err)
+		opt.Results.Fail("", err)
 	} else if c > 0 {
+		opt.Results.Add("ResolvedCollisions", r)
 		log.Infof("faces: resolved %d / %d ambiguous subjects [%s]", r, c, time.Since(start))
 	} else {
 		log.Debugf("faces: found no ambiguous subjects [%s]", time.Since(start))
@@ -100,6 +108,7 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 	if res, err := w.Optimize(); err != nil {
 		return err
 	} else if res.Merged > 0 {
+		opt.Results.Add("MergedClusters", res.Merged)
 		log.Infof("faces: merged %d clusters [%s]", res.Merged, time.Since(start))
 	} else {
 		log.Debugf("faces: found no clusters to be merged [%s]", time.Since(start))
@@ -111,7 +120,9 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 	start = time.Now()
 	if added, err = w.Cluster(opt); err != nil {
 		log.Errorf("faces: %s (cluster)", err)
+		opt.Results.Fail("", err)
 	} else if n := len(added); n > 0 {
+		opt.Results.Add("AddedFaces", n)
 		log.Infof("faces: added %d new faces [%s]", n, time.Since(start))
 	} else {
 		log.Debugf("faces: found no new faces [%s]", time.Since(start))
@@ -123,8 +134,13 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 
 	if err != nil {
 		log.Errorf("faces: %s (match)", err)
+		opt.Results.Fail("", err)
 	}
 
+	opt.Results.Add("UpdatedMarkers", int(matches.Updated))
+	opt.Results.Add("Recognized", int(matches.Recognized))
+	opt.Results.Add("Unknown", int(matches.Unknown))
+
 	// Log face matching results.
 	if matches.Updated > 0 {
 		log.Infof("faces: updated %s, recognized %s, %d unknown [%s]", english.Plural(int(matches.Updated), "marker", "markers"), english.Plural(int(matches.Recognized), "face", "faces"), matches.Unknown, time.Since(start))
@@ -136,7 +152,9 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 	start = time.Now()
 	if count, err := entity.DeleteOrphanPeople(); err != nil {
 		log.Errorf("faces: %s (remove people)", err)
+		opt.Results.Fail("", err)
 	} else if count > 0 {
+		opt.Results.Add("RemovedPeople", count)
 		log.Debugf("faces: removed %d people [%s]", count, time.Since(start))
 	}
 
@@ -144,7 +162,9 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 	start = time.Now()
 	if count, err := entity.DeleteOrphanFaces(); err != nil {
 		log.Errorf("faces: %s (remove clusters)", err)
+		opt.Results.Fail("", err)
 	} else if count > 0 {
+		opt.Results.Add("RemovedClusters", count)
 		log.Debugf("faces: removed %d clusters [%s]", count, time.Since(start))
 	}
 
@@ -0,0 +1,337 @@
+package photoprism
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clusters"
+)
+
+// FaceThresholds represents a combination of face clustering and matching thresholds.
+type FaceThresholds struct {
+	ClusterCore int
+	ClusterDist float64
+	MatchDist   float64
+}
+
+// String returns the thresholds as string for logging.
+func (t FaceThresholds) String() string {
+	return fmt.Sprintf("core %d, dist %.2f, match %.2f", t.ClusterCore, t.ClusterDist, t.MatchDist)
+}
+
+// FaceHoldout is the default share of named faces per person that is held out for validation.
+const FaceHoldout = 0.2
+
+// FacesEvaluateOptions represents the threshold values to be evaluated and the share of faces held out for validation.
+type FacesEvaluateOptions struct {
+	ClusterCore []int
+	ClusterDist []float64
+	MatchDist   []float64
+	Holdout     float64
+}
+
+// HoldoutShare returns the share of faces held out for validation, using the default if the value is invalid.
+func (o FacesEvaluateOptions) HoldoutShare() float64 {
+	if o.Holdout <= 0 || o.Holdout >= 1 {
+		return FaceHoldout
+	}
+
+	return o.Holdout
+}
+
+// Thresholds returns all combinations of the threshold values, using the current values if none were specified.
+func (o FacesEvaluateOptions) Thresholds() (result []FaceThresholds) {
+	core, dist, match := o.ClusterCore, o.ClusterDist, o.MatchDist
+
+	if len(core) == 0 {
+		core = []int{face.ClusterCore}
+	}
+
+	if len(dist) == 0 {
+		dist = []float64{face.ClusterDist}
+	}
+
+	if len(match) == 0 {
+		match = []float64{face.MatchDist}
+	}
+
+	for _, c := range core {
+		for _, d := range dist {
+			for _, m := range match {
+				result = append(result, FaceThresholds{ClusterCore: c, ClusterDist: d, MatchDist: m})
+			}
+		}
+	}
+
+	return result
+}
+
+// FaceSamples represents face embeddings and the subjects they belong to.
+type FaceSamples struct {
+	Subjects   []string
+	Embeddings face.Embeddings
+}
+
+// Len returns the number of samples.
+func (s FaceSamples) Len() int {
+	return len(s.Embeddings)
+}
+
+// Split splits the samples into a training and a validation set, so that thresholds are not evaluated with
+// the same faces they were learned from. The share of faces specified is held out for each subject in order.
+func (s FaceSamples) Split(holdout float64) (train, test FaceSamples) {
+	seen := make(map[string]int)
+
+	for i, e := range s.Embeddings {
+		subj := s.Subjects[i]
+		n := seen[subj]
+		seen[subj]++
+
+		if int(float64(n+1)*holdout) > int(float64(n)*holdout) {
+			test.Subjects = append(test.Subjects, subj)
+			test.Embeddings = append(test.Embeddings, e)
+		} else {
+			train.Subjects = append(train.Subjects, subj)
+			train.Embeddings = append(train.Embeddings, e)
+		}
+	}
+
+	return train, test
+}
+
+// FaceConfusion counts faces of a subject that were matched with a cluster of another subject.
+type FaceConfusion struct {
+	Actual    string
+	Predicted string
+	Count     int
+}
+
+// FaceEvaluation represents the results of evaluating face thresholds against named faces held out for validation.
+type FaceEvaluation struct {
+	Thresholds FaceThresholds
+	Samples    int
+	Subjects   int
+	Clusters   int
+	Mixed      int
+	Matched    int
+	Correct    int
+	Confusion  []FaceConfusion
+}
+
+// Precision returns the share of matched faces that were assigned to the correct subject.
+func (e FaceEvaluation) Precision() float64 {
+	if e.Matched == 0 {
+		return 0
+	}
+
+	return float64(e.Correct) / float64(e.Matched)
+}
+
+// Recall returns the share of all validation faces that were assigned to the correct subject.
+func (e FaceEvaluation) Recall() float64 {
+	if e.Samples == 0 {
+		return 0
+	}
+
+	return float64(e.Correct) / float64(e.Samples)
+}
+
+// F1 returns the harmonic mean of precision and recall.
+func (e FaceEvaluation) F1() float64 {
+	p, r := e.Precision(), e.Recall()
+
+	if p+r == 0 {
+		return 0
+	}
+
+	return 2 * p * r / (p + r)
+}
+
+// FaceEvaluations represents a list of evaluation results.
+type FaceEvaluations []FaceEvaluation
+
+// Best returns the result with the highest F1 score, preferring higher precision if scores are equal.
+func (r FaceEvaluations) Best() (best FaceEvaluation, ok bool) {
+	for i, e := range r {
+		if i == 0 || e.F1() > best.F1() || e.F1() == best.F1() && e.Precision() > best.Precision() {
+			best = e
+			ok = true
+		}
+	}
+
+	return best, ok
+}
+
+// Evaluate clusters and matches faces with manually assigned subjects using different thresholds,
+// so that the results can be compared with the known subjects.
+func (w *Faces) Evaluate(opt FacesEvaluateOptions) (results FaceEvaluations, err error) {
+	if w.Disabled() {
+		return results, fmt.Errorf("face recognition is disabled")
+	}
+
+	if err = mutex.FacesWorker.Start(); err != nil {
+		return results, err
+	}
+
+	defer mutex.FacesWorker.Stop()
+
+	subjects, embeddings, err := query.SubjectEmbeddings(face.ClusterSizeThreshold, face.ClusterScoreThreshold)
+
+	if err != nil {
+		return results, err
+	} else if len(embeddings) == 0 {
+		return results, fmt.Errorf("found no faces with known subjects")
+	} else if len(subjects) != len(embeddings) {
+		return results, fmt.Errorf("number of subjects and embeddings does not match")
+	}
+
+	train, test := FaceSamples{Subjects: subjects, Embeddings: embeddings}.Split(opt.HoldoutShare())
+
+	if test.Len() == 0 {
+		return results, fmt.Errorf("not enough named faces for validation")
+	}
+
+	log.Infof("faces: evaluating with %s and validating with %s", english.Plural(train.Len(), "named face", "named faces"),
+		english.Plural(test.Len(), "other face", "other faces"))
+
+	for _, t := range opt.Thresholds() {
+		if mutex.FacesWorker.Canceled() {
+			return results, fmt.Errorf("evaluation canceled")
+		}
+
+		if r, err := EvaluateFaces(train, test, t, w.conf.Workers()); err != nil {
+			return results, err
+		} else {
+			log.Debugf("faces: %s, precision %.3f, recall %.3f", t.String(), r.Precision(), r.Recall())
+			results = append(results, r)
+		}
+	}
+
+	return results, nil
+}
+
+// EvaluateFaces clusters the training embeddings with the thresholds passed, assigns each cluster to the subject
+// most of its faces belong to, and then matches the validation faces with the clusters like new faces would be.
+func EvaluateFaces(train, test FaceSamples, t FaceThresholds, workers int) (result FaceEvaluation, err error) {
+	if len(train.Subjects) != train.Len() || len(test.Subjects) != test.Len() {
+		return result, fmt.Errorf("number of subjects and embeddings does not match")
+	}
+
+	result.Thresholds = t
+	result.Samples = test.Len()
+
+	known := make(map[string]bool)
+
+	for _, subj := range train.Subjects {
+		known[subj] = true
+	}
+
+	result.Subjects = len(known)
+
+	if train.Len() == 0 || test.Len() == 0 {
+		return result, nil
+	}
+
+	subjects, embeddings := train.Subjects, train.Embeddings
+
+	c, err := clusters.DBSCAN(t.ClusterCore, t.ClusterDist, workers, clusters.EuclideanDist)
+
+	if err != nil {
+		return result, err
+	} else if err = c.Learn(embeddings.Float64()); err != nil {
+		return result, err
+	}
+
+	sizes := c.Sizes()
+	members := make([]face.Embeddings, len(sizes))
+	counts := make([]map[string]int, len(sizes))
+
+	for i := range sizes {
+		counts[i] = make(map[string]int)
+	}
+
+	for i, n := range c.Guesses() {
+		if n < 1 {
+			continue
+		}
+
+		members[n-1] = append(members[n-1], embeddings[i])
+		counts[n-1][subjects[i]]++
+	}
+
+	type cluster struct {
+		subject  string
+		midpoint face.Embedding
+		radius   float64
+	}
+
+	found := make([]cluster, 0, len(sizes))
+
+	for i := range members {
+		if len(members[i]) == 0 {
+			continue
+		}
+
+		// Assign the subject most faces belong to.
+		var subject string
+
+		for subj, n := range counts[i] {
+			if n > counts[i][subject] || n == counts[i][subject] && subj < subject {
+				subject = subj
+			}
+		}
+
+		if len(counts[i]) > 1 {
+			result.Mixed++
+		}
+
+		midpoint, radius, _ := face.EmbeddingsMidpoint(members[i])
+		found = append(found, cluster{subject: subject, midpoint: midpoint, radius: radius})
+	}
+
+	result.Clusters = len(found)
+
+	confusion := make(map[[2]string]int)
+
+	// Match each validation face with the closest cluster within range.
+	for i, e := range test.Embeddings {
+		best, bestDist := -1, -1.0
+
+		for j, cl := range found {
+			if dist := cl.midpoint.Dist(e); dist <= cl.radius+t.MatchDist && (best < 0 || dist < bestDist) {
+				best, bestDist = j, dist
+			}
+		}
+
+		if best < 0 {
+			continue
+		}
+
+		result.Matched++
+
+		if predicted := found[best].subject; predicted == test.Subjects[i] {
+			result.Correct++
+		} else {
+			confusion[[2]string{test.Subjects[i], predicted}]++
+		}
+	}
+
+	for k, n := range confusion {
+		result.Confusion = append(result.Confusion, FaceConfusion{Actual: k[0], Predicted: k[1], Count: n})
+	}
+
+	sort.Slice(result.Confusion, func(i, j int) bool {
+		if result.Confusion[i].Count != result.Confusion[j].Count {
+			return result.Confusion[i].Count > result.Confusion[j].Count
+		}
+
+		return result.Confusion[i].Actual+result.Confusion[i].Predicted < result.Confusion[j].Actual+result.Confusion[j].Predicted
+	})
+
+	return result, nil
+}
@@ -0,0 +1,106 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/face"
+)
+
+func TestFacesEvaluateOptions_Thresholds(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		result := FacesEvaluateOptions{}.Thresholds()
+		assert.Len(t, result, 1)
+		assert.Equal(t, face.ClusterCore, result[0].ClusterCore)
+		assert.Equal(t, face.ClusterDist, result[0].ClusterDist)
+		assert.Equal(t, face.MatchDist, result[0].MatchDist)
+	})
+	t.Run("Combinations", func(t *testing.T) {
+		opt := FacesEvaluateOptions{ClusterCore: []int{3, 4}, ClusterDist: []float64{0.6, 0.7}, MatchDist: []float64{0.4, 0.5, 0.6}}
+		assert.Len(t, opt.Thresholds(), 12)
+	})
+}
+
+func TestFacesEvaluateOptions_HoldoutShare(t *testing.T) {
+	assert.Equal(t, FaceHoldout, FacesEvaluateOptions{}.HoldoutShare())
+	assert.Equal(t, FaceHoldout, FacesEvaluateOptions{Holdout: 1}.HoldoutShare())
+	assert.Equal(t, 0.5, FacesEvaluateOptions{Holdout: 0.5}.HoldoutShare())
+}
+
+func TestFaceSamples_Split(t *testing.T) {
+	samples := FaceSamples{
+		Subjects:   []string{"a", "a", "a", "a", "a", "b", "b", "b", "b", "b", "c"},
+		Embeddings: make(face.Embeddings, 11),
+	}
+
+	train, test := samples.Split(0.2)
+
+	assert.Equal(t, 9, train.Len())
+	assert.Equal(t, []string{"a", "b"}, test.Subjects)
+	assert.Equal(t, 2, test.Len())
+}
+
+func TestEvaluateFaces(t *testing.T) {
+	train := FaceSamples{
+		Subjects: []string{"a", "a", "a", "b", "b", "b"},
+		Embeddings: face.Embeddings{
+			{0, 0, 0}, {0.1, 0, 0}, {0, 0.1, 0},
+			{5, 5, 5}, {5.1, 5, 5}, {5, 5.1, 5},
+		},
+	}
+
+	test := FaceSamples{
+		Subjects:   []string{"a", "b"},
+		Embeddings: face.Embeddings{{0.05, 0.05, 0}, {5.05, 5.05, 5}},
+	}
+
+	t.Run("Separated", func(t *testing.T) {
+		r, err := EvaluateFaces(train, test, FaceThresholds{ClusterCore: 2, ClusterDist: 0.5, MatchDist: 0.2}, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 2, r.Samples)
+		assert.Equal(t, 2, r.Subjects)
+		assert.Equal(t, 2, r.Clusters)
+		assert.Equal(t, 0, r.Mixed)
+		assert.Equal(t, 2, r.Correct)
+		assert.Equal(t, 1.0, r.Precision())
+		assert.Equal(t, 1.0, r.Recall())
+		assert.Empty(t, r.Confusion)
+	})
+	t.Run("Merged", func(t *testing.T) {
+		r, err := EvaluateFaces(train, test, FaceThresholds{ClusterCore: 2, ClusterDist: 10, MatchDist: 0.2}, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, r.Clusters)
+		assert.Equal(t, 1, r.Mixed)
+		assert.Equal(t, 2, r.Matched)
+		assert.Equal(t, 1, r.Correct)
+		assert.Equal(t, 0.5, r.Precision())
+		assert.Len(t, r.Confusion, 1)
+	})
+	t.Run("Mismatch", func(t *testing.T) {
+		_, err := EvaluateFaces(FaceSamples{Subjects: train.Subjects[1:], Embeddings: train.Embeddings}, test, FaceThresholds{ClusterCore: 2, ClusterDist: 0.5}, 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestFaceEvaluations_Best(t *testing.T) {
+	results := FaceEvaluations{
+		{Thresholds: FaceThresholds{ClusterCore: 4}, Samples: 10, Matched: 10, Correct: 5},
+		{Thresholds: FaceThresholds{ClusterCore: 3}, Samples: 10, Matched: 8, Correct: 8},
+	}
+
+	best, ok := results.Best()
+	assert.True(t, ok)
+	assert.Equal(t, 3, best.Thresholds.ClusterCore)
+
+	_, ok = FaceEvaluations{}.Best()
+	assert.False(t, ok)
+}
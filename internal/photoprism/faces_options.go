@@ -2,9 +2,11 @@ package photoprism
 
 import "github.com/photoprism/photoprism/internal/face"
 
+// FacesOptions represents face clustering and matching options.
 type FacesOptions struct {
 	Force     bool
 	Threshold int
+	Results   *Results
 }
 
 // SampleThreshold returns the face embeddings sample threshold for clustering.
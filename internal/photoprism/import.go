@@ -109,6 +109,7 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 	indexOpt := NewIndexOptions("/", true, convert, true, false, false)
 	indexOpt.UID = opt.UID
 	indexOpt.Action = opt.Action
+	indexOpt.Results = opt.Results
 	skipRaw := imp.conf.DisableRaw()
 	ignore := fs.NewIgnoreList(fs.IgnoreFile, true, false)
 
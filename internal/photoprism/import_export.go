@@ -0,0 +1,52 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ExportImportOptions returns the options for moving the files of a folder exported from another
+// application, e.g. Google Takeout or Apple Photos, to the originals folder.
+func ExportImportOptions(importPath, destFolder, uid string, results *Results) ImportOptions {
+	result := ImportOptionsMove(importPath, destFolder)
+	result.Results = results
+
+	if uid != "" {
+		result.UID = uid
+	}
+
+	return result
+}
+
+// ExportAlbum finds or creates the album of an exported folder and returns its UID.
+func ExportAlbum(title, description, location, uid string) (string, error) {
+	if uid == "" {
+		uid = entity.Admin.UID()
+	}
+
+	m := entity.NewUserAlbum(title, entity.AlbumManual, uid)
+	m.AlbumDescription = description
+	m.AlbumLocation = location
+
+	if found := m.Find(); found != nil {
+		return found.AlbumUID, nil
+	} else if err := m.Create(); err != nil {
+		return "", fmt.Errorf("%s (create album %s)", err, clean.Log(title))
+	}
+
+	return m.AlbumUID, nil
+}
+
+// ProcessedFiles returns the number of processed files.
+func ProcessedFiles(done fs.Done) (n int) {
+	for _, status := range done {
+		if status.Processed() {
+			n++
+		}
+	}
+
+	return n
+}
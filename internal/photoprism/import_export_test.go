@@ -0,0 +1,32 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestExportImportOptions(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		result := ExportImportOptions("/takeout", "2023", "", nil)
+		assert.Equal(t, "/takeout", result.Path)
+		assert.Equal(t, "2023", result.DestFolder)
+		assert.True(t, result.Move)
+		assert.Nil(t, result.Results)
+	})
+	t.Run("UID", func(t *testing.T) {
+		results := &Results{}
+		result := ExportImportOptions("/takeout", "", "uqxc08w3d0ej2283", results)
+		assert.Equal(t, "uqxc08w3d0ej2283", result.UID)
+		assert.Equal(t, results, result.Results)
+	})
+}
+
+func TestProcessedFiles(t *testing.T) {
+	done := fs.Done{"a.jpg": fs.Processed, "b.jpg": fs.Found, "c.jpg": fs.Processed}
+
+	assert.Equal(t, 2, ProcessedFiles(done))
+	assert.Equal(t, 0, ProcessedFiles(nil))
+}
@@ -104,6 +104,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 				}
 			} else {
 				log.Infof("import: %s", err)
+				opt.Results.Skip(relFileName, err.Error())
 
 				// Try to add duplicates to selected album(s) as well, see #991.
 				if fileHash := f.Hash(); fileHash == "" {
@@ -130,6 +131,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 
 			if err != nil {
 				log.Errorf("import: %s in %s", err.Error(), clean.Log(fs.RelName(destMainFileName, imp.originalsPath())))
+				opt.Results.Fail(fs.RelName(destMainFileName, imp.originalsPath()), err)
 				continue
 			}
 
@@ -147,6 +149,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 			if o.Convert && f.IsMedia() && !f.HasPreviewImage() {
 				if jpegFile, err := imp.convert.ToImage(f, false); err != nil {
 					log.Errorf("import: %s in %s (convert to jpeg)", err.Error(), clean.Log(f.RootRelName()))
+					opt.Results.Fail(f.RootRelName(), err)
 					continue
 				} else {
 					log.Debugf("import: created %s", clean.Log(jpegFile.BaseName()))
@@ -158,9 +161,11 @@ func ImportWorker(jobs <-chan ImportJob) {
 				log.Error(err)
 			} else if limitErr, _ := jpg.ExceedsResolution(o.ResolutionLimit); limitErr != nil {
 				log.Errorf("index: %s", limitErr)
+				opt.Results.Skip(f.RootRelName(), limitErr.Error())
 				continue
 			} else if err := jpg.CreateThumbnails(imp.thumbPath(), false); err != nil {
 				log.Errorf("import: failed creating thumbnails for %s (%s)", clean.Log(f.RootRelName()), err.Error())
+				opt.Results.Fail(f.RootRelName(), err)
 				continue
 			}
 
@@ -170,6 +175,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 			// Skip import if the finding related files results in an error.
 			if err != nil {
 				log.Errorf("import: %s in %s (find related files)", err.Error(), clean.Log(fs.RelName(destMainFileName, imp.originalsPath())))
+				opt.Results.Fail(fs.RelName(destMainFileName, imp.originalsPath()), err)
 				continue
 			}
 
@@ -183,9 +189,11 @@ func ImportWorker(jobs <-chan ImportJob) {
 				// Enforce file size and resolution limits.
 				if limitErr, _ := f.ExceedsBytes(o.ByteLimit); limitErr != nil {
 					log.Warnf("import: %s", limitErr)
+					opt.Results.Skip(f.RootRelName(), limitErr.Error())
 					continue
 				} else if limitErr, _ = f.ExceedsResolution(o.ResolutionLimit); limitErr != nil {
 					log.Warnf("import: %s", limitErr)
+					opt.Results.Skip(f.RootRelName(), limitErr.Error())
 					continue
 				}
 
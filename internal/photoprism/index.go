@@ -1,7 +1,6 @@
 package photoprism
 
 import (
-	"errors"
 	"fmt"
 	"path/filepath"
 	"runtime"
@@ -113,6 +112,9 @@ func (ind *Index) Start(o IndexOptions) (found fs.Done, updated int) {
 		return found, updated
 	}
 
+	// Add a persistent job, so that indexing is resumed by the job queue if it gets interrupted.
+	job := TrackJob(entity.JobIndex, o.Path, o.Rescan)
+
 	jobs := make(chan IndexJob)
 
 	// Start a fixed number of goroutines to index files.
@@ -143,149 +145,162 @@ func (ind *Index) Start(o IndexOptions) (found fs.Done, updated int) {
 		log.Infof(`index: ignored "%s"`, fs.RelName(fileName, originalsPath))
 	}
 
-	err := godirwalk.Walk(optionsPath, &godirwalk.Options{
-		ErrorCallback: func(fileName string, err error) godirwalk.ErrorAction {
-			return godirwalk.SkipNode
-		},
-		Callback: func(fileName string, info *godirwalk.Dirent) error {
-			defer func() {
-				if r := recover(); r != nil {
-					log.Errorf("index: %s (panic)\nstack: %s", r, debug.Stack())
-				}
-			}()
-
-			if mutex.MainWorker.Canceled() {
-				return errors.New("canceled")
+	callback := func(fileName string, info *godirwalk.Dirent) error {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Errorf("index: %s (panic)\nstack: %s", r, debug.Stack())
 			}
+		}()
 
-			isDir, _ := info.IsDirOrSymlinkToDir()
-			isSymlink := info.IsSymlink()
-			relName := fs.RelName(fileName, originalsPath)
-
-			// Skip directories and known files.
-			if skip, result := fs.SkipWalk(fileName, isDir, isSymlink, found, ignore); skip {
-				if !isDir {
-					return result
-				}
-
-				if result != filepath.SkipDir {
-					folder := entity.NewFolder(entity.RootOriginals, relName, fs.BirthTime(fileName))
-
-					if err := folder.Create(); err == nil {
-						log.Infof("index: added folder /%s", folder.Path)
-					}
-				}
+		if mutex.MainWorker.Canceled() {
+			return ErrCanceled
+		}
 
-				event.Publish("index.folder", event.Data{
-					"uid":      o.UID,
-					"filePath": relName,
-				})
+		isDir, _ := info.IsDirOrSymlinkToDir()
+		isSymlink := info.IsSymlink()
+		relName := fs.RelName(fileName, originalsPath)
 
+		// Skip directories and known files.
+		if skip, result := fs.SkipWalk(fileName, isDir, isSymlink, found, ignore); skip {
+			if !isDir {
 				return result
 			}
 
-			found[fileName] = fs.Found
+			if result != filepath.SkipDir {
+				folder := entity.NewFolder(entity.RootOriginals, relName, fs.BirthTime(fileName))
 
-			if !media.MainFile(fileName) {
-				return nil
+				if err := folder.Create(); err == nil {
+					log.Infof("index: added folder /%s", folder.Path)
+				}
 			}
 
-			var mf *MediaFile
-			var err error
-			if isSymlink {
-				mf, err = NewMediaFile(fileName)
-			} else {
-				// If the file found while scanning is not a symlink we can
-				// skip resolving the fileName, which is resource intensive.
-				mf, err = NewMediaFileSkipResolve(fileName, fileName)
-			}
+			event.Publish("index.folder", event.Data{
+				"uid":      o.UID,
+				"filePath": relName,
+			})
 
-			// Check if file exists and is not empty.
-			if err != nil {
-				log.Warnf("index: %s", err)
-				return nil
-			} else if mf.Empty() {
-				return nil
-			}
+			return result
+		}
 
-			// Skip already indexed?
-			if ind.files.Indexed(relName, entity.RootOriginals, mf.modTime, o.Rescan) {
-				return nil
-			}
+		found[fileName] = fs.Found
 
-			// Skip RAW image?
-			if mf.IsRaw() && skipRaw {
-				log.Infof("index: skipped raw %s", clean.Log(mf.RootRelName()))
-				return nil
-			}
+		if !media.MainFile(fileName) {
+			return nil
+		}
 
-			// Find related files to index.
-			related, err := mf.RelatedFiles(ind.conf.Settings().StackSequences())
+		var mf *MediaFile
+		var err error
+		if isSymlink {
+			mf, err = NewMediaFile(fileName)
+		} else {
+			// If the file found while scanning is not a symlink we can
+			// skip resolving the fileName, which is resource intensive.
+			mf, err = NewMediaFileSkipResolve(fileName, fileName)
+		}
 
-			if err != nil {
-				log.Warnf("index: %s", err)
-				return nil
-			}
+		// Check if file exists and is not empty.
+		if err != nil {
+			log.Warnf("index: %s", err)
+			return nil
+		} else if mf.Empty() {
+			return nil
+		}
+
+		// Skip already indexed?
+		if ind.files.Indexed(relName, entity.RootOriginals, mf.modTime, o.Rescan) {
+			return nil
+		}
 
-			var files MediaFiles
+		// Skip RAW image?
+		if mf.IsRaw() && skipRaw {
+			log.Infof("index: skipped raw %s", clean.Log(mf.RootRelName()))
+			o.Results.Skip(mf.RootRelName(), "raw images are disabled")
+			return nil
+		}
 
-			// Main media file is required to proceed.
-			if related.Main == nil {
-				return nil
-			}
+		// Find related files to index.
+		related, err := mf.RelatedFiles(ind.conf.Settings().StackSequences())
 
-			skip := false
-
-			// Check related files.
-			for _, f := range related.Files {
-				if found[f.FileName()].Processed() {
-					// Ignore already processed files.
-					continue
-				} else if limitErr, fileSize := f.ExceedsBytes(o.ByteLimit); fileSize == 0 || ind.files.Indexed(f.RootRelName(), f.Root(), f.ModTime(), o.Rescan) {
-					// Flag file as found but not processed.
-					found[f.FileName()] = fs.Found
-					continue
-				} else if limitErr == nil {
-					// Add to file list.
-					files = append(files, f)
-				} else if related.Main.FileName() != f.FileName() {
-					// Sidecar file is too large, ignore.
-					log.Infof("index: %s", limitErr)
-				} else {
-					// Main file is too large, skip all.
-					log.Warnf("index: %s", limitErr)
-					skip = true
-				}
+		if err != nil {
+			log.Warnf("index: %s", err)
+			return nil
+		}
 
-				found[f.FileName()] = fs.Processed
-			}
+		var files MediaFiles
 
-			found[fileName] = fs.Processed
+		// Main media file is required to proceed.
+		if related.Main == nil {
+			return nil
+		}
 
-			// Skip if main file is too large or there are no files left to index.
-			if skip || len(files) == 0 {
-				return nil
+		skip := false
+
+		// Check related files.
+		for _, f := range related.Files {
+			if found[f.FileName()].Processed() {
+				// Ignore already processed files.
+				continue
+			} else if limitErr, fileSize := f.ExceedsBytes(o.ByteLimit); fileSize == 0 || ind.files.Indexed(f.RootRelName(), f.Root(), f.ModTime(), o.Rescan) {
+				// Flag file as found but not processed.
+				found[f.FileName()] = fs.Found
+				continue
+			} else if limitErr == nil {
+				// Add to file list.
+				files = append(files, f)
+			} else if related.Main.FileName() != f.FileName() {
+				// Sidecar file is too large, ignore.
+				log.Infof("index: %s", limitErr)
+			} else {
+				// Main file is too large, skip all.
+				log.Warnf("index: %s", limitErr)
+				o.Results.Skip(f.RootRelName(), limitErr.Error())
+				skip = true
 			}
 
-			updated += len(files)
-			related.Files = files
+			found[f.FileName()] = fs.Processed
+		}
 
-			jobs <- IndexJob{
-				FileName: mf.FileName(),
-				Related:  related,
-				IndexOpt: o,
-				Ind:      ind,
-			}
+		found[fileName] = fs.Processed
 
+		// Skip if main file is too large or there are no files left to index.
+		if skip || len(files) == 0 {
 			return nil
-		},
-		Unsorted:            false,
-		FollowSymbolicLinks: true,
-	})
+		}
+
+		updated += len(files)
+		related.Files = files
+
+		jobs <- IndexJob{
+			FileName: mf.FileName(),
+			Related:  related,
+			IndexOpt: o,
+			Ind:      ind,
+		}
+
+		return nil
+	}
+
+	var err error
+
+	if len(o.Files) > 0 {
+		// Only index the specified files, e.g. after they have been changed on disk.
+		err = indexFiles(o.Files, originalsPath, ignore, callback)
+	} else {
+		err = godirwalk.Walk(optionsPath, &godirwalk.Options{
+			ErrorCallback: func(fileName string, err error) godirwalk.ErrorAction {
+				return godirwalk.SkipNode
+			},
+			Callback:            callback,
+			Unsorted:            false,
+			FollowSymbolicLinks: true,
+		})
+	}
 
 	close(jobs)
 	wg.Wait()
 
+	FinishJob(job, err)
+
 	if err != nil {
 		log.Error(err.Error())
 	}
@@ -1,29 +1,39 @@
 package photoprism
 
 import (
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
 
+	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/face"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
 
+// ManualFaceScore is the quality score of faces that were marked manually.
+var ManualFaceScore = 100
+
+// faceThumbSize returns the thumbnail size that is used to find faces.
+func faceThumbSize() thumb.Name {
+	// Select best thumbnail depending on configured size.
+	if Config().ThumbSizePrecached() < 1280 {
+		return thumb.Fit720
+	}
+
+	return thumb.Fit1280
+}
+
 // Faces finds faces in JPEG media files and returns them.
 func (ind *Index) Faces(jpeg *MediaFile, expected int) face.Faces {
 	if jpeg == nil {
 		return face.Faces{}
 	}
 
-	var thumbSize thumb.Name
-
-	// Select best thumbnail depending on configured size.
-	if Config().ThumbSizePrecached() < 1280 {
-		thumbSize = thumb.Fit720
-	} else {
-		thumbSize = thumb.Fit1280
-	}
+	thumbSize := faceThumbSize()
 
 	thumbName, err := jpeg.Thumbnail(Config().ThumbCachePath(), thumbSize)
 
@@ -51,3 +61,61 @@ func (ind *Index) Faces(jpeg *MediaFile, expected int) face.Faces {
 
 	return faces
 }
+
+// FaceMarker creates a face marker for an image area that was selected manually, e.g. because
+// the face was not detected automatically, and computes the embedding needed for matching and clustering.
+func (ind *Index) FaceMarker(file entity.File, area crop.Area) (*entity.Marker, error) {
+	if ind.faceNet == nil {
+		return nil, fmt.Errorf("face recognition is disabled")
+	}
+
+	jpeg, err := NewMediaFile(FileName(file.FileRoot, file.FileName))
+
+	if err != nil {
+		return nil, err
+	} else if !jpeg.IsPreviewImage() {
+		return nil, fmt.Errorf("%s is not a jpeg or png image", clean.Log(jpeg.BaseName()))
+	}
+
+	thumbSize := faceThumbSize()
+
+	thumbName, err := jpeg.Thumbnail(Config().ThumbCachePath(), thumbSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	embeddings, err := ind.faceNet.Embeddings(thumbName, area, true)
+
+	if err != nil {
+		return nil, err
+	} else if !embeddings.One() {
+		return nil, fmt.Errorf("face embedding could not be computed")
+	}
+
+	// Face size in pixels, relative to the thumbnail used for detection.
+	size := thumb.Sizes[thumbSize]
+	scale := 1.0
+
+	if file.FileWidth > 0 && file.FileHeight > 0 {
+		scale = math.Min(1, math.Min(float64(size.Width)/float64(file.FileWidth), float64(size.Height)/float64(file.FileHeight)))
+	}
+
+	pixels := int(float64(area.W) * float64(file.FileWidth) * scale)
+
+	marker := entity.NewMarker(file, area, "", entity.SrcManual, entity.MarkerFace, pixels, ManualFaceScore)
+
+	if marker == nil {
+		return nil, fmt.Errorf("failed to create marker")
+	}
+
+	marker.SetEmbeddings(embeddings)
+
+	if markers := file.Markers(); markers != nil && markers.Contains(*marker) {
+		return nil, fmt.Errorf("face overlaps with an existing marker")
+	} else if err = marker.Create(); err != nil {
+		return nil, err
+	}
+
+	return marker, nil
+}
@@ -0,0 +1,61 @@
+package photoprism
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/karrick/godirwalk"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// indexFiles calls the walk callback for each of the specified files, or for their contents in case of
+// directories, after loading the ignore files found in the parent directories.
+func indexFiles(fileNames []string, originalsPath string, ignore *fs.IgnoreList, callback godirwalk.WalkFunc) error {
+	originalsPath = filepath.Clean(originalsPath)
+
+	for _, fileName := range fileNames {
+		if !filepath.IsAbs(fileName) {
+			fileName = filepath.Join(originalsPath, fileName)
+		}
+
+		fileName = filepath.Clean(fileName)
+
+		if !strings.HasPrefix(fileName, originalsPath+string(filepath.Separator)) {
+			log.Warnf("index: %s is not in originals folder", clean.Log(fileName))
+			continue
+		}
+
+		// Load ignore files in parent directories.
+		for dir := filepath.Dir(fileName); len(dir) > len(originalsPath); dir = filepath.Dir(dir) {
+			_ = ignore.Dir(dir)
+		}
+
+		info, err := godirwalk.NewDirent(fileName)
+
+		if err != nil {
+			log.Debugf("index: %s", err)
+			continue
+		}
+
+		if isDir, _ := info.IsDirOrSymlinkToDir(); isDir {
+			err = godirwalk.Walk(fileName, &godirwalk.Options{
+				ErrorCallback: func(fileName string, err error) godirwalk.ErrorAction {
+					return godirwalk.SkipNode
+				},
+				Callback:            callback,
+				Unsorted:            false,
+				FollowSymbolicLinks: true,
+			})
+		} else if err = callback(fileName, info); err == filepath.SkipDir {
+			err = nil
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
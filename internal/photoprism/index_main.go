@@ -23,10 +23,12 @@ func IndexMain(related *RelatedFiles, ind *Index, o IndexOptions) (result IndexR
 	if limitErr, _ := f.ExceedsBytes(o.ByteLimit); limitErr != nil {
 		result.Err = fmt.Errorf("index: %s", limitErr)
 		result.Status = IndexFailed
+		o.Results.Fail(f.RootRelName(), result.Err)
 		return result
 	} else if limitErr, _ = f.ExceedsResolution(o.ResolutionLimit); limitErr != nil {
 		result.Err = fmt.Errorf("index: %s", limitErr)
 		result.Status = IndexFailed
+		o.Results.Fail(f.RootRelName(), result.Err)
 		return result
 	}
 
@@ -45,10 +47,12 @@ func IndexMain(related *RelatedFiles, ind *Index, o IndexOptions) (result IndexR
 		if jpg, err := ind.convert.ToImage(f, false); err != nil {
 			result.Err = fmt.Errorf("index: failed creating preview for %s (%s)", clean.Log(f.RootRelName()), err.Error())
 			result.Status = IndexFailed
+			o.Results.Fail(f.RootRelName(), result.Err)
 			return result
 		} else if limitErr, _ := jpg.ExceedsResolution(o.ResolutionLimit); limitErr != nil {
 			result.Err = fmt.Errorf("index: %s", limitErr)
 			result.Status = IndexFailed
+			o.Results.Fail(f.RootRelName(), result.Err)
 			return result
 		} else {
 			log.Debugf("index: created %s", clean.Log(jpg.BaseName()))
@@ -56,6 +60,7 @@ func IndexMain(related *RelatedFiles, ind *Index, o IndexOptions) (result IndexR
 			if err := jpg.CreateThumbnails(ind.thumbPath(), false); err != nil {
 				result.Err = fmt.Errorf("index: failed creating thumbnails for %s (%s)", clean.Log(f.RootRelName()), err.Error())
 				result.Status = IndexFailed
+				o.Results.Fail(f.RootRelName(), result.Err)
 				return result
 			}
 
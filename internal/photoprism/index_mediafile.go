@@ -33,6 +33,11 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		return result
 	}
 
+	// Add the result to the structured results, if any.
+	defer func() {
+		o.Results.AddFile(m.RootRelName(), result)
+	}()
+
 	// Skip file?
 	if ind.files.Ignore(m.RootRelName(), m.Root(), m.ModTime(), o.Rescan) {
 		// Skip known file.
@@ -426,6 +431,8 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			// Update basic metadata.
 			photo.SetTitle(metaData.Title, entity.SrcXmp)
 			photo.SetDescription(metaData.Description, entity.SrcXmp)
+			photo.SetRating(metaData.Rating, entity.SrcXmp)
+			photo.SetColorLabel(metaData.ColorLabel, entity.SrcXmp)
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcXmp)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcXmp)
 
@@ -436,6 +443,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			details.SetArtist(metaData.Artist, entity.SrcXmp)
 			details.SetCopyright(metaData.Copyright, entity.SrcXmp)
 			details.SetLicense(metaData.License, entity.SrcXmp)
+			details.SetIptc(metaData, entity.SrcXmp)
 			details.SetSoftware(metaData.Software, entity.SrcXmp)
 		} else {
 			log.Warn(err.Error())
@@ -446,6 +454,8 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			// Update basic metadata.
 			photo.SetTitle(metaData.Title, entity.SrcMeta)
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
+			photo.SetRating(metaData.Rating, entity.SrcMeta)
+			photo.SetColorLabel(metaData.ColorLabel, entity.SrcMeta)
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
 			photo.SetCameraSerial(metaData.CameraSerial)
@@ -457,6 +467,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			details.SetArtist(metaData.Artist, entity.SrcMeta)
 			details.SetCopyright(metaData.Copyright, entity.SrcMeta)
 			details.SetLicense(metaData.License, entity.SrcMeta)
+			details.SetIptc(metaData, entity.SrcMeta)
 			details.SetSoftware(metaData.Software, entity.SrcMeta)
 
 			if metaData.HasDocumentID() && photo.UUID == "" {
@@ -518,6 +529,8 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			// Update basic metadata.
 			photo.SetTitle(metaData.Title, entity.SrcMeta)
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
+			photo.SetRating(metaData.Rating, entity.SrcMeta)
+			photo.SetColorLabel(metaData.ColorLabel, entity.SrcMeta)
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 
 			// Update metadata details.
@@ -527,6 +540,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			details.SetArtist(metaData.Artist, entity.SrcMeta)
 			details.SetCopyright(metaData.Copyright, entity.SrcMeta)
 			details.SetLicense(metaData.License, entity.SrcMeta)
+			details.SetIptc(metaData, entity.SrcMeta)
 			details.SetSoftware(metaData.Software, entity.SrcMeta)
 
 			if metaData.HasDocumentID() && photo.UUID == "" {
@@ -575,6 +589,8 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		if metaData := m.MetaData(); metaData.Error == nil {
 			photo.SetTitle(metaData.Title, entity.SrcMeta)
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
+			photo.SetRating(metaData.Rating, entity.SrcMeta)
+			photo.SetColorLabel(metaData.ColorLabel, entity.SrcMeta)
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
 			photo.SetCameraSerial(metaData.CameraSerial)
@@ -586,6 +602,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			details.SetArtist(metaData.Artist, entity.SrcMeta)
 			details.SetCopyright(metaData.Copyright, entity.SrcMeta)
 			details.SetLicense(metaData.License, entity.SrcMeta)
+			details.SetIptc(metaData, entity.SrcMeta)
 			details.SetSoftware(metaData.Software, entity.SrcMeta)
 
 			if metaData.HasDocumentID() && photo.UUID == "" {
@@ -698,6 +715,8 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			// Update basic metadata.
 			photo.SetTitle(metaData.Title, entity.SrcMeta)
 			photo.SetDescription(metaData.Description, entity.SrcMeta)
+			photo.SetRating(metaData.Rating, entity.SrcMeta)
+			photo.SetColorLabel(metaData.ColorLabel, entity.SrcMeta)
 			photo.SetTakenAt(metaData.TakenAt, metaData.TakenAtLocal, metaData.TimeZone, entity.SrcMeta)
 			photo.SetCoordinates(metaData.Lat, metaData.Lng, metaData.Altitude, entity.SrcMeta)
 			photo.SetCameraSerial(metaData.CameraSerial)
@@ -709,6 +728,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			details.SetArtist(metaData.Artist, entity.SrcMeta)
 			details.SetCopyright(metaData.Copyright, entity.SrcMeta)
 			details.SetLicense(metaData.License, entity.SrcMeta)
+			details.SetIptc(metaData, entity.SrcMeta)
 			details.SetSoftware(metaData.Software, entity.SrcMeta)
 
 			if metaData.HasDocumentID() && photo.UUID == "" {
@@ -738,6 +758,11 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		photo.PlaceID = entity.UnknownPlace.ID
 	}
 
+	// Correct the camera time if a time rule matches.
+	if rule := entity.ApplyTimeRules(&photo); rule != nil {
+		log.Infof("index: corrected time of %s with %s", logName, rule.String())
+	}
+
 	photo.UpdateDateFields()
 
 	// Panorama?
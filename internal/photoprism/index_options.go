@@ -14,6 +14,8 @@ type IndexOptions struct {
 	SkipArchived    bool
 	ByteLimit       int64
 	ResolutionLimit int
+	Results         *Results
+	Files           []string
 }
 
 // NewIndexOptions returns new index options instance.
@@ -63,6 +65,14 @@ func IndexOptionsSingle() IndexOptions {
 	return NewIndexOptions("/", true, true, false, false, false)
 }
 
+// IndexOptionsFiles returns new index options for indexing changed files and folders only.
+func IndexOptionsFiles(fileNames []string, convert bool) IndexOptions {
+	result := NewIndexOptions("/", false, convert, true, false, true)
+	result.Files = fileNames
+
+	return result
+}
+
 // IndexOptionsNone returns new index options with all options set to false.
 func IndexOptionsNone() IndexOptions {
 	return NewIndexOptions("", false, false, false, false, false)
@@ -0,0 +1,129 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// ReclassifyOptions represents options for re-running image classification on indexed photos.
+type ReclassifyOptions struct {
+	Path string
+}
+
+// Reclassify runs image classification on indexed photos again and replaces the labels detected previously,
+// so that changes to the classification model or label rules are applied without re-indexing all files.
+func (ind *Index) Reclassify(opt ReclassifyOptions) (updated int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("index: %s (reclassify panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if ind.conf.DisableClassification() {
+		return 0, errors.New("index: image classification is disabled")
+	}
+
+	if err = mutex.MainWorker.Start(); err != nil {
+		return 0, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	if err = ind.tensorFlow.Init(); err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	limit := 500
+	offset := 0
+
+	for {
+		files, err := query.PrimaryFiles(limit, offset, opt.Path)
+
+		if err != nil {
+			return updated, err
+		}
+
+		if len(files) == 0 {
+			break
+		}
+
+		for _, file := range files {
+			if mutex.MainWorker.Canceled() {
+				return updated, errors.New("index: reclassify canceled")
+			}
+
+			if err = ind.reclassifyFile(file); err != nil {
+				log.Errorf("index: %s in %s (reclassify)", err, clean.Log(file.FileName))
+				continue
+			}
+
+			updated++
+		}
+
+		offset += limit
+	}
+
+	if updated > 0 {
+		// Update precalculated photo and file counts.
+		if err = entity.UpdateCounts(); err != nil {
+			log.Warnf("index: %s (update counts)", err)
+		}
+
+		log.Infof("index: reclassified %s [%s]", english.Plural(updated, "photo", "photos"), time.Since(start))
+	}
+
+	return updated, nil
+}
+
+// reclassifyFile replaces the image labels of the photo the primary file belongs to.
+func (ind *Index) reclassifyFile(file entity.File) error {
+	if !file.FilePrimary || file.PhotoUID == "" {
+		return fmt.Errorf("file %s is not a primary file", clean.Log(file.FileUID))
+	}
+
+	m, err := NewMediaFile(FileName(file.FileRoot, file.FileName))
+
+	if err != nil {
+		return err
+	}
+
+	photo, err := query.PhotoPreloadByUID(file.PhotoUID)
+
+	if err != nil {
+		return err
+	}
+
+	labels := ind.Labels(m)
+
+	if removed, err := photo.RemoveLabels(classify.SrcImage); err != nil {
+		return err
+	} else if removed > 0 {
+		log.Debugf("index: removed %s from %s", english.Plural(removed, "image label", "image labels"), photo.String())
+	}
+
+	photo.AddLabels(labels)
+
+	if err = photo.UpdateTitle(photo.ClassifyLabels()); err != nil {
+		log.Debugf("index: %s in %s (update title)", err, clean.Log(file.FileName))
+	}
+
+	photo.PhotoQuality = photo.QualityScore()
+
+	if err = photo.Save(); err != nil {
+		return err
+	}
+
+	return photo.IndexKeywords()
+}
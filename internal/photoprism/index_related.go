@@ -72,6 +72,7 @@ func IndexRelated(related RelatedFiles, ind *Index, o IndexOptions) (result Inde
 			if jpg, err := ind.convert.ToImage(f, false); err != nil {
 				result.Err = fmt.Errorf("index: failed creating preview for %s (%s)", clean.Log(f.RootRelName()), err.Error())
 				result.Status = IndexFailed
+				o.Results.Fail(f.RootRelName(), result.Err)
 				return result
 			} else {
 				log.Debugf("index: created %s", clean.Log(jpg.BaseName()))
@@ -79,6 +80,7 @@ func IndexRelated(related RelatedFiles, ind *Index, o IndexOptions) (result Inde
 				if err := jpg.CreateThumbnails(ind.thumbPath(), false); err != nil {
 					result.Err = fmt.Errorf("index: failed creating thumbnails for %s (%s)", clean.Log(f.RootRelName()), err.Error())
 					result.Status = IndexFailed
+					o.Results.Fail(f.RootRelName(), result.Err)
 					return result
 				}
 
@@ -0,0 +1,414 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/karrick/godirwalk"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/media"
+)
+
+// JobsPollInterval is the delay before the queue is checked again while jobs are still running.
+var JobsPollInterval = 250 * time.Millisecond
+
+// ErrCanceled is returned if a worker has been canceled, e.g. by the user or a shutdown.
+var ErrCanceled = errors.New("canceled")
+
+// Jobs processes persistent index, convert, and thumbnail jobs from the database queue,
+// so that work can be resumed after a restart.
+type Jobs struct {
+	conf    *config.Config
+	index   *Index
+	convert *Convert
+}
+
+// NewJobs returns a new job queue worker and expects the config, indexer, and converter as argument.
+func NewJobs(conf *config.Config, index *Index, convert *Convert) *Jobs {
+	return &Jobs{conf: conf, index: index, convert: convert}
+}
+
+// Enqueue adds a job for a file or folder in originals, folders are expanded to jobs for the files they contain
+// when the job is processed. If a job of the same type is already pending for the path, it is returned instead.
+func (w *Jobs) Enqueue(jobType, jobPath string, priority int, force bool) (*entity.Job, error) {
+	job := entity.NewJob(jobType, jobPath, priority, force)
+
+	if err := job.Validate(); err != nil {
+		return job, err
+	}
+
+	fileName := filepath.Join(w.conf.OriginalsPath(), job.JobPath)
+
+	if !fs.PathExists(fileName) && !fs.FileExists(fileName) {
+		return job, fmt.Errorf("%s not found", clean.Log("/"+job.JobPath))
+	}
+
+	if err := job.Create(); err != nil {
+		return job, err
+	}
+
+	publishJob("queued", job)
+
+	return job, nil
+}
+
+// Start processes queued jobs by priority with the configured number of workers until the queue is empty
+// or the worker is canceled, and returns the number of processed jobs.
+func (w *Jobs) Start() (processed int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("jobs: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if entity.NextJob() == nil {
+		return 0, nil
+	}
+
+	if err = mutex.MainWorker.Start(); err != nil {
+		return 0, err
+	}
+
+	defer mutex.MainWorker.Stop()
+
+	if err = w.index.tensorFlow.Init(); err != nil {
+		return 0, err
+	}
+
+	if err = w.index.files.Init(); err != nil {
+		log.Errorf("jobs: %s", err)
+	}
+
+	defer w.index.files.Done()
+
+	jobs := make(chan *entity.Job)
+
+	var indexed, running int32
+
+	// Start a fixed number of goroutines to process jobs.
+	var wg sync.WaitGroup
+	var numWorkers = w.conf.Workers()
+	wg.Add(numWorkers)
+	for i := 0; i < numWorkers; i++ {
+		go func() {
+			for job := range jobs {
+				if w.run(job) && job.JobType == entity.JobIndex {
+					atomic.AddInt32(&indexed, 1)
+				}
+
+				atomic.AddInt32(&running, -1)
+			}
+
+			wg.Done()
+		}()
+	}
+
+	for !mutex.MainWorker.Canceled() {
+		job := entity.NextJob()
+
+		// Wait for running jobs if the queue is empty, as folder jobs add jobs for the files they contain.
+		if job == nil {
+			if atomic.LoadInt32(&running) == 0 {
+				break
+			}
+
+			time.Sleep(JobsPollInterval)
+			continue
+		}
+
+		// Mark the job as running, so that it is not returned by the queue again.
+		if err = job.Start(); err != nil {
+			log.Errorf("jobs: %s (start %s)", err, job)
+			break
+		}
+
+		publishJob("started", job)
+
+		atomic.AddInt32(&running, 1)
+		processed++
+
+		jobs <- job
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if indexed > 0 {
+		// Run face recognition if enabled.
+		if faces := NewFaces(w.conf); faces.Disabled() {
+			log.Debugf("jobs: skipping face recognition")
+		} else if err = faces.Start(FacesOptionsDefault()); err != nil {
+			log.Errorf("jobs: %s", err)
+		}
+
+		// Update precalculated photo and file counts.
+		if err = entity.UpdateCounts(); err != nil {
+			log.Warnf("jobs: %s (update counts)", err)
+		}
+	}
+
+	return processed, nil
+}
+
+// run processes a single running job and returns true if it was successful.
+func (w *Jobs) run(job *entity.Job) (success bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("jobs: %s in %s (panic)\nstack: %s", r, job, debug.Stack())
+			finishJob(job, fmt.Errorf("%s", r))
+			success = false
+		}
+	}()
+
+	err := w.process(job)
+
+	if job.Canceled() {
+		log.Infof("jobs: canceled %s", job)
+		job.JobStatus = entity.JobCanceled
+		publishJob("canceled", job)
+		return false
+	}
+
+	return finishJob(job, err)
+}
+
+// process processes a job for a single file, or expands a folder job to jobs for the files it contains.
+func (w *Jobs) process(job *entity.Job) error {
+	fileName := filepath.Join(w.conf.OriginalsPath(), job.JobPath)
+
+	if fs.PathExists(fileName) {
+		return w.expand(job, fileName)
+	} else if !fs.FileExists(fileName) {
+		return fmt.Errorf("%s not found", clean.Log("/"+job.JobPath))
+	}
+
+	switch job.JobType {
+	case entity.JobIndex:
+		opt := NewIndexOptions("/", job.JobForce, w.conf.Settings().Index.Convert && w.conf.SidecarWritable(), true, false, true)
+
+		if res := w.index.FileName(fileName, opt); res.Failed() {
+			return res.Err
+		}
+	case entity.JobConvert:
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			return err
+		}
+
+		return w.convert.File(mf, job.JobForce)
+	case entity.JobThumbs:
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			return err
+		}
+
+		return mf.CreateThumbnails(w.conf.ThumbCachePath(), job.JobForce)
+	default:
+		return fmt.Errorf("unsupported job type %s", clean.Log(job.JobType))
+	}
+
+	return nil
+}
+
+// expand adds jobs for the files in a folder that need to be processed.
+func (w *Jobs) expand(job *entity.Job, dir string) error {
+	originalsPath := w.conf.OriginalsPath()
+	done := make(fs.Done)
+	ignore := fs.NewIgnoreList(fs.IgnoreFile, true, false)
+
+	if err := ignore.Dir(originalsPath); err != nil {
+		log.Debugf("jobs: %s", err)
+	}
+
+	added := 0
+
+	err := godirwalk.Walk(dir, &godirwalk.Options{
+		ErrorCallback: func(fileName string, err error) godirwalk.ErrorAction {
+			return godirwalk.SkipNode
+		},
+		Callback: func(fileName string, info *godirwalk.Dirent) error {
+			if mutex.MainWorker.Canceled() {
+				return ErrCanceled
+			}
+
+			isDir, _ := info.IsDirOrSymlinkToDir()
+			isSymlink := info.IsSymlink()
+
+			if skip, result := fs.SkipWalk(fileName, isDir, isSymlink, done, ignore); skip {
+				return result
+			}
+
+			done[fileName] = fs.Processed
+
+			if !w.pending(job, fileName) {
+				return nil
+			}
+
+			child := entity.NewJob(job.JobType, fs.RelName(fileName, originalsPath), job.JobPriority, job.JobForce)
+
+			if err := child.Create(); err != nil {
+				return err
+			}
+
+			added++
+
+			return nil
+		},
+		Unsorted:            false,
+		FollowSymbolicLinks: true,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("jobs: added %d %s jobs for %s", added, job.JobType, clean.Log("/"+job.JobPath))
+
+	return nil
+}
+
+// pending tests if a file in a folder job needs to be processed.
+func (w *Jobs) pending(job *entity.Job, fileName string) bool {
+	switch job.JobType {
+	case entity.JobIndex:
+		if !media.MainFile(fileName) {
+			return false
+		}
+
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil || mf.Empty() {
+			return false
+		}
+
+		return !w.index.files.Indexed(mf.RootRelName(), mf.Root(), mf.ModTime(), job.JobForce)
+	case entity.JobConvert:
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil || mf.Empty() || mf.IsPreviewImage() || !mf.IsMedia() {
+			return false
+		}
+
+		// Skip images that have already been converted, videos are checked when the job is processed.
+		return job.JobForce || mf.IsAnimated() || !mf.HasPreviewImage()
+	case entity.JobThumbs:
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil || mf.Empty() || !mf.IsPreviewImage() {
+			return false
+		} else if job.JobForce {
+			return true
+		}
+
+		// Skip images that already have thumbnails.
+		_, size := thumb.Find(w.conf.ThumbSizePrecached())
+
+		if size.Name == "" {
+			return true
+		}
+
+		_, err = size.ResolvedName(mf.Hash(), w.conf.ThumbCachePath())
+
+		return err != nil
+	}
+
+	return false
+}
+
+// TrackJob adds a running job for a worker that processes a folder in originals, so that the remaining
+// files are processed by the job queue if the worker is interrupted, e.g. by a restart.
+// Returns nil if the job could not be added, otherwise it must be finished with FinishJob.
+func TrackJob(jobType, jobPath string, force bool) *entity.Job {
+	job := entity.NewJob(jobType, jobPath, entity.JobPriorityDefault, force)
+
+	if err := job.Create(); err != nil {
+		log.Warnf("jobs: %s (track %s)", err, job)
+		return nil
+	} else if err = job.Start(); err != nil {
+		log.Warnf("jobs: %s (start %s)", err, job)
+		return nil
+	}
+
+	publishJob("started", job)
+
+	return job
+}
+
+// FinishJob updates a job added with TrackJob when the worker is done. Jobs that were interrupted
+// are queued again, unless they have been canceled in the meantime.
+func FinishJob(job *entity.Job, err error) {
+	if job == nil {
+		return
+	}
+
+	finishJob(job, err)
+}
+
+// finishJob updates the job status based on the result and returns true if it was successful.
+func finishJob(job *entity.Job, err error) bool {
+	if errors.Is(err, ErrCanceled) || err != nil && mutex.MainWorker.Canceled() {
+		if err = job.Requeue(); err != nil {
+			log.Errorf("jobs: %s (update %s)", err, job)
+		} else if job.Canceled() {
+			log.Infof("jobs: canceled %s", job)
+			job.JobStatus = entity.JobCanceled
+			publishJob("canceled", job)
+		} else {
+			log.Infof("jobs: interrupted %s", job)
+			publishJob("queued", job)
+		}
+
+		return false
+	} else if err != nil {
+		log.Warnf("jobs: %s in %s (attempt %d of %d)", err, job, job.JobAttempts, job.MaxAttempts)
+
+		if err = job.Fail(err); err != nil {
+			log.Errorf("jobs: %s (update %s)", err, job)
+		}
+
+		publishJob("failed", job)
+
+		return false
+	} else if err = job.Done(); err != nil {
+		log.Errorf("jobs: %s (update %s)", err, job)
+	}
+
+	log.Debugf("jobs: completed %s", job)
+	publishJob("completed", job)
+
+	return true
+}
+
+// publishJob publishes a job event and the current number of jobs by status.
+func publishJob(ev string, job *entity.Job) {
+	counts, err := entity.CountJobs()
+
+	if err != nil {
+		log.Warnf("jobs: %s (count)", err)
+	}
+
+	event.Publish("jobs."+ev, event.Data{
+		"id":       job.ID,
+		"type":     job.JobType,
+		"path":     job.JobPath,
+		"status":   job.JobStatus,
+		"attempts": job.JobAttempts,
+		"error":    job.JobError,
+		"counts":   counts,
+	})
+}
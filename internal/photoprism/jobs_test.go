@@ -0,0 +1,106 @@
+package photoprism
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/classify"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/nsfw"
+)
+
+func TestJobs_Enqueue(t *testing.T) {
+	conf := config.TestConfig()
+
+	tf := classify.New(conf.AssetsPath(), conf.DisableTensorFlow())
+	nd := nsfw.New(conf.NSFWModelPath())
+	fn := face.NewNet(conf.FaceNetModelPath(), "", conf.DisableTensorFlow())
+	convert := NewConvert(conf)
+
+	ind := NewIndex(conf, tf, nd, fn, convert, NewFiles(), NewPhotos())
+	w := NewJobs(conf, ind, convert)
+
+	t.Run("InvalidType", func(t *testing.T) {
+		_, err := w.Enqueue("foo", "/", entity.JobPriorityDefault, false)
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := w.Enqueue(entity.JobIndex, "/does-not-exist", entity.JobPriorityDefault, false)
+		assert.Error(t, err)
+	})
+	t.Run("Folder", func(t *testing.T) {
+		job, err := w.Enqueue(entity.JobThumbs, "/", entity.JobPriorityLow, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.UnscopedDb().Delete(job)
+
+		assert.Equal(t, "", job.JobPath)
+		assert.Equal(t, entity.JobQueued, job.JobStatus)
+
+		// Queuing the same job again returns the pending job with a higher priority.
+		again, err := w.Enqueue(entity.JobThumbs, "/", entity.JobPriorityHigh, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, job.ID, again.ID)
+		assert.Equal(t, entity.JobPriorityHigh, again.JobPriority)
+	})
+}
+
+func TestTrackJob(t *testing.T) {
+	t.Run("Done", func(t *testing.T) {
+		job := TrackJob(entity.JobThumbs, "2790/07", false)
+
+		if job == nil {
+			t.Fatal("job must not be nil")
+		}
+
+		defer entity.UnscopedDb().Delete(job)
+
+		assert.Equal(t, entity.JobRunning, job.JobStatus)
+
+		FinishJob(job, nil)
+
+		assert.Equal(t, entity.JobDone, job.JobStatus)
+	})
+	t.Run("Interrupted", func(t *testing.T) {
+		job := TrackJob(entity.JobThumbs, "2790/07", false)
+
+		if job == nil {
+			t.Fatal("job must not be nil")
+		}
+
+		defer entity.UnscopedDb().Delete(job)
+
+		FinishJob(job, ErrCanceled)
+
+		assert.Equal(t, entity.JobQueued, job.JobStatus)
+		assert.Equal(t, 0, job.JobAttempts)
+	})
+	t.Run("Failed", func(t *testing.T) {
+		job := TrackJob(entity.JobThumbs, "2790/07", false)
+
+		if job == nil {
+			t.Fatal("job must not be nil")
+		}
+
+		defer entity.UnscopedDb().Delete(job)
+
+		FinishJob(job, errors.New("disk full"))
+
+		assert.Equal(t, entity.JobQueued, job.JobStatus)
+		assert.Equal(t, "disk full", job.JobError)
+	})
+	t.Run("Nil", func(t *testing.T) {
+		FinishJob(nil, nil)
+	})
+}
@@ -301,17 +301,27 @@ func (m *MediaFile) Checksum() string {
 	return m.checksum
 }
 
-// EditedName returns the corresponding edited image file name as used by Apple (e.g. IMG_E12345.JPG).
-func (m *MediaFile) EditedName() string {
-	basename := filepath.Base(m.fileName)
+// EditedNames returns the file names of edited versions and adjustment sidecars Apple devices create
+// for an original, e.g. "IMG_E1234.JPG", "IMG_E1234.MOV", and "IMG_O1234.AAE" for "IMG_1234.HEIC".
+func (m *MediaFile) EditedNames() (result []string) {
+	prefix := fs.BasePrefix(m.fileName, false)
 
-	if strings.ToUpper(basename[:4]) == "IMG_" && strings.ToUpper(basename[:5]) != "IMG_E" {
-		if filename := filepath.Dir(m.fileName) + string(os.PathSeparator) + basename[:4] + "E" + basename[4:]; fs.FileExists(filename) {
-			return filename
+	if len(prefix) < 5 || strings.ToUpper(prefix[:4]) != "IMG_" || prefix[4] < '0' || prefix[4] > '9' {
+		return result
+	}
+
+	for _, variant := range []string{"E", "O"} {
+		matches, err := filepath.Glob(filepath.Join(filepath.Dir(m.fileName), prefix[:4]+variant+prefix[4:]) + ".*")
+
+		if err != nil {
+			log.Debugf("media: %s", err)
+			continue
 		}
+
+		result = append(result, matches...)
 	}
 
-	return ""
+	return result
 }
 
 // PathNameInfo returns file name infos for indexing.
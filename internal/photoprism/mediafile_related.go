@@ -51,7 +51,11 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 		return result, err
 	}
 
-	if name := m.EditedName(); name != "" {
+	// Add edited versions as created by Apple devices, but keep the original as main file.
+	edited := make(map[string]bool)
+
+	for _, name := range m.EditedNames() {
+		edited[name] = true
 		matches = append(matches, name)
 	}
 
@@ -78,7 +82,9 @@ func (m *MediaFile) RelatedFiles(stripSequence bool) (result RelatedFiles, err e
 		}
 
 		// Set main file.
-		if result.Main == nil && f.IsPreviewImage() {
+		if result.Main != nil && edited[fileName] {
+			// Keep original.
+		} else if result.Main == nil && f.IsPreviewImage() {
 			result.Main = f
 		} else if f.IsRaw() {
 			result.Main = f
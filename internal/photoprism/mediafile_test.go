@@ -417,7 +417,7 @@ func TestMediaFile_CanonicalNameFromFileWithDirectory(t *testing.T) {
 	assert.Equal(t, conf.ExamplesPath()+"/beach_wood", mediaFile.CanonicalNameFromFileWithDirectory())
 }
 
-func TestMediaFile_EditedFilename(t *testing.T) {
+func TestMediaFile_EditedNames(t *testing.T) {
 	conf := config.TestConfig()
 
 	t.Run("IMG_4120.JPG", func(t *testing.T) {
@@ -425,7 +425,15 @@ func TestMediaFile_EditedFilename(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		assert.Equal(t, conf.ExamplesPath()+"/IMG_E4120.JPG", mediaFile.EditedName())
+		assert.Contains(t, mediaFile.EditedNames(), conf.ExamplesPath()+"/IMG_E4120.JPG")
+	})
+
+	t.Run("IMG_E4120.JPG", func(t *testing.T) {
+		mediaFile, err := NewMediaFile(conf.ExamplesPath() + "/IMG_E4120.JPG")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Empty(t, mediaFile.EditedNames())
 	})
 
 	t.Run("fern_green.jpg", func(t *testing.T) {
@@ -433,7 +441,7 @@ func TestMediaFile_EditedFilename(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		assert.Equal(t, "", mediaFile.EditedName())
+		assert.Empty(t, mediaFile.EditedNames())
 	})
 }
 
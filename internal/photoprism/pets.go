@@ -0,0 +1,214 @@
+package photoprism
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/internal/crop"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// PetMatchDist is the max embedding distance of pet markers that are suggested as the same pet.
+// Embeddings are normalized image features, so a distance of 0.6 corresponds to a cosine similarity of 0.82.
+var PetMatchDist = 0.6
+
+// PetCropSize is the size of the image area used to compute pet embeddings.
+var PetCropSize = crop.Sizes[crop.Tile224]
+
+// PetSuggestion represents a pet that may be shown in a marked image area.
+type PetSuggestion struct {
+	SubjUID  string  `json:"UID"`
+	SubjName string  `json:"Name"`
+	Dist     float64 `json:"Dist"`
+}
+
+// PetMarker creates a pet marker for an image area that was selected manually, and computes
+// the image embedding needed to find similar markers.
+func (ind *Index) PetMarker(file entity.File, area crop.Area) (*entity.Marker, error) {
+	if ind.tensorFlow == nil {
+		return nil, fmt.Errorf("image classification is disabled")
+	}
+
+	jpeg, err := NewMediaFile(FileName(file.FileRoot, file.FileName))
+
+	if err != nil {
+		return nil, err
+	} else if !jpeg.IsPreviewImage() {
+		return nil, fmt.Errorf("%s is not a jpeg or png image", clean.Log(jpeg.BaseName()))
+	}
+
+	thumbName, err := jpeg.Thumbnail(Config().ThumbCachePath(), faceThumbSize())
+
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := crop.ImageFromThumb(thumbName, area, PetCropSize, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+
+	if err = imaging.Encode(buf, img, imaging.JPEG); err != nil {
+		return nil, err
+	}
+
+	embedding, err := ind.tensorFlow.Embedding(buf.Bytes())
+
+	if err != nil {
+		return nil, err
+	}
+
+	marker := entity.NewMarker(file, area, "", entity.SrcManual, entity.MarkerPet, PetCropSize.Width, ManualFaceScore)
+
+	if marker == nil {
+		return nil, fmt.Errorf("failed to create marker")
+	}
+
+	marker.SetEmbeddings(face.Embeddings{face.NewEmbedding(embedding)})
+
+	if markers := file.Markers(); markers != nil && markers.Contains(*marker) {
+		return nil, fmt.Errorf("pet overlaps with an existing marker")
+	} else if err = marker.Create(); err != nil {
+		return nil, err
+	}
+
+	return marker, nil
+}
+
+// SuggestPets returns the known pets that are most similar to a marker.
+func SuggestPets(marker entity.Marker, limit int) ([]PetSuggestion, error) {
+	if marker.MarkerType != entity.MarkerPet {
+		return nil, fmt.Errorf("not a pet marker")
+	}
+
+	known, err := query.PetMarkers(true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return RankPets(marker.Embeddings(), known, PetMatchDist, limit), nil
+}
+
+// SimilarPetMarkers returns markers without subject that are similar to the markers of a known pet.
+func SimilarPetMarkers(subjUID string, limit int) (entity.Markers, error) {
+	named, err := query.PetMarkers(true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var known entity.Markers
+
+	for _, m := range named {
+		if m.SubjUID == subjUID {
+			known = append(known, m)
+		}
+	}
+
+	if len(known) == 0 {
+		return entity.Markers{}, nil
+	}
+
+	candidates, err := query.PetMarkers(false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	type match struct {
+		marker entity.Marker
+		dist   float64
+	}
+
+	var matches []match
+
+	for _, c := range candidates {
+		e := c.Embeddings()
+
+		if e.Empty() {
+			continue
+		}
+
+		best := -1.0
+
+		for _, k := range known {
+			if d := k.Embeddings().Dist(e[0]); d >= 0 && (best < 0 || d < best) {
+				best = d
+			}
+		}
+
+		if best >= 0 && best <= PetMatchDist {
+			matches = append(matches, match{marker: c, dist: best})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].dist < matches[j].dist
+	})
+
+	result := make(entity.Markers, 0, len(matches))
+
+	for i, m := range matches {
+		if limit > 0 && i >= limit {
+			break
+		}
+
+		result = append(result, m.marker)
+	}
+
+	return result, nil
+}
+
+// RankPets returns the subjects of known markers sorted by their embedding distance,
+// ignoring those that are farther away than the max distance.
+func RankPets(embeddings face.Embeddings, known entity.Markers, maxDist float64, limit int) []PetSuggestion {
+	result := make([]PetSuggestion, 0, len(known))
+
+	if embeddings.Empty() {
+		return result
+	}
+
+	best := make(map[string]float64)
+
+	for _, m := range known {
+		if m.SubjUID == "" {
+			continue
+		}
+
+		d := m.Embeddings().Dist(embeddings[0])
+
+		if d < 0 || d > maxDist {
+			continue
+		} else if prev, ok := best[m.SubjUID]; !ok || d < prev {
+			best[m.SubjUID] = d
+		}
+	}
+
+	for uid, d := range best {
+		result = append(result, PetSuggestion{SubjUID: uid, SubjName: entity.SubjNames.Get(uid), Dist: d})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Dist == result[j].Dist {
+			return result[i].SubjUID < result[j].SubjUID
+		}
+
+		return result[i].Dist < result[j].Dist
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result
+}
@@ -0,0 +1,46 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/face"
+)
+
+func TestRankPets(t *testing.T) {
+	newMarker := func(subjUID string, e face.Embedding) entity.Marker {
+		m := entity.Marker{MarkerType: entity.MarkerPet, SubjUID: subjUID}
+		m.SetEmbeddings(face.Embeddings{e})
+		return m
+	}
+
+	known := entity.Markers{
+		newMarker("js6sg6b1h1njaaa1", face.Embedding{1, 0, 0}),
+		newMarker("js6sg6b1h1njaaa1", face.Embedding{0.9, 0.1, 0}),
+		newMarker("js6sg6b1h1njaaa2", face.Embedding{0, 1, 0}),
+		newMarker("js6sg6b1h1njaaa3", face.Embedding{0.8, 0.2, 0}),
+		newMarker("", face.Embedding{1, 0, 0}),
+	}
+
+	t.Run("Ranked", func(t *testing.T) {
+		result := RankPets(face.Embeddings{{1, 0, 0}}, known, 0.5, 0)
+
+		assert.Len(t, result, 2)
+		assert.Equal(t, "js6sg6b1h1njaaa1", result[0].SubjUID)
+		assert.Equal(t, 0.0, result[0].Dist)
+		assert.Equal(t, "js6sg6b1h1njaaa3", result[1].SubjUID)
+	})
+	t.Run("Limit", func(t *testing.T) {
+		result := RankPets(face.Embeddings{{1, 0, 0}}, known, 2, 1)
+
+		assert.Len(t, result, 1)
+		assert.Equal(t, "js6sg6b1h1njaaa1", result[0].SubjUID)
+	})
+	t.Run("NoEmbeddings", func(t *testing.T) {
+		result := RankPets(face.Embeddings{}, known, 0.5, 0)
+
+		assert.Empty(t, result)
+	})
+}
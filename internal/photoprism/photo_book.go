@@ -0,0 +1,332 @@
+package photoprism
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/pdf"
+)
+
+// PhotoBookLayout represents a printable page layout.
+type PhotoBookLayout string
+
+// Supported photo book layouts.
+const (
+	PhotoBookContactSheet PhotoBookLayout = "contact"
+	PhotoBookSingle       PhotoBookLayout = "single"
+	PhotoBookGrid         PhotoBookLayout = "grid"
+)
+
+// PhotoBookLayouts maps layout names to layouts.
+var PhotoBookLayouts = map[string]PhotoBookLayout{
+	"contact":       PhotoBookContactSheet,
+	"contact-sheet": PhotoBookContactSheet,
+	"sheet":         PhotoBookContactSheet,
+	"single":        PhotoBookSingle,
+	"page":          PhotoBookSingle,
+	"one-per-page":  PhotoBookSingle,
+	"grid":          PhotoBookGrid,
+	"captioned":     PhotoBookGrid,
+}
+
+// ParsePhotoBookLayout returns the layout matching the name, or the captioned grid if the name is unknown.
+func ParsePhotoBookLayout(name string) (PhotoBookLayout, bool) {
+	if l, ok := PhotoBookLayouts[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return l, true
+	}
+
+	return PhotoBookGrid, false
+}
+
+// PhotoBookMargin is the page margin in points.
+const PhotoBookMargin = 36.0
+
+// PhotoBookOptions represents photo book export options.
+type PhotoBookOptions struct {
+	Title    string
+	Layout   PhotoBookLayout
+	PageSize pdf.PageSize
+	Captions bool
+}
+
+// PhotoBook represents a printable PDF export of photos.
+type PhotoBook struct {
+	conf *config.Config
+	opt  PhotoBookOptions
+}
+
+// NewPhotoBook returns a new photo book exporter and expects the config and options as argument.
+func NewPhotoBook(conf *config.Config, opt PhotoBookOptions) *PhotoBook {
+	if opt.PageSize.Width <= 0 || opt.PageSize.Height <= 0 {
+		opt.PageSize = pdf.A4
+	}
+
+	if opt.Layout == "" {
+		opt.Layout = PhotoBookGrid
+	}
+
+	return &PhotoBook{conf: conf, opt: opt}
+}
+
+// FileName returns a file name for the PDF document.
+func (b *PhotoBook) FileName() string {
+	name := clean.FileName(strings.ReplaceAll(b.opt.Title, " ", "-"))
+
+	if name == "" {
+		name = "photoprism"
+	}
+
+	return fmt.Sprintf("%s-%s.pdf", name, b.opt.Layout)
+}
+
+// Write writes the photos as PDF document to w and returns the number of pages.
+func (b *PhotoBook) Write(w io.Writer, photos search.PhotoResults) (pages int, err error) {
+	doc := pdf.New(w, b.opt.PageSize, b.opt.Title)
+
+	switch b.opt.Layout {
+	case PhotoBookContactSheet:
+		b.grid(doc, photos, b.gridCols(100), 0, thumb.Fit720, 6, 1)
+	case PhotoBookSingle:
+		b.single(doc, photos)
+	default:
+		b.grid(doc, photos, b.gridCols(240), 0, thumb.Fit1280, 8, 3)
+	}
+
+	if err = doc.Close(); err != nil {
+		return 0, err
+	}
+
+	return doc.Pages(), nil
+}
+
+// gridCols returns the number of grid columns so that cells have at least the specified width.
+func (b *PhotoBook) gridCols(minWidth float64) int {
+	cols := int((b.opt.PageSize.Width - 2*PhotoBookMargin) / minWidth)
+
+	if cols < 1 {
+		return 1
+	}
+
+	return cols
+}
+
+// header adds a new page with the document title and page number, and returns the content area.
+func (b *PhotoBook) header(doc *pdf.Document) (p *pdf.Page, x, y, w, h float64) {
+	p = doc.AddPage()
+
+	x, y = PhotoBookMargin, PhotoBookMargin
+	w, h = p.Width()-2*PhotoBookMargin, p.Height()-2*PhotoBookMargin
+
+	if b.opt.Title != "" && b.opt.Layout != PhotoBookSingle {
+		p.Text(x, y+12, 12, true, pdf.Truncate(b.opt.Title, 12, true, w))
+		y += 24
+		h -= 24
+	}
+
+	p.TextCenter(0, p.Height()-PhotoBookMargin/2, p.Width(), 8, false, fmt.Sprintf("%d", doc.Pages()))
+
+	return p, x, y, w, h
+}
+
+// grid adds pages with photos arranged in a grid, each with the specified number of caption lines.
+func (b *PhotoBook) grid(doc *pdf.Document, photos search.PhotoResults, cols, rows int, size thumb.Name, fontSize float64, lines int) {
+	if len(photos) == 0 {
+		return
+	}
+
+	const gap = 12.0
+
+	var p *pdf.Page
+	var x, y, w, h, cellW, cellH, captionH float64
+
+	if b.opt.Captions {
+		captionH = float64(lines)*fontSize*1.25 + 4
+	}
+
+	for i, photo := range photos {
+		n := i % (cols * maxInt(rows, 1))
+
+		if p == nil || n == 0 {
+			p, x, y, w, h = b.header(doc)
+
+			cellW = (w - float64(cols-1)*gap) / float64(cols)
+
+			// Use square cells if the number of rows is not fixed.
+			if rows == 0 {
+				rows = maxInt(int((h+gap)/(cellW+captionH+gap)), 1)
+			}
+
+			cellH = (h-float64(rows-1)*gap)/float64(rows) - captionH
+		}
+
+		cx := x + float64(n%cols)*(cellW+gap)
+		cy := y + float64(n/cols)*(cellH+captionH+gap)
+
+		if img := b.image(doc, photo, size); img != nil {
+			p.ImageFit(img, cx, cy, cellW, cellH)
+		} else {
+			p.Rect(cx, cy, cellW, cellH, 0.8)
+		}
+
+		if !b.opt.Captions {
+			continue
+		}
+
+		ty := cy + cellH + fontSize + 2
+
+		for j, line := range b.caption(photo, lines, fontSize, cellW) {
+			p.TextCenter(cx, ty+float64(j)*fontSize*1.25, cellW, fontSize, j == 0 && lines > 1, line)
+		}
+	}
+}
+
+// single adds one page per photo with the caption below.
+func (b *PhotoBook) single(doc *pdf.Document, photos search.PhotoResults) {
+	for _, photo := range photos {
+		p, x, y, w, h := b.header(doc)
+
+		var captionH float64
+
+		if b.opt.Captions {
+			captionH = 72
+		}
+
+		if img := b.image(doc, photo, thumb.Fit1920); img != nil {
+			p.ImageFit(img, x, y, w, h-captionH)
+		} else {
+			p.Rect(x, y, w, h-captionH, 0.8)
+		}
+
+		if !b.opt.Captions {
+			continue
+		}
+
+		ty := y + h - captionH + 22
+
+		if title := strings.TrimSpace(photo.PhotoTitle); title != "" {
+			p.TextCenter(x, ty, w, 14, true, pdf.Truncate(title, 14, true, w))
+			ty += 16
+		}
+
+		for _, line := range pdf.Wrap(photo.PhotoDescription, 10, false, w, 2) {
+			p.TextCenter(x, ty, w, 10, false, line)
+			ty += 12
+		}
+
+		p.TextCenter(x, ty+2, w, 9, false, pdf.Truncate(photoBookDatePlace(photo), 9, false, w))
+	}
+}
+
+// caption returns up to the specified number of caption lines for a photo.
+func (b *PhotoBook) caption(photo search.Photo, lines int, fontSize, width float64) (result []string) {
+	datePlace := photoBookDatePlace(photo)
+
+	if lines < 2 {
+		if s := strings.TrimSpace(photo.PhotoTitle); s != "" {
+			return []string{pdf.Truncate(s, fontSize, false, width)}
+		}
+
+		return []string{pdf.Truncate(datePlace, fontSize, false, width)}
+	}
+
+	result = append(result, pdf.Truncate(photo.PhotoTitle, fontSize, true, width))
+
+	if lines > 2 && photo.PhotoDescription != "" {
+		result = append(result, pdf.Truncate(photo.PhotoDescription, fontSize, false, width))
+	}
+
+	return append(result, pdf.Truncate(datePlace, fontSize, false, width))
+}
+
+// photoBookDatePlace returns the date and place a photo was taken as caption text.
+func photoBookDatePlace(photo search.Photo) string {
+	var parts []string
+
+	if !photo.TakenAtLocal.IsZero() && photo.TakenSrc != entity.SrcAuto {
+		parts = append(parts, photo.TakenAtLocal.Format("2 January 2006"))
+	} else if photo.PhotoYear > 0 {
+		parts = append(parts, fmt.Sprintf("%d", photo.PhotoYear))
+	}
+
+	if photo.PlaceID != "" && photo.PlaceID != entity.UnknownID && photo.PlaceLabel != "" {
+		parts = append(parts, photo.PlaceLabel)
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// image adds the thumbnail of a photo to the document, or returns nil if it is not available.
+func (b *PhotoBook) image(doc *pdf.Document, photo search.Photo, name thumb.Name) *pdf.Image {
+	thumbName, err := b.thumb(photo, name)
+
+	if err != nil {
+		log.Warnf("pdf: %s", err)
+		return nil
+	}
+
+	data, err := os.ReadFile(thumbName)
+
+	if err != nil {
+		log.Warnf("pdf: %s", err)
+		return nil
+	}
+
+	img, err := doc.Image(data)
+
+	if err != nil {
+		log.Warnf("pdf: %s in %s", err, clean.Log(photo.FileName))
+		return nil
+	}
+
+	return img
+}
+
+// thumb returns the thumbnail file name of a photo with the specified size, taking file edits into account.
+func (b *PhotoBook) thumb(photo search.Photo, name thumb.Name) (string, error) {
+	if photo.FileHash == "" || photo.FileName == "" {
+		return "", fmt.Errorf("no file for %s", clean.Log(photo.PhotoUID))
+	}
+
+	size, ok := thumb.Sizes[name]
+
+	if !ok {
+		return "", fmt.Errorf("invalid thumb size %s", clean.Log(name.String()))
+	} else if size.Uncached() && !b.conf.ThumbUncached() {
+		if _, size = thumb.Find(b.conf.ThumbSizePrecached()); size.Width == 0 {
+			return "", fmt.Errorf("invalid thumb size %d", b.conf.ThumbSizePrecached())
+		}
+	}
+
+	fileName, err := fs.Resolve(FileName(photo.FileRoot, photo.FileName))
+
+	if err != nil {
+		return "", fmt.Errorf("file %s is missing", clean.Log(photo.FileName))
+	}
+
+	thumbPath := b.conf.ThumbCachePath()
+
+	if edit := entity.FindFileEditByHash(photo.FileHash); edit != nil {
+		return size.FromEdit(fileName, photo.FileHash, thumbPath, photo.FileOrientation, edit.Edit())
+	} else if b.conf.ThumbUncached() || size.Uncached() {
+		return size.FromFile(fileName, photo.FileHash, thumbPath, photo.FileOrientation)
+	}
+
+	return size.FromCache(fileName, photo.FileHash, thumbPath)
+}
+
+// maxInt returns the larger of two integers.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,67 @@
+package photoprism
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/search"
+	"github.com/photoprism/photoprism/pkg/pdf"
+)
+
+func TestParsePhotoBookLayout(t *testing.T) {
+	l, ok := ParsePhotoBookLayout("Contact-Sheet")
+	assert.True(t, ok)
+	assert.Equal(t, PhotoBookContactSheet, l)
+
+	l, ok = ParsePhotoBookLayout("foo")
+	assert.False(t, ok)
+	assert.Equal(t, PhotoBookGrid, l)
+}
+
+func TestPhotoBook_FileName(t *testing.T) {
+	conf := config.TestConfig()
+
+	assert.Equal(t, "photoprism-grid.pdf", NewPhotoBook(conf, PhotoBookOptions{}).FileName())
+	assert.Equal(t, "Family-2023-single.pdf", NewPhotoBook(conf, PhotoBookOptions{Title: "Family 2023", Layout: PhotoBookSingle}).FileName())
+}
+
+func TestPhotoBook_Write(t *testing.T) {
+	conf := config.TestConfig()
+
+	photos := search.PhotoResults{
+		{PhotoUID: "ps6sg6be2lvl0y12", PhotoTitle: "Missing File"},
+		{PhotoUID: "ps6sg6be2lvl0y13", PhotoTitle: "Another Missing File"},
+	}
+
+	t.Run("Grid", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		pages, err := NewPhotoBook(conf, PhotoBookOptions{Title: "Grid", Captions: true}).Write(buf, photos)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, pages)
+		assert.Contains(t, buf.String(), "(Missing File) Tj")
+	})
+	t.Run("Single", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		pages, err := NewPhotoBook(conf, PhotoBookOptions{Layout: PhotoBookSingle, PageSize: pdf.A5}).Write(buf, photos)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, pages)
+		assert.NotContains(t, buf.String(), "(Missing File) Tj")
+	})
+}
+
+func TestPhotoBookDatePlace(t *testing.T) {
+	assert.Equal(t, "", photoBookDatePlace(search.Photo{}))
+	assert.Equal(t, "2019", photoBookDatePlace(search.Photo{PhotoYear: 2019, PlaceID: "zz", PlaceLabel: "Unknown"}))
+	assert.Equal(t, "24 December 2020 · Berlin, Germany", photoBookDatePlace(search.Photo{
+		TakenAtLocal: time.Date(2020, 12, 24, 18, 0, 0, 0, time.UTC),
+		TakenSrc:     "meta",
+		PlaceID:      "de:abc",
+		PlaceLabel:   "Berlin, Germany",
+	}))
+}
@@ -0,0 +1,511 @@
+package photoprism
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/query"
+	"github.com/photoprism/photoprism/internal/search"
+)
+
+// PhotoExportFormat represents a metadata export file format.
+type PhotoExportFormat string
+
+// Supported metadata export formats.
+const (
+	PhotoExportCSV   PhotoExportFormat = "csv"   // Comma-separated values.
+	PhotoExportTSV   PhotoExportFormat = "tsv"   // Tab-separated values.
+	PhotoExportExcel PhotoExportFormat = "excel" // Comma-separated values with byte order mark and CRLF line endings.
+	PhotoExportJSONL PhotoExportFormat = "jsonl" // JSON Lines, one object per photo.
+)
+
+// PhotoExportFormats maps format names to export formats.
+var PhotoExportFormats = map[string]PhotoExportFormat{
+	"csv":    PhotoExportCSV,
+	"tsv":    PhotoExportTSV,
+	"tab":    PhotoExportTSV,
+	"excel":  PhotoExportExcel,
+	"xls":    PhotoExportExcel,
+	"jsonl":  PhotoExportJSONL,
+	"ndjson": PhotoExportJSONL,
+	"json":   PhotoExportJSONL,
+}
+
+// ParsePhotoExportFormat returns the export format matching the name, or CSV if the name is unknown.
+func ParsePhotoExportFormat(name string) (PhotoExportFormat, bool) {
+	if f, ok := PhotoExportFormats[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return f, true
+	}
+
+	return PhotoExportCSV, false
+}
+
+// PhotoExportColumns contains the names of the supported export columns in their default order.
+var PhotoExportColumns = []string{
+	"uid", "type", "title", "description",
+	"taken_at", "taken_at_local", "time_zone", "taken_src",
+	"file_name", "file_hash", "file_size", "file_type", "file_mime", "width", "height", "duration", "files",
+	"lat", "lng", "altitude", "place", "city", "state", "country",
+	"camera_make", "camera_model", "camera_serial", "lens_make", "lens_model",
+	"iso", "exposure", "f_number", "focal_length",
+	"favorite", "private", "archived", "rating", "color_label", "quality",
+	"labels", "keywords", "people", "albums",
+	"subject", "artist", "copyright", "license", "notes",
+	"created_at", "updated_at", "edited_at",
+}
+
+// ParsePhotoExportColumns returns the column names in a comma-separated list, or all columns if the list is empty.
+func ParsePhotoExportColumns(s string) (result []string, err error) {
+	if strings.TrimSpace(s) == "" {
+		return PhotoExportColumns, nil
+	}
+
+	known := make(map[string]bool, len(PhotoExportColumns))
+
+	for _, col := range PhotoExportColumns {
+		known[col] = true
+	}
+
+	for _, col := range strings.Split(s, ",") {
+		col = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(col)), "-", "_")
+
+		if col == "" {
+			continue
+		} else if !known[col] {
+			return result, fmt.Errorf("unknown column %s", strconv.Quote(col))
+		}
+
+		result = append(result, col)
+	}
+
+	return result, nil
+}
+
+// PhotoExportBatchSize is the default number of photos fetched from the database at once.
+const PhotoExportBatchSize = 500
+
+// PhotoExportOptions represents metadata export options.
+type PhotoExportOptions struct {
+	Format    PhotoExportFormat
+	Columns   []string
+	BatchSize int
+}
+
+// PhotoExport streams the metadata of photos matching a search as CSV, TSV, or JSON Lines.
+type PhotoExport struct {
+	conf *config.Config
+	opt  PhotoExportOptions
+}
+
+// NewPhotoExport returns a new metadata exporter and expects the config and options as argument.
+func NewPhotoExport(conf *config.Config, opt PhotoExportOptions) *PhotoExport {
+	if opt.Format == "" {
+		opt.Format = PhotoExportCSV
+	}
+
+	if len(opt.Columns) == 0 {
+		opt.Columns = PhotoExportColumns
+	}
+
+	if opt.BatchSize <= 0 || opt.BatchSize > search.MaxResults {
+		opt.BatchSize = PhotoExportBatchSize
+	}
+
+	return &PhotoExport{conf: conf, opt: opt}
+}
+
+// FileName returns a file name for the export, based on the current time.
+func (e *PhotoExport) FileName() string {
+	ext := "csv"
+
+	switch e.opt.Format {
+	case PhotoExportTSV:
+		ext = "tsv"
+	case PhotoExportJSONL:
+		ext = "jsonl"
+	}
+
+	return fmt.Sprintf("photoprism-metadata-%s.%s", time.Now().UTC().Format("20060102-150405"), ext)
+}
+
+// ContentType returns the MIME type of the export format.
+func (e *PhotoExport) ContentType() string {
+	switch e.opt.Format {
+	case PhotoExportTSV:
+		return "text/tab-separated-values; charset=utf-8"
+	case PhotoExportJSONL:
+		return "application/x-ndjson"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Write writes the metadata of photos matching the search form to w and returns the number of photos.
+// The search is restricted to what the user can see if a session is passed.
+func (e *PhotoExport) Write(w io.Writer, frm form.SearchPhotos, sess *entity.Session) (count int, err error) {
+	buf := bufio.NewWriter(w)
+	out := e.newWriter(buf)
+
+	if err = out.Header(e.opt.Columns); err != nil {
+		return 0, err
+	}
+
+	limit := frm.Count
+
+	// Export one row per photo.
+	frm.Primary = true
+	frm.Merged = false
+
+	for offset := frm.Offset; ; offset += e.opt.BatchSize {
+		n := e.opt.BatchSize
+
+		if limit > 0 && limit-count < n {
+			n = limit - count
+		}
+
+		if n <= 0 {
+			break
+		}
+
+		frm.Count = n
+		frm.Offset = offset
+
+		var photos search.PhotoResults
+		var related photoExportRelated
+
+		if sess != nil {
+			photos, _, err = search.UserPhotos(frm, sess)
+		} else {
+			photos, _, err = search.Photos(frm)
+		}
+
+		if err != nil {
+			return count, err
+		} else if len(photos) == 0 {
+			break
+		}
+
+		if related, err = e.related(photos); err != nil {
+			return count, err
+		}
+
+		for i := range photos {
+			if err = out.Row(e.opt.Columns, e.values(&photos[i], related)); err != nil {
+				return count, err
+			}
+		}
+
+		count += len(photos)
+
+		if err = buf.Flush(); err != nil {
+			return count, err
+		} else if len(photos) < n {
+			break
+		}
+	}
+
+	return count, buf.Flush()
+}
+
+// photoExportRelated contains related metadata of exported photos by photo id.
+type photoExportRelated struct {
+	labels  map[uint][]string
+	people  map[uint][]string
+	albums  map[uint][]string
+	files   map[uint][]string
+	details map[uint]entity.Details
+}
+
+// related fetches labels, people, albums, files, and details of the photos, if needed.
+func (e *PhotoExport) related(photos search.PhotoResults) (r photoExportRelated, err error) {
+	ids := make([]uint, len(photos))
+
+	for i := range photos {
+		ids[i] = photos[i].ID
+	}
+
+	for _, col := range e.opt.Columns {
+		switch col {
+		case "labels":
+			if r.labels == nil {
+				r.labels, err = query.PhotoLabelNames(ids)
+			}
+		case "people":
+			if r.people == nil {
+				r.people, err = query.PhotoSubjectNames(ids)
+			}
+		case "albums":
+			if r.albums == nil {
+				r.albums, err = query.PhotoAlbumTitles(ids)
+			}
+		case "files":
+			if r.files == nil {
+				r.files, err = query.PhotoFileNames(ids)
+			}
+		case "keywords", "subject", "artist", "copyright", "license", "notes":
+			if r.details == nil {
+				r.details, err = query.PhotoDetails(ids)
+			}
+		}
+
+		if err != nil {
+			return r, err
+		}
+	}
+
+	return r, nil
+}
+
+// values returns the column values of a photo.
+func (e *PhotoExport) values(p *search.Photo, r photoExportRelated) map[string]interface{} {
+	result := make(map[string]interface{}, len(e.opt.Columns))
+	details := r.details[p.ID]
+
+	for _, col := range e.opt.Columns {
+		var v interface{}
+
+		switch col {
+		case "uid":
+			v = p.PhotoUID
+		case "type":
+			v = p.PhotoType
+		case "title":
+			v = p.PhotoTitle
+		case "description":
+			v = p.PhotoDescription
+		case "taken_at":
+			v = p.TakenAt
+		case "taken_at_local":
+			v = p.TakenAtLocal.Format("2006-01-02T15:04:05")
+		case "time_zone":
+			v = p.TimeZone
+		case "taken_src":
+			v = p.TakenSrc
+		case "file_name":
+			v = p.FileName
+		case "file_hash":
+			v = p.FileHash
+		case "file_size":
+			v = p.FileSize
+		case "file_type":
+			v = p.FileType
+		case "file_mime":
+			v = p.FileMime
+		case "width":
+			v = p.FileWidth
+		case "height":
+			v = p.FileHeight
+		case "duration":
+			v = p.PhotoDuration.Seconds()
+		case "files":
+			v = stringList(r.files[p.ID])
+		case "lat":
+			v = p.PhotoLat
+		case "lng":
+			v = p.PhotoLng
+		case "altitude":
+			v = p.PhotoAltitude
+		case "place":
+			v = p.PlaceLabel
+		case "city":
+			v = p.PlaceCity
+		case "state":
+			v = p.PlaceState
+		case "country":
+			v = p.PhotoCountry
+		case "camera_make":
+			v = p.CameraMake
+		case "camera_model":
+			v = p.CameraModel
+		case "camera_serial":
+			v = p.CameraSerial
+		case "lens_make":
+			v = p.LensMake
+		case "lens_model":
+			v = p.LensModel
+		case "iso":
+			v = p.PhotoIso
+		case "exposure":
+			v = p.PhotoExposure
+		case "f_number":
+			v = p.PhotoFNumber
+		case "focal_length":
+			v = p.PhotoFocalLength
+		case "favorite":
+			v = p.PhotoFavorite
+		case "private":
+			v = p.PhotoPrivate
+		case "archived":
+			v = !p.DeletedAt.IsZero()
+		case "rating":
+			v = p.PhotoRating
+		case "color_label":
+			v = p.PhotoColorLabel
+		case "quality":
+			v = p.PhotoQuality
+		case "labels":
+			v = stringList(r.labels[p.ID])
+		case "keywords":
+			v = stringList(splitKeywords(details.Keywords))
+		case "people":
+			v = stringList(r.people[p.ID])
+		case "albums":
+			v = stringList(r.albums[p.ID])
+		case "subject":
+			v = details.Subject
+		case "artist":
+			v = details.Artist
+		case "copyright":
+			v = details.Copyright
+		case "license":
+			v = details.License
+		case "notes":
+			v = details.Notes
+		case "created_at":
+			v = p.CreatedAt
+		case "updated_at":
+			v = p.UpdatedAt
+		case "edited_at":
+			if !p.EditedAt.IsZero() {
+				v = p.EditedAt
+			}
+		}
+
+		result[col] = v
+	}
+
+	return result
+}
+
+// stringList returns an empty list instead of nil, so that it is exported as JSON array.
+func stringList(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+
+	return s
+}
+
+// splitKeywords returns the keywords in a comma-separated list.
+func splitKeywords(s string) (result []string) {
+	for _, w := range strings.Split(s, ",") {
+		if w = strings.TrimSpace(w); w != "" {
+			result = append(result, w)
+		}
+	}
+
+	return result
+}
+
+// photoExportWriter writes export rows in a specific format.
+type photoExportWriter interface {
+	Header(cols []string) error
+	Row(cols []string, values map[string]interface{}) error
+}
+
+// newWriter returns a writer for the export format.
+func (e *PhotoExport) newWriter(w io.Writer) photoExportWriter {
+	switch e.opt.Format {
+	case PhotoExportJSONL:
+		return &photoExportJSONL{enc: json.NewEncoder(w)}
+	case PhotoExportTSV:
+		c := csv.NewWriter(w)
+		c.Comma = '\t'
+		return &photoExportCSV{w: w, csv: c}
+	case PhotoExportExcel:
+		c := csv.NewWriter(w)
+		c.UseCRLF = true
+		return &photoExportCSV{w: w, csv: c, bom: true}
+	default:
+		return &photoExportCSV{w: w, csv: csv.NewWriter(w)}
+	}
+}
+
+// photoExportCSV writes rows as comma or tab separated values.
+type photoExportCSV struct {
+	w   io.Writer
+	csv *csv.Writer
+	bom bool
+}
+
+// Header writes the column names, preceded by a byte order mark if needed.
+func (c *photoExportCSV) Header(cols []string) error {
+	if c.bom {
+		if _, err := c.w.Write([]byte("\ufeff")); err != nil {
+			return err
+		}
+	}
+
+	if err := c.csv.Write(cols); err != nil {
+		return err
+	}
+
+	c.csv.Flush()
+
+	return c.csv.Error()
+}
+
+// Row writes the column values as strings.
+func (c *photoExportCSV) Row(cols []string, values map[string]interface{}) error {
+	row := make([]string, len(cols))
+
+	for i, col := range cols {
+		row[i] = photoExportString(values[col])
+	}
+
+	if err := c.csv.Write(row); err != nil {
+		return err
+	}
+
+	c.csv.Flush()
+
+	return c.csv.Error()
+}
+
+// photoExportString formats a column value as string.
+func photoExportString(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case []string:
+		return strings.Join(t, "; ")
+	case time.Time:
+		if t.IsZero() {
+			return ""
+		}
+
+		return t.UTC().Format(time.RFC3339)
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
+
+// photoExportJSONL writes rows as JSON objects, one per line.
+type photoExportJSONL struct {
+	enc *json.Encoder
+}
+
+// Header does nothing, as JSON objects contain the column names.
+func (j *photoExportJSONL) Header(cols []string) error {
+	return nil
+}
+
+// Row writes the column values as JSON object.
+func (j *photoExportJSONL) Row(cols []string, values map[string]interface{}) error {
+	return j.enc.Encode(values)
+}
@@ -0,0 +1,86 @@
+package photoprism
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePhotoExportFormat(t *testing.T) {
+	t.Run("Csv", func(t *testing.T) {
+		f, ok := ParsePhotoExportFormat("CSV")
+		assert.True(t, ok)
+		assert.Equal(t, PhotoExportCSV, f)
+	})
+	t.Run("Json", func(t *testing.T) {
+		f, ok := ParsePhotoExportFormat(" json ")
+		assert.True(t, ok)
+		assert.Equal(t, PhotoExportJSONL, f)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		f, ok := ParsePhotoExportFormat("pdf")
+		assert.False(t, ok)
+		assert.Equal(t, PhotoExportCSV, f)
+	})
+}
+
+func TestParsePhotoExportColumns(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		cols, err := ParsePhotoExportColumns("")
+		assert.NoError(t, err)
+		assert.Equal(t, PhotoExportColumns, cols)
+	})
+	t.Run("Selected", func(t *testing.T) {
+		cols, err := ParsePhotoExportColumns("UID, file-hash,,labels")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"uid", "file_hash", "labels"}, cols)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := ParsePhotoExportColumns("uid,foo")
+		assert.EqualError(t, err, "unknown column \"foo\"")
+	})
+}
+
+func TestPhotoExport_FileName(t *testing.T) {
+	e := NewPhotoExport(nil, PhotoExportOptions{Format: PhotoExportJSONL})
+	assert.Regexp(t, `^photoprism-metadata-\d{8}-\d{6}\.jsonl$`, e.FileName())
+}
+
+func TestPhotoExport_newWriter(t *testing.T) {
+	cols := []string{"uid", "labels", "lat"}
+	values := map[string]interface{}{"uid": "pt9jtdre2lvl0y11", "labels": []string{"Cat", "Dog"}, "lat": 48.519234}
+
+	t.Run("Excel", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := NewPhotoExport(nil, PhotoExportOptions{Format: PhotoExportExcel}).newWriter(&buf)
+		assert.NoError(t, w.Header(cols))
+		assert.NoError(t, w.Row(cols, values))
+		assert.Equal(t, "\ufeffuid,labels,lat\r\npt9jtdre2lvl0y11,Cat; Dog,48.519234\r\n", buf.String())
+	})
+	t.Run("TSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := NewPhotoExport(nil, PhotoExportOptions{Format: PhotoExportTSV}).newWriter(&buf)
+		assert.NoError(t, w.Header(cols))
+		assert.NoError(t, w.Row(cols, values))
+		assert.Equal(t, "uid\tlabels\tlat\npt9jtdre2lvl0y11\tCat; Dog\t48.519234\n", buf.String())
+	})
+	t.Run("JSONL", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := NewPhotoExport(nil, PhotoExportOptions{Format: PhotoExportJSONL}).newWriter(&buf)
+		assert.NoError(t, w.Header(cols))
+		assert.NoError(t, w.Row(cols, values))
+		assert.Equal(t, "{\"labels\":[\"Cat\",\"Dog\"],\"lat\":48.519234,\"uid\":\"pt9jtdre2lvl0y11\"}\n", buf.String())
+	})
+}
+
+func TestPhotoExportString(t *testing.T) {
+	assert.Equal(t, "", photoExportString(nil))
+	assert.Equal(t, "a; b", photoExportString([]string{"a", "b"}))
+	assert.Equal(t, "", photoExportString(time.Time{}))
+	assert.Equal(t, "2021-02-03T04:05:06Z", photoExportString(time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)))
+	assert.Equal(t, "2.8", photoExportString(float32(2.8)))
+	assert.Equal(t, "100", photoExportString(100))
+	assert.Equal(t, "true", photoExportString(true))
+}
@@ -0,0 +1,173 @@
+package photoprism
+
+import (
+	"sync"
+	"time"
+)
+
+// FileResult represents the result of processing a single file.
+type FileResult struct {
+	FileName string `json:"FileName"`
+	Status   string `json:"Status"`
+	FileUID  string `json:"FileUID,omitempty"`
+	PhotoUID string `json:"PhotoUID,omitempty"`
+	Error    string `json:"Error,omitempty"`
+}
+
+// Results represents the structured results of an index, import, faces, purge, or cleanup
+// operation, so that counts, errors, and skipped files can be reported in a machine-readable format.
+// All methods may safely be called on a nil pointer, in which case they do nothing.
+type Results struct {
+	mutex      sync.Mutex
+	Action     string           `json:"Action"`
+	Path       string           `json:"Path,omitempty"`
+	Counts     map[string]int   `json:"Counts"`
+	Skipped    []FileResult     `json:"Skipped"`
+	Errors     []FileResult     `json:"Errors"`
+	StartedAt  time.Time        `json:"StartedAt"`
+	FinishedAt time.Time        `json:"FinishedAt"`
+	Duration   float64          `json:"Duration"`
+	OnFile     func(FileResult) `json:"-"`
+}
+
+// NewResults returns new results for the specified action and path.
+func NewResults(action, path string) *Results {
+	return &Results{
+		Action:    action,
+		Path:      path,
+		Counts:    make(map[string]int),
+		Skipped:   []FileResult{},
+		Errors:    []FileResult{},
+		StartedAt: time.Now().UTC(),
+	}
+}
+
+// Add adds n to the named counter.
+func (r *Results) Add(name string, n int) {
+	if r == nil || name == "" {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.Counts[name] += n
+}
+
+// Count returns the value of the named counter.
+func (r *Results) Count(name string) int {
+	if r == nil {
+		return 0
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.Counts[name]
+}
+
+// AddFile adds the indexing result of a file.
+func (r *Results) AddFile(fileName string, res IndexResult) {
+	if r == nil {
+		return
+	}
+
+	f := FileResult{
+		FileName: fileName,
+		Status:   string(res.Status),
+		FileUID:  res.FileUID,
+		PhotoUID: res.PhotoUID,
+	}
+
+	if res.Err != nil {
+		f.Error = res.Err.Error()
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	switch res.Status {
+	case IndexAdded:
+		r.Counts["Added"]++
+	case IndexUpdated:
+		r.Counts["Updated"]++
+	case IndexStacked:
+		r.Counts["Stacked"]++
+	case IndexFailed:
+		r.Counts["Failed"]++
+		r.Errors = append(r.Errors, f)
+	case IndexSkipped, IndexDuplicate, IndexArchived:
+		r.Counts["Skipped"]++
+		r.Skipped = append(r.Skipped, f)
+	}
+
+	r.publish(f)
+}
+
+// Skip adds a file that was skipped for the specified reason.
+func (r *Results) Skip(fileName, reason string) {
+	if r == nil {
+		return
+	}
+
+	f := FileResult{FileName: fileName, Status: string(IndexSkipped), Error: reason}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.Counts["Skipped"]++
+	r.Skipped = append(r.Skipped, f)
+
+	r.publish(f)
+}
+
+// Fail adds an error, optionally for a specific file.
+func (r *Results) Fail(fileName string, err error) {
+	if r == nil || err == nil {
+		return
+	}
+
+	f := FileResult{FileName: fileName, Status: string(IndexFailed), Error: err.Error()}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.Counts["Failed"]++
+	r.Errors = append(r.Errors, f)
+
+	r.publish(f)
+}
+
+// Failed tests if errors have been reported.
+func (r *Results) Failed() bool {
+	if r == nil {
+		return false
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return len(r.Errors) > 0
+}
+
+// Finish sets the finish time and duration in seconds, and returns the results.
+func (r *Results) Finish() *Results {
+	if r == nil {
+		return r
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.FinishedAt = time.Now().UTC()
+	r.Duration = r.FinishedAt.Sub(r.StartedAt).Seconds()
+
+	return r
+}
+
+// publish passes a file result to the OnFile callback, if any. The caller must hold the lock.
+func (r *Results) publish(f FileResult) {
+	if r.OnFile != nil {
+		r.OnFile(f)
+	}
+}
@@ -0,0 +1,54 @@
+package photoprism
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResults(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		var r *Results
+
+		r.Add("Found", 1)
+		r.AddFile("foo.jpg", IndexResult{Status: IndexAdded})
+		r.Skip("foo.jpg", "too large")
+		r.Fail("foo.jpg", errors.New("failed"))
+
+		assert.Equal(t, 0, r.Count("Found"))
+		assert.False(t, r.Failed())
+		assert.Nil(t, r.Finish())
+	})
+	t.Run("Index", func(t *testing.T) {
+		r := NewResults(ActionIndex, "2023")
+
+		var streamed []FileResult
+
+		r.OnFile = func(f FileResult) {
+			streamed = append(streamed, f)
+		}
+
+		r.Add("Found", 5)
+		r.AddFile("2023/added.jpg", IndexResult{Status: IndexAdded, FileUID: "fs6sg6bw45bnlqdw", PhotoUID: "ps6sg6be2lvl0yh7"})
+		r.AddFile("2023/updated.jpg", IndexResult{Status: IndexUpdated})
+		r.AddFile("2023/duplicate.jpg", IndexResult{Status: IndexDuplicate})
+		r.AddFile("2023/broken.jpg", IndexResult{Status: IndexFailed, Err: errors.New("invalid header")})
+		r.Skip("2023/large.jpg", "file size exceeds limit")
+
+		assert.Equal(t, 5, r.Count("Found"))
+		assert.Equal(t, 1, r.Count("Added"))
+		assert.Equal(t, 1, r.Count("Updated"))
+		assert.Equal(t, 2, r.Count("Skipped"))
+		assert.Equal(t, 1, r.Count("Failed"))
+		assert.True(t, r.Failed())
+		assert.Len(t, streamed, 5)
+		assert.Equal(t, "invalid header", r.Errors[0].Error)
+		assert.Equal(t, "2023/large.jpg", r.Skipped[1].FileName)
+
+		r.Finish()
+
+		assert.False(t, r.FinishedAt.IsZero())
+		assert.GreaterOrEqual(t, r.Duration, 0.0)
+	})
+}
@@ -0,0 +1,404 @@
+package photoprism
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/media"
+)
+
+// TakeoutNameLimit is the maximum number of characters Google Takeout keeps from a
+// media file name when naming its JSON sidecar file, not counting the ".json" extension.
+const TakeoutNameLimit = 46
+
+// TakeoutSupplemental is the infix newer Takeout exports add to JSON sidecar file names.
+const TakeoutSupplemental = ".supplemental-metadata"
+
+// TakeoutEditedSuffixes contains the (localized) suffixes Google Photos adds to edited copies.
+var TakeoutEditedSuffixes = []string{
+	"-edited",
+	"-bearbeitet",
+	"-modifié",
+	"-editado",
+	"-modificato",
+	"-bewerkt",
+	"-redigerad",
+	"-muokattu",
+}
+
+// takeoutSequence matches file names with a numeric sequence like "IMG_1234(1).jpg".
+var takeoutSequence = regexp.MustCompile(`^(.*)(\(\d+\))(\.[^.]*)?$`)
+
+// TakeoutOptions represents Google Photos Takeout import options.
+type TakeoutOptions struct {
+	UID        string
+	Path       string
+	DestFolder string
+	Results    *Results
+}
+
+// TakeoutAlbum represents an album reconstructed from a Takeout export.
+type TakeoutAlbum struct {
+	Title       string `json:"Title"`
+	Description string `json:"Description,omitempty"`
+	Location    string `json:"Location,omitempty"`
+	Path        string `json:"Path"`
+	UID         string `json:"UID,omitempty"`
+	Shared      bool   `json:"Shared"`
+	Files       int    `json:"Files"`
+}
+
+// TakeoutReport represents the reconciliation report of a Takeout import.
+type TakeoutReport struct {
+	Path             string         `json:"Path"`
+	Albums           []TakeoutAlbum `json:"Albums"`
+	Files            int            `json:"Files"`
+	Sidecars         int            `json:"Sidecars"`
+	Renamed          int            `json:"Renamed"`
+	Edited           int            `json:"Edited"`
+	Shared           int            `json:"Shared"`
+	Imported         int            `json:"Imported"`
+	MissingSidecars  []string       `json:"MissingSidecars"`
+	OrphanedSidecars []string       `json:"OrphanedSidecars"`
+	UnpairedEdits    []string       `json:"UnpairedEdits"`
+}
+
+// NewTakeoutReport returns a new, empty reconciliation report.
+func NewTakeoutReport(path string) *TakeoutReport {
+	return &TakeoutReport{
+		Path:             path,
+		Albums:           []TakeoutAlbum{},
+		MissingSidecars:  []string{},
+		OrphanedSidecars: []string{},
+		UnpairedEdits:    []string{},
+	}
+}
+
+// Takeout imports Google Photos Takeout exports, reconstructing albums and pairing
+// JSON sidecar files before the files are moved to originals.
+type Takeout struct {
+	conf *config.Config
+	imp  *Import
+}
+
+// NewTakeout returns a new Takeout importer and expects the config and importer as argument.
+func NewTakeout(conf *config.Config, imp *Import) *Takeout {
+	return &Takeout{conf: conf, imp: imp}
+}
+
+// Start prepares and imports a Takeout export and returns the reconciliation report.
+func (t *Takeout) Start(opt TakeoutOptions) (*TakeoutReport, error) {
+	report := NewTakeoutReport(opt.Path)
+
+	if !fs.PathExists(opt.Path) {
+		return report, fmt.Errorf("takeout: %s not found", clean.Log(opt.Path))
+	}
+
+	var dirs []string
+
+	err := filepath.WalkDir(opt.Path, func(fileName string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		} else if !d.IsDir() {
+			return nil
+		} else if fileName != opt.Path && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		dirs = append(dirs, fileName)
+
+		return nil
+	})
+
+	if err != nil {
+		return report, err
+	}
+
+	var albums []TakeoutAlbum
+
+	for _, dir := range dirs {
+		album, err := t.Prepare(dir, opt.Path, report)
+
+		if err != nil {
+			log.Warnf("takeout: %s", err)
+		} else if album != nil && album.Path != "" {
+			albums = append(albums, *album)
+		}
+	}
+
+	// Import album folders first so that their files are added to the reconstructed albums,
+	// copies in other folders are detected as duplicates and added to the same albums.
+	for i := range albums {
+		if albums[i].UID, err = ExportAlbum(albums[i].Title, albums[i].Description, albums[i].Location, opt.UID); err != nil {
+			log.Errorf("takeout: %s", err)
+			continue
+		}
+
+		impOpt := ExportImportOptions(filepath.Join(opt.Path, albums[i].Path), opt.DestFolder, opt.UID, opt.Results)
+		impOpt.Albums = []string{albums[i].UID}
+
+		report.Imported += ProcessedFiles(t.imp.Start(impOpt))
+	}
+
+	report.Imported += ProcessedFiles(t.imp.Start(ExportImportOptions(opt.Path, opt.DestFolder, opt.UID, opt.Results)))
+	report.Albums = albums
+
+	return report, nil
+}
+
+// Prepare pairs media files in a Takeout folder with their JSON sidecar files, renaming sidecars
+// and edited copies so that they are found during import, and returns the album if the folder has
+// album metadata.
+func (t *Takeout) Prepare(dir, root string, report *TakeoutReport) (album *TakeoutAlbum, err error) {
+	entries, err := os.ReadDir(dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var mediaFiles []string
+	sidecars := make(map[string]meta.GPhoto)
+
+	for _, e := range entries {
+		name := e.Name()
+
+		if e.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		switch {
+		case strings.EqualFold(filepath.Ext(name), ".json"):
+			jsonData, readErr := os.ReadFile(filepath.Join(dir, name))
+
+			if readErr != nil {
+				log.Warnf("takeout: %s", readErr)
+			} else if bytes.Contains(jsonData, []byte("photoTakenTime")) {
+				p := meta.GPhoto{}
+
+				if jsonErr := json.Unmarshal(jsonData, &p); jsonErr != nil {
+					log.Warnf("takeout: %s in %s", jsonErr, clean.Log(name))
+				} else {
+					sidecars[name] = p
+				}
+			} else if a, albumErr := meta.ParseGAlbum(jsonData); albumErr == nil && album == nil {
+				album = &TakeoutAlbum{
+					Title:       a.SanitizedTitle(),
+					Description: a.SanitizedDescription(),
+					Location:    clean.Name(a.Location),
+					Path:        fs.RelName(dir, root),
+					Shared:      a.Shared(),
+				}
+			}
+		case media.MainFile(name):
+			mediaFiles = append(mediaFiles, name)
+		}
+	}
+
+	sort.Strings(mediaFiles)
+
+	claimed := make(map[string]bool, len(sidecars))
+	names := make(map[string]bool, len(mediaFiles))
+
+	for _, name := range mediaFiles {
+		names[name] = true
+	}
+
+	for _, name := range mediaFiles {
+		relName := fs.RelName(filepath.Join(dir, name), root)
+
+		report.Files++
+
+		if album != nil {
+			album.Files++
+		}
+
+		// Stack edited copies with their originals.
+		if original := TakeoutOriginalName(name, names); original != "" {
+			editedName := original + ".edited" + filepath.Ext(name)
+
+			if renameErr := t.rename(dir, name, editedName); renameErr != nil {
+				log.Warnf("takeout: %s", renameErr)
+			} else {
+				report.Edited++
+			}
+
+			continue
+		} else if TakeoutEdited(name) {
+			report.UnpairedEdits = append(report.UnpairedEdits, relName)
+		}
+
+		jsonName := TakeoutSidecar(name, sidecars, claimed)
+
+		if jsonName == "" {
+			report.MissingSidecars = append(report.MissingSidecars, relName)
+			continue
+		}
+
+		claimed[jsonName] = true
+		report.Sidecars++
+
+		if sidecars[jsonName].Origin.Shared() {
+			report.Shared++
+		}
+
+		// Rename the sidecar so that it is found and imported along with the media file.
+		if canonical := name + ".json"; jsonName != canonical {
+			if renameErr := t.rename(dir, jsonName, canonical); renameErr != nil {
+				log.Warnf("takeout: %s", renameErr)
+			} else {
+				report.Renamed++
+			}
+		}
+	}
+
+	for jsonName := range sidecars {
+		if !claimed[jsonName] {
+			report.OrphanedSidecars = append(report.OrphanedSidecars, fs.RelName(filepath.Join(dir, jsonName), root))
+		}
+	}
+
+	sort.Strings(report.OrphanedSidecars)
+
+	return album, nil
+}
+
+// rename renames a file in the specified folder unless the destination already exists.
+func (t *Takeout) rename(dir, from, to string) error {
+	dest := filepath.Join(dir, to)
+
+	if fs.FileExists(dest) {
+		return fmt.Errorf("cannot rename %s to %s, file already exists", clean.Log(from), clean.Log(to))
+	}
+
+	log.Debugf("takeout: renaming %s to %s", clean.Log(from), clean.Log(to))
+
+	return os.Rename(filepath.Join(dir, from), dest)
+}
+
+// TakeoutEdited tests if the file name belongs to an edited copy.
+func TakeoutEdited(name string) bool {
+	return takeoutEditedStem(name) != ""
+}
+
+// takeoutEditedStem returns the file name without edited suffix and extension, or an empty string
+// if it is not an edited copy.
+func takeoutEditedStem(name string) string {
+	stem := strings.TrimSuffix(name, filepath.Ext(name))
+
+	for _, suffix := range TakeoutEditedSuffixes {
+		if strings.HasSuffix(strings.ToLower(stem), suffix) {
+			return stem[:len(stem)-len(suffix)]
+		}
+	}
+
+	return ""
+}
+
+// TakeoutOriginalName returns the name of the original file if name belongs to an edited copy
+// and the original exists in names, preferring originals with the same file extension.
+func TakeoutOriginalName(name string, names map[string]bool) string {
+	stem := takeoutEditedStem(name)
+
+	if stem == "" {
+		return ""
+	}
+
+	if original := stem + filepath.Ext(name); names[original] {
+		return original
+	}
+
+	var found []string
+
+	for n := range names {
+		if n != name && strings.TrimSuffix(n, filepath.Ext(n)) == stem {
+			found = append(found, n)
+		}
+	}
+
+	if len(found) == 0 {
+		return ""
+	}
+
+	sort.Strings(found)
+
+	return found[0]
+}
+
+// TakeoutSidecarNames returns the possible JSON sidecar file names for a media file in order of preference,
+// taking into account that Takeout truncates long names and moves sequence numbers to the end.
+func TakeoutSidecarNames(name string) (result []string) {
+	done := make(map[string]bool)
+
+	add := func(s string) {
+		if !done[s] {
+			done[s] = true
+			result = append(result, s)
+		}
+	}
+
+	type base struct{ name, seq string }
+
+	bases := []base{{name: name}}
+
+	// Sequence numbers are appended to the sidecar name, e.g. "IMG_1234(1).jpg" => "IMG_1234.jpg(1).json".
+	if m := takeoutSequence.FindStringSubmatch(name); m != nil {
+		bases = append(bases, base{name: m[1] + m[3], seq: m[2]})
+	}
+
+	for _, infix := range []string{"", TakeoutSupplemental} {
+		for _, b := range bases {
+			add(b.name + infix + b.seq + ".json")
+			add(takeoutTruncate(b.name+infix) + b.seq + ".json")
+		}
+	}
+
+	// Some sidecars omit the media file extension, e.g. "IMG_1234.json".
+	add(strings.TrimSuffix(name, filepath.Ext(name)) + ".json")
+
+	return result
+}
+
+// TakeoutSidecar returns the name of the unclaimed JSON sidecar file that belongs to a media file,
+// or an empty string if none was found.
+func TakeoutSidecar(name string, sidecars map[string]meta.GPhoto, claimed map[string]bool) string {
+	for _, jsonName := range TakeoutSidecarNames(name) {
+		if _, ok := sidecars[jsonName]; ok && !claimed[jsonName] {
+			return jsonName
+		}
+	}
+
+	// Fall back to the original file name stored in the sidecar, if unique.
+	var found []string
+
+	for jsonName, p := range sidecars {
+		if !claimed[jsonName] && p.Title == name {
+			found = append(found, jsonName)
+		}
+	}
+
+	if len(found) == 1 {
+		return found[0]
+	}
+
+	return ""
+}
+
+// takeoutTruncate truncates a name to the maximum length Takeout uses for sidecar file names.
+func takeoutTruncate(s string) string {
+	if utf8.RuneCountInString(s) <= TakeoutNameLimit {
+		return s
+	}
+
+	return string([]rune(s)[:TakeoutNameLimit])
+}
@@ -0,0 +1,127 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestTakeoutSidecarNames(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		result := TakeoutSidecarNames("IMG_1234.jpg")
+		assert.Equal(t, "IMG_1234.jpg.json", result[0])
+		assert.Contains(t, result, "IMG_1234.jpg.supplemental-metadata.json")
+		assert.Contains(t, result, "IMG_1234.json")
+	})
+	t.Run("Sequence", func(t *testing.T) {
+		result := TakeoutSidecarNames("IMG_1234(1).jpg")
+		assert.Equal(t, "IMG_1234(1).jpg.json", result[0])
+		assert.Contains(t, result, "IMG_1234.jpg(1).json")
+		assert.Contains(t, result, "IMG_1234.jpg.supplemental-metadata(1).json")
+	})
+	t.Run("Truncated", func(t *testing.T) {
+		result := TakeoutSidecarNames("Screenshot_20200101-123456_Google Photos App.jpg")
+		assert.Contains(t, result, "Screenshot_20200101-123456_Google Photos App.j.json")
+	})
+	t.Run("TruncatedSupplemental", func(t *testing.T) {
+		result := TakeoutSidecarNames("PXL_20230615_101112345.jpg")
+		assert.Contains(t, result, "PXL_20230615_101112345.jpg.supplemental-metada.json")
+	})
+}
+
+func TestTakeoutOriginalName(t *testing.T) {
+	names := map[string]bool{
+		"IMG_1234.jpg":        true,
+		"IMG_1234-edited.jpg": true,
+		"IMG_5678.HEIC":       true,
+		"IMG_5678-edited.jpg": true,
+		"IMG_9999-edited.jpg": true,
+	}
+
+	assert.Equal(t, "IMG_1234.jpg", TakeoutOriginalName("IMG_1234-edited.jpg", names))
+	assert.Equal(t, "IMG_5678.HEIC", TakeoutOriginalName("IMG_5678-edited.jpg", names))
+	assert.Equal(t, "", TakeoutOriginalName("IMG_9999-edited.jpg", names))
+	assert.Equal(t, "", TakeoutOriginalName("IMG_1234.jpg", names))
+	assert.True(t, TakeoutEdited("IMG_9999-bearbeitet.jpg"))
+	assert.False(t, TakeoutEdited("IMG_9999.jpg"))
+}
+
+func TestTakeoutSidecar(t *testing.T) {
+	sidecars := map[string]meta.GPhoto{
+		"IMG_1234.jpg.json":    {Title: "IMG_1234.jpg"},
+		"IMG_1234.jpg(1).json": {Title: "IMG_1234.jpg"},
+		"renamed.json":         {Title: "vacation.jpg"},
+	}
+
+	claimed := make(map[string]bool)
+
+	assert.Equal(t, "IMG_1234.jpg.json", TakeoutSidecar("IMG_1234.jpg", sidecars, claimed))
+	assert.Equal(t, "IMG_1234.jpg(1).json", TakeoutSidecar("IMG_1234(1).jpg", sidecars, claimed))
+	assert.Equal(t, "renamed.json", TakeoutSidecar("vacation.jpg", sidecars, claimed))
+	assert.Equal(t, "", TakeoutSidecar("unknown.jpg", sidecars, claimed))
+
+	claimed["IMG_1234.jpg.json"] = true
+
+	assert.Equal(t, "", TakeoutSidecar("IMG_1234.jpg", map[string]meta.GPhoto{"IMG_1234.jpg.json": {}}, claimed))
+}
+
+func TestTakeout_Prepare(t *testing.T) {
+	conf := config.TestConfig()
+	root := filepath.Join(conf.TempPath(), "takeout")
+	dir := filepath.Join(root, "Summer in Berlin")
+
+	if err := os.MkdirAll(dir, fs.ModeDir); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(root)
+
+	files := map[string]string{
+		"metadata.json":        `{"title": "Summer in Berlin", "description": "Family trip", "access": "protected"}`,
+		"IMG_1234.jpg":         "jpeg",
+		"IMG_1234-edited.jpg":  "jpeg",
+		"IMG_1234(1).jpg":      "jpeg",
+		"IMG_1234.jpg(1).json": `{"title": "IMG_1234.jpg", "photoTakenTime": {"timestamp": "1596276610"}, "googlePhotosOrigin": {"fromSharedAlbum": {}}}`,
+		"IMG_1234.jpg.json":    `{"title": "IMG_1234.jpg", "photoTakenTime": {"timestamp": "1596276610"}}`,
+		"IMG_5678.jpg":         "jpeg",
+		"orphan.json":          `{"title": "orphan.jpg", "photoTakenTime": {"timestamp": "1596276610"}}`,
+	}
+
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report := NewTakeoutReport(root)
+	album, err := NewTakeout(conf, nil).Prepare(dir, root, report)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if album == nil {
+		t.Fatal("album must not be nil")
+	}
+
+	assert.Equal(t, "Summer in Berlin", album.Title)
+	assert.Equal(t, "Family trip", album.Description)
+	assert.Equal(t, "Summer in Berlin", album.Path)
+	assert.True(t, album.Shared)
+	assert.Equal(t, 4, album.Files)
+	assert.Equal(t, 4, report.Files)
+	assert.Equal(t, 2, report.Sidecars)
+	assert.Equal(t, 1, report.Renamed)
+	assert.Equal(t, 1, report.Edited)
+	assert.Equal(t, 1, report.Shared)
+	assert.Equal(t, []string{"Summer in Berlin/IMG_5678.jpg"}, report.MissingSidecars)
+	assert.Equal(t, []string{"Summer in Berlin/orphan.json"}, report.OrphanedSidecars)
+	assert.True(t, fs.FileExists(filepath.Join(dir, "IMG_1234(1).jpg.json")))
+	assert.True(t, fs.FileExists(filepath.Join(dir, "IMG_1234.jpg.edited.jpg")))
+}
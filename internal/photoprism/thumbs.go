@@ -1,7 +1,6 @@
 package photoprism
 
 import (
-	"errors"
 	"fmt"
 	"path/filepath"
 	"runtime/debug"
@@ -10,6 +9,7 @@ import (
 	"github.com/karrick/godirwalk"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -48,10 +48,18 @@ func (w *Thumbs) Start(dir string, force, originalsOnly bool) (err error) {
 	// Scan sidecar folder?
 	originalsOnly = originalsOnly || sidecarPath == "" || sidecarPath == originalsPath || !fs.PathExists(sidecarDir)
 
+	// Add a persistent job, so that the remaining thumbnails are created by the job queue if it gets interrupted.
+	job := TrackJob(entity.JobThumbs, dir, force)
+
 	// Start creating thumbnails.
 	if _, err = w.Dir(originalsDir, force); err != nil || originalsOnly {
+		FinishJob(job, err)
 		return err
-	} else if _, err = w.Dir(sidecarDir, force); err != nil {
+	}
+
+	FinishJob(job, nil)
+
+	if _, err = w.Dir(sidecarDir, force); err != nil {
 		return err
 	}
 
@@ -97,7 +105,7 @@ func (w *Thumbs) Dir(dir string, force bool) (fs.Done, error) {
 		}()
 
 		if mutex.MainWorker.Canceled() {
-			return errors.New("canceled")
+			return ErrCanceled
 		}
 
 		isDir, _ := info.IsDirOrSymlinkToDir()
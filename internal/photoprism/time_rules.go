@@ -0,0 +1,192 @@
+package photoprism
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/query"
+)
+
+// TimeRuleResult represents a photo whose time is corrected by a time rule.
+type TimeRuleResult struct {
+	PhotoUID  string    `json:"PhotoUID"`
+	PhotoName string    `json:"PhotoName"`
+	RuleID    uint      `json:"RuleID"`
+	TakenAt   time.Time `json:"TakenAt"`
+	Corrected time.Time `json:"Corrected"`
+	TimeZone  string    `json:"TimeZone"`
+}
+
+// TimeRuleResults represents a list of photos whose time is corrected by time rules.
+type TimeRuleResults []TimeRuleResult
+
+// ApplyTimeRules corrects the time of indexed photos with a camera time that matches a time rule,
+// or only returns the photos that would be corrected if preview is true. Photos that have already been
+// corrected are recomputed from their metadata time, so that changed and deleted rules are applied as well.
+func ApplyTimeRules(preview bool) (results TimeRuleResults, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("index: %s (apply time rules)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	results = TimeRuleResults{}
+
+	if !preview {
+		if err = mutex.MainWorker.Start(); err != nil {
+			return results, err
+		}
+
+		defer mutex.MainWorker.Stop()
+	}
+
+	rules, err := entity.FindTimeRules()
+
+	if err != nil {
+		return results, err
+	}
+
+	// Only previously corrected photos need to be checked if there are no rules.
+	sources := []string{entity.SrcMeta, entity.SrcRule}
+
+	if len(rules) == 0 {
+		log.Infof("index: found no time rules")
+		sources = []string{entity.SrcRule}
+	}
+
+	var lastId uint
+
+	for {
+		var photos entity.Photos
+
+		if err = query.UnscopedDb().Preload("Camera").
+			Where("id > ? AND taken_src IN (?)", lastId, sources).
+			Order("id").Limit(1000).Find(&photos).Error; err != nil {
+			return results, err
+		} else if len(photos) == 0 {
+			return results, nil
+		}
+
+		for i := range photos {
+			p := &photos[i]
+			lastId = p.ID
+
+			if mutex.MainWorker.Canceled() {
+				return results, nil
+			}
+
+			prev := *p
+
+			// Restore the metadata time if the photo has been corrected before.
+			if p.TakenSrc == entity.SrcRule {
+				if restoreErr := restoreMetaTime(p); restoreErr != nil {
+					log.Warnf("index: %s (restore time of %s)", restoreErr, p.String())
+					continue
+				}
+			}
+
+			rule := rules.Match(p)
+
+			if rule != nil {
+				rule.Apply(p)
+			} else if prev.TakenSrc != entity.SrcRule {
+				continue
+			}
+
+			// Skip photos whose time remains unchanged.
+			if p.TakenSrc == prev.TakenSrc && p.TakenAt.Equal(prev.TakenAt) &&
+				p.TakenAtLocal.Equal(prev.TakenAtLocal) && p.TimeZone == prev.TimeZone {
+				continue
+			}
+
+			result := TimeRuleResult{
+				PhotoUID:  p.PhotoUID,
+				PhotoName: p.PhotoName,
+				TakenAt:   prev.TakenAtLocal,
+				Corrected: p.TakenAtLocal,
+				TimeZone:  p.TimeZone,
+			}
+
+			if rule != nil {
+				result.RuleID = rule.ID
+			}
+
+			results = append(results, result)
+
+			if preview {
+				continue
+			}
+
+			if err = saveTimeRuleResult(p); err != nil {
+				log.Errorf("index: %s while correcting time of %s", err, p.String())
+			} else if rule != nil {
+				log.Infof("index: corrected time of %s with %s", p.String(), rule.String())
+			} else {
+				log.Infof("index: restored time of %s from metadata", p.String())
+			}
+		}
+	}
+}
+
+// restoreMetaTime sets the time of a photo that has been corrected by a time rule back
+// to the time found in the metadata of its primary file, without saving it.
+func restoreMetaTime(p *entity.Photo) error {
+	f, err := p.PrimaryFile()
+
+	if err != nil {
+		return err
+	}
+
+	m, err := NewMediaFile(FileName(f.FileRoot, f.FileName))
+
+	if err != nil {
+		return err
+	}
+
+	data := m.MetaData()
+
+	if data.Error != nil {
+		return data.Error
+	} else if data.TakenAt.IsZero() {
+		return fmt.Errorf("no time found in metadata")
+	}
+
+	p.SetTakenAt(data.TakenAt, data.TakenAtLocal, data.TimeZone, entity.SrcMeta)
+
+	if p.TakenSrc != entity.SrcMeta {
+		return fmt.Errorf("invalid time found in metadata")
+	}
+
+	return nil
+}
+
+// saveTimeRuleResult updates the time of a photo and its files in the index.
+func saveTimeRuleResult(p *entity.Photo) error {
+	if err := entity.UnscopedDb().Model(p).UpdateColumns(entity.Values{
+		"TakenAt":      p.TakenAt,
+		"TakenAtLocal": p.TakenAtLocal,
+		"TakenSrc":     p.TakenSrc,
+		"TimeZone":     p.TimeZone,
+		"PhotoYear":    p.PhotoYear,
+		"PhotoMonth":   p.PhotoMonth,
+		"PhotoDay":     p.PhotoDay,
+	}).Error; err != nil {
+		return err
+	}
+
+	entity.File{PhotoID: p.ID}.RegenerateIndex()
+
+	if Config().BackupYaml() {
+		yamlFile := p.YamlFileName(Config().OriginalsPath(), Config().SidecarPath())
+
+		if err := p.SaveAsYaml(yamlFile); err != nil {
+			log.Errorf("index: %s (update yaml)", err)
+		}
+	}
+
+	return nil
+}
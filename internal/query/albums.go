@@ -147,6 +147,23 @@ func AlbumEntryFound(uid string) error {
 	}
 }
 
+// PhotoInAlbum tests if the photo has been added to the album and was not removed.
+func PhotoInAlbum(photoUid, albumUid string) bool {
+	if photoUid == "" || albumUid == "" {
+		return false
+	}
+
+	count := 0
+
+	if err := UnscopedDb().Model(&entity.PhotoAlbum{}).
+		Where("photo_uid = ? AND album_uid = ? AND hidden = 0", photoUid, albumUid).
+		Count(&count).Error; err != nil {
+		return false
+	}
+
+	return count > 0
+}
+
 // AlbumsPhotoUIDs returns up to 100000 photo UIDs that belong to the specified albums.
 func AlbumsPhotoUIDs(albums []string, includeDefault, includePrivate bool) (photos []string, err error) {
 	for _, albumUid := range albums {
@@ -104,3 +104,9 @@ func TestGetAlbums(t *testing.T) {
 		assert.Equal(t, 3, len(r))
 	})
 }
+
+func TestPhotoInAlbum(t *testing.T) {
+	assert.True(t, PhotoInAlbum("pt9jtdre2lvl0yh7", "at9lxuqxpogaaba8"))
+	assert.False(t, PhotoInAlbum("pt9jtdre2lvl0yh7", "at9lxuqxpogaaba9"))
+	assert.False(t, PhotoInAlbum("", "at9lxuqxpogaaba8"))
+}
@@ -0,0 +1,27 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// EntityComments returns the comments of a photo or album, optionally including hidden comments.
+func EntityComments(uid string, hidden bool) (result entity.Comments, err error) {
+	stmt := Db().Where("entity_uid = ?", uid)
+
+	if !hidden {
+		stmt = stmt.Where("comment_hidden = 0")
+	}
+
+	err = stmt.Order("created_at, id").Find(&result).Error
+
+	return result, err
+}
+
+// PhotoAlbumUIDs returns the UIDs of the albums that contain a photo.
+func PhotoAlbumUIDs(photoUid string) (albums []string, err error) {
+	err = Db().Model(&entity.PhotoAlbum{}).
+		Where("photo_uid = ? AND hidden = 0", photoUid).
+		Pluck("album_uid", &albums).Error
+
+	return albums, err
+}
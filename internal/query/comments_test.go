@@ -0,0 +1,50 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestEntityComments(t *testing.T) {
+	t.Run("Photo", func(t *testing.T) {
+		results, err := EntityComments(entity.PhotoFixtures.Get("Photo01").PhotoUID, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 2)
+		assert.Equal(t, "cs6sg6bw45bn0001", results[0].CommentUID)
+	})
+	t.Run("Hidden", func(t *testing.T) {
+		albumUid := entity.AlbumFixtures.Get("holiday-2030").AlbumUID
+
+		visible, err := EntityComments(albumUid, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		all, err := EntityComments(albumUid, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, all, len(visible)+1)
+	})
+}
+
+func TestPhotoAlbumUIDs(t *testing.T) {
+	albums, err := PhotoAlbumUIDs("pt9jtdre2lvl0y21")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, albums, "at9lxuqxpogaaba7")
+	assert.Contains(t, albums, "at9lxuqxpogaaba8")
+}
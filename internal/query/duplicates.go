@@ -6,7 +6,8 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 )
 
-// Duplicates finds duplicate files in the range of limit and offset sorted by file name.
+// Duplicates finds duplicate files in the range of limit and offset sorted by file name,
+// optionally limited to a folder or a single file.
 func Duplicates(limit, offset int, pathName string) (files entity.Duplicates, err error) {
 	if strings.HasPrefix(pathName, "/") {
 		pathName = pathName[1:]
@@ -15,7 +16,7 @@ func Duplicates(limit, offset int, pathName string) (files entity.Duplicates, er
 	stmt := Db()
 
 	if pathName != "" {
-		stmt = stmt.Where("file_name LIKE ?", pathName+"/%")
+		stmt = stmt.Where("file_name = ? OR file_name LIKE ?", pathName, pathName+"/%")
 	}
 
 	err = stmt.Order("file_name").Limit(limit).Offset(offset).Find(&files).Error
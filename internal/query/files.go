@@ -33,7 +33,8 @@ func FilesByPath(limit, offset int, rootName, pathName string, public bool) (fil
 	return files, err
 }
 
-// Files returns not-missing and not-deleted file entities in the range of limit and offset sorted by id.
+// Files returns not-missing and not-deleted file entities in the range of limit and offset sorted by id,
+// optionally limited to a folder or a single file.
 func Files(limit, offset int, pathName string, includeMissing bool) (files entity.Files, err error) {
 	if strings.HasPrefix(pathName, "/") {
 		pathName = pathName[1:]
@@ -46,7 +47,7 @@ func Files(limit, offset int, pathName string, includeMissing bool) (files entit
 	}
 
 	if pathName != "" {
-		stmt = stmt.Where("files.file_name LIKE ?", pathName+"/%")
+		stmt = stmt.Where("files.file_name = ? OR files.file_name LIKE ?", pathName, pathName+"/%")
 	}
 
 	err = stmt.Order("id").Limit(limit).Offset(offset).Find(&files).Error
@@ -54,6 +55,27 @@ func Files(limit, offset int, pathName string, includeMissing bool) (files entit
 	return files, err
 }
 
+// PrimaryFiles returns primary image files of photos that are not deleted, optionally limited to a folder.
+func PrimaryFiles(limit, offset int, pathName string) (files entity.Files, err error) {
+	if strings.HasPrefix(pathName, "/") {
+		pathName = pathName[1:]
+	}
+
+	stmt := Db().
+		Table("files").Select("files.*").
+		Joins("JOIN photos ON photos.id = files.photo_id AND photos.deleted_at IS NULL").
+		Where("files.file_primary = 1 AND files.file_missing = 0 AND files.deleted_at IS NULL").
+		Where("files.file_root = ?", entity.RootOriginals)
+
+	if pathName != "" {
+		stmt = stmt.Where("files.file_name = ? OR files.file_name LIKE ?", pathName, pathName+"/%")
+	}
+
+	err = stmt.Order("files.id").Limit(limit).Offset(offset).Find(&files).Error
+
+	return files, err
+}
+
 // FilesByUID finds files for the given UIDs.
 func FilesByUID(u []string, limit int, offset int) (files entity.Files, err error) {
 	if err := Db().Where("(photo_uid IN (?) AND file_primary = 1) OR file_uid IN (?)", u, u).Preload("Photo").Limit(limit).Offset(offset).Find(&files).Error; err != nil {
@@ -118,6 +140,20 @@ func FileByHash(fileHash string) (*entity.File, error) {
 	return &f, err
 }
 
+// FilesByBaseName returns indexed originals with the specified base file name, e.g. to match external catalogs.
+func FilesByBaseName(baseName string) (files entity.Files, err error) {
+	if baseName == "" {
+		return files, fmt.Errorf("file name required")
+	}
+
+	err = Db().
+		Where("file_root = ? AND file_missing = 0 AND deleted_at IS NULL", entity.RootOriginals).
+		Where("file_name = ? OR file_name LIKE ?", baseName, "%/"+baseName).
+		Order("file_name").Find(&files).Error
+
+	return files, err
+}
+
 // RenameFile renames an indexed file.
 func RenameFile(srcRoot, srcName, destRoot, destName string) error {
 	if srcRoot == "" || srcName == "" || destRoot == "" || destName == "" {
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -87,6 +88,47 @@ func TestExistingFiles(t *testing.T) {
 
 		assert.Empty(t, files)
 	})
+	t.Run("search for file name", func(t *testing.T) {
+		files, err := Files(1000, 0, "/2790/07/27900704_070228_D6D51B6C.jpg", true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, files)
+
+		for _, f := range files {
+			assert.Equal(t, "2790/07/27900704_070228_D6D51B6C.jpg", f.FileName)
+		}
+	})
+}
+
+func TestPrimaryFiles(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		files, err := PrimaryFiles(1000, 0, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 1, len(files))
+
+		for _, f := range files {
+			assert.True(t, f.FilePrimary)
+			assert.Equal(t, entity.RootOriginals, f.FileRoot)
+		}
+	})
+	t.Run("Path", func(t *testing.T) {
+		files, err := PrimaryFiles(1000, 0, "/2790")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, f := range files {
+			assert.True(t, strings.HasPrefix(f.FileName, "2790/"))
+		}
+	})
 }
 
 func TestFilesByUID(t *testing.T) {
@@ -193,6 +235,31 @@ func TestFileByHash(t *testing.T) {
 	})
 }
 
+func TestFilesByBaseName(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		files, err := FilesByBaseName("27900704_070228_D6D51B6C.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.NotEmpty(t, files) {
+			assert.Equal(t, "2790/07/27900704_070228_D6D51B6C.jpg", files[0].FileName)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		files, err := FilesByBaseName("missing-file.jpg")
+
+		assert.NoError(t, err)
+		assert.Empty(t, files)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, err := FilesByBaseName("")
+
+		assert.Error(t, err)
+	})
+}
+
 func TestSetPhotoPrimary(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		assert.Equal(t, false, entity.FileFixturesExampleXMP.FilePrimary)
@@ -0,0 +1,24 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Jobs returns queued and processed jobs, optionally filtered by status and type.
+func Jobs(limit, offset int, status, jobType string) (results entity.Jobs, err error) {
+	stmt := Db()
+
+	if status = strings.TrimSpace(status); status != "" {
+		stmt = stmt.Where("job_status = ?", status)
+	}
+
+	if jobType = strings.TrimSpace(jobType); jobType != "" {
+		stmt = stmt.Where("job_type = ?", jobType)
+	}
+
+	err = stmt.Order("job_priority DESC, id").Limit(limit).Offset(offset).Find(&results).Error
+
+	return results, err
+}
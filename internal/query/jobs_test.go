@@ -0,0 +1,38 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestJobs(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		results, err := Jobs(100, 0, entity.JobFailed, "invalid")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+	t.Run("Queued", func(t *testing.T) {
+		job := entity.NewJob(entity.JobThumbs, "2790/07", entity.JobPriorityDefault, false)
+
+		if err := job.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.UnscopedDb().Delete(job)
+
+		results, err := Jobs(100, 0, entity.JobQueued, entity.JobThumbs)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+	})
+}
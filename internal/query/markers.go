@@ -122,6 +122,62 @@ func Embeddings(single, unclustered bool, size, score int) (result face.Embeddin
 	return result, nil
 }
 
+// SubjectEmbeddings returns the embeddings of face markers with a manually assigned subject,
+// along with the subject UIDs, so they can be used as ground truth.
+func SubjectEmbeddings(size, score int) (subjects []string, result face.Embeddings, err error) {
+	var markers []entity.Marker
+
+	stmt := Db().
+		Select("subj_uid, embeddings_json").
+		Where("marker_type = ?", entity.MarkerFace).
+		Where("marker_invalid = 0").
+		Where("subj_uid <> '' AND subj_src <> ?", entity.SrcAuto).
+		Where("embeddings_json <> ''").
+		Order("marker_uid")
+
+	if size > 0 {
+		stmt = stmt.Where("size >= ?", size)
+	}
+
+	if score > 0 {
+		stmt = stmt.Where("score >= ?", score)
+	}
+
+	if err = stmt.Find(&markers).Error; err != nil {
+		return subjects, result, err
+	}
+
+	for _, m := range markers {
+		if embeddings, err := face.UnmarshalEmbeddings(string(m.EmbeddingsJSON)); err != nil {
+			log.Warnf("faces: %s", err)
+		} else if !embeddings.Empty() {
+			subjects = append(subjects, m.SubjUID)
+			result = append(result, embeddings[0])
+		}
+	}
+
+	return subjects, result, nil
+}
+
+// PetMarkers returns valid pet markers with embeddings that either have a subject or not.
+func PetMarkers(named bool) (result entity.Markers, err error) {
+	stmt := Db().
+		Where("marker_type = ?", entity.MarkerPet).
+		Where("marker_invalid = 0").
+		Where("embeddings_json <> ''").
+		Order("marker_uid")
+
+	if named {
+		stmt = stmt.Where("subj_uid <> ''")
+	} else {
+		stmt = stmt.Where("subj_uid = ''")
+	}
+
+	err = stmt.Find(&result).Error
+
+	return result, err
+}
+
 // RemoveInvalidMarkerReferences removes face and subject references from invalid markers.
 func RemoveInvalidMarkerReferences() (removed int64, err error) {
 	res := Db().
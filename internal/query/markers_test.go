@@ -109,6 +109,47 @@ func TestFaceMarkers(t *testing.T) {
 	})
 }
 
+func TestPetMarkers(t *testing.T) {
+	t.Run("Named", func(t *testing.T) {
+		results, err := PetMarkers(true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, m := range results {
+			assert.Equal(t, entity.MarkerPet, m.MarkerType)
+			assert.NotEmpty(t, m.SubjUID)
+		}
+	})
+	t.Run("Unnamed", func(t *testing.T) {
+		results, err := PetMarkers(false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, m := range results {
+			assert.Equal(t, entity.MarkerPet, m.MarkerType)
+			assert.Empty(t, m.SubjUID)
+		}
+	})
+}
+
+func TestSubjectEmbeddings(t *testing.T) {
+	subjects, results, err := SubjectEmbeddings(0, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, len(subjects), len(results))
+
+	for _, subj := range subjects {
+		assert.NotEmpty(t, subj)
+	}
+}
+
 func TestEmbeddings(t *testing.T) {
 	t.Run("all", func(t *testing.T) {
 		results, err := Embeddings(false, false, 0, 0)
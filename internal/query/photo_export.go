@@ -0,0 +1,121 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// photoNames represents a name that belongs to a photo.
+type photoNames struct {
+	PhotoID uint
+	Name    string
+}
+
+// groupNames groups the names by photo id.
+func groupNames(rows []photoNames) map[uint][]string {
+	result := make(map[uint][]string, len(rows))
+
+	for _, r := range rows {
+		if r.Name == "" {
+			continue
+		}
+
+		result[r.PhotoID] = append(result[r.PhotoID], r.Name)
+	}
+
+	return result
+}
+
+// PhotoLabelNames returns the names of labels by photo id, starting with the most certain label.
+func PhotoLabelNames(photoIds []uint) (map[uint][]string, error) {
+	var rows []photoNames
+
+	if len(photoIds) == 0 {
+		return map[uint][]string{}, nil
+	}
+
+	err := Db().Table(entity.PhotoLabel{}.TableName()).
+		Select("photos_labels.photo_id, labels.label_name AS name").
+		Joins("JOIN labels ON labels.id = photos_labels.label_id AND labels.deleted_at IS NULL").
+		Where("photos_labels.photo_id IN (?) AND photos_labels.uncertainty < 100", photoIds).
+		Order("photos_labels.photo_id, photos_labels.uncertainty, labels.label_name").
+		Scan(&rows).Error
+
+	return groupNames(rows), err
+}
+
+// PhotoSubjectNames returns the names of people recognized or tagged in photos by photo id.
+func PhotoSubjectNames(photoIds []uint) (map[uint][]string, error) {
+	var rows []photoNames
+
+	if len(photoIds) == 0 {
+		return map[uint][]string{}, nil
+	}
+
+	err := Db().Table(entity.Marker{}.TableName()).
+		Select("DISTINCT files.photo_id, subjects.subj_name AS name").
+		Joins("JOIN files ON files.file_uid = markers.file_uid").
+		Joins("JOIN subjects ON subjects.subj_uid = markers.subj_uid AND subjects.deleted_at IS NULL").
+		Where("files.photo_id IN (?) AND markers.marker_invalid = 0 AND markers.marker_type = ?", photoIds, entity.MarkerFace).
+		Order("files.photo_id, subjects.subj_name").
+		Scan(&rows).Error
+
+	return groupNames(rows), err
+}
+
+// PhotoAlbumTitles returns the titles of the albums that contain the photos by photo id.
+func PhotoAlbumTitles(photoIds []uint) (map[uint][]string, error) {
+	var rows []photoNames
+
+	if len(photoIds) == 0 {
+		return map[uint][]string{}, nil
+	}
+
+	err := Db().Table(entity.PhotoAlbum{}.TableName()).
+		Select("photos.id AS photo_id, albums.album_title AS name").
+		Joins("JOIN photos ON photos.photo_uid = photos_albums.photo_uid").
+		Joins("JOIN albums ON albums.album_uid = photos_albums.album_uid AND albums.deleted_at IS NULL").
+		Where("photos.id IN (?) AND photos_albums.hidden = 0 AND albums.album_type = ?", photoIds, entity.AlbumManual).
+		Order("photos.id, albums.album_title").
+		Scan(&rows).Error
+
+	return groupNames(rows), err
+}
+
+// PhotoFileNames returns the names of the original files by photo id, starting with the primary file.
+func PhotoFileNames(photoIds []uint) (map[uint][]string, error) {
+	var rows []photoNames
+
+	if len(photoIds) == 0 {
+		return map[uint][]string{}, nil
+	}
+
+	err := Db().Table(entity.File{}.TableName()).
+		Select("files.photo_id, files.file_name AS name").
+		Where("files.photo_id IN (?) AND files.file_root = ? AND files.file_missing = 0 AND files.file_sidecar = 0 AND files.deleted_at IS NULL",
+			photoIds, entity.RootOriginals).
+		Order("files.photo_id, files.file_primary DESC, files.file_name").
+		Scan(&rows).Error
+
+	return groupNames(rows), err
+}
+
+// PhotoDetails returns the details such as keywords, artist, and copyright by photo id.
+func PhotoDetails(photoIds []uint) (map[uint]entity.Details, error) {
+	var rows []entity.Details
+
+	result := make(map[uint]entity.Details, len(photoIds))
+
+	if len(photoIds) == 0 {
+		return result, nil
+	}
+
+	if err := Db().Where("photo_id IN (?)", photoIds).Find(&rows).Error; err != nil {
+		return result, err
+	}
+
+	for _, d := range rows {
+		result[d.PhotoID] = d
+	}
+
+	return result, nil
+}
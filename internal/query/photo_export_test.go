@@ -0,0 +1,72 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestPhotoLabelNames(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		id := entity.PhotoFixtures.Get("Photo01").ID
+
+		result, err := PhotoLabelNames([]uint{id})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, result[id])
+	})
+	t.Run("Empty", func(t *testing.T) {
+		result, err := PhotoLabelNames(nil)
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestPhotoSubjectNames(t *testing.T) {
+	result, err := PhotoSubjectNames([]uint{entity.PhotoFixtures.Get("Photo01").ID, entity.PhotoFixtures.Get("Photo04").ID})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.IsType(t, map[uint][]string{}, result)
+}
+
+func TestPhotoAlbumTitles(t *testing.T) {
+	result, err := PhotoAlbumTitles([]uint{entity.PhotoFixtures.Get("Photo01").ID, entity.PhotoFixtures.Get("Photo04").ID})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.IsType(t, map[uint][]string{}, result)
+}
+
+func TestPhotoFileNames(t *testing.T) {
+	id := entity.PhotoFixtures.Get("Photo01").ID
+
+	result, err := PhotoFileNames([]uint{id})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotEmpty(t, result[id])
+}
+
+func TestPhotoDetails(t *testing.T) {
+	id := entity.PhotoFixtures.Get("Photo01").ID
+
+	result, err := PhotoDetails([]uint{id})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, id, result[id].PhotoID)
+}
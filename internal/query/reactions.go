@@ -0,0 +1,56 @@
+package query
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/react"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// EntityReaction represents a reaction to a photo or album, along with the name of the user.
+type EntityReaction struct {
+	UserUID     string     `json:"UserUID,omitempty"`
+	UserName    string     `json:"UserName,omitempty"`
+	DisplayName string     `json:"DisplayName,omitempty"`
+	Reaction    string     `json:"Reaction"`
+	Name        string     `json:"Name"`
+	ReactedAt   *time.Time `json:"ReactedAt,omitempty"`
+}
+
+// EntityReactions represents a list of reactions to a photo or album.
+type EntityReactions []EntityReaction
+
+// Counts returns the number of reactions by emoji.
+func (m EntityReactions) Counts() map[string]int {
+	result := make(map[string]int, len(m))
+
+	for _, r := range m {
+		result[r.Reaction]++
+	}
+
+	return result
+}
+
+// Reactions returns who reacted with what to a photo or album. Link visitors without
+// a user account react anonymously, so their user uid is omitted.
+func Reactions(uid string) (result EntityReactions, err error) {
+	err = UnscopedDb().Table(entity.Reaction{}.TableName()).
+		Select("reactions.user_uid, u.user_name, u.display_name, reactions.reaction, reactions.reacted_at").
+		Joins("LEFT JOIN auth_users u ON u.user_uid = reactions.user_uid AND u.deleted_at IS NULL").
+		Where("reactions.uid = ?", uid).
+		Order("reactions.reacted_at, reactions.user_uid").
+		Scan(&result).Error
+
+	for i := range result {
+		if !rnd.IsUID(result[i].UserUID, entity.UserUID) || result[i].UserName == "" {
+			result[i].UserUID = ""
+			result[i].UserName = ""
+			result[i].DisplayName = ""
+		}
+
+		result[i].Name = react.Emoji(result[i].Reaction).Name()
+	}
+
+	return result, err
+}
@@ -0,0 +1,37 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/react"
+)
+
+func TestReactions(t *testing.T) {
+	t.Run("Photo", func(t *testing.T) {
+		results, err := Reactions(entity.PhotoFixtures.Get("Photo01").PhotoUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 2)
+		assert.GreaterOrEqual(t, results.Counts()[react.Love.String()], 2)
+
+		for _, r := range results {
+			assert.NotEmpty(t, r.UserName)
+			assert.NotEmpty(t, r.Name)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		results, err := Reactions("pt9jtxrexxvl0xxx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
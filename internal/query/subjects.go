@@ -78,7 +78,7 @@ func CreateMarkerSubjects() (affected int64, err error) {
 
 	if err := Db().
 		Where("subj_uid = '' AND marker_name <> '' AND subj_src <> ?", entity.SrcAuto).
-		Where("marker_invalid = 0 AND marker_type = ?", entity.MarkerFace).
+		Where("marker_invalid = 0 AND marker_type IN (?)", []string{entity.MarkerFace, entity.MarkerPet}).
 		Order("marker_name").
 		Find(&markers).Error; err != nil {
 		return affected, err
@@ -92,7 +92,7 @@ func CreateMarkerSubjects() (affected int64, err error) {
 	for _, m := range markers {
 		if name == m.MarkerName && subj != nil {
 			// Do nothing.
-		} else if subj = entity.NewSubject(m.MarkerName, entity.SubjPerson, entity.SrcMarker); subj == nil {
+		} else if subj = entity.NewSubject(m.MarkerName, m.SubjType(), entity.SrcMarker); subj == nil {
 			log.Errorf("faces: invalid subject %s", clean.Log(m.MarkerName))
 			continue
 		} else if subj = entity.FirstOrCreateSubject(subj); subj == nil {
@@ -5,11 +5,12 @@ import (
 	"github.com/photoprism/photoprism/internal/form"
 )
 
-// AlbumPhotos returns up to count photos from an album.
+// AlbumPhotos returns up to count photos from an album in the album sort order.
 func AlbumPhotos(a entity.Album, count int, shared bool) (results PhotoResults, err error) {
 	frm := form.SearchPhotos{
 		Album:  a.AlbumUID,
 		Filter: a.AlbumFilter,
+		Order:  a.AlbumOrder,
 		Count:  count,
 		Offset: 0,
 	}
@@ -32,6 +32,7 @@ type Album struct {
 	AlbumPrivate     bool      `json:"Private"`
 	PhotoCount       int       `json:"PhotoCount"`
 	LinkCount        int       `json:"LinkCount"`
+	ReactionCount    int       `json:"ReactionCount"`
 	CreatedAt        time.Time `json:"CreatedAt"`
 	UpdatedAt        time.Time `json:"UpdatedAt"`
 	DeletedAt        time.Time `json:"DeletedAt,omitempty"`
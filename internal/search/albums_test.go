@@ -21,9 +21,50 @@ func TestAlbumPhotos(t *testing.T) {
 			t.Errorf("at least 2 results expected: %d", len(results))
 		}
 	})
+	t.Run("Shared", func(t *testing.T) {
+		album := entity.AlbumFixtures.Get("holiday-2030")
+		private := entity.PhotoFixtures.Get("Photo06")
+
+		if added := album.AddPhotos([]string{private.PhotoUID}); len(added) != 1 {
+			t.Fatal("failed adding private photo to album")
+		}
+
+		defer album.RemovePhotos([]string{private.PhotoUID})
+
+		results, err := AlbumPhotos(album, 1000, true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+
+		for _, p := range results {
+			assert.NotEqual(t, private.PhotoUID, p.PhotoUID)
+			assert.False(t, p.PhotoPrivate)
+		}
+
+		results, err = AlbumPhotos(album, 1000, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, results.UIDs(), private.PhotoUID)
+	})
 }
 
 func TestAlbums(t *testing.T) {
+	t.Run("ReactionCount", func(t *testing.T) {
+		query := form.NewAlbumSearch("chr")
+		result, err := Albums(query)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, result[0].ReactionCount)
+	})
 	t.Run("search with string", func(t *testing.T) {
 		query := form.NewAlbumSearch("chr")
 		result, err := Albums(query)
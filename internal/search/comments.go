@@ -0,0 +1,66 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Comments searches comments, e.g. for moderation, and returns them.
+func Comments(f form.SearchComments) (results entity.Comments, err error) {
+	if err = f.ParseQueryString(); err != nil {
+		return results, err
+	}
+
+	// Base query.
+	s := Db().Model(&entity.Comment{})
+
+	// Limit result count.
+	if f.Count > 0 && f.Count <= MaxResults {
+		s = s.Limit(f.Count).Offset(f.Offset)
+	} else {
+		s = s.Limit(MaxResults).Offset(f.Offset)
+	}
+
+	// Set sort order.
+	switch f.Order {
+	case "oldest":
+		s = s.Order("created_at, id")
+	default:
+		s = s.Order("created_at DESC, id DESC")
+	}
+
+	if f.UID != "" {
+		s = s.Where("comment_uid IN (?)", strings.Split(strings.ToLower(f.UID), txt.Or))
+	}
+
+	if f.Entity != "" {
+		s = s.Where("entity_uid IN (?)", strings.Split(strings.ToLower(f.Entity), txt.Or))
+	}
+
+	if f.User != "" {
+		s = s.Where("user_uid IN (?)", strings.Split(strings.ToLower(f.User), txt.Or))
+	}
+
+	if txt.Yes(f.Hidden) {
+		s = s.Where("comment_hidden = 1")
+	} else if txt.No(f.Hidden) {
+		s = s.Where("comment_hidden = 0")
+	}
+
+	if f.Query != "" {
+		for _, where := range LikeAllWords("comment_text", f.Query) {
+			s = s.Where("?", gorm.Expr(where))
+		}
+	}
+
+	if err = s.Find(&results).Error; err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
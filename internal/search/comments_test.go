@@ -0,0 +1,50 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestComments(t *testing.T) {
+	t.Run("Hidden", func(t *testing.T) {
+		results, err := Comments(form.SearchComments{Hidden: "yes", Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 1)
+
+		for _, r := range results {
+			assert.True(t, r.CommentHidden)
+		}
+	})
+	t.Run("Entity", func(t *testing.T) {
+		uid := entity.PhotoFixtures.Get("Photo01").PhotoUID
+
+		results, err := Comments(form.SearchComments{Entity: uid, Order: "oldest", Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 2)
+
+		for _, r := range results {
+			assert.Equal(t, uid, r.EntityUID)
+		}
+	})
+	t.Run("Query", func(t *testing.T) {
+		results, err := Comments(form.SearchComments{Query: "grandpa", Count: 10})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+	})
+}
@@ -2,6 +2,7 @@ package search
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -234,6 +235,50 @@ func AnyInt(col, numbers, sep string, min, max int) (where string) {
 	return strings.Join(wheres, " OR ")
 }
 
+// CompareInt returns a where condition that matches integers using comparisons like >=4, <2, =3,
+// or ranges like 2-4, ignoring numbers outside the min and max values.
+func CompareInt(col, s, sep string, min, max int) (where string) {
+	if s == "" {
+		return ""
+	}
+
+	if sep == "" {
+		sep = txt.Or
+	}
+
+	var wheres []string
+
+	for _, v := range strings.Split(s, sep) {
+		v = strings.TrimSpace(v)
+
+		op := "="
+
+		for _, o := range []string{">=", "<=", ">", "<", "="} {
+			if strings.HasPrefix(v, o) {
+				op, v = o, strings.TrimPrefix(v, o)
+				break
+			}
+		}
+
+		if r := strings.SplitN(v, "-", 2); len(r) == 2 && op == "=" && r[0] != "" {
+			a, errA := strconv.Atoi(r[0])
+			b, errB := strconv.Atoi(r[1])
+
+			if errA != nil || errB != nil || a > b || b < min || a > max {
+				continue
+			}
+
+			wheres = append(wheres, fmt.Sprintf("%s BETWEEN %d AND %d", col, a, b))
+		} else if i, err := strconv.Atoi(v); err != nil || i < min || i > max {
+			continue
+		} else {
+			wheres = append(wheres, fmt.Sprintf("%s %s %d", col, op, i))
+		}
+	}
+
+	return strings.Join(wheres, " OR ")
+}
+
 // OrLike returns a where condition and values for finding multiple terms combined with OR.
 func OrLike(col, s string) (where string, values []interface{}) {
 	if txt.Empty(col) || txt.Empty(s) {
@@ -262,6 +307,22 @@ func OrLike(col, s string) (where string, values []interface{}) {
 	return where, values
 }
 
+// OrLikeCols returns a where condition and values for finding multiple terms combined with OR in any of the columns.
+func OrLikeCols(cols Cols, s string) (where string, values []interface{}) {
+	var wheres []string
+
+	values = []interface{}{}
+
+	for _, col := range cols {
+		if w, v := OrLike(col, s); w != "" {
+			wheres = append(wheres, w)
+			values = append(values, v...)
+		}
+	}
+
+	return strings.Join(wheres, " OR "), values
+}
+
 // Split splits a search string into separate values and trims whitespace.
 func Split(s string, sep string) (result []string) {
 	if s == "" {
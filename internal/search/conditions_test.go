@@ -298,6 +298,27 @@ func TestAnySlug(t *testing.T) {
 	})
 }
 
+func TestCompareInt(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", CompareInt("photos.photo_rating", "", txt.Or, 0, 5))
+	})
+	t.Run("Equal", func(t *testing.T) {
+		assert.Equal(t, "photos.photo_rating = 4", CompareInt("photos.photo_rating", "4", txt.Or, 0, 5))
+		assert.Equal(t, "photos.photo_rating = 0", CompareInt("photos.photo_rating", "=0", txt.Or, 0, 5))
+	})
+	t.Run("Operators", func(t *testing.T) {
+		assert.Equal(t, "photos.photo_rating >= 4", CompareInt("photos.photo_rating", ">=4", txt.Or, 0, 5))
+		assert.Equal(t, "photos.photo_rating < 2 OR photos.photo_rating > 4", CompareInt("photos.photo_rating", "<2|>4", txt.Or, 0, 5))
+	})
+	t.Run("Range", func(t *testing.T) {
+		assert.Equal(t, "photos.photo_rating BETWEEN 2 AND 4", CompareInt("photos.photo_rating", "2-4", txt.Or, 0, 5))
+		assert.Equal(t, "", CompareInt("photos.photo_rating", "4-2", txt.Or, 0, 5))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, "", CompareInt("photos.photo_rating", "a|>=9|-1", txt.Or, 0, 5))
+	})
+}
+
 func TestAnyInt(t *testing.T) {
 	t.Run("Empty", func(t *testing.T) {
 		where := AnyInt("photos.photo_month", "", txt.Or, entity.UnknownMonth, txt.MonthMax)
@@ -358,6 +379,21 @@ func TestOrLike(t *testing.T) {
 	})
 }
 
+func TestOrLikeCols(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		where, values := OrLikeCols(Cols{"details.copyright", "details.license"}, "")
+
+		assert.Equal(t, "", where)
+		assert.Equal(t, []interface{}{}, values)
+	})
+	t.Run("TwoCols", func(t *testing.T) {
+		where, values := OrLikeCols(Cols{"details.copyright", "details.license"}, "*Editorial*|Press")
+
+		assert.Equal(t, "details.copyright LIKE ? OR details.copyright LIKE ? OR details.license LIKE ? OR details.license LIKE ?", where)
+		assert.Equal(t, []interface{}{"%Editorial%", "Press", "%Editorial%", "Press"}, values)
+	})
+}
+
 func TestSplit(t *testing.T) {
 	t.Run("Empty", func(t *testing.T) {
 		values := Split("", "")
@@ -3,7 +3,6 @@ package search
 import (
 	"strings"
 
-	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -51,27 +50,14 @@ func Labels(f form.SearchLabels) (results []Label, err error) {
 	}
 
 	if f.Query != "" {
-		var labelIds []uint
-		var categories []entity.Category
-		var label entity.Label
-
-		slugString := txt.Slug(f.Query)
 		likeString := "%" + f.Query + "%"
 
-		if result := Db().First(&label, "label_slug = ? OR custom_slug = ?", slugString, slugString); result.Error != nil {
+		if labelIds := LabelIDs(f.Query, txt.Or); len(labelIds) == 0 {
 			log.Infof("search: label %s not found", clean.Log(f.Query))
 
 			s = s.Where("labels.label_name LIKE ?", likeString)
 		} else {
-			labelIds = append(labelIds, label.ID)
-
-			Db().Where("category_id = ?", label.ID).Find(&categories)
-
-			for _, category := range categories {
-				labelIds = append(labelIds, category.LabelID)
-			}
-
-			log.Infof("search: label %s includes %d categories", clean.Log(label.LabelName), len(labelIds))
+			log.Infof("search: label %s includes %d labels", clean.Log(f.Query), len(labelIds))
 
 			s = s.Where("labels.id IN (?)", labelIds)
 		}
@@ -0,0 +1,58 @@
+package search
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// LabelIDs finds labels by slug or synonym and returns their IDs, including the IDs of all descendant labels.
+// The label slug columns can optionally be specified, the default is "label_slug" and "custom_slug".
+func LabelIDs(search, sep string, cols ...string) (ids []uint) {
+	if search == "" {
+		return ids
+	}
+
+	if len(cols) == 0 {
+		cols = []string{"label_slug", "custom_slug"}
+	}
+
+	var labels []entity.Label
+
+	stmt := Db()
+
+	for i, col := range cols {
+		if i == 0 {
+			stmt = stmt.Where(AnySlug(col, search, sep))
+		} else {
+			stmt = stmt.Or(AnySlug(col, search, sep))
+		}
+	}
+
+	if err := stmt.Find(&labels).Error; err != nil {
+		log.Errorf("search: %s (find labels)", err)
+	}
+
+	for _, l := range labels {
+		ids = append(ids, l.ID)
+	}
+
+	var synonyms []entity.LabelSynonym
+
+	if err := Db().Where(AnySlug("synonym_slug", search, sep)).Find(&synonyms).Error; err != nil {
+		log.Errorf("search: %s (find label synonyms)", err)
+	}
+
+	for _, s := range synonyms {
+		ids = append(ids, s.LabelID)
+	}
+
+	if len(ids) == 0 {
+		return ids
+	}
+
+	result := entity.LabelDescendantIDs(ids)
+
+	log.Debugf("search: label %s includes %d labels", txt.LogParamLower(search), len(result))
+
+	return result
+}
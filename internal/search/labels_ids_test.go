@@ -0,0 +1,57 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+func TestLabelIDs(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Len(t, LabelIDs("", txt.Or), 0)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Len(t, LabelIDs("label-not-found-123", txt.Or), 0)
+	})
+	t.Run("Descendants", func(t *testing.T) {
+		landscape := entity.LabelFixtures.Get("landscape")
+		flower := entity.LabelFixtures.Get("flower")
+
+		ids := LabelIDs("landscape", txt.Or)
+
+		assert.Contains(t, ids, landscape.ID)
+		assert.Contains(t, ids, flower.ID)
+	})
+	t.Run("Synonym", func(t *testing.T) {
+		flower := entity.LabelFixtures.Get("flower")
+
+		if _, err := flower.AddSynonym("Blossom"); err != nil {
+			t.Fatal(err)
+		}
+
+		ids := LabelIDs("blossom", txt.Or)
+
+		assert.Equal(t, []uint{flower.ID}, ids)
+
+		var f form.SearchPhotos
+
+		f.Label = "blossom"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, len(photos))
+
+		if err = flower.RemoveSynonym("Blossom"); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
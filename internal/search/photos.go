@@ -230,25 +230,12 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 	}
 
 	// Filter by label, label category and keywords.
-	var categories []entity.Category
-	var labels []entity.Label
 	var labelIds []uint
 	if txt.NotEmpty(f.Label) {
-		if err := Db().Where(AnySlug("label_slug", f.Label, txt.Or)).Or(AnySlug("custom_slug", f.Label, txt.Or)).Find(&labels).Error; len(labels) == 0 || err != nil {
+		if labelIds = LabelIDs(f.Label, txt.Or); len(labelIds) == 0 {
 			log.Debugf("search: label %s not found", txt.LogParamLower(f.Label))
 			return PhotoResults{}, 0, nil
 		} else {
-			for _, l := range labels {
-				labelIds = append(labelIds, l.ID)
-
-				Log("find categories", Db().Where("category_id = ?", l.ID).Find(&categories).Error)
-				log.Debugf("search: label %s includes %d categories", txt.LogParamLower(l.LabelName), len(categories))
-
-				for _, category := range categories {
-					labelIds = append(labelIds, category.LabelID)
-				}
-			}
-
 			s = s.Joins("JOIN photos_labels ON photos_labels.photo_id = files.photo_id AND photos_labels.uncertainty < 100 AND photos_labels.label_id IN (?)", labelIds).
 				Group("photos.id, files.id")
 		}
@@ -327,25 +314,13 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 			s = s.Where("files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
 		}
 	} else if f.Query != "" {
-		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
+		if labelIds = LabelIDs(f.Query, " ", "custom_slug"); len(labelIds) == 0 {
 			log.Debugf("search: label %s not found, using fuzzy search", txt.LogParamLower(f.Query))
 
 			for _, where := range LikeAnyKeyword("k.keyword", f.Query) {
 				s = s.Where("files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
 			}
 		} else {
-			for _, l := range labels {
-				labelIds = append(labelIds, l.ID)
-
-				Db().Where("category_id = ?", l.ID).Find(&categories)
-
-				log.Debugf("search: label %s includes %d categories", txt.LogParamLower(l.LabelName), len(categories))
-
-				for _, category := range categories {
-					labelIds = append(labelIds, category.LabelID)
-				}
-			}
-
 			if wheres := LikeAnyKeyword("k.keyword", f.Query); len(wheres) > 0 {
 				for _, where := range wheres {
 					s = s.Where("files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?)) OR "+
@@ -418,6 +393,22 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		}
 	}
 
+	// Filter for one or more pets.
+	if txt.Yes(f.Pet) {
+		s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ?)",
+			entity.Marker{}.TableName()), entity.MarkerPet)
+	} else if txt.NotEmpty(f.Pet) {
+		for _, pet := range SplitAnd(strings.ToLower(f.Pet)) {
+			if pets := SplitOr(pet); rnd.ContainsUID(pets, 'j') {
+				s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE subj_uid IN (?))",
+					entity.Marker{}.TableName()), entity.MarkerPet, pets)
+			} else {
+				s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 JOIN %s s ON s.subj_uid = m.subj_uid WHERE s.subj_type = ? AND (?))",
+					entity.Marker{}.TableName(), entity.Subject{}.TableName()), entity.SubjPet, gorm.Expr(AnySlug("s.subj_slug", pet, txt.Or)))
+			}
+		}
+	}
+
 	// Filter by status.
 	if f.Hidden {
 		s = s.Where("photos.photo_quality = -1")
@@ -457,6 +448,25 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		s = s.Where("lenses.lens_name LIKE ? OR lenses.lens_model LIKE ? OR lenses.lens_slug LIKE ?", v, v, v)
 	}
 
+	// Filter by artist or IPTC metadata such as usage terms and event.
+	if txt.NotEmpty(f.Artist) {
+		where, values := OrLike("details.artist", f.Artist)
+		s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM %s WHERE %s)",
+			entity.Details{}.TableName(), where), values...)
+	}
+
+	if txt.NotEmpty(f.Copyright) {
+		where, values := OrLikeCols(Cols{"details.copyright", "details.license", "details.credit_line"}, f.Copyright)
+		s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM %s WHERE %s)",
+			entity.Details{}.TableName(), where), values...)
+	}
+
+	if txt.NotEmpty(f.Event) {
+		where, values := OrLike("details.event", f.Event)
+		s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM %s WHERE %s)",
+			entity.Details{}.TableName(), where), values...)
+	}
+
 	// Filter by year.
 	if f.Year != "" {
 		s = s.Where(AnyInt("photos.photo_year", f.Year, txt.Or, entity.UnknownYear, txt.YearMax))
@@ -477,6 +487,16 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 		s = s.Where("files.file_main_color IN (?)", SplitOr(strings.ToLower(f.Color)))
 	}
 
+	// Filter by star rating.
+	if where := CompareInt("photos.photo_rating", f.Rating, txt.Or, 0, entity.RatingMax); where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by color label.
+	if f.ColorLabel != "" {
+		s = s.Where("photos.photo_color_label IN (?)", SplitOr(strings.ToLower(f.ColorLabel)))
+	}
+
 	// Find favorites only.
 	if f.Favorite {
 		s = s.Where("photos.photo_favorite = 1")
@@ -677,6 +697,9 @@ func searchPhotos(f form.SearchPhotos, sess *entity.Session, resultCols string)
 	// Log number of results.
 	log.Debugf("photos: found %s for %s [%s]", english.Plural(len(results), "result", "results"), f.SerializeAll(), time.Since(start))
 
+	// Add thumbnail cache keys of edited files.
+	results.SetThumbKeys()
+
 	// Merge files that belong to the same photo.
 	if f.Merged {
 		// Return merged files.
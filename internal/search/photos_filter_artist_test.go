@@ -0,0 +1,54 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterArtist(t *testing.T) {
+	t.Run("JensMander", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Artist = "Jens Mander"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+	})
+	t.Run("Wildcard", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "artist:\"Jens*|Hans\""
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 2)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Artist = "Jane Doe"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, len(photos))
+	})
+}
@@ -0,0 +1,54 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterCopyright(t *testing.T) {
+	t.Run("CreditLine", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Copyright = "*PhotoPrism"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+	})
+	t.Run("Copyright", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "copyright:\"Copyright 2020\""
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Copyright = "Editorial use only"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, len(photos))
+	})
+}
@@ -0,0 +1,54 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterEvent(t *testing.T) {
+	t.Run("Bridge*", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Event = "Bridge*"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Event = "Berlin Marathon|Bridge"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, len(photos))
+	})
+	t.Run("QueryEvent", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "event:\"Bridge Opening\""
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+	})
+}
@@ -0,0 +1,69 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterPet(t *testing.T) {
+	t.Run("Yes", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Pet = "yes"
+		f.Merged = true
+
+		_, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("PersonName", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Pet = "Actress A"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
+	t.Run("UID", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Pet = "jqu0xs11qekk9jx8"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
+}
+
+func TestPhotosQueryPet(t *testing.T) {
+	t.Run("Names", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "pet:\"Bello|Luna\""
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 0)
+	})
+}
@@ -0,0 +1,79 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosFilterRating(t *testing.T) {
+	t.Run("GreaterOrEqual", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Rating = ">=4"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.GreaterOrEqual(t, p.PhotoRating, int8(4))
+		}
+	})
+	t.Run("Range", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Rating = "1-3"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, p := range photos {
+			assert.GreaterOrEqual(t, p.PhotoRating, int8(1))
+			assert.LessOrEqual(t, p.PhotoRating, int8(3))
+		}
+	})
+	t.Run("QueryString", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "rating:>=4"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+	})
+	t.Run("ColorLabel", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.ColorLabel = "red|green"
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(photos), 1)
+
+		for _, p := range photos {
+			assert.Contains(t, []string{"red", "green"}, p.PhotoColorLabel)
+		}
+	})
+}
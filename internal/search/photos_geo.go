@@ -248,28 +248,13 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 
 	// Filter by label, label category, and keywords.
 	if f.Query != "" {
-		var categories []entity.Category
-		var labels []entity.Label
-		var labelIds []uint
-
-		if err := Db().Where(AnySlug("custom_slug", f.Query, " ")).Find(&labels).Error; len(labels) == 0 || err != nil {
+		if labelIds := LabelIDs(f.Query, " ", "custom_slug"); len(labelIds) == 0 {
 			log.Debugf("search: label %s not found, using fuzzy search", txt.LogParamLower(f.Query))
 
 			for _, where := range LikeAnyKeyword("k.keyword", f.Query) {
 				s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
 			}
 		} else {
-			for _, l := range labels {
-				labelIds = append(labelIds, l.ID)
-
-				Log("find categories", Db().Where("category_id = ?", l.ID).Find(&categories).Error)
-				log.Debugf("search: label %s includes %d categories", txt.LogParamLower(l.LabelName), len(categories))
-
-				for _, category := range categories {
-					labelIds = append(labelIds, category.LabelID)
-				}
-			}
-
 			if wheres := LikeAnyKeyword("k.keyword", f.Query); len(wheres) > 0 {
 				for _, where := range wheres {
 					s = s.Where("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?)) OR "+
@@ -342,6 +327,22 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 		}
 	}
 
+	// Filter for one or more pets.
+	if txt.Yes(f.Pet) {
+		s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ?)",
+			entity.Marker{}.TableName()), entity.MarkerPet)
+	} else if txt.NotEmpty(f.Pet) {
+		for _, pet := range SplitAnd(strings.ToLower(f.Pet)) {
+			if pets := SplitOr(pet); rnd.ContainsUID(pets, 'j') {
+				s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 AND m.marker_type = ? WHERE subj_uid IN (?))",
+					entity.Marker{}.TableName()), entity.MarkerPet, pets)
+			} else {
+				s = s.Where(fmt.Sprintf("photos.id IN (SELECT photo_id FROM files f JOIN %s m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 JOIN %s s ON s.subj_uid = m.subj_uid WHERE s.subj_type = ? AND (?))",
+					entity.Marker{}.TableName(), entity.Subject{}.TableName()), entity.SubjPet, gorm.Expr(AnySlug("s.subj_slug", pet, txt.Or)))
+			}
+		}
+	}
+
 	// Find photos in albums or not in an album, unless search results are limited to a scope.
 	if f.Scope == "" {
 		if f.Unsorted {
@@ -386,6 +387,16 @@ func UserPhotosGeo(f form.SearchPhotosGeo, sess *entity.Session) (results GeoRes
 		s = s.Where("files.file_main_color IN (?)", SplitOr(strings.ToLower(f.Color)))
 	}
 
+	// Filter by star rating.
+	if where := CompareInt("photos.photo_rating", f.Rating, txt.Or, 0, entity.RatingMax); where != "" {
+		s = s.Where(where)
+	}
+
+	// Filter by color label.
+	if f.ColorLabel != "" {
+		s = s.Where("photos.photo_color_label IN (?)", SplitOr(strings.ToLower(f.ColorLabel)))
+	}
+
 	// Find favorites only.
 	if f.Favorite {
 		s = s.Where("photos.photo_favorite = 1")
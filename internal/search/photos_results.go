@@ -34,7 +34,10 @@ type Photo struct {
 	PhotoCountry     string        `json:"Country" select:"photos.photo_country"`
 	PhotoStack       int8          `json:"Stack" select:"photos.photo_stack"`
 	PhotoFavorite    bool          `json:"Favorite" select:"photos.photo_favorite"`
+	PhotoRating      int8          `json:"Rating" select:"photos.photo_rating"`
+	PhotoColorLabel  string        `json:"ColorLabel" select:"photos.photo_color_label"`
 	PhotoPrivate     bool          `json:"Private" select:"photos.photo_private"`
+	PhotoReactions   int           `json:"Reactions,omitempty" select:"(SELECT COUNT(*) FROM reactions WHERE reactions.uid = photos.photo_uid) AS photo_reactions"`
 	PhotoIso         int           `json:"Iso" select:"photos.photo_iso"`
 	PhotoFocalLength int           `json:"FocalLength" select:"photos.photo_focal_length"`
 	PhotoFNumber     float32       `json:"FNumber" select:"photos.photo_f_number"`
@@ -52,8 +55,8 @@ type Photo struct {
 	CameraModel      string        `json:"CameraModel,omitempty" select:"cameras.camera_model"`
 	CameraMake       string        `json:"CameraMake,omitempty" select:"cameras.camera_make"`
 	LensID           uint          `json:"LensID" select:"photos.lens_id"` // Lens
-	LensModel        string        `json:"LensModel,omitempty" select:"lenses.lens_make"`
-	LensMake         string        `json:"LensMake,omitempty" select:"lenses.lens_model"`
+	LensModel        string        `json:"LensModel,omitempty" select:"lenses.lens_model"`
+	LensMake         string        `json:"LensMake,omitempty" select:"lenses.lens_make"`
 	PhotoAltitude    int           `json:"Altitude,omitempty" select:"photos.photo_altitude"`
 	PhotoLat         float32       `json:"Lat" select:"photos.photo_lat"`
 	PhotoLng         float32       `json:"Lng" select:"photos.photo_lng"`
@@ -71,6 +74,7 @@ type Photo struct {
 	FileRoot         string        `json:"FileRoot" select:"files.file_root"`
 	FileName         string        `json:"FileName" select:"files.file_name"`
 	FileHash         string        `json:"Hash" select:"files.file_hash"`
+	FileThumb        string        `json:"Thumb,omitempty" select:"-"`
 	FileWidth        int           `json:"Width" select:"files.file_width"`
 	FileHeight       int           `json:"Height" select:"files.file_height"`
 	FilePortrait     bool          `json:"Portrait" select:"files.file_portrait"`
@@ -145,6 +149,29 @@ func (photos PhotoResults) UIDs() []string {
 	return result
 }
 
+// SetThumbKeys sets the thumbnail cache keys of edited files, so that clients request updated thumbnails.
+func (photos PhotoResults) SetThumbKeys() {
+	hashes := make([]string, 0, len(photos))
+
+	for _, p := range photos {
+		if p.FileHash != "" {
+			hashes = append(hashes, p.FileHash)
+		}
+	}
+
+	keys := entity.FileEditKeys(hashes)
+
+	if len(keys) == 0 {
+		return
+	}
+
+	for i := range photos {
+		if key, ok := keys[photos[i].FileHash]; ok && key != photos[i].FileHash {
+			photos[i].FileThumb = key
+		}
+	}
+}
+
 // Merge consecutive file results that belong to the same photo.
 func (photos PhotoResults) Merge() (merged PhotoResults, count int, err error) {
 	count = len(photos)
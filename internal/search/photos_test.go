@@ -12,6 +12,20 @@ import (
 )
 
 func TestPhotos(t *testing.T) {
+	t.Run("Reactions", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.UID = "pt9jtdre2lvl0yh8"
+		frm.Count = 1
+
+		photos, _, err := Photos(frm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, photos, 1)
+		assert.GreaterOrEqual(t, photos[0].PhotoReactions, 2)
+	})
 	t.Run("OrderDuration", func(t *testing.T) {
 		var frm form.SearchPhotos
 
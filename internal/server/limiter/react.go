@@ -0,0 +1,13 @@
+package limiter
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const DefaultReactLimit = 30
+const DefaultReactInterval = 2 * time.Second
+
+// React limits reactions by link visitors without a user account (one every two seconds).
+var React = NewLimit(rate.Every(DefaultReactInterval), DefaultReactLimit)
@@ -0,0 +1,19 @@
+package limiter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReact(t *testing.T) {
+	clientIp := "192.0.2.43"
+
+	for i := 0; i < DefaultReactLimit; i++ {
+		assert.True(t, React.Allow(clientIp))
+	}
+
+	assert.False(t, React.Allow(clientIp))
+	assert.True(t, React.Reject(clientIp))
+	assert.False(t, React.Reject("192.0.2.44"))
+}
@@ -76,11 +76,23 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.StartIndexing(APIv1)
 	api.CancelIndexing(APIv1)
 
+	// Job Queue.
+	api.GetJobs(APIv1)
+	api.GetJobCounts(APIv1)
+	api.CreateJob(APIv1)
+	api.PauseJobs(APIv1)
+	api.ResumeJobs(APIv1)
+	api.CancelJob(APIv1)
+	api.RetryJob(APIv1)
+
 	// Photo Search and Organization.
 	api.SearchPhotos(APIv1)
+	api.SearchPhotosPdf(APIv1)
+	api.SearchPhotosExport(APIv1)
 	api.SearchGeo(APIv1)
 	api.GetPhoto(APIv1)
 	api.GetPhotoYaml(APIv1)
+	api.GetPhotoXmp(APIv1)
 	api.UpdatePhoto(APIv1)
 	api.GetPhotoDownload(APIv1)
 	// api.GetPhotoLinks(APIv1)
@@ -97,8 +109,15 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.GetFile(APIv1)
 	api.DeleteFile(APIv1)
 	api.ChangeFileOrientation(APIv1)
+	api.GetFileEdit(APIv1)
+	api.UpdateFileEdit(APIv1)
+	api.ResetFileEdit(APIv1)
+	api.GetFileEditXmp(APIv1)
 	api.UpdateMarker(APIv1)
 	api.ClearMarkerSubject(APIv1)
+	api.CreateMarker(APIv1)
+	api.DeleteMarker(APIv1)
+	api.GetMarkerSuggestions(APIv1)
 	api.PhotoPrimary(APIv1)
 	api.PhotoUnstack(APIv1)
 
@@ -110,7 +129,13 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.UpdateAlbum(APIv1)
 	api.DeleteAlbum(APIv1)
 	api.DownloadAlbum(APIv1)
+	api.AlbumPdf(APIv1)
 	api.GetAlbumLinks(APIv1)
+	api.GetAlbumComments(APIv1)
+	api.AddAlbumComment(APIv1)
+	api.GetAlbumReactions(APIv1)
+	api.ReactToAlbum(APIv1)
+	api.RemoveAlbumReaction(APIv1)
 	api.CreateAlbumLink(APIv1)
 	api.UpdateAlbumLink(APIv1)
 	api.DeleteAlbumLink(APIv1)
@@ -130,6 +155,14 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	// api.DeleteLabelLink(APIv1)
 	api.LikeLabel(APIv1)
 	api.DislikeLabel(APIv1)
+	api.GetLabelParents(APIv1)
+	api.AddLabelParent(APIv1)
+	api.RemoveLabelParent(APIv1)
+	api.GetLabelChildren(APIv1)
+	api.MergeLabels(APIv1)
+	api.GetLabelSynonyms(APIv1)
+	api.AddLabelSynonym(APIv1)
+	api.RemoveLabelSynonym(APIv1)
 
 	// Files and Folders.
 	api.SearchFoldersOriginals(APIv1)
@@ -142,17 +175,33 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.UpdateSubject(APIv1)
 	api.LikeSubject(APIv1)
 	api.DislikeSubject(APIv1)
+	api.GetSubjectSuggestions(APIv1)
 
 	// Faces.
 	api.SearchFaces(APIv1)
 	api.GetFace(APIv1)
 	api.UpdateFace(APIv1)
 
+	// Photo and Album Comments.
+	api.GetPhotoComments(APIv1)
+	api.AddPhotoComment(APIv1)
+	api.SearchComments(APIv1)
+	api.UpdateComment(APIv1)
+	api.DeleteComment(APIv1)
+	api.ModerateComment(APIv1)
+
+	// Photo Reactions.
+	api.GetPhotoReactions(APIv1)
+	api.ReactToPhoto(APIv1)
+	api.RemovePhotoReaction(APIv1)
+
 	// Batch Operations.
 	api.BatchPhotosApprove(APIv1)
 	api.BatchPhotosArchive(APIv1)
 	api.BatchPhotosRestore(APIv1)
 	api.BatchPhotosPrivate(APIv1)
+	api.BatchPhotosRating(APIv1)
+	api.BatchPhotosEdit(APIv1)
 	api.BatchPhotosDelete(APIv1)
 	api.BatchAlbumsDelete(APIv1)
 	api.BatchLabelsDelete(APIv1)
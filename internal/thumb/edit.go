@@ -0,0 +1,235 @@
+package thumb
+
+import (
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/disintegration/imaging"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Edit represents non-destructive image adjustments. They are applied after the image has been rotated
+// based on its Exif orientation, in the following order: straighten, crop, exposure, white balance,
+// contrast, and saturation.
+type Edit struct {
+	CropX       float64 // Left edge of the crop area relative to the image width, 0 to 1.
+	CropY       float64 // Top edge of the crop area relative to the image height, 0 to 1.
+	CropW       float64 // Width of the crop area relative to the image width, 0 to 1.
+	CropH       float64 // Height of the crop area relative to the image height, 0 to 1.
+	Angle       float64 // Straighten angle in degrees, -45 to 45.
+	Exposure    float64 // Exposure compensation in EV, -5 to 5.
+	Contrast    float64 // Contrast, -100 to 100.
+	Saturation  float64 // Saturation, -100 to 100.
+	Temperature float64 // White balance from cool to warm, -100 to 100.
+	Tint        float64 // White balance from green to magenta, -100 to 100.
+}
+
+// Cropped tests if a crop area smaller than the image is set.
+func (e Edit) Cropped() bool {
+	return e.CropW > 0 && e.CropH > 0 && (e.CropX > 0 || e.CropY > 0 || e.CropW < 1 || e.CropH < 1)
+}
+
+// Empty tests if no adjustments are set.
+func (e Edit) Empty() bool {
+	return !e.Cropped() && e.Angle == 0 && e.Exposure == 0 && e.Contrast == 0 &&
+		e.Saturation == 0 && e.Temperature == 0 && e.Tint == 0
+}
+
+// Checksum returns a short checksum of the adjustments.
+func (e Edit) Checksum() string {
+	s := fmt.Sprintf("%.4f,%.4f,%.4f,%.4f,%.2f,%.2f,%.2f,%.2f,%.2f,%.2f",
+		e.CropX, e.CropY, e.CropW, e.CropH, e.Angle, e.Exposure, e.Contrast, e.Saturation, e.Temperature, e.Tint)
+
+	return fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(s)))
+}
+
+// Key returns the thumbnail cache key for the file hash, so that edited images are cached separately.
+func (e Edit) Key(hash string) string {
+	if e.Empty() {
+		return hash
+	}
+
+	return hash + "e" + e.Checksum()
+}
+
+// ParseKey splits a thumbnail cache key into the file hash and the optional checksum of the adjustments.
+func ParseKey(key string) (hash, checksum string) {
+	// Checksums have 8 characters and are appended to the hash with an "e" as separator.
+	if n := len(key) - 9; n >= 40 && key[n] == 'e' {
+		return key[:n], key[n+1:]
+	}
+
+	return key, ""
+}
+
+// Apply returns the adjusted image.
+func (e Edit) Apply(img image.Image) image.Image {
+	if img == nil || e.Empty() {
+		return img
+	}
+
+	// Straighten and crop to the largest area without borders.
+	if e.Angle != 0 {
+		b := img.Bounds()
+		w, h := float64(b.Dx()), float64(b.Dy())
+		rad := math.Abs(e.Angle) * math.Pi / 180
+		sin, cos := math.Sin(rad), math.Cos(rad)
+		scale := math.Min(w/(w*cos+h*sin), h/(w*sin+h*cos))
+
+		img = imaging.Rotate(img, -e.Angle, color.Black)
+		img = imaging.CropCenter(img, int(w*scale), int(h*scale))
+	}
+
+	// Crop to the selected area.
+	if e.Cropped() {
+		b := img.Bounds()
+		w, h := float64(b.Dx()), float64(b.Dy())
+
+		area := image.Rect(
+			b.Min.X+int(math.Round(e.CropX*w)),
+			b.Min.Y+int(math.Round(e.CropY*h)),
+			b.Min.X+int(math.Round((e.CropX+e.CropW)*w)),
+			b.Min.Y+int(math.Round((e.CropY+e.CropH)*h)),
+		)
+
+		if area.Dx() > 0 && area.Dy() > 0 {
+			img = imaging.Crop(img, area)
+		}
+	}
+
+	// Adjust exposure and white balance.
+	if e.Exposure != 0 || e.Temperature != 0 || e.Tint != 0 {
+		gain := math.Pow(2, e.Exposure)
+		r := gain * (1 + 0.2*e.Temperature/100)
+		g := gain * (1 - 0.2*e.Tint/100)
+		b := gain * (1 - 0.2*e.Temperature/100)
+
+		img = imaging.AdjustFunc(img, func(c color.NRGBA) color.NRGBA {
+			return color.NRGBA{R: clampUint8(float64(c.R) * r), G: clampUint8(float64(c.G) * g), B: clampUint8(float64(c.B) * b), A: c.A}
+		})
+	}
+
+	if e.Contrast != 0 {
+		img = imaging.AdjustContrast(img, e.Contrast)
+	}
+
+	if e.Saturation != 0 {
+		img = imaging.AdjustSaturation(img, e.Saturation)
+	}
+
+	return img
+}
+
+// clampUint8 rounds and limits a color value to the range from 0 to 255.
+func clampUint8(v float64) uint8 {
+	if v <= 0 {
+		return 0
+	} else if v >= 255 {
+		return 255
+	}
+
+	return uint8(v + 0.5)
+}
+
+// FromEdit creates a thumbnail of the edited image if it was not found in the cache, and returns the filename.
+func FromEdit(imageFilename, hash, thumbPath string, width, height, orientation int, edit Edit, opts ...ResampleOption) (fileName string, err error) {
+	key := edit.Key(hash)
+
+	if fileName, err = FromCache(imageFilename, key, thumbPath, width, height, opts...); err == nil {
+		return fileName, err
+	} else if err != ErrNotCached {
+		return "", err
+	}
+
+	// Generate thumb cache filename.
+	if fileName, err = FileName(key, thumbPath, width, height, opts...); err != nil {
+		return "", err
+	}
+
+	// Load image from storage.
+	img, err := Open(imageFilename, orientation)
+
+	if err != nil {
+		log.Debugf("thumb: %s in %s", err, clean.Log(filepath.Base(imageFilename)))
+		return "", err
+	}
+
+	// Create thumb from edited image.
+	if _, err = Create(edit.Apply(img), fileName, width, height, opts...); err != nil {
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// EditedName returns the cache file name of the full-size edited image.
+func EditedName(hash, thumbPath string, edit Edit) (fileName string, err error) {
+	if len(hash) < 4 {
+		return "", fmt.Errorf("thumb: file hash is empty or too short (%s)", clean.Log(hash))
+	}
+
+	p := path.Join(thumbPath, hash[0:1], hash[1:2], hash[2:3])
+
+	if err = os.MkdirAll(p, fs.ModeDir); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/%s_edited.%s", p, edit.Key(hash), fs.ExtJPEG[1:]), nil
+}
+
+// Edited creates a full-size JPEG of the edited image if it was not found in the cache, and returns the filename.
+func Edited(imageFilename, hash, thumbPath string, orientation int, edit Edit) (fileName string, err error) {
+	if fileName, err = EditedName(hash, thumbPath, edit); err != nil {
+		return "", err
+	} else if fs.FileExists(fileName) {
+		return fileName, nil
+	}
+
+	img, err := Open(imageFilename, orientation)
+
+	if err != nil {
+		log.Debugf("thumb: %s in %s", err, clean.Log(filepath.Base(imageFilename)))
+		return "", err
+	}
+
+	if err = imaging.Save(edit.Apply(img), fileName, JpegQuality.EncodeOption()); err != nil {
+		log.Debugf("thumb: failed to save %s", clean.Log(filepath.Base(fileName)))
+		return "", err
+	}
+
+	return fileName, nil
+}
+
+// RemoveEdited deletes all cached images that were created from edits of the file with the specified hash.
+func RemoveEdited(hash, thumbPath string) (removed int, err error) {
+	if len(hash) < 4 {
+		return 0, fmt.Errorf("thumb: file hash is empty or too short (%s)", clean.Log(hash))
+	}
+
+	matches, err := filepath.Glob(path.Join(thumbPath, hash[0:1], hash[1:2], hash[2:3], hash+"e*"))
+
+	if err != nil {
+		return 0, err
+	}
+
+	for _, fileName := range matches {
+		if !strings.HasPrefix(filepath.Base(fileName), hash+"e") {
+			continue
+		} else if err = os.Remove(fileName); err != nil {
+			return removed, err
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
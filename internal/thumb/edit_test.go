@@ -0,0 +1,121 @@
+package thumb
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestEdit_Empty(t *testing.T) {
+	assert.True(t, Edit{}.Empty())
+	assert.True(t, Edit{CropW: 1, CropH: 1}.Empty())
+	assert.False(t, Edit{CropX: 0.1, CropW: 0.5, CropH: 0.5}.Empty())
+	assert.False(t, Edit{Exposure: 0.5}.Empty())
+}
+
+func TestEdit_Key(t *testing.T) {
+	hash := "ca3f9c0c5df21fd8a2e08c1fbe7bd2d8c97a0e5f"
+
+	assert.Equal(t, hash, Edit{}.Key(hash))
+	assert.True(t, strings.HasPrefix(Edit{Contrast: 10}.Key(hash), hash+"e"))
+	assert.Len(t, Edit{Contrast: 10}.Key(hash), len(hash)+9)
+	assert.NotEqual(t, Edit{Contrast: 10}.Key(hash), Edit{Contrast: 20}.Key(hash))
+}
+
+func TestParseKey(t *testing.T) {
+	hash := "ca3f9c0c5df21fd8a2e08c1fbe7bd2d8c97a0e5f"
+	edit := Edit{Contrast: 10}
+
+	t.Run("Hash", func(t *testing.T) {
+		fileHash, checksum := ParseKey(hash)
+		assert.Equal(t, hash, fileHash)
+		assert.Equal(t, "", checksum)
+	})
+	t.Run("Edited", func(t *testing.T) {
+		fileHash, checksum := ParseKey(edit.Key(hash))
+		assert.Equal(t, hash, fileHash)
+		assert.Equal(t, edit.Checksum(), checksum)
+	})
+	t.Run("Short", func(t *testing.T) {
+		fileHash, checksum := ParseKey("abce12345678")
+		assert.Equal(t, "abce12345678", fileHash)
+		assert.Equal(t, "", checksum)
+	})
+}
+
+func TestEdit_Apply(t *testing.T) {
+	img, err := Open("testdata/example.jpg", OrientationNormal)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := img.Bounds()
+
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, img, Edit{}.Apply(img))
+	})
+	t.Run("Crop", func(t *testing.T) {
+		result := Edit{CropX: 0.25, CropY: 0.25, CropW: 0.5, CropH: 0.5}.Apply(img)
+		assert.InDelta(t, b.Dx()/2, result.Bounds().Dx(), 1)
+		assert.InDelta(t, b.Dy()/2, result.Bounds().Dy(), 1)
+	})
+	t.Run("Straighten", func(t *testing.T) {
+		result := Edit{Angle: 5}.Apply(img)
+		assert.Less(t, result.Bounds().Dx(), b.Dx())
+		assert.Less(t, result.Bounds().Dy(), b.Dy())
+	})
+	t.Run("Adjust", func(t *testing.T) {
+		result := Edit{Exposure: 1, Contrast: 10, Saturation: -20, Temperature: 30, Tint: -10}.Apply(img)
+		assert.Equal(t, b.Size(), result.Bounds().Size())
+	})
+}
+
+func TestFromEdit(t *testing.T) {
+	thumbsPath := "testdata/cache"
+	hash := "1234567890abcdef1234567890abcdef12345678"
+
+	defer os.RemoveAll(thumbsPath)
+
+	edit := Edit{CropW: 0.5, CropH: 0.5, Exposure: 0.5}
+
+	fileName, err := Sizes[Tile224].FromEdit("testdata/example.jpg", hash, thumbsPath, OrientationNormal, edit)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, fs.FileExists(fileName))
+	assert.Contains(t, fileName, edit.Key(hash))
+
+	edited, err := Edited("testdata/example.jpg", hash, thumbsPath, OrientationNormal, edit)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, fs.FileExists(edited))
+
+	removed, err := RemoveEdited(hash, thumbsPath)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, removed)
+	assert.False(t, fs.FileExists(fileName))
+}
+
+func TestEdit_Xmp(t *testing.T) {
+	xmp := string(Edit{CropX: 0.1, CropY: 0.2, CropW: 0.5, CropH: 0.6, Angle: -2.5, Exposure: 0.7, Temperature: 20}.Xmp("doc-id"))
+
+	assert.Contains(t, xmp, `crs:HasCrop="True"`)
+	assert.Contains(t, xmp, `crs:CropLeft="0.100000"`)
+	assert.Contains(t, xmp, `crs:CropBottom="0.800000"`)
+	assert.Contains(t, xmp, `crs:CropAngle="-2.50"`)
+	assert.Contains(t, xmp, `crs:Exposure2012="+0.70"`)
+	assert.Contains(t, xmp, `crs:IncrementalTemperature="20"`)
+	assert.Contains(t, xmp, `xmpMM:DocumentID="doc-id"`)
+	assert.NotContains(t, Edit{Contrast: 5}.Xmp(""), `crs:CropLeft`)
+}
@@ -0,0 +1,59 @@
+package thumb
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+)
+
+// Xmp returns the adjustments as XMP sidecar data with Adobe Camera Raw settings, which can be imported
+// by darktable and other apps. Relative white balance and the crop area are used, so that the data
+// does not depend on the image size.
+func (e Edit) Xmp(documentID string) []byte {
+	var b bytes.Buffer
+
+	b.WriteString("<?xpacket begin=\"\ufeff\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n")
+	b.WriteString("<x:xmpmeta xmlns:x=\"adobe:ns:meta/\">\n")
+	b.WriteString(" <rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">\n")
+	b.WriteString("  <rdf:Description rdf:about=\"\"\n")
+	b.WriteString("   xmlns:xmpMM=\"http://ns.adobe.com/xap/1.0/mm/\"\n")
+	b.WriteString("   xmlns:crs=\"http://ns.adobe.com/camera-raw-settings/1.0/\"\n")
+
+	if documentID != "" {
+		fmt.Fprintf(&b, "   xmpMM:DocumentID=\"%s\"\n", html.EscapeString(documentID))
+	}
+
+	b.WriteString("   crs:Version=\"15.0\"\n")
+	b.WriteString("   crs:ProcessVersion=\"11.0\"\n")
+	b.WriteString("   crs:WhiteBalance=\"Custom\"\n")
+	fmt.Fprintf(&b, "   crs:IncrementalTemperature=\"%d\"\n", int(e.Temperature))
+	fmt.Fprintf(&b, "   crs:IncrementalTint=\"%d\"\n", int(e.Tint))
+	fmt.Fprintf(&b, "   crs:Exposure2012=\"%+.2f\"\n", e.Exposure)
+	fmt.Fprintf(&b, "   crs:Contrast2012=\"%+d\"\n", int(e.Contrast))
+	fmt.Fprintf(&b, "   crs:Saturation=\"%+d\"\n", int(e.Saturation))
+
+	if e.Cropped() || e.Angle != 0 {
+		left, top, right, bottom := 0.0, 0.0, 1.0, 1.0
+
+		if e.Cropped() {
+			left, top, right, bottom = e.CropX, e.CropY, e.CropX+e.CropW, e.CropY+e.CropH
+		}
+
+		b.WriteString("   crs:HasCrop=\"True\"\n")
+		fmt.Fprintf(&b, "   crs:CropTop=\"%.6f\"\n", top)
+		fmt.Fprintf(&b, "   crs:CropLeft=\"%.6f\"\n", left)
+		fmt.Fprintf(&b, "   crs:CropBottom=\"%.6f\"\n", bottom)
+		fmt.Fprintf(&b, "   crs:CropRight=\"%.6f\"\n", right)
+		fmt.Fprintf(&b, "   crs:CropAngle=\"%.2f\"\n", e.Angle)
+		b.WriteString("   crs:CropConstrainToWarp=\"0\"\n")
+	} else {
+		b.WriteString("   crs:HasCrop=\"False\"\n")
+	}
+
+	b.WriteString("   crs:HasSettings=\"True\"/>\n")
+	b.WriteString(" </rdf:RDF>\n")
+	b.WriteString("</x:xmpmeta>\n")
+	b.WriteString("<?xpacket end=\"w\"?>\n")
+
+	return b.Bytes()
+}
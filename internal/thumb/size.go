@@ -40,6 +40,11 @@ func (s Size) FromFile(fileName, fileHash, cachePath string, fileOrientation int
 	return FromFile(fileName, fileHash, cachePath, s.Width, s.Height, fileOrientation, s.Options...)
 }
 
+// FromEdit creates a thumbnail of the edited image with the matching size if it was not found in the cache, and returns the filename.
+func (s Size) FromEdit(fileName, fileHash, cachePath string, fileOrientation int, edit Edit) (string, error) {
+	return FromEdit(fileName, fileHash, cachePath, s.Width, s.Height, fileOrientation, edit, s.Options...)
+}
+
 // Create creates a thumbnail with the matching size and returns it as image.Image.
 func (s Size) Create(img image.Image, fileName string) (image.Image, error) {
 	return Create(img, fileName, s.Width, s.Height, s.Options...)
@@ -0,0 +1,79 @@
+package workers
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/backup"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+)
+
+// Backup represents a scheduled index backup worker.
+type Backup struct {
+	conf *config.Config
+}
+
+// NewBackup returns a new backup worker.
+func NewBackup(conf *config.Config) *Backup {
+	return &Backup{conf: conf}
+}
+
+// Due checks if a scheduled backup should be created, based on the time of the latest archive.
+func (w *Backup) Due() bool {
+	interval := w.conf.BackupInterval()
+
+	if interval <= 0 {
+		return false
+	}
+
+	entries, err := backup.List(w.conf.BackupArchivePath())
+
+	if err != nil {
+		log.Warnf("backup: %s", err)
+		return false
+	} else if latest := entries.Latest(); latest != nil && time.Since(latest.CreatedAt) < interval {
+		return false
+	}
+
+	return true
+}
+
+// Start creates a new index backup archive if one is due, and removes expired archives.
+func (w *Backup) Start() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("backup: %s (worker panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if !w.Due() {
+		return nil
+	}
+
+	if err = mutex.BackupWorker.Start(); err != nil {
+		return err
+	}
+
+	defer mutex.BackupWorker.Stop()
+
+	archivePath := w.conf.BackupArchivePath()
+
+	fileName, err := photoprism.BackupArchive(archivePath)
+
+	if err != nil {
+		return err
+	}
+
+	// Make sure the new archive can be restored before older archives are removed.
+	if _, err = backup.Verify(fileName); err != nil {
+		return fmt.Errorf("%s failed verification: %s", fileName, err)
+	}
+
+	_, err = photoprism.RotateBackups(archivePath)
+
+	return err
+}
@@ -0,0 +1,24 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestBackup_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewBackup(conf)
+
+	assert.IsType(t, &Backup{}, worker)
+
+	// Scheduled backups are disabled by default.
+	assert.False(t, worker.Due())
+
+	if err := worker.Start(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -27,16 +27,19 @@ package workers
 import (
 	"time"
 
+	"github.com/dustin/go-humanize/english"
+
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/get"
 	"github.com/photoprism/photoprism/internal/mutex"
 )
 
 var log = event.Log
 var stop = make(chan bool, 1)
 
-// Start runs the metadata, share & sync background workers at regular intervals.
+// Start runs the metadata, share, sync & backup background workers at regular intervals.
 func Start(conf *config.Config) {
 	interval := conf.WakeupInterval()
 
@@ -46,6 +49,13 @@ func Start(conf *config.Config) {
 		return
 	}
 
+	// Queue jobs again that were interrupted by a restart.
+	if n, err := entity.ResetRunningJobs(); err != nil {
+		log.Warnf("jobs: %s", err)
+	} else if n > 0 {
+		log.Infof("jobs: resuming %s", english.Plural(int(n), "interrupted job", "interrupted jobs"))
+	}
+
 	ticker := time.NewTicker(interval)
 
 	go func() {
@@ -57,11 +67,14 @@ func Start(conf *config.Config) {
 				mutex.MetaWorker.Cancel()
 				mutex.ShareWorker.Cancel()
 				mutex.SyncWorker.Cancel()
+				mutex.BackupWorker.Cancel()
 				return
 			case <-ticker.C:
 				RunMeta(conf)
 				RunShare(conf)
 				RunSync(conf)
+				RunBackup(conf)
+				RunJobs(conf)
 			}
 		}
 	}()
@@ -111,3 +124,30 @@ func RunSync(conf *config.Config) {
 		}()
 	}
 }
+
+// RunBackup runs the backup worker once.
+func RunBackup(conf *config.Config) {
+	if !mutex.BackupWorker.Running() {
+		go func() {
+			worker := NewBackup(conf)
+			if err := worker.Start(); err != nil {
+				log.Warnf("backup: %s", err)
+			}
+		}()
+	}
+}
+
+// RunJobs processes queued index, convert, and thumbnail jobs once and removes old finished jobs.
+func RunJobs(conf *config.Config) {
+	if !mutex.IndexWorkersRunning() {
+		go func() {
+			if _, err := get.Jobs().Start(); err != nil {
+				log.Warnf("jobs: %s", err)
+			}
+
+			if _, err := entity.DeleteFinishedJobs(time.Now().Add(-1 * entity.JobRetention)); err != nil {
+				log.Warnf("jobs: %s", err)
+			}
+		}()
+	}
+}
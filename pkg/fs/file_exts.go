@@ -152,6 +152,27 @@ func (m FileExtensions) Known(name string) bool {
 	return false
 }
 
+// RegisterExt adds a file extension for the specified type if it is not known yet,
+// and returns true if it was added, e.g. for formats supported by user-defined converters.
+func RegisterExt(ext string, t Type) bool {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+
+	if ext == "" || t == UnknownType {
+		return false
+	} else if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+
+	if _, ok := Extensions[ext]; ok {
+		return false
+	}
+
+	Extensions[ext] = t
+	FileTypes = Extensions.Types(ignoreCase)
+
+	return true
+}
+
 // Types returns known extensions by file type.
 func (m FileExtensions) Types(noUppercase bool) TypesExt {
 	result := make(TypesExt)
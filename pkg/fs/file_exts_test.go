@@ -29,3 +29,26 @@ func TestFileExtensions_Known(t *testing.T) {
 		assert.True(t, Extensions.Known("file.mp"))
 	})
 }
+
+func TestRegisterExt(t *testing.T) {
+	t.Run("New", func(t *testing.T) {
+		defer func() {
+			delete(Extensions, ".scx")
+			FileTypes = Extensions.Types(ignoreCase)
+		}()
+
+		assert.False(t, Extensions.Known("scan.scx"))
+		assert.True(t, RegisterExt("SCX", ImageRaw))
+		assert.True(t, Extensions.Known("scan.scx"))
+		assert.Equal(t, ImageRaw, Extensions[".scx"])
+		assert.Contains(t, FileTypes[ImageRaw], ".scx")
+	})
+	t.Run("Known", func(t *testing.T) {
+		assert.False(t, RegisterExt(".jpg", ImageRaw))
+		assert.Equal(t, ImageJPEG, Extensions[".jpg"])
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.False(t, RegisterExt("", ImageRaw))
+		assert.False(t, RegisterExt(".foo", UnknownType))
+	})
+}
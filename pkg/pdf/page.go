@@ -0,0 +1,79 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Page represents a document page. Coordinates are in points with the origin in the top left corner.
+type Page struct {
+	doc     *Document
+	content bytes.Buffer
+	images  map[string]int
+}
+
+// Width returns the page width in points.
+func (p *Page) Width() float64 {
+	return p.doc.size.Width
+}
+
+// Height returns the page height in points.
+func (p *Page) Height() float64 {
+	return p.doc.size.Height
+}
+
+// Image draws an image scaled to the specified rectangle.
+func (p *Page) Image(img *Image, x, y, w, h float64) {
+	if img == nil || w <= 0 || h <= 0 {
+		return
+	}
+
+	p.images[img.name] = img.obj
+
+	fmt.Fprintf(&p.content, "q %.2f 0 0 %.2f %.2f %.2f cm /%s Do Q\n", w, h, x, p.Height()-y-h, img.name)
+}
+
+// ImageFit draws an image centered in the specified rectangle, keeping its aspect ratio.
+func (p *Page) ImageFit(img *Image, x, y, w, h float64) (fx, fy, fw, fh float64) {
+	if img == nil || img.Width == 0 || img.Height == 0 {
+		return x, y, 0, 0
+	}
+
+	scale := w / float64(img.Width)
+
+	if s := h / float64(img.Height); s < scale {
+		scale = s
+	}
+
+	fw, fh = float64(img.Width)*scale, float64(img.Height)*scale
+	fx, fy = x+(w-fw)/2, y+(h-fh)/2
+
+	p.Image(img, fx, fy, fw, fh)
+
+	return fx, fy, fw, fh
+}
+
+// Text draws a single line of text with its baseline at y.
+func (p *Page) Text(x, y, size float64, bold bool, s string) {
+	if s == "" {
+		return
+	}
+
+	font := "F1"
+
+	if bold {
+		font = "F2"
+	}
+
+	fmt.Fprintf(&p.content, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, p.Height()-y, escape(encode(s)))
+}
+
+// TextCenter draws a single line of text horizontally centered in the specified width.
+func (p *Page) TextCenter(x, y, w, size float64, bold bool, s string) {
+	p.Text(x+(w-TextWidth(s, size, bold))/2, y, size, bold, s)
+}
+
+// Rect draws the outline of a rectangle with the specified gray level from 0 (black) to 1 (white).
+func (p *Page) Rect(x, y, w, h, gray float64) {
+	fmt.Fprintf(&p.content, "q %.2f G 0.5 w %.2f %.2f %.2f %.2f re S Q\n", gray, x, p.Height()-y-h, w, h)
+}
@@ -0,0 +1,269 @@
+/*
+Package pdf provides a minimal PDF writer for printable photo layouts with JPEG images and text.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	"io"
+	"strings"
+	"time"
+)
+
+// Reserved object numbers.
+const (
+	catalogObj  = 1
+	pagesObj    = 2
+	fontObj     = 3
+	fontBoldObj = 4
+	firstObj    = 5
+)
+
+// Document represents a PDF document that is written to the underlying writer page by page,
+// so that images do not need to be kept in memory.
+type Document struct {
+	w       io.Writer
+	size    PageSize
+	title   string
+	offset  int64
+	objects map[int]int64
+	next    int
+	pages   []int
+	page    *Page
+	images  int
+	err     error
+	closed  bool
+}
+
+// Image represents a JPEG image that has been written to the document.
+type Image struct {
+	obj    int
+	name   string
+	Width  int
+	Height int
+}
+
+// New creates a new document with the specified page size and writes the file header.
+func New(w io.Writer, size PageSize, title string) *Document {
+	doc := &Document{
+		w:       w,
+		size:    size,
+		title:   title,
+		objects: make(map[int]int64),
+		next:    firstObj,
+	}
+
+	doc.write("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	return doc
+}
+
+// Size returns the page size.
+func (doc *Document) Size() PageSize {
+	return doc.size
+}
+
+// Pages returns the number of pages added so far.
+func (doc *Document) Pages() int {
+	if doc.page != nil {
+		return len(doc.pages) + 1
+	}
+
+	return len(doc.pages)
+}
+
+// Err returns the first error that occurred while writing, if any.
+func (doc *Document) Err() error {
+	return doc.err
+}
+
+// write writes a string to the underlying writer and keeps track of the offset.
+func (doc *Document) write(s string) {
+	doc.writeBytes([]byte(s))
+}
+
+// writeBytes writes data to the underlying writer and keeps track of the offset.
+func (doc *Document) writeBytes(b []byte) {
+	if doc.err != nil {
+		return
+	}
+
+	n, err := doc.w.Write(b)
+	doc.offset += int64(n)
+	doc.err = err
+}
+
+// beginObj starts a new indirect object with the specified number.
+func (doc *Document) beginObj(obj int) {
+	doc.objects[obj] = doc.offset
+	doc.write(fmt.Sprintf("%d 0 obj\n", obj))
+}
+
+// newObj reserves and returns the next object number.
+func (doc *Document) newObj() int {
+	obj := doc.next
+	doc.next++
+	return obj
+}
+
+// writeStream writes a stream object with the dictionary entries and data passed.
+func (doc *Document) writeStream(obj int, dict string, data []byte) {
+	doc.beginObj(obj)
+	doc.write(fmt.Sprintf("<< %s /Length %d >>\nstream\n", dict, len(data)))
+	doc.writeBytes(data)
+	doc.write("\nendstream\nendobj\n")
+}
+
+// Image adds a JPEG image to the document, so that it can be drawn on the current or following pages.
+func (doc *Document) Image(data []byte) (*Image, error) {
+	if doc.closed {
+		return nil, errors.New("pdf: document closed")
+	}
+
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+
+	if err != nil {
+		return nil, err
+	} else if format != "jpeg" {
+		return nil, fmt.Errorf("pdf: unsupported image format %s", format)
+	}
+
+	var colorSpace string
+
+	switch cfg.ColorModel {
+	case color.GrayModel:
+		colorSpace = "/DeviceGray"
+	case color.CMYKModel:
+		colorSpace = "/DeviceCMYK /Decode [1 0 1 0 1 0 1 0]"
+	default:
+		colorSpace = "/DeviceRGB"
+	}
+
+	doc.images++
+
+	img := &Image{
+		obj:    doc.newObj(),
+		name:   fmt.Sprintf("Im%d", doc.images),
+		Width:  cfg.Width,
+		Height: cfg.Height,
+	}
+
+	doc.writeStream(img.obj, fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace %s /BitsPerComponent 8 /Filter /DCTDecode",
+		cfg.Width, cfg.Height, colorSpace), data)
+
+	return img, doc.err
+}
+
+// AddPage finishes the current page, if any, and starts a new one.
+func (doc *Document) AddPage() *Page {
+	doc.flushPage()
+
+	doc.page = &Page{doc: doc, images: make(map[string]int)}
+
+	return doc.page
+}
+
+// flushPage writes the current page to the document.
+func (doc *Document) flushPage() {
+	if doc.page == nil {
+		return
+	}
+
+	p := doc.page
+	doc.page = nil
+
+	contentObj := doc.newObj()
+	pageObj := doc.newObj()
+
+	doc.writeStream(contentObj, "", p.content.Bytes())
+
+	var xObjects strings.Builder
+
+	for name, obj := range p.images {
+		xObjects.WriteString(fmt.Sprintf(" /%s %d 0 R", name, obj))
+	}
+
+	doc.beginObj(pageObj)
+	doc.write(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.2f %.2f] /Contents %d 0 R /Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> /XObject <<%s >> >> >>\nendobj\n",
+		pagesObj, doc.size.Width, doc.size.Height, contentObj, fontObj, fontBoldObj, xObjects.String()))
+
+	doc.pages = append(doc.pages, pageObj)
+}
+
+// Close finishes the last page and writes the document catalog, cross-reference table, and trailer.
+func (doc *Document) Close() error {
+	if doc.closed {
+		return doc.err
+	}
+
+	doc.flushPage()
+	doc.closed = true
+
+	// Add an empty page, as documents without pages are invalid.
+	if len(doc.pages) == 0 {
+		doc.AddPage()
+		doc.flushPage()
+	}
+
+	doc.beginObj(fontObj)
+	doc.write("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>\nendobj\n")
+
+	doc.beginObj(fontBoldObj)
+	doc.write("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>\nendobj\n")
+
+	kids := make([]string, len(doc.pages))
+
+	for i, obj := range doc.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", obj)
+	}
+
+	doc.beginObj(pagesObj)
+	doc.write(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", strings.Join(kids, " "), len(doc.pages)))
+
+	doc.beginObj(catalogObj)
+	doc.write(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>\nendobj\n", pagesObj))
+
+	infoObj := doc.newObj()
+	doc.beginObj(infoObj)
+	doc.write(fmt.Sprintf("<< /Title (%s) /Producer (PhotoPrism) /CreationDate (D:%s) >>\nendobj\n",
+		escape(encode(doc.title)), time.Now().UTC().Format("20060102150405Z")))
+
+	// Write cross-reference table.
+	xref := doc.offset
+
+	doc.write(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", doc.next))
+
+	for obj := 1; obj < doc.next; obj++ {
+		doc.write(fmt.Sprintf("%010d 00000 n \n", doc.objects[obj]))
+	}
+
+	doc.write(fmt.Sprintf("trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", doc.next, catalogObj, infoObj, xref))
+
+	return doc.err
+}
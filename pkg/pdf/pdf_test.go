@@ -0,0 +1,109 @@
+package pdf
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testJpeg(t *testing.T, w, h int) []byte {
+	buf := &bytes.Buffer{}
+
+	if err := jpeg.Encode(buf, image.NewRGBA(image.Rect(0, 0, w, h)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestDocument(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		doc := New(buf, A4, "Empty")
+
+		assert.NoError(t, doc.Close())
+		assert.Equal(t, 1, len(doc.pages))
+
+		s := buf.String()
+
+		assert.True(t, strings.HasPrefix(s, "%PDF-1.4\n"))
+		assert.True(t, strings.HasSuffix(s, "%%EOF\n"))
+		assert.Contains(t, s, "/Count 1")
+	})
+	t.Run("Pages", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		doc := New(buf, Letter.Landscape(), "Family (2023)")
+
+		img, err := doc.Image(testJpeg(t, 40, 30))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 40, img.Width)
+		assert.Equal(t, 30, img.Height)
+
+		p := doc.AddPage()
+		assert.Equal(t, 792.0, p.Width())
+		assert.Equal(t, 612.0, p.Height())
+
+		x, y, w, h := p.ImageFit(img, 0, 0, 100, 100)
+		assert.Equal(t, 0.0, x)
+		assert.Equal(t, 12.5, y)
+		assert.Equal(t, 100.0, w)
+		assert.Equal(t, 75.0, h)
+
+		p.Text(10, 20, 12, true, "Grüße")
+		doc.AddPage()
+
+		assert.Equal(t, 2, doc.Pages())
+		assert.NoError(t, doc.Close())
+
+		s := buf.String()
+
+		assert.Contains(t, s, "/Count 2")
+		assert.Contains(t, s, "/Im1 Do")
+		assert.Contains(t, s, "/Title (Family \\(2023\\))")
+		assert.Contains(t, s, "(Gr\xfc\xdfe) Tj")
+	})
+	t.Run("UnsupportedImage", func(t *testing.T) {
+		doc := New(&bytes.Buffer{}, A4, "")
+		_, err := doc.Image([]byte("foo"))
+		assert.Error(t, err)
+	})
+}
+
+func TestFindPageSize(t *testing.T) {
+	s, ok := FindPageSize("Letter")
+	assert.True(t, ok)
+	assert.Equal(t, Letter, s)
+
+	s, ok = FindPageSize("foo")
+	assert.False(t, ok)
+	assert.Equal(t, A4, s)
+
+	assert.Equal(t, A4, A4.Landscape().Portrait())
+}
+
+func TestTextWidth(t *testing.T) {
+	assert.Equal(t, 0.0, TextWidth("", 10, false))
+	assert.InDelta(t, 5.56, TextWidth("a", 10, false), 0.001)
+	assert.InDelta(t, 6.11, TextWidth("b", 10, true), 0.001)
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "Hello", Truncate("Hello", 10, false, 100))
+	assert.Equal(t, "Hello W…", Truncate("Hello World", 10, false, 45))
+	assert.Equal(t, "", Truncate("Hello", 10, false, 1))
+}
+
+func TestWrap(t *testing.T) {
+	assert.Empty(t, Wrap("", 10, false, 100, 2))
+	assert.Equal(t, []string{"Hello World"}, Wrap("Hello World", 10, false, 100, 2))
+	assert.Equal(t, []string{"Hello", "World"}, Wrap("Hello World", 10, false, 40, 2))
+
+	lines := Wrap("The quick brown fox jumps over the lazy dog", 10, false, 60, 2)
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasSuffix(lines[1], "…"))
+}
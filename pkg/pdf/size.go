@@ -0,0 +1,57 @@
+package pdf
+
+import (
+	"strings"
+)
+
+// PageSize represents a page size in points, where one point is 1/72 inch.
+type PageSize struct {
+	Name   string
+	Width  float64
+	Height float64
+}
+
+// Landscape returns the page size in landscape orientation.
+func (s PageSize) Landscape() PageSize {
+	if s.Width < s.Height {
+		s.Width, s.Height = s.Height, s.Width
+	}
+
+	return s
+}
+
+// Portrait returns the page size in portrait orientation.
+func (s PageSize) Portrait() PageSize {
+	if s.Width > s.Height {
+		s.Width, s.Height = s.Height, s.Width
+	}
+
+	return s
+}
+
+// Standard page sizes.
+var (
+	A3     = PageSize{Name: "a3", Width: 841.89, Height: 1190.55}
+	A4     = PageSize{Name: "a4", Width: 595.28, Height: 841.89}
+	A5     = PageSize{Name: "a5", Width: 419.53, Height: 595.28}
+	Letter = PageSize{Name: "letter", Width: 612, Height: 792}
+	Legal  = PageSize{Name: "legal", Width: 612, Height: 1008}
+)
+
+// PageSizes maps page size names to page sizes.
+var PageSizes = map[string]PageSize{
+	A3.Name:     A3,
+	A4.Name:     A4,
+	A5.Name:     A5,
+	Letter.Name: Letter,
+	Legal.Name:  Legal,
+}
+
+// FindPageSize returns the page size with the specified name, or A4 if the name is unknown.
+func FindPageSize(name string) (PageSize, bool) {
+	if s, ok := PageSizes[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return s, true
+	}
+
+	return A4, false
+}
@@ -0,0 +1,124 @@
+package pdf
+
+import (
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// helvetica contains the widths of the printable ASCII characters in the standard Helvetica font.
+var helvetica = [95]float64{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// helveticaBold contains the widths of the printable ASCII characters in the standard Helvetica-Bold font.
+var helveticaBold = [95]float64{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// encode converts a UTF-8 string to the WinAnsi encoding used by the standard fonts,
+// replacing unsupported characters with a question mark.
+func encode(s string) string {
+	var b strings.Builder
+
+	for _, r := range s {
+		if r == '\n' || r == '\r' || r == '\t' {
+			b.WriteByte(' ')
+		} else if c, ok := charmap.Windows1252.EncodeRune(r); ok && c >= 0x20 {
+			b.WriteByte(c)
+		} else {
+			b.WriteByte('?')
+		}
+	}
+
+	return b.String()
+}
+
+// escape escapes parentheses and backslashes in PDF string literals.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`).Replace(s)
+}
+
+// charWidth returns the width of a WinAnsi encoded character in 1/1000 of the font size.
+func charWidth(c byte, bold bool) float64 {
+	switch {
+	case c < 32:
+		return 0
+	case c > 126:
+		// Approximate the width of non-ASCII characters, most of them being accented letters.
+		return 556
+	case bold:
+		return helveticaBold[c-32]
+	default:
+		return helvetica[c-32]
+	}
+}
+
+// TextWidth returns the width of a single line of text in points.
+func TextWidth(s string, size float64, bold bool) (w float64) {
+	enc := encode(s)
+
+	for i := 0; i < len(enc); i++ {
+		w += charWidth(enc[i], bold)
+	}
+
+	return w * size / 1000
+}
+
+// Truncate shortens a single line of text so that it fits in the specified width, adding an ellipsis if needed.
+func Truncate(s string, size float64, bold bool, width float64) string {
+	s = strings.TrimSpace(s)
+
+	if TextWidth(s, size, bold) <= width {
+		return s
+	}
+
+	runes := []rune(s)
+
+	for n := len(runes) - 1; n > 0; n-- {
+		if t := strings.TrimSpace(string(runes[:n])) + "…"; TextWidth(t, size, bold) <= width {
+			return t
+		}
+	}
+
+	return ""
+}
+
+// Wrap splits text into lines that fit in the specified width, returning no more than max lines.
+// The last line is truncated with an ellipsis if the text does not fit.
+func Wrap(s string, size float64, bold bool, width float64, max int) (lines []string) {
+	words := strings.Fields(s)
+
+	if len(words) == 0 || max < 1 {
+		return lines
+	}
+
+	line := ""
+
+	for i, word := range words {
+		if line == "" {
+			line = word
+		} else if next := line + " " + word; TextWidth(next, size, bold) <= width {
+			line = next
+		} else {
+			if len(lines) == max-1 {
+				return append(lines, Truncate(strings.Join(append([]string{line}, words[i:]...), " "), size, bold, width))
+			}
+
+			lines = append(lines, Truncate(line, size, bold, width))
+			line = word
+		}
+	}
+
+	return append(lines, Truncate(line, size, bold, width))
+}
@@ -0,0 +1,114 @@
+package raw
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// Canon CR3 box UUIDs.
+var (
+	uuidCanonMoov    = []byte{0x85, 0xc0, 0xb6, 0x87, 0x82, 0x0f, 0x11, 0xe0, 0x81, 0x11, 0xf4, 0xce, 0x46, 0x2b, 0x6a, 0x48}
+	uuidCanonPreview = []byte{0xea, 0xf4, 0x2b, 0x5e, 0x1c, 0x98, 0x4b, 0x88, 0xb9, 0xfb, 0xb7, 0xdc, 0x40, 0x6e, 0x4d, 0x16}
+)
+
+const bmffMaxDepth = 8
+
+// isBmff tests if data starts with an ISO base media file format header, as used by Canon CR3 files.
+func isBmff(data []byte) bool {
+	return len(data) >= 12 && string(data[4:8]) == "ftyp"
+}
+
+// bmff keeps track of the embedded images found in ISO base media file format data.
+type bmff struct {
+	data        []byte
+	segments    []segment
+	orientation int
+	chunk       int
+	sample      int
+}
+
+// bmffPreviews returns the embedded images in ISO base media file format data, and the image orientation.
+func bmffPreviews(data []byte) ([]segment, int) {
+	b := &bmff{data: data}
+	b.boxes(0, len(data), 0)
+
+	return b.segments, b.orientation
+}
+
+// boxes parses the boxes in the specified range.
+func (b *bmff) boxes(start, end, depth int) {
+	if depth > bmffMaxDepth {
+		return
+	}
+
+	for offset := start; offset+8 <= end; {
+		size := int(binary.BigEndian.Uint32(b.data[offset:]))
+		boxType := string(b.data[offset+4 : offset+8])
+		header := 8
+
+		if size == 1 {
+			if offset+16 > end {
+				return
+			}
+
+			size = int(binary.BigEndian.Uint64(b.data[offset+8:]))
+			header = 16
+		} else if size == 0 {
+			size = end - offset
+		}
+
+		if size < header || size > end-offset {
+			return
+		}
+
+		payload, boxEnd := offset+header, offset+size
+
+		switch boxType {
+		case "moov", "mdia", "minf", "stbl":
+			b.boxes(payload, boxEnd, depth+1)
+		case "trak":
+			// The first sample of each track may contain a JPEG image, e.g. the full-size preview in CR3 files.
+			b.chunk, b.sample = 0, 0
+			b.boxes(payload, boxEnd, depth+1)
+
+			if b.chunk > 0 && b.sample > 0 {
+				b.segments = append(b.segments, segment{offset: b.chunk, length: b.sample})
+			}
+		case "uuid":
+			if payload+16 > boxEnd {
+				break
+			} else if uuid := b.data[payload : payload+16]; bytes.Equal(uuid, uuidCanonMoov) {
+				b.boxes(payload+16, boxEnd, depth+1)
+			} else if bytes.Equal(uuid, uuidCanonPreview) {
+				b.boxes(payload+24, boxEnd, depth+1)
+			}
+		case "PRVW", "THMB":
+			// Find the start of the JPEG image after the preview header.
+			if i := bytes.Index(b.data[payload:boxEnd], []byte{0xFF, 0xD8, 0xFF}); i >= 0 {
+				b.segments = append(b.segments, segment{offset: payload + i, length: boxEnd - payload - i})
+			}
+		case "CMT1":
+			// Contains the TIFF IFD0 of the image.
+			if o := tiffOrientation(b.data[payload:boxEnd]); o > 0 {
+				b.orientation = o
+			}
+		case "stsz":
+			// Full box header, default sample size, sample count, and the size of the first sample.
+			if payload+16 <= boxEnd {
+				if b.sample = int(binary.BigEndian.Uint32(b.data[payload+4:])); b.sample == 0 {
+					b.sample = int(binary.BigEndian.Uint32(b.data[payload+12:]))
+				}
+			}
+		case "stco":
+			if payload+12 <= boxEnd {
+				b.chunk = int(binary.BigEndian.Uint32(b.data[payload+8:]))
+			}
+		case "co64":
+			if payload+16 <= boxEnd {
+				b.chunk = int(binary.BigEndian.Uint64(b.data[payload+8:]))
+			}
+		}
+
+		offset = boxEnd
+	}
+}
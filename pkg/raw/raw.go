@@ -0,0 +1,231 @@
+/*
+Package raw extracts embedded JPEG previews from RAW image files without external tools.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package raw
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/jpeg"
+	"os"
+)
+
+// MinSize is the minimum width or height in pixels of a preview that is considered large enough,
+// smaller previews cause the whole file to be searched for a larger embedded image.
+var MinSize = 1280
+
+// MaxFileSize is the maximum size of files that are searched for embedded previews.
+var MaxFileSize int64 = 512 * 1024 * 1024
+
+// ErrNotFound is returned if a file does not contain an embedded JPEG preview.
+var ErrNotFound = errors.New("no embedded preview found")
+
+// Preview represents an embedded JPEG preview image.
+type Preview struct {
+	Data        []byte
+	Width       int
+	Height      int
+	Orientation int
+}
+
+// Size returns the longest side of the preview in pixels.
+func (p *Preview) Size() int {
+	if p.Width > p.Height {
+		return p.Width
+	}
+
+	return p.Height
+}
+
+// segment represents the position of embedded image data.
+type segment struct {
+	offset int
+	length int
+}
+
+// Extract returns the largest embedded JPEG preview of a RAW file, e.g. in CR2, CR3, NEF, ARW, DNG, ORF, RW2, or PEF format.
+func Extract(fileName string) (*Preview, error) {
+	info, err := os.Stat(fileName)
+
+	if err != nil {
+		return nil, err
+	} else if info.Size() > MaxFileSize {
+		return nil, fmt.Errorf("file size exceeds %d bytes", MaxFileSize)
+	}
+
+	data, err := os.ReadFile(fileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return FromBytes(data)
+}
+
+// FromBytes returns the largest embedded JPEG preview of RAW file data.
+func FromBytes(data []byte) (*Preview, error) {
+	var segments []segment
+	var orientation int
+
+	if order, ok := tiffOrder(data); ok {
+		segments, orientation = tiffPreviews(data, order)
+	} else if isBmff(data) {
+		segments, orientation = bmffPreviews(data)
+	} else {
+		return nil, fmt.Errorf("unsupported file format")
+	}
+
+	result := largest(data, segments)
+
+	// Search the whole file, e.g. for previews in maker notes.
+	if result == nil || result.Size() < MinSize {
+		if found := largest(data, scan(data)); found != nil && (result == nil || found.Size() > result.Size()) {
+			result = found
+		}
+	}
+
+	if result == nil {
+		return nil, ErrNotFound
+	}
+
+	if orientation >= 1 && orientation <= 8 {
+		result.Orientation = orientation
+	} else {
+		result.Orientation = 1
+	}
+
+	return result, nil
+}
+
+// largest returns the largest valid JPEG image in the specified segments.
+func largest(data []byte, segments []segment) (result *Preview) {
+	for _, s := range segments {
+		if s.offset <= 0 || s.offset >= len(data) || s.length < 0 {
+			continue
+		}
+
+		b := data[s.offset:]
+
+		if s.length > 0 && s.length < len(b) {
+			b = b[:s.length]
+		}
+
+		// Embedded images must start with a JPEG start of image marker.
+		if len(b) < 4 || b[0] != 0xFF || b[1] != 0xD8 {
+			continue
+		}
+
+		// Remove padding after the end of image marker, if any.
+		if n := jpegLength(b); n > 0 {
+			b = b[:n]
+		}
+
+		// Lossless JPEG as used for RAW image data is not supported and will be skipped.
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(b))
+
+		if err != nil || cfg.Width <= 0 || cfg.Height <= 0 {
+			continue
+		}
+
+		if result == nil || cfg.Width*cfg.Height > result.Width*result.Height {
+			result = &Preview{Data: b, Width: cfg.Width, Height: cfg.Height}
+		}
+	}
+
+	return result
+}
+
+// scan finds the positions of embedded JPEG images by searching for start of image markers.
+func scan(data []byte) (segments []segment) {
+	soi := []byte{0xFF, 0xD8, 0xFF}
+
+	for i := 1; i < len(data); {
+		n := bytes.Index(data[i:], soi)
+
+		if n < 0 {
+			break
+		}
+
+		i += n
+
+		if length := jpegLength(data[i:]); length > 0 {
+			segments = append(segments, segment{offset: i, length: length})
+			i += length
+		} else {
+			i += len(soi)
+		}
+	}
+
+	return segments
+}
+
+// jpegLength returns the length of the JPEG image at the beginning of b including the end of image marker,
+// or 0 if the data is not a valid JPEG image.
+func jpegLength(b []byte) int {
+	if len(b) < 4 || b[0] != 0xFF || b[1] != 0xD8 {
+		return 0
+	}
+
+	for i := 2; i+1 < len(b); {
+		if b[i] != 0xFF {
+			return 0
+		}
+
+		m := b[i+1]
+
+		switch {
+		case m == 0xFF:
+			// Fill byte.
+			i++
+			continue
+		case m == 0xD9:
+			// End of image.
+			return i + 2
+		case m == 0x01 || m >= 0xD0 && m <= 0xD7:
+			// Markers without payload.
+			i += 2
+			continue
+		case i+3 >= len(b):
+			return 0
+		}
+
+		length := int(b[i+2])<<8 | int(b[i+3])
+
+		if length < 2 {
+			return 0
+		}
+
+		i += 2 + length
+
+		// Skip entropy-coded image data after start of scan.
+		if m == 0xDA {
+			for i+1 < len(b) && (b[i] != 0xFF || b[i+1] == 0x00 || b[i+1] >= 0xD0 && b[i+1] <= 0xD7) {
+				i++
+			}
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,171 @@
+package raw
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testJpeg returns a JPEG image with the specified size.
+func testJpeg(t *testing.T, w, h int) []byte {
+	var buf bytes.Buffer
+
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// testTiff returns little-endian TIFF data with an orientation tag in IFD0
+// and a preview referenced by JPEGInterchangeFormat in a SubIFD.
+func testTiff(preview []byte, orientation uint16) []byte {
+	le := binary.LittleEndian
+	ifd0, sub := 8, 8+2+2*12+4
+	data := sub + 2 + 2*12 + 4
+
+	b := make([]byte, data+len(preview)+16)
+	copy(b, "II")
+	le.PutUint16(b[2:], 42)
+	le.PutUint32(b[4:], uint32(ifd0))
+
+	entry := func(pos int, tag, typ uint16, count, value uint32) {
+		le.PutUint16(b[pos:], tag)
+		le.PutUint16(b[pos+2:], typ)
+		le.PutUint32(b[pos+4:], count)
+
+		if typ == tiffTypeShort {
+			le.PutUint16(b[pos+8:], uint16(value))
+		} else {
+			le.PutUint32(b[pos+8:], value)
+		}
+	}
+
+	le.PutUint16(b[ifd0:], 2)
+	entry(ifd0+2, tagOrientation, tiffTypeShort, 1, uint32(orientation))
+	entry(ifd0+14, tagSubIFDs, tiffTypeLong, 1, uint32(sub))
+
+	le.PutUint16(b[sub:], 2)
+	entry(sub+2, tagJpegOffset, tiffTypeLong, 1, uint32(data))
+	entry(sub+14, tagJpegLength, tiffTypeLong, 1, uint32(len(preview)))
+
+	copy(b[data:], preview)
+
+	return b
+}
+
+// testBox returns an ISO base media file format box.
+func testBox(boxType string, payload ...[]byte) []byte {
+	p := bytes.Join(payload, nil)
+	b := make([]byte, 8, 8+len(p))
+	binary.BigEndian.PutUint32(b, uint32(8+len(p)))
+	copy(b[4:], boxType)
+
+	return append(b, p...)
+}
+
+func TestExtract(t *testing.T) {
+	t.Run("DNG", func(t *testing.T) {
+		p, err := Extract("../../assets/examples/canon_eos_6d.dng")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1024, p.Width)
+		assert.Equal(t, 683, p.Height)
+		assert.Equal(t, 1024, p.Size())
+		assert.Equal(t, 1, p.Orientation)
+		assert.Equal(t, []byte{0xFF, 0xD8}, p.Data[:2])
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Extract("../../assets/examples/not-existing.dng")
+		assert.Error(t, err)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := Extract("../../assets/examples/beach_sand.jpg")
+		assert.Error(t, err)
+	})
+}
+
+func TestFromBytes(t *testing.T) {
+	t.Run("Tiff", func(t *testing.T) {
+		preview := testJpeg(t, 160, 120)
+
+		p, err := FromBytes(testTiff(preview, 6))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 160, p.Width)
+		assert.Equal(t, 120, p.Height)
+		assert.Equal(t, 6, p.Orientation)
+		assert.Equal(t, preview, p.Data)
+	})
+	t.Run("TiffNoPreview", func(t *testing.T) {
+		_, err := FromBytes(testTiff(nil, 1))
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+	t.Run("Bmff", func(t *testing.T) {
+		thumb := testJpeg(t, 32, 24)
+		large := testJpeg(t, 320, 240)
+		cmt1 := testTiff(nil, 8)
+
+		moov := testBox("moov",
+			testBox("uuid", uuidCanonMoov, testBox("CMT1", cmt1), testBox("THMB", make([]byte, 16), thumb)))
+		prvw := testBox("uuid", uuidCanonPreview, make([]byte, 8), testBox("PRVW", make([]byte, 16), large))
+
+		b := append(testBox("ftyp", []byte("crx "), make([]byte, 4)), moov...)
+		b = append(b, prvw...)
+
+		p, err := FromBytes(b)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 320, p.Width)
+		assert.Equal(t, 240, p.Height)
+		assert.Equal(t, 8, p.Orientation)
+	})
+	t.Run("Scan", func(t *testing.T) {
+		preview := testJpeg(t, 200, 100)
+		b := testTiff(nil, 3)
+		b = append(b, make([]byte, 64)...)
+		b = append(b, preview...)
+
+		p, err := FromBytes(b)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 200, p.Width)
+		assert.Equal(t, 3, p.Orientation)
+		assert.Equal(t, preview, p.Data)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := FromBytes([]byte("foo bar baz"))
+		assert.Error(t, err)
+	})
+}
+
+func TestJpegLength(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		b := testJpeg(t, 16, 16)
+		assert.Equal(t, len(b), jpegLength(append(b, 0, 0, 0, 0)))
+	})
+	t.Run("Truncated", func(t *testing.T) {
+		b := testJpeg(t, 16, 16)
+		assert.Equal(t, 0, jpegLength(b[:len(b)-2]))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, 0, jpegLength([]byte{0xFF, 0xD8, 0x00, 0x01}))
+		assert.Equal(t, 0, jpegLength(nil))
+	})
+}
@@ -0,0 +1,209 @@
+package raw
+
+import (
+	"encoding/binary"
+)
+
+// TIFF tags that reference embedded images.
+const (
+	tagJpgFromRaw      = 0x002E // Panasonic RW2
+	tagCompression     = 0x0103
+	tagStripOffsets    = 0x0111
+	tagOrientation     = 0x0112
+	tagStripByteCounts = 0x0117
+	tagSubIFDs         = 0x014A
+	tagJpegOffset      = 0x0201
+	tagJpegLength      = 0x0202
+	tagExifIFD         = 0x8769
+	compressionJpeg    = 6
+	compressionJpegDNG = 7
+	tiffMaxDepth       = 4
+	tiffMaxEntries     = 1024
+	tiffMaxChainedIFDs = 16
+	tiffEntrySize      = 12
+	tiffTypeShort      = 3
+	tiffTypeLong       = 4
+	tiffTypeIFD        = 13
+	tiffMagic          = 42
+	tiffMagicOlympusRO = 0x4F52 // Olympus ORF
+	tiffMagicOlympusRS = 0x5352 // Olympus ORF
+	tiffMagicPanasonic = 0x0055 // Panasonic RW2
+)
+
+// tiffOrder returns the byte order if data starts with a TIFF header, as used by most RAW formats.
+func tiffOrder(data []byte) (binary.ByteOrder, bool) {
+	if len(data) < 8 {
+		return nil, false
+	}
+
+	var order binary.ByteOrder
+
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, false
+	}
+
+	switch order.Uint16(data[2:4]) {
+	case tiffMagic, tiffMagicOlympusRO, tiffMagicOlympusRS, tiffMagicPanasonic:
+		return order, true
+	}
+
+	return nil, false
+}
+
+// tiff represents TIFF data and keeps track of visited directories.
+type tiff struct {
+	data     []byte
+	order    binary.ByteOrder
+	visited  map[int]bool
+	segments []segment
+}
+
+// tiffPreviews returns the embedded images referenced in TIFF directories and the image orientation.
+func tiffPreviews(data []byte, order binary.ByteOrder) ([]segment, int) {
+	t := &tiff{data: data, order: order, visited: make(map[int]bool)}
+
+	orientation := 0
+	offset := int(order.Uint32(data[4:8]))
+
+	// Follow the chain of top-level directories, e.g. IFD0 and IFD1.
+	for i := 0; i < tiffMaxChainedIFDs && offset > 0; i++ {
+		next, o := t.ifd(offset, 0)
+
+		if i == 0 {
+			orientation = o
+		}
+
+		offset = next
+	}
+
+	return t.segments, orientation
+}
+
+// tiffOrientation returns the orientation stored in the first directory of TIFF data, or 0 if unknown.
+func tiffOrientation(data []byte) int {
+	order, ok := tiffOrder(data)
+
+	if !ok {
+		return 0
+	}
+
+	t := &tiff{data: data, order: order, visited: make(map[int]bool)}
+
+	_, o := t.ifd(int(order.Uint32(data[4:8])), tiffMaxDepth)
+
+	return o
+}
+
+// ifd adds the images referenced in a directory and its sub directories,
+// and returns the offset of the next directory and the image orientation.
+func (t *tiff) ifd(offset, depth int) (next, orientation int) {
+	if offset < 8 || offset+2 > len(t.data) || t.visited[offset] {
+		return 0, 0
+	}
+
+	t.visited[offset] = true
+
+	count := int(t.order.Uint16(t.data[offset:]))
+
+	if count > tiffMaxEntries || offset+2+count*tiffEntrySize+4 > len(t.data) {
+		return 0, 0
+	}
+
+	var jpegOffset, jpegLength, stripOffset, stripLength, compression int
+	var subIFDs []int
+
+	for i := 0; i < count; i++ {
+		e := t.data[offset+2+i*tiffEntrySize:]
+		tag := t.order.Uint16(e[0:2])
+		typ := t.order.Uint16(e[2:4])
+		n := int(t.order.Uint32(e[4:8]))
+
+		switch tag {
+		case tagOrientation:
+			orientation = t.value(e, typ, 0)
+		case tagCompression:
+			compression = t.value(e, typ, 0)
+		case tagJpegOffset:
+			jpegOffset = t.value(e, typ, 0)
+		case tagJpegLength:
+			jpegLength = t.value(e, typ, 0)
+		case tagStripOffsets:
+			if n == 1 {
+				stripOffset = t.value(e, typ, 0)
+			}
+		case tagStripByteCounts:
+			if n == 1 {
+				stripLength = t.value(e, typ, 0)
+			}
+		case tagJpgFromRaw:
+			if n > tiffEntrySize {
+				t.segments = append(t.segments, segment{offset: int(t.order.Uint32(e[8:12])), length: n})
+			}
+		case tagSubIFDs, tagExifIFD:
+			for j := 0; j < n && j < tiffMaxChainedIFDs; j++ {
+				subIFDs = append(subIFDs, t.value(e, typ, j))
+			}
+		}
+	}
+
+	if jpegOffset > 0 {
+		t.segments = append(t.segments, segment{offset: jpegOffset, length: jpegLength})
+	}
+
+	if stripOffset > 0 && (compression == compressionJpeg || compression == compressionJpegDNG) {
+		t.segments = append(t.segments, segment{offset: stripOffset, length: stripLength})
+	}
+
+	if depth < tiffMaxDepth {
+		for _, sub := range subIFDs {
+			t.ifd(sub, depth+1)
+		}
+	}
+
+	next = int(t.order.Uint32(t.data[offset+2+count*tiffEntrySize:]))
+
+	return next, orientation
+}
+
+// value returns the i-th numeric value of a directory entry.
+func (t *tiff) value(e []byte, typ uint16, i int) int {
+	n := int(t.order.Uint32(e[4:8]))
+
+	if i >= n {
+		return 0
+	}
+
+	switch typ {
+	case tiffTypeShort:
+		if n <= 2 {
+			return int(t.order.Uint16(e[8+i*2:]))
+		}
+
+		pos := int(t.order.Uint32(e[8:12])) + i*2
+
+		if pos+2 > len(t.data) {
+			return 0
+		}
+
+		return int(t.order.Uint16(t.data[pos:]))
+	case tiffTypeLong, tiffTypeIFD:
+		if n == 1 {
+			return int(t.order.Uint32(e[8:12]))
+		}
+
+		pos := int(t.order.Uint32(e[8:12])) + i*4
+
+		if pos+4 > len(t.data) {
+			return 0
+		}
+
+		return int(t.order.Uint32(t.data[pos:]))
+	}
+
+	return 0
+}
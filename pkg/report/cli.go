@@ -2,6 +2,7 @@ package report
 
 import "github.com/urfave/cli"
 
+// CliFormat returns the report format based on the command flags.
 func CliFormat(ctx *cli.Context) Format {
 	switch {
 	case ctx.Bool("md"), ctx.Bool("markdown"):
@@ -10,6 +11,10 @@ func CliFormat(ctx *cli.Context) Format {
 		return TSV
 	case ctx.Bool("csv"):
 		return CSV
+	case ctx.Bool("json"):
+		return JSON
+	case ctx.Bool("ndjson"):
+		return NDJSON
 	default:
 		return Default
 	}
@@ -28,4 +33,24 @@ var CliFlags = []cli.Flag{
 		Name:  "tsv, t",
 		Usage: "export as tab separated values",
 	},
+	cli.BoolFlag{
+		Name:  "json, j",
+		Usage: "export as JSON array of objects",
+	},
+	cli.BoolFlag{
+		Name:  "ndjson",
+		Usage: "export as newline delimited JSON",
+	},
+}
+
+// ResultFlags are command flags for machine-readable results of long-running commands.
+var ResultFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "json, j",
+		Usage: "print a summary of the results as JSON",
+	},
+	cli.BoolFlag{
+		Name:  "ndjson",
+		Usage: "stream file results as newline delimited JSON, followed by a summary",
+	},
 }
@@ -7,4 +7,6 @@ const (
 	Markdown = "markdown"
 	TSV      = "tsv"
 	CSV      = "csv"
+	JSON     = "json"
+	NDJSON   = "ndjson"
 )
@@ -0,0 +1,104 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// JsonExport returns the report as a JSON array of objects, using the column names as keys.
+func JsonExport(rows [][]string, cols []string) (string, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString("[")
+
+	for i, row := range rows {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+
+		buf.WriteString("\n  ")
+
+		if err := writeObject(buf, row, cols); err != nil {
+			return "", err
+		}
+	}
+
+	if len(rows) > 0 {
+		buf.WriteString("\n")
+	}
+
+	buf.WriteString("]\n")
+
+	return buf.String(), nil
+}
+
+// NdjsonExport returns the report as newline delimited JSON, with one object per row.
+func NdjsonExport(rows [][]string, cols []string) (string, error) {
+	buf := &bytes.Buffer{}
+
+	for _, row := range rows {
+		if err := writeObject(buf, row, cols); err != nil {
+			return "", err
+		}
+
+		buf.WriteString("\n")
+	}
+
+	return buf.String(), nil
+}
+
+// writeObject writes a row as JSON object, keeping the column order.
+func writeObject(buf *bytes.Buffer, row []string, cols []string) error {
+	if len(row) > len(cols) {
+		return fmt.Errorf("row has %d values, but only %d columns", len(row), len(cols))
+	}
+
+	buf.WriteString("{")
+
+	for i, col := range cols {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+
+		var value string
+
+		if i < len(row) {
+			value = row[i]
+		}
+
+		writeString(buf, col)
+		buf.WriteString(":")
+		writeString(buf, value)
+	}
+
+	buf.WriteString("}")
+
+	return nil
+}
+
+// writeString writes a JSON string without escaping HTML characters.
+func writeString(buf *bytes.Buffer, s string) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+
+	// Remove the newline added by the encoder.
+	buf.Truncate(buf.Len() - 1)
+}
+
+// Encode writes a structured result to w, either as indented JSON or as a single NDJSON line.
+func Encode(w io.Writer, v interface{}, format Format) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	switch format {
+	case JSON:
+		enc.SetIndent("", "  ")
+	case NDJSON:
+	default:
+		return fmt.Errorf("unsupported result format %s", string(format))
+	}
+
+	return enc.Encode(v)
+}
@@ -0,0 +1,41 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonExport(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		result, err := JsonExport([][]string{}, []string{"Col1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "[]\n", result)
+	})
+	t.Run("TooManyValues", func(t *testing.T) {
+		_, err := JsonExport([][]string{{"a", "b"}}, []string{"Col1"})
+		assert.Error(t, err)
+	})
+}
+
+func TestEncode(t *testing.T) {
+	v := struct {
+		Name  string
+		Count int
+	}{Name: "index", Count: 3}
+
+	t.Run("JSON", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.NoError(t, Encode(buf, v, JSON))
+		assert.Equal(t, "{\n  \"Name\": \"index\",\n  \"Count\": 3\n}\n", buf.String())
+	})
+	t.Run("NDJSON", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		assert.NoError(t, Encode(buf, v, NDJSON))
+		assert.Equal(t, "{\"Name\":\"index\",\"Count\":3}\n", buf.String())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Error(t, Encode(&bytes.Buffer{}, v, CSV))
+	})
+}
@@ -14,6 +14,10 @@ func RenderFormat(rows [][]string, cols []string, format Format) (string, error)
 		return Render(rows, cols, Options{Format: CSV})
 	case TSV:
 		return Render(rows, cols, Options{Format: TSV})
+	case JSON:
+		return Render(rows, cols, Options{Format: JSON})
+	case NDJSON:
+		return Render(rows, cols, Options{Format: NDJSON})
 	case Markdown:
 		return Render(rows, cols, Options{Format: Markdown, Valid: true})
 	case Default:
@@ -31,6 +35,10 @@ func Render(rows [][]string, cols []string, opt Options) (string, error) {
 		return CsvExport(rows, cols, ';')
 	case TSV:
 		return CsvExport(rows, cols, '\t')
+	case JSON:
+		return JsonExport(rows, cols)
+	case NDJSON:
+		return NdjsonExport(rows, cols)
 	case Markdown:
 		opt.Valid = true
 		return MarkdownTable(rows, cols, opt), nil
@@ -49,6 +49,27 @@ func TestTable(t *testing.T) {
 
 		assert.Contains(t, result, "Col1\tCol2\nfoo\tbar, abc, abc")
 	})
+	t.Run("JsonExport", func(t *testing.T) {
+		rows := [][]string{{"foo", "bar, abc"}, {"bar", "b & a | z"}}
+		result, err := RenderFormat(rows, cols, JSON)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, strings.HasPrefix(result, "[\n  {\"Col1\":\"foo\",\"Col2\":\"bar, abc"))
+		assert.Contains(t, result, "{\"Col1\":\"bar\",\"Col2\":\"b & a | z\"}\n]\n")
+	})
+	t.Run("NdjsonExport", func(t *testing.T) {
+		rows := [][]string{{"foo", "bar, abc"}, {"bar", "b & a | z"}}
+		result, err := RenderFormat(rows, cols, NDJSON)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(result), "\n")
+		assert.Len(t, lines, 2)
+		assert.Equal(t, "{\"Col1\":\"bar\",\"Col2\":\"b & a | z\"}", lines[1])
+	})
 	t.Run("Invalid", func(t *testing.T) {
 		_, err := RenderFormat(rows, cols, Format("invalid"))
 
@@ -0,0 +1,88 @@
+/*
+Package watch provides a recursive file system watcher for detecting changed files and folders.
+
+Copyright (c) 2018 - 2023 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package watch
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrUnsupported is returned if file system events are not supported on the current platform.
+var ErrUnsupported = errors.New("watch: file system events are not supported")
+
+// ErrLimit is returned if the maximum number of watches has been reached.
+var ErrLimit = errors.New("watch: too many watches, consider increasing fs.inotify.max_user_watches")
+
+// Op represents a file system operation.
+type Op uint8
+
+// Supported file system operations.
+const (
+	Create Op = 1 << iota
+	Write
+	Remove
+	Rename
+	Overflow
+)
+
+// String returns the operation names.
+func (op Op) String() string {
+	var names []string
+
+	if op&Create != 0 {
+		names = append(names, "create")
+	}
+
+	if op&Write != 0 {
+		names = append(names, "write")
+	}
+
+	if op&Remove != 0 {
+		names = append(names, "remove")
+	}
+
+	if op&Rename != 0 {
+		names = append(names, "rename")
+	}
+
+	if op&Overflow != 0 {
+		names = append(names, "overflow")
+	}
+
+	return strings.Join(names, "|")
+}
+
+// Event represents a file system event. Overflow events indicate that events have been lost,
+// so that a full rescan is required.
+type Event struct {
+	Name string
+	Op   Op
+	Dir  bool
+}
+
+// Hidden tests if the file or folder name starts with a dot, or is a known temporary file name.
+func Hidden(name string) bool {
+	return name == "" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "~") || strings.HasPrefix(name, "@eaDir")
+}
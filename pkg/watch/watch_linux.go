@@ -0,0 +1,230 @@
+//go:build linux
+// +build linux
+
+package watch
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// watchMask contains the inotify events that are watched.
+const watchMask = unix.IN_CREATE | unix.IN_CLOSE_WRITE | unix.IN_MOVED_FROM | unix.IN_MOVED_TO |
+	unix.IN_DELETE | unix.IN_DELETE_SELF | unix.IN_MOVE_SELF
+
+// Watcher watches directories recursively using inotify.
+type Watcher struct {
+	Events  chan Event
+	Errors  chan error
+	file    *os.File
+	mutex   sync.Mutex
+	paths   map[int]string
+	watches map[string]int
+	done    chan struct{}
+}
+
+// New creates a new watcher and starts reading events.
+func New() (*Watcher, error) {
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+
+	if err != nil {
+		return nil, fmt.Errorf("watch: %s", err)
+	}
+
+	w := &Watcher{
+		Events:  make(chan Event, 256),
+		Errors:  make(chan error, 16),
+		file:    os.NewFile(uintptr(fd), "inotify"),
+		paths:   make(map[int]string),
+		watches: make(map[string]int),
+		done:    make(chan struct{}),
+	}
+
+	go w.read()
+
+	return w, nil
+}
+
+// Len returns the number of watched directories.
+func (w *Watcher) Len() int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return len(w.watches)
+}
+
+// Add watches a directory and its subdirectories, skipping hidden directories.
+func (w *Watcher) Add(dir string) error {
+	return filepath.WalkDir(dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Ignore directories that have been removed in the meantime.
+			return nil
+		} else if !d.IsDir() {
+			return nil
+		} else if name != dir && Hidden(d.Name()) {
+			return filepath.SkipDir
+		}
+
+		return w.addWatch(name)
+	})
+}
+
+// addWatch adds an inotify watch for a single directory.
+func (w *Watcher) addWatch(dir string) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	wd, err := unix.InotifyAddWatch(int(w.file.Fd()), dir, watchMask)
+
+	if errors.Is(err, unix.ENOSPC) {
+		return ErrLimit
+	} else if err != nil {
+		return fmt.Errorf("watch: %s (%s)", err, dir)
+	}
+
+	w.paths[wd] = dir
+	w.watches[dir] = wd
+
+	return nil
+}
+
+// removeWatch forgets a watch that has been removed by the kernel.
+func (w *Watcher) removeWatch(wd int) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if dir, ok := w.paths[wd]; ok {
+		delete(w.watches, dir)
+		delete(w.paths, wd)
+	}
+}
+
+// path returns the directory name of a watch.
+func (w *Watcher) path(wd int) string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.paths[wd]
+}
+
+// Close stops watching and closes the event channels.
+func (w *Watcher) Close() error {
+	select {
+	case <-w.done:
+		return nil
+	default:
+		close(w.done)
+	}
+
+	return w.file.Close()
+}
+
+// send passes an event to the events channel unless the watcher is closed.
+func (w *Watcher) send(ev Event) bool {
+	select {
+	case w.Events <- ev:
+		return true
+	case <-w.done:
+		return false
+	}
+}
+
+// sendErr passes an error to the errors channel, or drops it if the channel is full.
+func (w *Watcher) sendErr(err error) {
+	select {
+	case w.Errors <- err:
+	default:
+	}
+}
+
+// read reads inotify events until the watcher is closed.
+func (w *Watcher) read() {
+	defer close(w.Events)
+	defer close(w.Errors)
+
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+
+	for {
+		n, err := w.file.Read(buf)
+
+		if err != nil {
+			select {
+			case <-w.done:
+			default:
+				w.sendErr(fmt.Errorf("watch: %s", err))
+			}
+
+			return
+		}
+
+		for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+			raw := (*unix.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameLen := int(raw.Len)
+			offset += unix.SizeofInotifyEvent + nameLen
+
+			if raw.Mask&unix.IN_Q_OVERFLOW != 0 {
+				if !w.send(Event{Op: Overflow}) {
+					return
+				}
+
+				continue
+			} else if raw.Mask&unix.IN_IGNORED != 0 {
+				w.removeWatch(int(raw.Wd))
+				continue
+			}
+
+			dir := w.path(int(raw.Wd))
+
+			if dir == "" {
+				continue
+			}
+
+			name := dir
+
+			if nameLen > 0 {
+				b := buf[offset-nameLen : offset]
+				base := string(bytes.TrimRight(b, "\x00"))
+
+				if Hidden(base) {
+					continue
+				}
+
+				name = filepath.Join(dir, base)
+			}
+
+			ev := Event{Name: name, Dir: raw.Mask&unix.IN_ISDIR != 0}
+
+			switch {
+			case raw.Mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0:
+				ev.Op = Create
+
+				// Watch new directories, including their subdirectories.
+				if ev.Dir {
+					if err = w.Add(name); err != nil {
+						w.sendErr(err)
+					}
+				}
+			case raw.Mask&unix.IN_CLOSE_WRITE != 0:
+				ev.Op = Write
+			case raw.Mask&unix.IN_MOVED_FROM != 0:
+				ev.Op = Rename
+			case raw.Mask&(unix.IN_DELETE|unix.IN_DELETE_SELF|unix.IN_MOVE_SELF) != 0:
+				ev.Op = Remove
+			default:
+				continue
+			}
+
+			if !w.send(ev) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+//go:build !linux
+// +build !linux
+
+package watch
+
+// Watcher watches directories recursively, which is not supported on the current platform.
+type Watcher struct {
+	Events chan Event
+	Errors chan error
+}
+
+// New returns ErrUnsupported, as file system events are not supported on the current platform.
+func New() (*Watcher, error) {
+	return nil, ErrUnsupported
+}
+
+// Len returns the number of watched directories.
+func (w *Watcher) Len() int {
+	return 0
+}
+
+// Add returns ErrUnsupported.
+func (w *Watcher) Add(dir string) error {
+	return ErrUnsupported
+}
+
+// Close does nothing.
+func (w *Watcher) Close() error {
+	return nil
+}
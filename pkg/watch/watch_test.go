@@ -0,0 +1,91 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOp_String(t *testing.T) {
+	assert.Equal(t, "", Op(0).String())
+	assert.Equal(t, "create", Create.String())
+	assert.Equal(t, "write|rename", (Write | Rename).String())
+}
+
+func TestHidden(t *testing.T) {
+	assert.True(t, Hidden(""))
+	assert.True(t, Hidden(".stfolder"))
+	assert.True(t, Hidden("~lock.jpg"))
+	assert.True(t, Hidden("@eaDir"))
+	assert.False(t, Hidden("IMG_1234.jpg"))
+}
+
+func TestWatcher(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("file system events are not supported")
+	}
+
+	dir := t.TempDir()
+
+	w, err := New()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer w.Close()
+
+	assert.NoError(t, w.Add(dir))
+	assert.Equal(t, 1, w.Len())
+
+	next := func() Event {
+		select {
+		case ev := <-w.Events:
+			return ev
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+			return Event{}
+		}
+	}
+
+	t.Run("CreateDir", func(t *testing.T) {
+		sub := filepath.Join(dir, "2023")
+		assert.NoError(t, os.Mkdir(sub, os.ModePerm))
+
+		ev := next()
+		assert.Equal(t, Create, ev.Op)
+		assert.Equal(t, sub, ev.Name)
+		assert.True(t, ev.Dir)
+		assert.Equal(t, 2, w.Len())
+	})
+	t.Run("WriteFile", func(t *testing.T) {
+		fileName := filepath.Join(dir, "2023", "photo.jpg")
+		assert.NoError(t, os.WriteFile(fileName, []byte("foo"), os.ModePerm))
+
+		assert.Equal(t, Event{Name: fileName, Op: Create}, next())
+		assert.Equal(t, Event{Name: fileName, Op: Write}, next())
+	})
+	t.Run("HiddenFile", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte("foo"), os.ModePerm))
+		fileName := filepath.Join(dir, "visible.jpg")
+		assert.NoError(t, os.WriteFile(fileName, []byte("foo"), os.ModePerm))
+
+		assert.Equal(t, Event{Name: fileName, Op: Create}, next())
+	})
+	t.Run("Remove", func(t *testing.T) {
+		fileName := filepath.Join(dir, "visible.jpg")
+
+		// Skip remaining write event.
+		assert.Equal(t, Write, next().Op)
+		assert.NoError(t, os.Remove(fileName))
+		assert.Equal(t, Event{Name: fileName, Op: Remove}, next())
+	})
+	t.Run("Close", func(t *testing.T) {
+		assert.NoError(t, w.Close())
+		assert.NoError(t, w.Close())
+	})
+}